@@ -55,6 +55,14 @@ func main() {
 		fmt.Println(version.Version)
 		os.Exit(0)
 	}
+	if err := config.CertificateConfig.Verify(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	if err := config.GrantConfig.Verify(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
 	slog.Info("Version info:", slog.String("version", version.Version))
 	if config.WatchingAllNamespaces() {
 		slog.Info("Skupper controller watching all namespaces")
@@ -77,6 +85,8 @@ func main() {
 		reg := prometheus.NewRegistry()
 		metrics.MustRegisterClientGoMetrics(reg)
 		eventProcessorMetrics = metrics.MustRegisterEventProcessorMetrics(reg)
+		config.CertificateMetrics = metrics.MustRegisterCertificateMetrics(reg)
+		config.GrantConfig.Metrics = metrics.MustRegisterGrantMetrics(reg)
 		srv := metrics.NewServer(config.MetricsConfig, reg)
 		if err := srv.Start(stopCh); err != nil {
 			slog.Error("Error starting metrics server", slog.Any("error", err))
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/internal/utils/tlscfg"
 )
 
@@ -15,6 +17,18 @@ type Config struct {
 	APIListenAddress    string
 	APIEnableAccessLogs bool
 	APITLS              TLSSpec
+	APIClientCA         string
+
+	AuthTokenFile     string
+	AuthHtpasswdFile  string
+	AuthClientCert    bool
+	AuthRolesFile     string
+	AuthScrapeToken   string
+	OIDCIssuer        string
+	OIDCAudience      string
+	OIDCJWKSURL       string
+	OIDCUsernameClaim string
+	OIDCGroupsClaim   string
 
 	EnableConsole   bool
 	ConsoleLocation string
@@ -24,6 +38,25 @@ type Config struct {
 	RouterTLS     TLSSpec
 	FlowRecordTTL time.Duration
 
+	StoragePath      string
+	StorageRetention string
+
+	TimeSeriesResolution time.Duration
+	TimeSeriesRetention  time.Duration
+
+	AlertRulesFile string
+
+	OTLPEndpoint        string
+	OTLPHeaders         string
+	OTLPTLS             TLSSpec
+	OTLPMetricsInterval time.Duration
+
+	IPFIXCollector           string
+	IPFIXExportInterval      time.Duration
+	IPFIXTemplateRefresh     time.Duration
+	IPFIXObservationDomainID uint
+	IPFIXEnterpriseNumber    uint
+
 	VanflowLoggingProfile string
 
 	EnableProfile bool
@@ -71,6 +104,100 @@ func (t TLSSpec) config() (*tls.Config, error) {
 	return config, nil
 }
 
+// apiTLSConfig returns the API server TLS configuration, which requests
+// client certificates signed by the client CA when one is set.
+func (c Config) apiTLSConfig() (*tls.Config, error) {
+	config, err := c.APITLS.config()
+	if err != nil {
+		return nil, err
+	}
+	if len(c.APIClientCA) > 0 {
+		certPool := x509.NewCertPool()
+		file, err := os.ReadFile(c.APIClientCA)
+		if err != nil {
+			return nil, err
+		}
+		if ok := certPool.AppendCertsFromPEM(file); !ok {
+			return nil, fmt.Errorf("failed to add client CA to certificate pool")
+		}
+		config.ClientCAs = certPool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return config, nil
+}
+
+// authenticators returns the API authenticators enabled by the
+// configuration, or none when authentication is disabled.
+func (c Config) authenticators() ([]auth.Authenticator, error) {
+	var authenticators []auth.Authenticator
+	if c.AuthClientCert {
+		if len(c.APIClientCA) == 0 || !c.APITLS.hasCert() {
+			return nil, fmt.Errorf("auth-client-cert requires tls-cert and tls-client-ca")
+		}
+		authenticators = append(authenticators, auth.NewClientCert())
+	}
+	if len(c.AuthTokenFile) > 0 {
+		a, err := auth.NewTokenFile(c.AuthTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, a)
+	}
+	if len(c.OIDCIssuer) > 0 {
+		a, err := auth.NewOIDC(auth.OIDCConfig{
+			Issuer:        c.OIDCIssuer,
+			Audience:      c.OIDCAudience,
+			JWKSURL:       c.OIDCJWKSURL,
+			UsernameClaim: c.OIDCUsernameClaim,
+			GroupsClaim:   c.OIDCGroupsClaim,
+		})
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, a)
+	}
+	if len(c.AuthHtpasswdFile) > 0 {
+		a, err := auth.NewHtpasswdFile(c.AuthHtpasswdFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, a)
+	}
+	if len(c.AuthRolesFile) > 0 && len(authenticators) == 0 {
+		return nil, fmt.Errorf("auth-roles requires an authentication method to be configured")
+	}
+	if len(c.AuthScrapeToken) > 0 && len(authenticators) == 0 {
+		return nil, fmt.Errorf("auth-metrics-token-file requires an authentication method to be configured")
+	}
+	return authenticators, nil
+}
+
+// scraper returns the Authenticator for the metrics scrape token, or nil
+// when there is none.
+func (c Config) scraper() (auth.Authenticator, error) {
+	if len(c.AuthScrapeToken) == 0 {
+		return nil, nil
+	}
+	return auth.NewScrapeTokenFile(c.AuthScrapeToken)
+}
+
+// parseOTLPHeaders parses a comma separated list of key=value pairs.
+func parseOTLPHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	if value == "" {
+		return headers, nil
+	}
+	for _, item := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header %q: expected key=value", item)
+		}
+		headers[key] = strings.TrimSpace(val)
+	}
+	return headers, nil
+}
+
 func parsePrometheusAPI(base string) (*url.URL, error) {
 	targetPromAPI, err := url.Parse(base)
 	if err != nil {
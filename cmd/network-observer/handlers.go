@@ -10,6 +10,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 )
 
 func handleMetrics(reg *prometheus.Registry) http.Handler {
@@ -75,6 +77,12 @@ func handleGetUser() http.Handler {
 	handleEmpty := handleNoContent()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var response UserResponse
+		if id, ok := auth.IdentityFrom(r.Context()); ok {
+			response.Username = id.Username
+			response.AuthMode = id.Method
+			json.NewEncoder(w).Encode(response)
+			return
+		}
 		if cookie, err := r.Cookie("_oauth_proxy"); err == nil && cookie != nil {
 			if cookieDecoded, _ := base64.StdEncoding.DecodeString(cookie.Value); cookieDecoded != nil {
 				response.Username = string(cookieDecoded)
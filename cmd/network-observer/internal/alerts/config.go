@@ -0,0 +1,202 @@
+package alerts
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"text/template"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// RuleType is the condition a rule checks for.
+type RuleType string
+
+const (
+	// RuleLinkDown fires for each router link that is not operational.
+	RuleLinkDown RuleType = "linkDown"
+	// RuleUnboundService fires for each service with listeners but no
+	// connectors.
+	RuleUnboundService RuleType = "unboundService"
+	// RuleRouterMissing fires for each site without a running router.
+	RuleRouterMissing RuleType = "routerMissing"
+	// RuleErrorRate fires for each service where the proportion of
+	// requests with a 5xx response over the window exceeds the threshold.
+	RuleErrorRate RuleType = "errorRate"
+	// RuleLatency fires for each service where the quantile of connection
+	// latencies over the window exceeds the threshold in seconds.
+	RuleLatency RuleType = "latency"
+)
+
+// Format is the payload format of a webhook.
+type Format string
+
+const (
+	// FormatWebhook posts notifications in the format of Alertmanager
+	// webhook receivers.
+	FormatWebhook Format = "webhook"
+	// FormatAlertmanager posts alerts to the Alertmanager v2 API, e.g.
+	// http://alertmanager:9093/api/v2/alerts.
+	FormatAlertmanager Format = "alertmanager"
+	// FormatSlack posts notifications as Slack incoming webhook messages.
+	FormatSlack Format = "slack"
+)
+
+const (
+	defaultInterval = 30 * time.Second
+	defaultWindow   = 5 * time.Minute
+	defaultQuantile = 0.95
+	defaultSeverity = "warning"
+)
+
+type Config struct {
+	// Interval is how often rules are evaluated. Rules on the topology of
+	// the network are also evaluated as it changes.
+	Interval metav1.Duration `json:"interval"`
+	Rules    []Rule          `json:"rules"`
+	Webhooks []Webhook       `json:"webhooks"`
+}
+
+type Rule struct {
+	// Name of the rule, the alertname label of its alerts.
+	Name string   `json:"name"`
+	Type RuleType `json:"type"`
+	// Severity label of the rule's alerts. Defaults to warning.
+	Severity string `json:"severity"`
+	// Summary overrides the description of the rule's alerts. It is a
+	// text/template with the alert's .Labels and .Value.
+	Summary string `json:"summary"`
+	// For is how long a condition must hold before its alert fires.
+	For metav1.Duration `json:"for"`
+	// Service limits errorRate, latency and unboundService rules to the
+	// service with this routing key.
+	Service string `json:"service"`
+	// Threshold is the error ratio, between 0 and 1, of errorRate rules
+	// and the latency in seconds of latency rules.
+	Threshold float64 `json:"threshold"`
+	// Window is the period errorRate and latency rules aggregate over.
+	// Defaults to 5m.
+	Window metav1.Duration `json:"window"`
+	// Quantile of latency rules. Defaults to 0.95.
+	Quantile float64 `json:"quantile"`
+	// MinRequests is the number of requests in the window below which
+	// errorRate rules do not fire.
+	MinRequests uint64 `json:"minRequests"`
+
+	summary *template.Template
+}
+
+type Webhook struct {
+	URL string `json:"url"`
+	// Format of the payload. Defaults to webhook.
+	Format Format `json:"format"`
+	// Headers added to each request, e.g. Authorization.
+	Headers map[string]string `json:"headers"`
+}
+
+// LoadConfig reads alert rules and webhooks from a YAML file.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("error parsing alert rules %s: %s", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return cfg, fmt.Errorf("invalid alert rules %s: %s", path, err)
+	}
+	return cfg, nil
+}
+
+// validate checks the configuration and sets defaults.
+func (c *Config) validate() error {
+	if c.Interval.Duration < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	if c.Interval.Duration == 0 {
+		c.Interval.Duration = defaultInterval
+	}
+	names := make(map[string]struct{}, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i+1)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rule %q: %s", rule.Name, err)
+		}
+	}
+	for i := range c.Webhooks {
+		webhook := &c.Webhooks[i]
+		if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("webhook %d: invalid url %q", i+1, webhook.URL)
+		}
+		switch webhook.Format {
+		case "":
+			webhook.Format = FormatWebhook
+		case FormatWebhook, FormatAlertmanager, FormatSlack:
+		default:
+			return fmt.Errorf("webhook %d: unknown format %q", i+1, webhook.Format)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) validate() error {
+	if r.Severity == "" {
+		r.Severity = defaultSeverity
+	}
+	if r.For.Duration < 0 {
+		return fmt.Errorf("for must not be negative")
+	}
+	if r.Summary != "" {
+		summary, err := parseSummary(r.Summary)
+		if err != nil {
+			return fmt.Errorf("invalid summary: %s", err)
+		}
+		r.summary = summary
+	}
+	switch r.Type {
+	case RuleLinkDown, RuleUnboundService, RuleRouterMissing:
+		return nil
+	case RuleErrorRate:
+		if r.Threshold < 0 || r.Threshold >= 1 {
+			return fmt.Errorf("threshold must be at least 0 and less than 1")
+		}
+	case RuleLatency:
+		if r.Threshold <= 0 {
+			return fmt.Errorf("threshold must be greater than 0")
+		}
+		if r.Quantile == 0 {
+			r.Quantile = defaultQuantile
+		}
+		if r.Quantile < 0 || r.Quantile > 1 {
+			return fmt.Errorf("quantile must be between 0 and 1")
+		}
+	case "":
+		return fmt.Errorf("type is required")
+	default:
+		return fmt.Errorf("unknown type %q", r.Type)
+	}
+	if r.Window.Duration < 0 {
+		return fmt.Errorf("window must not be negative")
+	}
+	if r.Window.Duration == 0 {
+		r.Window.Duration = defaultWindow
+	}
+	return nil
+}
+
+// usesTimeSeries reports whether the rule is evaluated from the time
+// series of services.
+func (r Rule) usesTimeSeries() bool {
+	return r.Type == RuleErrorRate || r.Type == RuleLatency
+}
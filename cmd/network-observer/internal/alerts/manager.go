@@ -0,0 +1,314 @@
+// Package alerts evaluates alert rules against the records collected by the
+// network observer and sends notifications of firing and resolved alerts to
+// webhooks.
+//
+// Rules on the topology of the network (links, services and routers) are
+// evaluated when the records they depend on change as well as on an
+// interval. Rules on service traffic are evaluated on the interval from the
+// in memory time series. An alert is pending until its condition has held
+// for the rule's duration, then fires until its condition no longer holds.
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// State of an alert.
+type State string
+
+const (
+	StatePending  State = "pending"
+	StateFiring   State = "firing"
+	StateResolved State = "resolved"
+)
+
+const (
+	// topologyDebounce delays evaluation of topology rules after a change
+	// so that bursts of changes are evaluated once.
+	topologyDebounce = time.Second
+	// resolvedRetention is how long resolved alerts are listed.
+	resolvedRetention = time.Hour
+	maxResolved       = 256
+)
+
+// Alert is an occurrence of the condition of a rule.
+type Alert struct {
+	// ID is a fingerprint of the labels.
+	ID       string
+	Rule     string
+	Severity string
+	State    State
+	// Labels identify the alert. They include the alertname and severity.
+	Labels  map[string]string
+	Summary string
+	// Value is the error rate or latency of service traffic rules.
+	Value float64
+	// StartsAt is when the condition was first seen.
+	StartsAt time.Time
+	// FiredAt is when the alert started firing, zero while pending.
+	FiredAt time.Time
+	// EndsAt is when the alert was resolved.
+	EndsAt time.Time
+}
+
+// EventSource provides subscriptions to changes in the collector's records.
+type EventSource interface {
+	Subscribe(types ...vanflow.TypeMeta) *collector.Subscription
+}
+
+// topologyTypes are the record types topology rules depend on.
+var topologyTypes = []vanflow.TypeMeta{
+	vanflow.SiteRecord{}.GetTypeMeta(),
+	vanflow.RouterRecord{}.GetTypeMeta(),
+	vanflow.LinkRecord{}.GetTypeMeta(),
+	vanflow.ListenerRecord{}.GetTypeMeta(),
+	vanflow.ConnectorRecord{}.GetTypeMeta(),
+	collector.AddressRecord{}.GetTypeMeta(),
+}
+
+// Manager evaluates alert rules and tracks their alerts.
+type Manager struct {
+	logger    *slog.Logger
+	rules     []Rule
+	interval  time.Duration
+	evaluator evaluator
+	events    EventSource
+	notifier  *notifier
+	now       func() time.Time
+
+	mu       sync.RWMutex
+	active   map[string]*Alert
+	resolved []Alert
+}
+
+func New(logger *slog.Logger, cfg Config, records store.Interface, graph collector.Graph, series *timeseries.Store, events EventSource) (*Manager, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	for _, rule := range cfg.Rules {
+		if rule.usesTimeSeries() && series == nil {
+			return nil, fmt.Errorf("rule %q requires the time series, which are disabled", rule.Name)
+		}
+	}
+	return &Manager{
+		logger:   logger,
+		rules:    cfg.Rules,
+		interval: cfg.Interval.Duration,
+		evaluator: evaluator{
+			records: records,
+			graph:   graph,
+			series:  series,
+		},
+		events:   events,
+		notifier: newNotifier(logger, cfg.Webhooks, cfg.Interval.Duration),
+		now:      time.Now,
+		active:   make(map[string]*Alert),
+	}, nil
+}
+
+// Alerts returns the pending and firing alerts, followed by those resolved
+// in the last hour. A nil Manager has no alerts.
+func (m *Manager) Alerts() []Alert {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	alerts := make([]Alert, 0, len(m.active)+len(m.resolved))
+	for _, alert := range m.active {
+		alerts = append(alerts, *alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].StartsAt.Equal(alerts[j].StartsAt) {
+			return alerts[i].StartsAt.Before(alerts[j].StartsAt)
+		}
+		return alerts[i].ID < alerts[j].ID
+	})
+	return append(alerts, m.resolved...)
+}
+
+// Run evaluates the rules until the context is cancelled.
+func (m *Manager) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.notifier.run(ctx)
+	}()
+
+	topology := slices.DeleteFunc(slices.Clone(m.rules), Rule.usesTimeSeries)
+	var sub *collector.Subscription
+	subscribe := func() <-chan collector.RecordEvent {
+		if m.events == nil || len(topology) == 0 {
+			return nil
+		}
+		sub = m.events.Subscribe(topologyTypes...)
+		return sub.C
+	}
+	events := subscribe()
+	defer func() {
+		if sub != nil {
+			sub.Close()
+		}
+	}()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	debounce := time.NewTimer(topologyDebounce)
+	debounce.Stop()
+	m.evaluate(m.rules, true)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.evaluate(m.rules, true)
+		case _, ok := <-events:
+			if !ok {
+				m.logger.Debug("resubscribing to record changes", slog.Any("error", sub.Err()))
+				events = subscribe()
+			}
+			debounce.Reset(topologyDebounce)
+		case <-debounce.C:
+			m.evaluate(topology, false)
+		}
+	}
+}
+
+// evaluate updates the alerts of rules and notifies webhooks of alerts that
+// fired or resolved. When refresh is set, Alertmanager webhooks are sent
+// all firing alerts so that they do not expire.
+func (m *Manager) evaluate(rules []Rule, refresh bool) {
+	now := m.now()
+	var fired, resolved []Alert
+	m.mu.Lock()
+	for _, rule := range rules {
+		seen := make(map[string]struct{})
+		for _, c := range m.evaluator.evaluate(rule) {
+			current := newAlert(rule, c)
+			seen[current.ID] = struct{}{}
+			alert, ok := m.active[current.ID]
+			if !ok {
+				current.State = StatePending
+				current.StartsAt = now
+				alert = &current
+				m.active[alert.ID] = alert
+			} else {
+				alert.Summary, alert.Value = current.Summary, current.Value
+			}
+			if alert.State == StatePending && now.Sub(alert.StartsAt) >= rule.For.Duration {
+				alert.State = StateFiring
+				alert.FiredAt = now
+				fired = append(fired, *alert)
+			}
+		}
+		for id, alert := range m.active {
+			if _, ok := seen[id]; ok || alert.Rule != rule.Name {
+				continue
+			}
+			delete(m.active, id)
+			if alert.State != StateFiring {
+				continue
+			}
+			alert.State = StateResolved
+			alert.EndsAt = now
+			resolved = append(resolved, *alert)
+			m.resolved = append(m.resolved, *alert)
+		}
+	}
+	m.pruneResolved(now)
+	var firing []Alert
+	if refresh {
+		for _, alert := range m.active {
+			if alert.State == StateFiring {
+				firing = append(firing, *alert)
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	for _, alert := range fired {
+		m.logger.Info("alert firing", slog.String("rule", alert.Rule), slog.String("summary", alert.Summary))
+	}
+	for _, alert := range resolved {
+		m.logger.Info("alert resolved", slog.String("rule", alert.Rule), slog.String("summary", alert.Summary))
+	}
+	m.notifier.notify(now, StateFiring, fired)
+	m.notifier.notify(now, StateResolved, resolved)
+	if refresh {
+		m.notifier.refresh(now, firing)
+	}
+}
+
+func (m *Manager) pruneResolved(now time.Time) {
+	expired := 0
+	for expired < len(m.resolved) && now.Sub(m.resolved[expired].EndsAt) > resolvedRetention {
+		expired++
+	}
+	expired = max(expired, len(m.resolved)-maxResolved)
+	if expired > 0 {
+		m.resolved = slices.Delete(m.resolved, 0, expired)
+	}
+}
+
+func newAlert(rule Rule, c condition) Alert {
+	labels := maps.Clone(c.labels)
+	if labels == nil {
+		labels = make(map[string]string, 2)
+	}
+	labels["alertname"] = rule.Name
+	labels["severity"] = rule.Severity
+	alert := Alert{
+		ID:       fingerprint(labels),
+		Rule:     rule.Name,
+		Severity: rule.Severity,
+		Labels:   labels,
+		Summary:  c.summary,
+		Value:    c.value,
+	}
+	if rule.summary != nil {
+		var buf bytes.Buffer
+		if err := rule.summary.Execute(&buf, summaryData{Labels: labels, Value: c.value}); err == nil {
+			alert.Summary = buf.String()
+		}
+	}
+	return alert
+}
+
+// summaryData is the data of summary templates.
+type summaryData struct {
+	Labels map[string]string
+	Value  float64
+}
+
+func parseSummary(text string) (*template.Template, error) {
+	return template.New("summary").Option("missingkey=zero").Parse(text)
+}
+
+func fingerprint(labels map[string]string) string {
+	h := fnv.New64a()
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(labels[key]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
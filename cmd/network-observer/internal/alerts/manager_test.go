@@ -0,0 +1,255 @@
+package alerts
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Config      string
+		ExpectError string
+		Expect      func(t *testing.T, cfg Config)
+	}{
+		{
+			Name: "defaults",
+			Config: `
+rules:
+- name: SlowService
+  type: latency
+  threshold: 0.5
+webhooks:
+- url: http://localhost:9093/hook
+`,
+			Expect: func(t *testing.T, cfg Config) {
+				assert.Equal(t, cfg.Interval.Duration, defaultInterval)
+				assert.Equal(t, cfg.Rules[0].Severity, defaultSeverity)
+				assert.Equal(t, cfg.Rules[0].Window.Duration, defaultWindow)
+				assert.Equal(t, cfg.Rules[0].Quantile, defaultQuantile)
+				assert.Equal(t, cfg.Webhooks[0].Format, FormatWebhook)
+			},
+		}, {
+			Name: "full",
+			Config: `
+interval: 10s
+rules:
+- name: LinkDown
+  type: linkDown
+  severity: critical
+  for: 1m
+  summary: "{{ .Labels.link }} down"
+- name: Errors
+  type: errorRate
+  service: backend:8080
+  threshold: 0.1
+  window: 10m
+  minRequests: 20
+webhooks:
+- url: https://hooks.slack.com/services/x
+  format: slack
+- url: http://alertmanager:9093/api/v2/alerts
+  format: alertmanager
+  headers:
+    Authorization: Bearer token
+`,
+			Expect: func(t *testing.T, cfg Config) {
+				assert.Equal(t, cfg.Interval.Duration, 10*time.Second)
+				assert.Equal(t, cfg.Rules[0].For.Duration, time.Minute)
+				assert.Assert(t, cfg.Rules[0].summary != nil)
+				assert.Equal(t, cfg.Rules[1].Window.Duration, 10*time.Minute)
+				assert.Equal(t, cfg.Rules[1].MinRequests, uint64(20))
+				assert.Equal(t, cfg.Webhooks[1].Headers["Authorization"], "Bearer token")
+			},
+		}, {
+			Name:        "unknown field",
+			Config:      "rules:\n- name: a\n  type: linkDown\n  treshold: 1\n",
+			ExpectError: `unknown field "treshold"`,
+		}, {
+			Name:        "unknown type",
+			Config:      "rules:\n- name: a\n  type: linkFlapping\n",
+			ExpectError: `rule "a": unknown type "linkFlapping"`,
+		}, {
+			Name:        "duplicate name",
+			Config:      "rules:\n- name: a\n  type: linkDown\n- name: a\n  type: routerMissing\n",
+			ExpectError: `duplicate rule name "a"`,
+		}, {
+			Name:        "error rate threshold",
+			Config:      "rules:\n- name: a\n  type: errorRate\n  threshold: 5\n",
+			ExpectError: "threshold must be at least 0 and less than 1",
+		}, {
+			Name:        "latency threshold",
+			Config:      "rules:\n- name: a\n  type: latency\n",
+			ExpectError: "threshold must be greater than 0",
+		}, {
+			Name:        "invalid summary",
+			Config:      "rules:\n- name: a\n  type: linkDown\n  summary: \"{{ .Labels\"\n",
+			ExpectError: "invalid summary",
+		}, {
+			Name:        "webhook url",
+			Config:      "webhooks:\n- url: alertmanager:9093\n",
+			ExpectError: `webhook 1: invalid url "alertmanager:9093"`,
+		}, {
+			Name:        "webhook format",
+			Config:      "webhooks:\n- url: http://localhost\n  format: teams\n",
+			ExpectError: `webhook 1: unknown format "teams"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			assert.Assert(t, os.WriteFile(path, []byte(tc.Config), 0o600))
+			cfg, err := LoadConfig(path)
+			if tc.ExpectError != "" {
+				assert.ErrorContains(t, err, tc.ExpectError)
+				return
+			}
+			assert.Assert(t, err)
+			tc.Expect(t, cfg)
+		})
+	}
+}
+
+func TestManagerTopologyRules(t *testing.T) {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	stor.Replace(wrapRecords(
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site1"), Name: ptrTo("west")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router1"), Parent: ptrTo("site1")},
+		vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link1"), Parent: ptrTo("router1"), Name: ptrTo("west-east"), Status: ptrTo("down")},
+		vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link2"), Parent: ptrTo("router1"), Status: ptrTo("up")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener1"), Parent: ptrTo("router1"), Address: ptrTo("backend:8080"), Protocol: ptrTo("tcp")},
+		collector.AddressRecord{ID: "address1", Name: "backend:8080", Protocol: "tcp"},
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site2"), Name: ptrTo("east")},
+	))
+	graph.(reset).Reset()
+
+	m, err := New(slog.Default(), Config{Rules: []Rule{
+		{Name: "LinkDown", Type: RuleLinkDown, For: metav1.Duration{Duration: time.Minute}},
+		{Name: "UnboundService", Type: RuleUnboundService, Severity: "critical", Summary: "{{ .Labels.service }} unbound"},
+		{Name: "RouterMissing", Type: RuleRouterMissing},
+	}}, stor, graph, nil, nil)
+	assert.Assert(t, err)
+	now := time.Unix(1_000_000, 0)
+	m.now = func() time.Time { return now }
+
+	m.evaluate(m.rules, false)
+	alerts := byRule(m.Alerts())
+	assert.Equal(t, len(alerts), 3)
+	assert.Equal(t, alerts["LinkDown"].State, StatePending)
+	assert.DeepEqual(t, alerts["LinkDown"].Labels, map[string]string{
+		"alertname": "LinkDown",
+		"severity":  "warning",
+		"link_id":   "link1",
+		"link":      "west-east",
+		"site_id":   "site1",
+		"site":      "west",
+	})
+	assert.Equal(t, alerts["LinkDown"].Summary, `link "west-east" from site "west" is down`)
+	assert.Equal(t, alerts["UnboundService"].State, StateFiring)
+	assert.Equal(t, alerts["UnboundService"].Labels["severity"], "critical")
+	assert.Equal(t, alerts["UnboundService"].Summary, "backend:8080 unbound")
+	assert.Equal(t, alerts["RouterMissing"].State, StateFiring)
+	assert.Equal(t, alerts["RouterMissing"].Labels["site_id"], "site2")
+
+	now = now.Add(time.Minute)
+	m.evaluate(m.rules, false)
+	alerts = byRule(m.Alerts())
+	assert.Equal(t, alerts["LinkDown"].State, StateFiring)
+	assert.Equal(t, alerts["LinkDown"].FiredAt, now)
+
+	connector := vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("connector1"), Parent: ptrTo("router1"), Address: ptrTo("backend:8080"), Protocol: ptrTo("tcp")}
+	stor.Add(connector, store.SourceRef{})
+	graph.(reset).Reset()
+	stor.Patch(vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link1"), Status: ptrTo("up")}, store.SourceRef{})
+
+	now = now.Add(time.Minute)
+	m.evaluate(m.rules, false)
+	all := m.Alerts()
+	assert.Equal(t, len(all), 3)
+	assert.Equal(t, all[0].Rule, "RouterMissing")
+	assert.Equal(t, all[0].State, StateFiring)
+	for _, alert := range all[1:] {
+		assert.Equal(t, alert.State, StateResolved)
+		assert.Equal(t, alert.EndsAt, now)
+	}
+
+	now = now.Add(resolvedRetention + time.Second)
+	m.evaluate(m.rules, false)
+	assert.Equal(t, len(m.Alerts()), 1)
+}
+
+func TestManagerTrafficRules(t *testing.T) {
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	stor.Replace(wrapRecords(
+		collector.AddressRecord{ID: "address1", Name: "backend:8080", Protocol: "http1"},
+		collector.AddressRecord{ID: "address2", Name: "frontend:8080", Protocol: "http1"},
+	))
+	rules := []Rule{
+		{Name: "Errors", Type: RuleErrorRate, Threshold: 0.5, MinRequests: 4},
+		{Name: "Slow", Type: RuleLatency, Threshold: 0.1, Service: "backend:8080"},
+	}
+
+	_, err := New(slog.Default(), Config{Rules: rules}, stor, graph, nil, nil)
+	assert.ErrorContains(t, err, `rule "Errors" requires the time series`)
+
+	series := timeseries.New(timeseries.Config{Resolution: time.Minute, Retention: time.Hour})
+	m, err := New(slog.Default(), Config{Rules: rules}, stor, graph, series, nil)
+	assert.Assert(t, err)
+
+	backend := series.Group(timeseries.ServiceKey("backend:8080", "http1"))
+	frontend := series.Group(timeseries.ServiceKey("frontend:8080", "http1"))
+	for _, class := range []string{"5xx", "5xx", "5xx", "2xx"} {
+		backend.Request(class)
+		backend.ObserveLatency(0.2)
+	}
+	for _, class := range []string{"5xx", "5xx", "5xx"} {
+		frontend.Request(class)
+		frontend.ObserveLatency(0.2)
+	}
+
+	m.evaluate(m.rules, false)
+	alerts := m.Alerts()
+	assert.Equal(t, len(alerts), 2)
+	found := byRule(alerts)
+	assert.Equal(t, found["Errors"].Labels["service"], "backend:8080")
+	assert.Equal(t, found["Errors"].Value, 0.75)
+	assert.Equal(t, found["Errors"].Summary, `service "backend:8080" error rate is 75.0% over 5m0s`)
+	assert.Equal(t, found["Slow"].Labels["service"], "backend:8080")
+	assert.Assert(t, found["Slow"].Value > 0.1)
+}
+
+type reset interface {
+	Reset()
+}
+
+func byRule(alerts []Alert) map[string]Alert {
+	out := make(map[string]Alert, len(alerts))
+	for _, alert := range alerts {
+		out[alert.Rule] = alert
+	}
+	return out
+}
+
+func wrapRecords(records ...vanflow.Record) []store.Entry {
+	entries := make([]store.Entry, len(records))
+	for i := range records {
+		entries[i].Record = records[i]
+	}
+	return entries
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
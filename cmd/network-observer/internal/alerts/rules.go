@@ -0,0 +1,217 @@
+package alerts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+)
+
+// condition is an occurrence of the condition of a rule, such as one link
+// that is down.
+type condition struct {
+	labels  map[string]string
+	summary string
+	value   float64
+}
+
+// evaluator finds the conditions of rules in the collector's records and
+// the time series of services.
+type evaluator struct {
+	records store.Interface
+	graph   collector.Graph
+	series  *timeseries.Store
+}
+
+func (e evaluator) evaluate(rule Rule) []condition {
+	switch rule.Type {
+	case RuleLinkDown:
+		return e.linksDown()
+	case RuleUnboundService:
+		return e.unboundServices(rule)
+	case RuleRouterMissing:
+		return e.sitesWithoutRouters()
+	case RuleErrorRate:
+		return e.serviceErrorRates(rule)
+	case RuleLatency:
+		return e.serviceLatencies(rule)
+	default:
+		return nil
+	}
+}
+
+func (e evaluator) linksDown() []condition {
+	var conditions []condition
+	for _, entry := range e.records.Index(store.TypeIndex, store.Entry{Record: vanflow.LinkRecord{}}) {
+		link, ok := entry.Record.(vanflow.LinkRecord)
+		if !ok || ended(link.BaseRecord) || strings.EqualFold(dref(link.Status), "up") {
+			continue
+		}
+		labels := map[string]string{
+			"link_id": link.ID,
+			"link":    nameOr(link.Name, link.ID),
+		}
+		site := e.graph.Link(link.ID).Parent().Parent()
+		if record, ok := site.GetRecord(); ok {
+			labels["site_id"] = record.ID
+			labels["site"] = nameOr(record.Name, record.ID)
+		}
+		if link.Peer != nil {
+			peer := e.graph.RouterAccess(*link.Peer).Parent().Parent()
+			if record, ok := peer.GetRecord(); ok {
+				labels["peer_site_id"] = record.ID
+				labels["peer_site"] = nameOr(record.Name, record.ID)
+			}
+		}
+		summary := fmt.Sprintf("link %q is down", labels["link"])
+		if site, ok := labels["site"]; ok {
+			summary = fmt.Sprintf("link %q from site %q is down", labels["link"], site)
+		}
+		conditions = append(conditions, condition{labels: labels, summary: summary})
+	}
+	return conditions
+}
+
+func (e evaluator) unboundServices(rule Rule) []condition {
+	var conditions []condition
+	for _, service := range e.services(rule) {
+		routingKey := e.graph.Address(service.ID).RoutingKey()
+		if len(routingKey.Listeners()) == 0 || len(routingKey.Connectors()) > 0 {
+			continue
+		}
+		conditions = append(conditions, condition{
+			labels:  serviceLabels(service),
+			summary: fmt.Sprintf("service %q has listeners but no connectors", service.Name),
+		})
+	}
+	return conditions
+}
+
+func (e evaluator) sitesWithoutRouters() []condition {
+	var conditions []condition
+	for _, entry := range e.records.Index(store.TypeIndex, store.Entry{Record: vanflow.SiteRecord{}}) {
+		site, ok := entry.Record.(vanflow.SiteRecord)
+		if !ok || ended(site.BaseRecord) {
+			continue
+		}
+		var running int
+		for _, router := range e.graph.Site(site.ID).Routers() {
+			if record, ok := router.GetRecord(); ok && !ended(record.BaseRecord) {
+				running++
+			}
+		}
+		if running > 0 {
+			continue
+		}
+		name := nameOr(site.Name, site.ID)
+		conditions = append(conditions, condition{
+			labels: map[string]string{
+				"site_id": site.ID,
+				"site":    name,
+			},
+			summary: fmt.Sprintf("site %q has no running router", name),
+		})
+	}
+	return conditions
+}
+
+func (e evaluator) serviceErrorRates(rule Rule) []condition {
+	var conditions []condition
+	for _, service := range e.services(rule) {
+		point, ok := e.window(service, rule.Window.Duration)
+		if !ok {
+			continue
+		}
+		var total uint64
+		for _, n := range point.Requests {
+			total += n
+		}
+		if total == 0 || total < rule.MinRequests {
+			continue
+		}
+		rate := float64(point.Requests["5xx"]) / float64(total)
+		if rate <= rule.Threshold {
+			continue
+		}
+		conditions = append(conditions, condition{
+			labels:  serviceLabels(service),
+			value:   rate,
+			summary: fmt.Sprintf("service %q error rate is %.1f%% over %s", service.Name, rate*100, rule.Window.Duration),
+		})
+	}
+	return conditions
+}
+
+func (e evaluator) serviceLatencies(rule Rule) []condition {
+	var conditions []condition
+	for _, service := range e.services(rule) {
+		point, ok := e.window(service, rule.Window.Duration)
+		if !ok || point.Latency.Count == 0 {
+			continue
+		}
+		latency := point.Latency.Quantile(rule.Quantile)
+		if latency <= rule.Threshold {
+			continue
+		}
+		conditions = append(conditions, condition{
+			labels: serviceLabels(service),
+			value:  latency,
+			summary: fmt.Sprintf("service %q p%g latency is %s over %s",
+				service.Name, rule.Quantile*100, time.Duration(latency*float64(time.Second)).Round(time.Millisecond), rule.Window.Duration),
+		})
+	}
+	return conditions
+}
+
+// services returns the services a rule applies to.
+func (e evaluator) services(rule Rule) []collector.AddressRecord {
+	var services []collector.AddressRecord
+	for _, entry := range e.records.Index(store.TypeIndex, store.Entry{Record: collector.AddressRecord{}}) {
+		service, ok := entry.Record.(collector.AddressRecord)
+		if !ok || (rule.Service != "" && service.Name != rule.Service) {
+			continue
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+// window returns the traffic of a service aggregated over the window.
+func (e evaluator) window(service collector.AddressRecord, window time.Duration) (timeseries.Point, bool) {
+	points, err := e.series.Query(timeseries.ServiceKey(service.Name, service.Protocol), window, window)
+	if err != nil || len(points) == 0 {
+		return timeseries.Point{}, false
+	}
+	return points[len(points)-1], true
+}
+
+func serviceLabels(service collector.AddressRecord) map[string]string {
+	return map[string]string{
+		"service_id": service.ID,
+		"service":    service.Name,
+		"protocol":   service.Protocol,
+	}
+}
+
+func ended(b vanflow.BaseRecord) bool {
+	return b.EndTime != nil && b.EndTime.After(time.Unix(0, 0))
+}
+
+func nameOr(name *string, fallback string) string {
+	if name == nil || *name == "" {
+		return fallback
+	}
+	return *name
+}
+
+func dref[T any](ptr *T) T {
+	var t T
+	if ptr != nil {
+		return *ptr
+	}
+	return t
+}
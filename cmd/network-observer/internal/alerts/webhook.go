@@ -0,0 +1,278 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	webhookQueueSize = 64
+	webhookTimeout   = 10 * time.Second
+	// webhookRetryTime is how long a notification is retried before it is
+	// dropped.
+	webhookRetryTime = 2 * time.Minute
+	receiverName     = "skupper-network-observer"
+)
+
+// notifier delivers notifications to webhooks. Each webhook has its own
+// queue so that one failing webhook does not delay the others.
+type notifier struct {
+	logger   *slog.Logger
+	client   *http.Client
+	targets  []*target
+	interval time.Duration
+}
+
+type target struct {
+	Webhook
+	queue   chan []byte
+	dropped atomic.Int64
+}
+
+func newNotifier(logger *slog.Logger, webhooks []Webhook, interval time.Duration) *notifier {
+	n := &notifier{
+		logger:   logger,
+		client:   &http.Client{Timeout: webhookTimeout},
+		interval: interval,
+	}
+	for _, webhook := range webhooks {
+		n.targets = append(n.targets, &target{
+			Webhook: webhook,
+			queue:   make(chan []byte, webhookQueueSize),
+		})
+	}
+	return n
+}
+
+// notify queues a notification of alerts that fired or resolved for each
+// webhook.
+func (n *notifier) notify(now time.Time, status State, alerts []Alert) {
+	if len(alerts) == 0 {
+		return
+	}
+	for _, t := range n.targets {
+		var (
+			body []byte
+			err  error
+		)
+		switch t.Format {
+		case FormatAlertmanager:
+			body, err = json.Marshal(n.alertmanagerAlerts(now, alerts))
+		case FormatSlack:
+			body, err = json.Marshal(slackMessage(status, alerts))
+		default:
+			body, err = json.Marshal(webhookMessage(status, alerts))
+		}
+		if err != nil {
+			n.logger.Error("failed to encode notification", slog.String("url", t.URL), slog.Any("error", err))
+			continue
+		}
+		t.enqueue(body)
+	}
+}
+
+// refresh resends the firing alerts to Alertmanager webhooks, which
+// resolve alerts that are not resent before they end.
+func (n *notifier) refresh(now time.Time, firing []Alert) {
+	if len(firing) == 0 {
+		return
+	}
+	for _, t := range n.targets {
+		if t.Format != FormatAlertmanager {
+			continue
+		}
+		body, err := json.Marshal(n.alertmanagerAlerts(now, firing))
+		if err != nil {
+			n.logger.Error("failed to encode notification", slog.String("url", t.URL), slog.Any("error", err))
+			continue
+		}
+		t.enqueue(body)
+	}
+}
+
+func (t *target) enqueue(body []byte) {
+	select {
+	case t.queue <- body:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+// run delivers queued notifications until the context is cancelled.
+func (n *notifier) run(ctx context.Context) {
+	done := make(chan struct{})
+	for _, t := range n.targets {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case body := <-t.queue:
+					if dropped := t.dropped.Swap(0); dropped > 0 {
+						n.logger.Warn("dropped webhook notifications: queue full",
+							slog.String("url", t.URL), slog.Int64("count", dropped))
+					}
+					if err := n.deliver(ctx, t, body); err != nil && ctx.Err() == nil {
+						n.logger.Error("failed to deliver webhook notification",
+							slog.String("url", t.URL), slog.Any("error", err))
+					}
+				}
+			}
+		}()
+	}
+	for range n.targets {
+		<-done
+	}
+}
+
+func (n *notifier) deliver(ctx context.Context, t *target, body []byte) error {
+	b := backoff.WithContext(backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(time.Second),
+		backoff.WithMaxInterval(30*time.Second),
+		backoff.WithMaxElapsedTime(webhookRetryTime),
+	), ctx)
+	return backoff.Retry(func() error {
+		return n.post(ctx, t, body)
+	}, b)
+}
+
+func (n *notifier) post(ctx context.Context, t *target, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("unexpected response from %s: %s", t.URL, resp.Status)
+	default:
+		return backoff.Permanent(fmt.Errorf("unexpected response from %s: %s", t.URL, resp.Status))
+	}
+}
+
+// amAlert is an alert in the form used by Alertmanager.
+type amAlert struct {
+	Status       string            `json:"status,omitempty"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+	Fingerprint  string            `json:"fingerprint,omitempty"`
+}
+
+func annotations(alert Alert) map[string]string {
+	out := map[string]string{"summary": alert.Summary}
+	if alert.Value != 0 {
+		out["value"] = fmt.Sprintf("%g", alert.Value)
+	}
+	return out
+}
+
+// alertmanagerAlerts are alerts for the Alertmanager v2 API. Firing alerts
+// end a few evaluation intervals in the future, so that Alertmanager
+// resolves them if the network observer stops sending them.
+func (n *notifier) alertmanagerAlerts(now time.Time, alerts []Alert) []amAlert {
+	out := make([]amAlert, len(alerts))
+	for i, alert := range alerts {
+		endsAt := alert.EndsAt
+		if alert.State == StateFiring {
+			endsAt = now.Add(4 * n.interval)
+		}
+		out[i] = amAlert{
+			Labels:      alert.Labels,
+			Annotations: annotations(alert),
+			StartsAt:    alert.StartsAt,
+			EndsAt:      endsAt,
+		}
+	}
+	return out
+}
+
+// webhookPayload is the message format of Alertmanager webhook receivers.
+type webhookPayload struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []amAlert         `json:"alerts"`
+}
+
+func webhookMessage(status State, alerts []Alert) webhookPayload {
+	out := webhookPayload{
+		Version:     "4",
+		GroupKey:    "{}:{}",
+		Status:      string(status),
+		Receiver:    receiverName,
+		GroupLabels: map[string]string{},
+		Alerts:      make([]amAlert, len(alerts)),
+	}
+	for i, alert := range alerts {
+		out.Alerts[i] = amAlert{
+			Status:      string(alert.State),
+			Labels:      alert.Labels,
+			Annotations: annotations(alert),
+			StartsAt:    alert.StartsAt,
+			EndsAt:      alert.EndsAt,
+			Fingerprint: alert.ID,
+		}
+		if i == 0 {
+			out.CommonLabels = maps.Clone(alert.Labels)
+			out.CommonAnnotations = maps.Clone(out.Alerts[i].Annotations)
+			continue
+		}
+		intersect(out.CommonLabels, alert.Labels)
+		intersect(out.CommonAnnotations, out.Alerts[i].Annotations)
+	}
+	return out
+}
+
+// intersect removes entries of common that differ in m.
+func intersect(common, m map[string]string) {
+	for key, value := range common {
+		if m[key] != value {
+			delete(common, key)
+		}
+	}
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+func slackMessage(status State, alerts []Alert) slackPayload {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*[%s:%d]* skupper network observer", strings.ToUpper(string(status)), len(alerts))
+	for _, alert := range alerts {
+		fmt.Fprintf(&text, "\n• *%s* (%s): %s", alert.Rule, alert.Severity, alert.Summary)
+	}
+	return slackPayload{Text: text.String()}
+}
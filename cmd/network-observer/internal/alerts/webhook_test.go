@@ -0,0 +1,130 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type delivery struct {
+	Path    string
+	Header  http.Header
+	Payload json.RawMessage
+}
+
+func TestNotifierDelivery(t *testing.T) {
+	received := make(chan delivery, 8)
+	var unavailable atomic.Bool
+	unavailable.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// fail the first alertmanager request to exercise retries
+		if r.URL.Path == "/api/v2/alerts" && unavailable.Swap(false) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- delivery{Path: r.URL.Path, Header: r.Header, Payload: body}
+	}))
+	defer srv.Close()
+
+	n := newNotifier(slog.Default(), []Webhook{
+		{URL: srv.URL + "/hook", Format: FormatWebhook, Headers: map[string]string{"Authorization": "Bearer secret"}},
+		{URL: srv.URL + "/api/v2/alerts", Format: FormatAlertmanager},
+		{URL: srv.URL + "/slack", Format: FormatSlack},
+	}, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go n.run(ctx)
+
+	startsAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := startsAt.Add(time.Minute)
+	alerts := []Alert{
+		{
+			ID: "a1", Rule: "LinkDown", Severity: "critical", State: StateFiring,
+			Labels:  map[string]string{"alertname": "LinkDown", "severity": "critical", "link": "west-east"},
+			Summary: `link "west-east" is down`, StartsAt: startsAt, FiredAt: now,
+		}, {
+			ID: "a2", Rule: "LinkDown", Severity: "critical", State: StateFiring,
+			Labels:  map[string]string{"alertname": "LinkDown", "severity": "critical", "link": "east-south"},
+			Summary: `link "east-south" is down`, StartsAt: startsAt, FiredAt: now,
+		},
+	}
+	n.notify(now, StateFiring, alerts)
+
+	deliveries := make(map[string]delivery)
+	for len(deliveries) < 3 {
+		select {
+		case d := <-received:
+			deliveries[d.Path] = d
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for deliveries, got %d", len(deliveries))
+		}
+	}
+
+	hook := deliveries["/hook"]
+	assert.Equal(t, hook.Header.Get("Content-Type"), "application/json")
+	assert.Equal(t, hook.Header.Get("Authorization"), "Bearer secret")
+	var message webhookPayload
+	assert.Assert(t, json.Unmarshal(hook.Payload, &message))
+	assert.Equal(t, message.Version, "4")
+	assert.Equal(t, message.Status, "firing")
+	assert.DeepEqual(t, message.CommonLabels, map[string]string{"alertname": "LinkDown", "severity": "critical"})
+	assert.Equal(t, len(message.Alerts), 2)
+	assert.Equal(t, message.Alerts[0].Fingerprint, "a1")
+	assert.Equal(t, message.Alerts[0].Annotations["summary"], `link "west-east" is down`)
+
+	var posted []amAlert
+	assert.Assert(t, json.Unmarshal(deliveries["/api/v2/alerts"].Payload, &posted))
+	assert.Equal(t, len(posted), 2)
+	assert.Equal(t, posted[1].Labels["link"], "east-south")
+	assert.Assert(t, posted[1].StartsAt.Equal(startsAt))
+	assert.Assert(t, posted[1].EndsAt.Equal(now.Add(4*time.Minute)))
+
+	var slack slackPayload
+	assert.Assert(t, json.Unmarshal(deliveries["/slack"].Payload, &slack))
+	assert.Assert(t, strings.HasPrefix(slack.Text, "*[FIRING:2]*"), slack.Text)
+	assert.Assert(t, strings.Contains(slack.Text, `link "east-south" is down`), slack.Text)
+
+	// only alertmanager is sent firing alerts again
+	n.refresh(now.Add(time.Minute), alerts[:1])
+	select {
+	case d := <-received:
+		assert.Equal(t, d.Path, "/api/v2/alerts")
+		assert.Assert(t, json.Unmarshal(d.Payload, &posted))
+		assert.Equal(t, len(posted), 1)
+		assert.Assert(t, posted[0].EndsAt.Equal(now.Add(5*time.Minute)))
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for refresh")
+	}
+
+	resolved := alerts[0]
+	resolved.State, resolved.EndsAt = StateResolved, now.Add(2*time.Minute)
+	n.notify(resolved.EndsAt, StateResolved, []Alert{resolved})
+	deliveries = make(map[string]delivery)
+	for len(deliveries) < 3 {
+		select {
+		case d := <-received:
+			deliveries[d.Path] = d
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for deliveries, got %d", len(deliveries))
+		}
+	}
+	assert.Assert(t, json.Unmarshal(deliveries["/hook"].Payload, &message))
+	assert.Equal(t, message.Status, "resolved")
+	assert.Equal(t, message.Alerts[0].Status, "resolved")
+	assert.Assert(t, json.Unmarshal(deliveries["/api/v2/alerts"].Payload, &posted))
+	assert.Assert(t, posted[0].EndsAt.Equal(resolved.EndsAt))
+}
@@ -23,6 +23,13 @@ const (
 	Remote   ProcessRecordRole = "remote"
 )
 
+// Defines values for AlertState.
+const (
+	Firing   AlertState = "firing"
+	Pending  AlertState = "pending"
+	Resolved AlertState = "resolved"
+)
+
 // Defines values for FlowAggregatePairType.
 const (
 	PROCESS      FlowAggregatePairType = "PROCESS"
@@ -55,6 +62,26 @@ const (
 	NONE ProxyProtocolType = "NONE"
 )
 
+// Defines values for RecordEventAction.
+const (
+	Add    RecordEventAction = "add"
+	Delete RecordEventAction = "delete"
+	Update RecordEventAction = "update"
+)
+
+// Defines values for RecordEventType.
+const (
+	Component    RecordEventType = "component"
+	Connector    RecordEventType = "connector"
+	Link         RecordEventType = "link"
+	Listener     RecordEventType = "listener"
+	Process      RecordEventType = "process"
+	Router       RecordEventType = "router"
+	Routeraccess RecordEventType = "routeraccess"
+	Service      RecordEventType = "service"
+	Site         RecordEventType = "site"
+)
+
 // Defines values for SitePlatformType.
 const (
 	SitePlatformTypeDocker     SitePlatformType = "docker"
@@ -64,6 +91,42 @@ const (
 	SitePlatformTypeUnknown    SitePlatformType = "unknown"
 )
 
+// AlertListResponse defines model for AlertListResponse.
+type AlertListResponse struct {
+	// Count number of results in response
+	Count   int64         `json:"count"`
+	Results []AlertRecord `json:"results"`
+
+	// TimeRangeCount number of results matching filtering and time range constraints before any limit or offset is applied.
+	TimeRangeCount int64 `json:"timeRangeCount"`
+}
+
+// AlertRecord defines model for AlertRecord.
+type AlertRecord struct {
+	// EndTime The end time in microseconds of the record in Unix timestamp format.
+	EndTime uint64 `json:"endTime"`
+
+	// Identity The unique identifier for the record.
+	Identity string `json:"identity"`
+
+	// Labels Labels identifying the alert, including alertname and severity.
+	Labels map[string]string `json:"labels"`
+
+	// Rule The name of the rule.
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+
+	// StartTime The creation time in microseconds of the record in Unix timestamp format. The value 0 means that the record is not terminated
+	StartTime uint64 `json:"startTime"`
+
+	// State The state of an alert. An alert is pending until its condition has held for the duration of its rule.
+	State   AlertState `json:"state"`
+	Summary string     `json:"summary"`
+
+	// Value The error rate or latency in seconds of service traffic rules, 0 for other rules.
+	Value float64 `json:"value"`
+}
+
 // ApplicationFlowRecord defines model for ApplicationFlowRecord.
 type ApplicationFlowRecord struct {
 	ConnectionId    string  `json:"connectionId"`
@@ -280,6 +343,17 @@ type FlowAggregateResponse struct {
 	Results FlowAggregateRecord `json:"results"`
 }
 
+// LatencyHistogram defines model for LatencyHistogram.
+type LatencyHistogram struct {
+	Count uint64 `json:"count"`
+
+	// Counts Number of connection latencies in each bucket, not cumulative.
+	Counts []uint64 `json:"counts"`
+
+	// Sum Sum of the connection latencies in seconds.
+	Sum float64 `json:"sum"`
+}
+
 // ListenerListResponse defines model for ListenerListResponse.
 type ListenerListResponse struct {
 	// Count number of results in response
@@ -370,6 +444,18 @@ type ProcessResponse struct {
 	Results ProcessRecord `json:"results"`
 }
 
+// RecordEvent defines model for RecordEvent.
+type RecordEvent struct {
+	// Action The change made to a record.
+	Action RecordEventAction `json:"action"`
+
+	// Record The record in the same form as it is returned by the list endpoint for its type.
+	Record interface{} `json:"record"`
+
+	// Type The type of record a RecordEvent refers to.
+	Type RecordEventType `json:"type"`
+}
+
 // RouterAccessListResponse defines model for RouterAccessListResponse.
 type RouterAccessListResponse struct {
 	// Count number of results in response
@@ -574,6 +660,42 @@ type SiteResponse struct {
 	Results SiteRecord `json:"results"`
 }
 
+// TimeSeries defines model for TimeSeries.
+type TimeSeries struct {
+	// LatencyBuckets The upper bounds in seconds of the latency histogram buckets. Histograms have one additional bucket for greater latencies.
+	LatencyBuckets []float64         `json:"latencyBuckets"`
+	Points         []TimeSeriesPoint `json:"points"`
+
+	// Step The interval in microseconds aggregated by each point.
+	Step uint64 `json:"step"`
+}
+
+// TimeSeriesPoint defines model for TimeSeriesPoint.
+type TimeSeriesPoint struct {
+	// BytesReceived Bytes sent back from servers to clients.
+	BytesReceived uint64 `json:"bytesReceived"`
+
+	// BytesSent Bytes sent from clients to servers.
+	BytesSent         uint64           `json:"bytesSent"`
+	ConnectionsClosed uint64           `json:"connectionsClosed"`
+	ConnectionsOpened uint64           `json:"connectionsOpened"`
+	Latency           LatencyHistogram `json:"latency"`
+
+	// Requests Number of completed requests by HTTP response class: 1xx, 2xx, 3xx, 4xx, 5xx or unknown.
+	Requests map[string]uint64 `json:"requests"`
+
+	// Timestamp The start time in microseconds of the point in Unix timestamp format.
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// TimeSeriesResponse defines model for TimeSeriesResponse.
+type TimeSeriesResponse struct {
+	Results TimeSeries `json:"results"`
+}
+
+// AlertState The state of an alert. An alert is pending until its condition has held for the duration of its rule.
+type AlertState string
+
 // BaseRecord defines model for baseRecord.
 type BaseRecord struct {
 	// EndTime The end time in microseconds of the record in Unix timestamp format.
@@ -610,6 +732,12 @@ type ProcessBindingType string
 // ProxyProtocolType The proxy protocol used when the link connection is established.
 type ProxyProtocolType string
 
+// RecordEventAction The change made to a record.
+type RecordEventAction string
+
+// RecordEventType The type of record a RecordEvent refers to.
+type RecordEventType string
+
 // ServiceIdentifierType a special string for identifying services uses the form `name@identity@protocol`
 type ServiceIdentifierType = AtmarkDelimitedString
 
@@ -619,12 +747,21 @@ type SitePlatformType string
 // PathID defines model for pathID.
 type PathID = string
 
+// TimeSeriesRange defines model for timeSeriesRange.
+type TimeSeriesRange = string
+
+// TimeSeriesStep defines model for timeSeriesStep.
+type TimeSeriesStep = string
+
 // ErrorBadRequest defines model for errorBadRequest.
 type ErrorBadRequest = ErrorResponse
 
 // ErrorNotFound defines model for errorNotFound.
 type ErrorNotFound = ErrorResponse
 
+// GetAlerts defines model for getAlerts.
+type GetAlerts = AlertListResponse
+
 // GetApplicationFlows defines model for getApplicationFlows.
 type GetApplicationFlows = ApplicationFlowResponse
 
@@ -691,9 +828,44 @@ type GetSiteByID = SiteResponse
 // GetSites defines model for getSites.
 type GetSites = SiteListResponse
 
+// GetTimeSeries defines model for getTimeSeries.
+type GetTimeSeries = TimeSeriesResponse
+
 // NotSupported defines model for notSupported.
 type NotSupported = ErrorResponse
 
+// EventsParams defines parameters for Events.
+type EventsParams struct {
+	Types []RecordEventType `form:"types" json:"types"`
+}
+
+// TimeSeriesByProcesspairParams defines parameters for TimeSeriesByProcesspair.
+type TimeSeriesByProcesspairParams struct {
+	// Range How far back from now the series covers, as a duration such as 30m. Defaults to, and is limited by, the retention period of the network observer.
+	Range *TimeSeriesRange `form:"range,omitempty" json:"range,omitempty"`
+
+	// Step The interval aggregated by each point, as a duration such as 1m. Defaults to the resolution of the network observer and is rounded up to a multiple of it.
+	Step *TimeSeriesStep `form:"step,omitempty" json:"step,omitempty"`
+}
+
+// TimeSeriesByServiceParams defines parameters for TimeSeriesByService.
+type TimeSeriesByServiceParams struct {
+	// Range How far back from now the series covers, as a duration such as 30m. Defaults to, and is limited by, the retention period of the network observer.
+	Range *TimeSeriesRange `form:"range,omitempty" json:"range,omitempty"`
+
+	// Step The interval aggregated by each point, as a duration such as 1m. Defaults to the resolution of the network observer and is rounded up to a multiple of it.
+	Step *TimeSeriesStep `form:"step,omitempty" json:"step,omitempty"`
+}
+
+// TimeSeriesBySitepairParams defines parameters for TimeSeriesBySitepair.
+type TimeSeriesBySitepairParams struct {
+	// Range How far back from now the series covers, as a duration such as 30m. Defaults to, and is limited by, the retention period of the network observer.
+	Range *TimeSeriesRange `form:"range,omitempty" json:"range,omitempty"`
+
+	// Step The interval aggregated by each point, as a duration such as 1m. Defaults to the resolution of the network observer and is rounded up to a multiple of it.
+	Step *TimeSeriesStep `form:"step,omitempty" json:"step,omitempty"`
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -767,6 +939,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// Alerts request
+	Alerts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Applicationflows request
 	Applicationflows(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -791,6 +966,9 @@ type ClientInterface interface {
 	// ConnectorByID request
 	ConnectorByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// Events request
+	Events(ctx context.Context, params *EventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Hosts request
 	Hosts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -815,6 +993,9 @@ type ClientInterface interface {
 	// ProcesspairByID request
 	ProcesspairByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TimeSeriesByProcesspair request
+	TimeSeriesByProcesspair(ctx context.Context, id PathID, params *TimeSeriesByProcesspairParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Routeraccess request
 	Routeraccess(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -848,12 +1029,18 @@ type ClientInterface interface {
 	// ProcessPairsByService request
 	ProcessPairsByService(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TimeSeriesByService request
+	TimeSeriesByService(ctx context.Context, id PathID, params *TimeSeriesByServiceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Sitepairs request
 	Sitepairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SitepairByID request
 	SitepairByID(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// TimeSeriesBySitepair request
+	TimeSeriesBySitepair(ctx context.Context, id PathID, params *TimeSeriesBySitepairParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Sites request
 	Sites(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -870,6 +1057,18 @@ type ClientInterface interface {
 	RoutersBySite(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) Alerts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAlertsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Applicationflows(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewApplicationflowsRequest(c.Server)
 	if err != nil {
@@ -966,6 +1165,18 @@ func (c *Client) ConnectorByID(ctx context.Context, id PathID, reqEditors ...Req
 	return c.Client.Do(req)
 }
 
+func (c *Client) Events(ctx context.Context, params *EventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Hosts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewHostsRequest(c.Server)
 	if err != nil {
@@ -1062,6 +1273,18 @@ func (c *Client) ProcesspairByID(ctx context.Context, id PathID, reqEditors ...R
 	return c.Client.Do(req)
 }
 
+func (c *Client) TimeSeriesByProcesspair(ctx context.Context, id PathID, params *TimeSeriesByProcesspairParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTimeSeriesByProcesspairRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Routeraccess(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewRouteraccessRequest(c.Server)
 	if err != nil {
@@ -1194,6 +1417,18 @@ func (c *Client) ProcessPairsByService(ctx context.Context, id PathID, reqEditor
 	return c.Client.Do(req)
 }
 
+func (c *Client) TimeSeriesByService(ctx context.Context, id PathID, params *TimeSeriesByServiceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTimeSeriesByServiceRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Sitepairs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSitepairsRequest(c.Server)
 	if err != nil {
@@ -1218,6 +1453,18 @@ func (c *Client) SitepairByID(ctx context.Context, id PathID, reqEditors ...Requ
 	return c.Client.Do(req)
 }
 
+func (c *Client) TimeSeriesBySitepair(ctx context.Context, id PathID, params *TimeSeriesBySitepairParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTimeSeriesBySitepairRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) Sites(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSitesRequest(c.Server)
 	if err != nil {
@@ -1278,6 +1525,33 @@ func (c *Client) RoutersBySite(ctx context.Context, id PathID, reqEditors ...Req
 	return c.Client.Do(req)
 }
 
+// NewAlertsRequest generates requests for Alerts
+func NewAlertsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/alerts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewApplicationflowsRequest generates requests for Applicationflows
 func NewApplicationflowsRequest(server string) (*http.Request, error) {
 	var err error
@@ -1515,6 +1789,51 @@ func NewConnectorByIDRequest(server string, id PathID) (*http.Request, error) {
 	return req, nil
 }
 
+// NewEventsRequest generates requests for Events
+func NewEventsRequest(server string, params *EventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", false, "types", runtime.ParamLocationQuery, params.Types); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewHostsRequest generates requests for Hosts
 func NewHostsRequest(server string) (*http.Request, error) {
 	var err error
@@ -1759,6 +2078,78 @@ func NewProcesspairByIDRequest(server string, id PathID) (*http.Request, error)
 	return req, nil
 }
 
+// NewTimeSeriesByProcesspairRequest generates requests for TimeSeriesByProcesspair
+func NewTimeSeriesByProcesspairRequest(server string, id PathID, params *TimeSeriesByProcesspairParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/processpairs/%s/timeseries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Range != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "range", runtime.ParamLocationQuery, *params.Range); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Step != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "step", runtime.ParamLocationQuery, *params.Step); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewRouteraccessRequest generates requests for Routeraccess
 func NewRouteraccessRequest(server string) (*http.Request, error) {
 	var err error
@@ -2105,16 +2496,23 @@ func NewProcessPairsByServiceRequest(server string, id PathID) (*http.Request, e
 	return req, nil
 }
 
-// NewSitepairsRequest generates requests for Sitepairs
-func NewSitepairsRequest(server string) (*http.Request, error) {
+// NewTimeSeriesByServiceRequest generates requests for TimeSeriesByService
+func NewTimeSeriesByServiceRequest(server string, id PathID, params *TimeSeriesByServiceParams) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v2alpha1/sitepairs")
+	operationPath := fmt.Sprintf("/api/v2alpha1/services/%s/timeseries", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2124,6 +2522,44 @@ func NewSitepairsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Range != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "range", runtime.ParamLocationQuery, *params.Range); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Step != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "step", runtime.ParamLocationQuery, *params.Step); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -2132,23 +2568,16 @@ func NewSitepairsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewSitepairByIDRequest generates requests for SitepairByID
-func NewSitepairByIDRequest(server string, id PathID) (*http.Request, error) {
+// NewSitepairsRequest generates requests for Sitepairs
+func NewSitepairsRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v2alpha1/sitepairs/%s", pathParam0)
+	operationPath := fmt.Sprintf("/api/v2alpha1/sitepairs")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2166,11 +2595,117 @@ func NewSitepairByIDRequest(server string, id PathID) (*http.Request, error) {
 	return req, nil
 }
 
-// NewSitesRequest generates requests for Sites
-func NewSitesRequest(server string) (*http.Request, error) {
+// NewSitepairByIDRequest generates requests for SitepairByID
+func NewSitepairByIDRequest(server string, id PathID) (*http.Request, error) {
 	var err error
 
-	serverURL, err := url.Parse(server)
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/sitepairs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTimeSeriesBySitepairRequest generates requests for TimeSeriesBySitepair
+func NewTimeSeriesBySitepairRequest(server string, id PathID, params *TimeSeriesBySitepairParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v2alpha1/sitepairs/%s/timeseries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Range != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "range", runtime.ParamLocationQuery, *params.Range); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Step != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "step", runtime.ParamLocationQuery, *params.Step); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSitesRequest generates requests for Sites
+func NewSitesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
@@ -2372,6 +2907,9 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// AlertsWithResponse request
+	AlertsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AlertsResponse, error)
+
 	// ApplicationflowsWithResponse request
 	ApplicationflowsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ApplicationflowsResponse, error)
 
@@ -2396,6 +2934,9 @@ type ClientWithResponsesInterface interface {
 	// ConnectorByIDWithResponse request
 	ConnectorByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ConnectorByIDResponse, error)
 
+	// EventsWithResponse request
+	EventsWithResponse(ctx context.Context, params *EventsParams, reqEditors ...RequestEditorFn) (*EventsResponse, error)
+
 	// HostsWithResponse request
 	HostsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HostsResponse, error)
 
@@ -2420,6 +2961,9 @@ type ClientWithResponsesInterface interface {
 	// ProcesspairByIDWithResponse request
 	ProcesspairByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcesspairByIDResponse, error)
 
+	// TimeSeriesByProcesspairWithResponse request
+	TimeSeriesByProcesspairWithResponse(ctx context.Context, id PathID, params *TimeSeriesByProcesspairParams, reqEditors ...RequestEditorFn) (*TimeSeriesByProcesspairResponse, error)
+
 	// RouteraccessWithResponse request
 	RouteraccessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RouteraccessResponse, error)
 
@@ -2453,12 +2997,18 @@ type ClientWithResponsesInterface interface {
 	// ProcessPairsByServiceWithResponse request
 	ProcessPairsByServiceWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*ProcessPairsByServiceResponse, error)
 
+	// TimeSeriesByServiceWithResponse request
+	TimeSeriesByServiceWithResponse(ctx context.Context, id PathID, params *TimeSeriesByServiceParams, reqEditors ...RequestEditorFn) (*TimeSeriesByServiceResponse, error)
+
 	// SitepairsWithResponse request
 	SitepairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitepairsResponse, error)
 
 	// SitepairByIDWithResponse request
 	SitepairByIDWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*SitepairByIDResponse, error)
 
+	// TimeSeriesBySitepairWithResponse request
+	TimeSeriesBySitepairWithResponse(ctx context.Context, id PathID, params *TimeSeriesBySitepairParams, reqEditors ...RequestEditorFn) (*TimeSeriesBySitepairResponse, error)
+
 	// SitesWithResponse request
 	SitesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitesResponse, error)
 
@@ -2475,10 +3025,34 @@ type ClientWithResponsesInterface interface {
 	RoutersBySiteWithResponse(ctx context.Context, id PathID, reqEditors ...RequestEditorFn) (*RoutersBySiteResponse, error)
 }
 
+type AlertsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetAlerts
+	JSON400      *ErrorBadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r AlertsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AlertsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ApplicationflowsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *GetApplicationFlows
+	JSON400      *ErrorBadRequest
 }
 
 // Status returns HTTPResponse.Status
@@ -2593,6 +3167,7 @@ type ConnectionsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *GetConnections
+	JSON400      *ErrorBadRequest
 }
 
 // Status returns HTTPResponse.Status
@@ -2657,6 +3232,28 @@ func (r ConnectorByIDResponse) StatusCode() int {
 	return 0
 }
 
+type EventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorBadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r EventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type HostsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2840,6 +3437,30 @@ func (r ProcesspairByIDResponse) StatusCode() int {
 	return 0
 }
 
+type TimeSeriesByProcesspairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetTimeSeries
+	JSON400      *ErrorBadRequest
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r TimeSeriesByProcesspairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TimeSeriesByProcesspairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type RouteraccessResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -3093,6 +3714,30 @@ func (r ProcessPairsByServiceResponse) StatusCode() int {
 	return 0
 }
 
+type TimeSeriesByServiceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetTimeSeries
+	JSON400      *ErrorBadRequest
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r TimeSeriesByServiceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TimeSeriesByServiceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SitepairsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -3139,6 +3784,30 @@ func (r SitepairByIDResponse) StatusCode() int {
 	return 0
 }
 
+type TimeSeriesBySitepairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetTimeSeries
+	JSON400      *ErrorBadRequest
+	JSON404      *ErrorNotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r TimeSeriesBySitepairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TimeSeriesBySitepairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SitesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -3253,6 +3922,15 @@ func (r RoutersBySiteResponse) StatusCode() int {
 	return 0
 }
 
+// AlertsWithResponse request returning *AlertsResponse
+func (c *ClientWithResponses) AlertsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AlertsResponse, error) {
+	rsp, err := c.Alerts(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAlertsResponse(rsp)
+}
+
 // ApplicationflowsWithResponse request returning *ApplicationflowsResponse
 func (c *ClientWithResponses) ApplicationflowsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ApplicationflowsResponse, error) {
 	rsp, err := c.Applicationflows(ctx, reqEditors...)
@@ -3325,6 +4003,15 @@ func (c *ClientWithResponses) ConnectorByIDWithResponse(ctx context.Context, id
 	return ParseConnectorByIDResponse(rsp)
 }
 
+// EventsWithResponse request returning *EventsResponse
+func (c *ClientWithResponses) EventsWithResponse(ctx context.Context, params *EventsParams, reqEditors ...RequestEditorFn) (*EventsResponse, error) {
+	rsp, err := c.Events(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEventsResponse(rsp)
+}
+
 // HostsWithResponse request returning *HostsResponse
 func (c *ClientWithResponses) HostsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HostsResponse, error) {
 	rsp, err := c.Hosts(ctx, reqEditors...)
@@ -3397,6 +4084,15 @@ func (c *ClientWithResponses) ProcesspairByIDWithResponse(ctx context.Context, i
 	return ParseProcesspairByIDResponse(rsp)
 }
 
+// TimeSeriesByProcesspairWithResponse request returning *TimeSeriesByProcesspairResponse
+func (c *ClientWithResponses) TimeSeriesByProcesspairWithResponse(ctx context.Context, id PathID, params *TimeSeriesByProcesspairParams, reqEditors ...RequestEditorFn) (*TimeSeriesByProcesspairResponse, error) {
+	rsp, err := c.TimeSeriesByProcesspair(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTimeSeriesByProcesspairResponse(rsp)
+}
+
 // RouteraccessWithResponse request returning *RouteraccessResponse
 func (c *ClientWithResponses) RouteraccessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RouteraccessResponse, error) {
 	rsp, err := c.Routeraccess(ctx, reqEditors...)
@@ -3496,6 +4192,15 @@ func (c *ClientWithResponses) ProcessPairsByServiceWithResponse(ctx context.Cont
 	return ParseProcessPairsByServiceResponse(rsp)
 }
 
+// TimeSeriesByServiceWithResponse request returning *TimeSeriesByServiceResponse
+func (c *ClientWithResponses) TimeSeriesByServiceWithResponse(ctx context.Context, id PathID, params *TimeSeriesByServiceParams, reqEditors ...RequestEditorFn) (*TimeSeriesByServiceResponse, error) {
+	rsp, err := c.TimeSeriesByService(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTimeSeriesByServiceResponse(rsp)
+}
+
 // SitepairsWithResponse request returning *SitepairsResponse
 func (c *ClientWithResponses) SitepairsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitepairsResponse, error) {
 	rsp, err := c.Sitepairs(ctx, reqEditors...)
@@ -3514,6 +4219,15 @@ func (c *ClientWithResponses) SitepairByIDWithResponse(ctx context.Context, id P
 	return ParseSitepairByIDResponse(rsp)
 }
 
+// TimeSeriesBySitepairWithResponse request returning *TimeSeriesBySitepairResponse
+func (c *ClientWithResponses) TimeSeriesBySitepairWithResponse(ctx context.Context, id PathID, params *TimeSeriesBySitepairParams, reqEditors ...RequestEditorFn) (*TimeSeriesBySitepairResponse, error) {
+	rsp, err := c.TimeSeriesBySitepair(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTimeSeriesBySitepairResponse(rsp)
+}
+
 // SitesWithResponse request returning *SitesResponse
 func (c *ClientWithResponses) SitesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*SitesResponse, error) {
 	rsp, err := c.Sites(ctx, reqEditors...)
@@ -3559,6 +4273,39 @@ func (c *ClientWithResponses) RoutersBySiteWithResponse(ctx context.Context, id
 	return ParseRoutersBySiteResponse(rsp)
 }
 
+// ParseAlertsResponse parses an HTTP response from a AlertsWithResponse call
+func ParseAlertsResponse(rsp *http.Response) (*AlertsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AlertsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetAlerts
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseApplicationflowsResponse parses an HTTP response from a ApplicationflowsWithResponse call
 func ParseApplicationflowsResponse(rsp *http.Response) (*ApplicationflowsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -3580,6 +4327,13 @@ func ParseApplicationflowsResponse(rsp *http.Response) (*ApplicationflowsRespons
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	}
 
 	return response, nil
@@ -3738,6 +4492,13 @@ func ParseConnectionsResponse(rsp *http.Response) (*ConnectionsResponse, error)
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	}
 
 	return response, nil
@@ -3802,7 +4563,33 @@ func ParseConnectorByIDResponse(rsp *http.Response) (*ConnectorByIDResponse, err
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEventsResponse parses an HTTP response from a EventsWithResponse call
+func ParseEventsResponse(rsp *http.Response) (*EventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
 	}
 
@@ -4066,6 +4853,46 @@ func ParseProcesspairByIDResponse(rsp *http.Response) (*ProcesspairByIDResponse,
 	return response, nil
 }
 
+// ParseTimeSeriesByProcesspairResponse parses an HTTP response from a TimeSeriesByProcesspairWithResponse call
+func ParseTimeSeriesByProcesspairResponse(rsp *http.Response) (*TimeSeriesByProcesspairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TimeSeriesByProcesspairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetTimeSeries
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseRouteraccessResponse parses an HTTP response from a RouteraccessWithResponse call
 func ParseRouteraccessResponse(rsp *http.Response) (*RouteraccessResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4429,6 +5256,46 @@ func ParseProcessPairsByServiceResponse(rsp *http.Response) (*ProcessPairsByServ
 	return response, nil
 }
 
+// ParseTimeSeriesByServiceResponse parses an HTTP response from a TimeSeriesByServiceWithResponse call
+func ParseTimeSeriesByServiceResponse(rsp *http.Response) (*TimeSeriesByServiceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TimeSeriesByServiceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetTimeSeries
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSitepairsResponse parses an HTTP response from a SitepairsWithResponse call
 func ParseSitepairsResponse(rsp *http.Response) (*SitepairsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4495,6 +5362,46 @@ func ParseSitepairByIDResponse(rsp *http.Response) (*SitepairByIDResponse, error
 	return response, nil
 }
 
+// ParseTimeSeriesBySitepairResponse parses an HTTP response from a TimeSeriesBySitepairWithResponse call
+func ParseTimeSeriesBySitepairResponse(rsp *http.Response) (*TimeSeriesBySitepairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TimeSeriesBySitepairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetTimeSeries
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorBadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorNotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSitesResponse parses an HTTP response from a SitesWithResponse call
 func ParseSitesResponse(rsp *http.Response) (*SitesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -4656,6 +5563,9 @@ func ParseRoutersBySiteResponse(rsp *http.Response) (*RoutersBySiteResponse, err
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /api/v2alpha1/alerts)
+	Alerts(w http.ResponseWriter, r *http.Request)
+
 	// (GET /api/v2alpha1/applicationflows)
 	Applicationflows(w http.ResponseWriter, r *http.Request)
 
@@ -4680,6 +5590,9 @@ type ServerInterface interface {
 	// (GET /api/v2alpha1/connectors/{id})
 	ConnectorByID(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/events)
+	Events(w http.ResponseWriter, r *http.Request, params EventsParams)
+
 	// (GET /api/v2alpha1/hosts)
 	Hosts(w http.ResponseWriter, r *http.Request)
 
@@ -4704,6 +5617,9 @@ type ServerInterface interface {
 	// (GET /api/v2alpha1/processpairs/{id})
 	ProcesspairByID(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/processpairs/{id}/timeseries)
+	TimeSeriesByProcesspair(w http.ResponseWriter, r *http.Request, id PathID, params TimeSeriesByProcesspairParams)
+
 	// (GET /api/v2alpha1/routeraccess)
 	Routeraccess(w http.ResponseWriter, r *http.Request)
 
@@ -4737,12 +5653,18 @@ type ServerInterface interface {
 	// (GET /api/v2alpha1/services/{id}/processpairs)
 	ProcessPairsByService(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/services/{id}/timeseries)
+	TimeSeriesByService(w http.ResponseWriter, r *http.Request, id PathID, params TimeSeriesByServiceParams)
+
 	// (GET /api/v2alpha1/sitepairs)
 	Sitepairs(w http.ResponseWriter, r *http.Request)
 
 	// (GET /api/v2alpha1/sitepairs/{id})
 	SitepairByID(w http.ResponseWriter, r *http.Request, id PathID)
 
+	// (GET /api/v2alpha1/sitepairs/{id}/timeseries)
+	TimeSeriesBySitepair(w http.ResponseWriter, r *http.Request, id PathID, params TimeSeriesBySitepairParams)
+
 	// (GET /api/v2alpha1/sites)
 	Sites(w http.ResponseWriter, r *http.Request)
 
@@ -4768,6 +5690,20 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// Alerts operation middleware
+func (siw *ServerInterfaceWrapper) Alerts(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Alerts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Applicationflows operation middleware
 func (siw *ServerInterfaceWrapper) Applicationflows(w http.ResponseWriter, r *http.Request) {
 
@@ -4913,6 +5849,40 @@ func (siw *ServerInterfaceWrapper) ConnectorByID(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
+// Events operation middleware
+func (siw *ServerInterfaceWrapper) Events(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params EventsParams
+
+	// ------------- Required query parameter "types" -------------
+
+	if paramValue := r.URL.Query().Get("types"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "types"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "types", r.URL.Query(), &params.Types)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "types", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Events(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Hosts operation middleware
 func (siw *ServerInterfaceWrapper) Hosts(w http.ResponseWriter, r *http.Request) {
 
@@ -5069,6 +6039,50 @@ func (siw *ServerInterfaceWrapper) ProcesspairByID(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
+// TimeSeriesByProcesspair operation middleware
+func (siw *ServerInterfaceWrapper) TimeSeriesByProcesspair(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TimeSeriesByProcesspairParams
+
+	// ------------- Optional query parameter "range" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "range", r.URL.Query(), &params.Range)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "range", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "step" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "step", r.URL.Query(), &params.Step)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "step", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TimeSeriesByProcesspair(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Routeraccess operation middleware
 func (siw *ServerInterfaceWrapper) Routeraccess(w http.ResponseWriter, r *http.Request) {
 
@@ -5300,6 +6314,50 @@ func (siw *ServerInterfaceWrapper) ProcessPairsByService(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
+// TimeSeriesByService operation middleware
+func (siw *ServerInterfaceWrapper) TimeSeriesByService(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TimeSeriesByServiceParams
+
+	// ------------- Optional query parameter "range" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "range", r.URL.Query(), &params.Range)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "range", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "step" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "step", r.URL.Query(), &params.Step)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "step", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TimeSeriesByService(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Sitepairs operation middleware
 func (siw *ServerInterfaceWrapper) Sitepairs(w http.ResponseWriter, r *http.Request) {
 
@@ -5339,6 +6397,50 @@ func (siw *ServerInterfaceWrapper) SitepairByID(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// TimeSeriesBySitepair operation middleware
+func (siw *ServerInterfaceWrapper) TimeSeriesBySitepair(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", mux.Vars(r)["id"], &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TimeSeriesBySitepairParams
+
+	// ------------- Optional query parameter "range" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "range", r.URL.Query(), &params.Range)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "range", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "step" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "step", r.URL.Query(), &params.Step)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "step", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TimeSeriesBySitepair(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // Sites operation middleware
 func (siw *ServerInterfaceWrapper) Sites(w http.ResponseWriter, r *http.Request) {
 
@@ -5566,6 +6668,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/alerts", wrapper.Alerts).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/applicationflows", wrapper.Applicationflows).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/componentpairs", wrapper.Componentpairs).Methods("GET")
@@ -5582,6 +6686,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/connectors/{id}", wrapper.ConnectorByID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/events", wrapper.Events).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/hosts", wrapper.Hosts).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/hosts/{id}", wrapper.HostsByID).Methods("GET")
@@ -5598,6 +6704,8 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/processpairs/{id}", wrapper.ProcesspairByID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/processpairs/{id}/timeseries", wrapper.TimeSeriesByProcesspair).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/routeraccess", wrapper.Routeraccess).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/routeraccess/{id}", wrapper.RouteraccessByID).Methods("GET")
@@ -5620,10 +6728,14 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/services/{id}/processpairs", wrapper.ProcessPairsByService).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/services/{id}/timeseries", wrapper.TimeSeriesByService).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sitepairs", wrapper.Sitepairs).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sitepairs/{id}", wrapper.SitepairByID).Methods("GET")
 
+	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sitepairs/{id}/timeseries", wrapper.TimeSeriesBySitepair).Methods("GET")
+
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sites", wrapper.Sites).Methods("GET")
 
 	r.HandleFunc(options.BaseURL+"/api/v2alpha1/sites/{id}", wrapper.SiteById).Methods("GET")
@@ -0,0 +1,127 @@
+// Package auth authenticates requests to the network observer API and
+// console, and authorizes them by role.
+//
+// Requests are authenticated by one of a set of pluggable Authenticators:
+// static bearer tokens, htpasswd basic auth, TLS client certificates and
+// OIDC issued JWTs. Each identity is then assigned a Role that determines
+// which API operations it may use.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// ErrNoCredentials is returned by an Authenticator when a request does not
+// carry credentials of the kind it handles.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Identity of an authenticated client.
+type Identity struct {
+	Username string
+	Groups   []string
+	// Method is the name of the authentication method used: token, basic,
+	// client-cert or oidc.
+	Method string
+	Role   Role
+}
+
+// Authenticator verifies the credentials carried by a request.
+type Authenticator interface {
+	// Authenticate returns the identity the request's credentials belong
+	// to. It returns ErrNoCredentials when the request has none it
+	// handles, and any other error when they are invalid.
+	Authenticate(r *http.Request) (Identity, error)
+	// Challenge returns the WWW-Authenticate challenge sent with
+	// unauthenticated responses, or an empty string for none.
+	Challenge() string
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the identity.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFrom returns the identity of an authenticated request, and false
+// when authentication is not enabled.
+func IdentityFrom(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// Authenticate returns middleware that rejects requests that are not
+// authenticated by any of the authenticators, and adds the identity of
+// those that are, with its role from the role mapping, to the request
+// context.
+func Authenticate(logger *slog.Logger, authenticators []Authenticator, roles RoleMapping) func(http.Handler) http.Handler {
+	var challenges []string
+	for _, a := range authenticators {
+		if c := a.Challenge(); c != "" {
+			challenges = append(challenges, c)
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var errs []error
+			for _, a := range authenticators {
+				id, err := a.Authenticate(r)
+				if errors.Is(err, ErrNoCredentials) {
+					continue
+				}
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				// authenticators for a single purpose, such as the
+				// scrape token, assign the role themselves
+				if id.Role == "" {
+					id.Role = roles.RoleFor(id)
+				}
+				next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), id)))
+				return
+			}
+			message := "authentication required"
+			if len(errs) > 0 {
+				logger.Debug("authentication failed",
+					slog.String("endpoint", r.URL.Path),
+					slog.Any("error", errors.Join(errs...)))
+				message = "invalid credentials"
+			}
+			for _, c := range challenges {
+				w.Header().Add("WWW-Authenticate", c)
+			}
+			writeError(w, http.StatusUnauthorized, "ErrUnauthorized", message)
+		})
+	}
+}
+
+// Forbidden writes the response to a request for an operation the caller's
+// role does not permit.
+func Forbidden(w http.ResponseWriter) {
+	writeError(w, http.StatusForbidden, "ErrForbidden", "operation not permitted")
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{code, message})
+}
+
+// bearerToken returns the token from a request's Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
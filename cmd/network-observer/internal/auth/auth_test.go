@@ -0,0 +1,280 @@
+package auth
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/bcrypt"
+	"gotest.tools/v3/assert"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func htpasswd(t *testing.T) string {
+	t.Helper()
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("bcrypt-pass"), bcrypt.MinCost)
+	assert.NilError(t, err)
+	sha := sha1.Sum([]byte("sha-pass"))
+	salt := []byte("salt")
+	ssha := sha1.Sum(append([]byte("ssha-pass"), salt...))
+	return writeFile(t, "htpasswd", "# users\n"+
+		"bcrypt:"+string(bcryptHash)+"\n"+
+		"sha:{SHA}"+base64.StdEncoding.EncodeToString(sha[:])+"\n"+
+		"ssha:{SSHA}"+base64.StdEncoding.EncodeToString(append(ssha[:], salt...))+"\n"+
+		"skupper:{PLAIN}plain-pass\n")
+}
+
+func TestHtpasswd(t *testing.T) {
+	a, err := NewHtpasswdFile(htpasswd(t))
+	assert.NilError(t, err)
+	testCases := []struct {
+		User     string
+		Password string
+		Valid    bool
+	}{
+		{User: "bcrypt", Password: "bcrypt-pass", Valid: true},
+		{User: "bcrypt", Password: "sha-pass"},
+		{User: "sha", Password: "sha-pass", Valid: true},
+		{User: "sha", Password: "ssha-pass"},
+		{User: "ssha", Password: "ssha-pass", Valid: true},
+		{User: "ssha", Password: "salt"},
+		{User: "skupper", Password: "plain-pass", Valid: true},
+		{User: "skupper", Password: "{PLAIN}plain-pass"},
+		{User: "unknown", Password: "plain-pass"},
+	}
+	for _, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.SetBasicAuth(tc.User, tc.Password)
+		id, err := a.Authenticate(r)
+		if !tc.Valid {
+			assert.Assert(t, err != nil, "%s:%s", tc.User, tc.Password)
+			continue
+		}
+		assert.NilError(t, err)
+		assert.DeepEqual(t, id, Identity{Username: tc.User, Method: "basic"})
+	}
+
+	_, err = a.Authenticate(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	_, err = NewHtpasswdFile(writeFile(t, "htpasswd", "user:$apr1$salt$hash\n"))
+	assert.ErrorContains(t, err, "unsupported password hash")
+}
+
+func TestTokenFile(t *testing.T) {
+	a, err := NewTokenFile(writeFile(t, "tokens.csv", `token-1,alice,uid-1,"admins,ops"
+token-2,bob
+`))
+	assert.NilError(t, err)
+
+	authenticate := func(header string) (Identity, error) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			r.Header.Set("Authorization", header)
+		}
+		return a.Authenticate(r)
+	}
+	id, err := authenticate("Bearer token-1")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, id, Identity{Username: "alice", Groups: []string{"admins", "ops"}, Method: "token"})
+	id, err = authenticate("bearer token-2")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, id, Identity{Username: "bob", Method: "token"})
+	_, err = authenticate("Bearer token-3")
+	assert.ErrorContains(t, err, "unknown bearer token")
+	_, err = authenticate("Basic dXNlcjpwYXNz")
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	_, err = NewTokenFile(writeFile(t, "tokens.csv", "token-only\n"))
+	assert.ErrorContains(t, err, "expected token and user")
+}
+
+func TestRoleMapping(t *testing.T) {
+	var unset RoleMapping
+	assert.Equal(t, unset.RoleFor(Identity{Username: "anyone"}), RoleFull)
+
+	m, err := LoadRoleMapping(writeFile(t, "roles.yaml", `
+users:
+  alice: full
+  bob: topology
+groups:
+  admins: full
+`))
+	assert.NilError(t, err)
+	assert.Equal(t, m.RoleFor(Identity{Username: "alice"}), RoleFull)
+	assert.Equal(t, m.RoleFor(Identity{Username: "bob", Groups: []string{"admins"}}), RoleTopology)
+	assert.Equal(t, m.RoleFor(Identity{Username: "carol", Groups: []string{"devs", "admins"}}), RoleFull)
+	assert.Equal(t, m.RoleFor(Identity{Username: "dave", Groups: []string{"devs"}}), RoleTopology)
+
+	_, err = LoadRoleMapping(writeFile(t, "roles.yaml", "users:\n  alice: admin\n"))
+	assert.ErrorContains(t, err, `invalid role "admin" for user "alice"`)
+	_, err = LoadRoleMapping(writeFile(t, "roles.yaml", "default: full\nuser:\n  alice: full\n"))
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestAuthenticateAndAuthorize(t *testing.T) {
+	spec, err := os.ReadFile("../../spec/openapi.yaml")
+	assert.NilError(t, err)
+	tags, err := ParseOperationTags(spec)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, tags["GET /api/v2alpha1/sites/{id}/processes"], []string{"site", TagProcess})
+
+	tokens, err := NewTokenFile(writeFile(t, "tokens.csv", "full-token,alice\ntopology-token,bob\n"))
+	assert.NilError(t, err)
+	basic, err := NewHtpasswdFile(htpasswd(t))
+	assert.NilError(t, err)
+	roles := RoleMapping{Default: RoleTopology, Users: map[string]Role{"alice": RoleFull}}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := IdentityFrom(r.Context())
+		json.NewEncoder(w).Encode(id)
+	})
+	router := mux.NewRouter()
+	router.Use(Authenticate(slog.New(slog.NewTextHandler(io.Discard, nil)), []Authenticator{tokens, basic}, roles))
+	api := router.PathPrefix("/api").Subrouter()
+	api.Use(tags.Authorize)
+	api.Methods(http.MethodGet).Path("/v2alpha1/sites/{id}").Handler(ok)
+	api.Methods(http.MethodGet).Path("/v2alpha1/connections").Handler(ok)
+	api.Methods(http.MethodGet).Path("/v2alpha1/unspecified").Handler(ok)
+	router.Path("/user").Handler(ok)
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	testCases := []struct {
+		Name     string
+		Path     string
+		Token    string
+		User     string
+		Password string
+		Status   int
+		Identity Identity
+	}{
+		{
+			Name:   "unauthenticated",
+			Path:   "/api/v2alpha1/sites/site-1",
+			Status: http.StatusUnauthorized,
+		}, {
+			Name:   "invalid token",
+			Path:   "/api/v2alpha1/sites/site-1",
+			Token:  "other-token",
+			Status: http.StatusUnauthorized,
+		}, {
+			Name:     "invalid password",
+			Path:     "/user",
+			User:     "skupper",
+			Password: "wrong",
+			Status:   http.StatusUnauthorized,
+		}, {
+			Name:     "full",
+			Path:     "/api/v2alpha1/connections",
+			Token:    "full-token",
+			Status:   http.StatusOK,
+			Identity: Identity{Username: "alice", Method: "token", Role: RoleFull},
+		}, {
+			Name:     "full unspecified operation",
+			Path:     "/api/v2alpha1/unspecified",
+			Token:    "full-token",
+			Status:   http.StatusOK,
+			Identity: Identity{Username: "alice", Method: "token", Role: RoleFull},
+		}, {
+			Name:     "topology",
+			Path:     "/api/v2alpha1/sites/site-1",
+			Token:    "topology-token",
+			Status:   http.StatusOK,
+			Identity: Identity{Username: "bob", Method: "token", Role: RoleTopology},
+		}, {
+			Name:   "topology flows",
+			Path:   "/api/v2alpha1/connections",
+			Token:  "topology-token",
+			Status: http.StatusForbidden,
+		}, {
+			Name:   "topology unspecified operation",
+			Path:   "/api/v2alpha1/unspecified",
+			Token:  "topology-token",
+			Status: http.StatusForbidden,
+		}, {
+			Name:     "basic",
+			Path:     "/user",
+			User:     "skupper",
+			Password: "plain-pass",
+			Status:   http.StatusOK,
+			Identity: Identity{Username: "skupper", Method: "basic", Role: RoleTopology},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+tc.Path, nil)
+			assert.NilError(t, err)
+			if tc.Token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.Token)
+			}
+			if tc.User != "" {
+				req.SetBasicAuth(tc.User, tc.Password)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			assert.NilError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, resp.StatusCode, tc.Status)
+			switch tc.Status {
+			case http.StatusOK:
+				var id Identity
+				assert.NilError(t, json.NewDecoder(resp.Body).Decode(&id))
+				assert.DeepEqual(t, id, tc.Identity)
+			case http.StatusUnauthorized:
+				assert.DeepEqual(t, resp.Header.Values("WWW-Authenticate"), []string{"Bearer", `Basic realm="skupper network observer"`})
+			}
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	tokens, err := NewTokenFile(writeFile(t, "tokens.csv", "full-token,alice\ntopology-token,bob\n"))
+	assert.NilError(t, err)
+	scraper, err := NewScrapeTokenFile(writeFile(t, "scrape-token", "scrape-token\n"))
+	assert.NilError(t, err)
+	roles := RoleMapping{Default: RoleTopology, Users: map[string]Role{"alice": RoleFull}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	metrics := Metrics(logger, []Authenticator{tokens}, scraper, roles)(ok)
+	router := mux.NewRouter()
+	router.Use(Authenticate(logger, []Authenticator{tokens}, roles))
+	router.Path("/user").Handler(ok)
+
+	status := func(handler http.Handler, token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		return res.Code
+	}
+	assert.Equal(t, status(metrics, ""), http.StatusUnauthorized)
+	assert.Equal(t, status(metrics, "full-token"), http.StatusOK)
+	assert.Equal(t, status(metrics, "topology-token"), http.StatusForbidden)
+	assert.Equal(t, status(metrics, "scrape-token"), http.StatusOK)
+	// the scrape token is only accepted for metrics
+	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req.Header.Set("Authorization", "Bearer scrape-token")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, res.Code, http.StatusUnauthorized)
+
+	_, err = NewScrapeTokenFile(writeFile(t, "scrape-token", "\n"))
+	assert.ErrorContains(t, err, "no token")
+}
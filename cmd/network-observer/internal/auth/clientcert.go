@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+)
+
+type clientCertAuthenticator struct{}
+
+// NewClientCert returns an Authenticator for TLS client certificates
+// verified by the server. As with kubernetes, the certificate subject's
+// common name is the username and its organizations are the groups.
+func NewClientCert() Authenticator {
+	return clientCertAuthenticator{}
+}
+
+func (clientCertAuthenticator) Authenticate(r *http.Request) (Identity, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return Identity{}, ErrNoCredentials
+	}
+	// certificates are only verified when the server is configured with
+	// client CAs
+	if len(r.TLS.VerifiedChains) == 0 {
+		return Identity{}, errors.New("client certificate not verified")
+	}
+	subject := r.TLS.VerifiedChains[0][0].Subject
+	if subject.CommonName == "" {
+		return Identity{}, errors.New("client certificate subject has no common name")
+	}
+	return Identity{
+		Username: subject.CommonName,
+		Groups:   subject.Organization,
+		Method:   "client-cert",
+	}, nil
+}
+
+func (clientCertAuthenticator) Challenge() string {
+	return ""
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Challenge realm for basic auth.
+const basicRealm = "skupper network observer"
+
+type htpasswdAuthenticator struct {
+	passwords map[string]string
+}
+
+// NewHtpasswdFile returns an Authenticator for basic auth against the users
+// in an htpasswd file. Passwords may be hashed with bcrypt, {SHA} or
+// {SSHA}, or stored in plain text with the {PLAIN} prefix used by nginx.
+func NewHtpasswdFile(path string) (Authenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	a := &htpasswdAuthenticator{passwords: make(map[string]string)}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		user, hash, ok := strings.Cut(text, ":")
+		if !ok || user == "" {
+			return nil, fmt.Errorf("error parsing htpasswd file %s: line %d: expected user:password", path, line)
+		}
+		if !supportedHash(hash) {
+			return nil, fmt.Errorf("error parsing htpasswd file %s: line %d: unsupported password hash for user %q", path, line, user)
+		}
+		a.passwords[user] = hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading htpasswd file %s: %s", path, err)
+	}
+	return a, nil
+}
+
+func supportedHash(hash string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$", "{SHA}", "{SSHA}", "{PLAIN}"} {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *htpasswdAuthenticator) Authenticate(r *http.Request) (Identity, error) {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return Identity{}, ErrNoCredentials
+	}
+	hash, ok := a.passwords[user]
+	if !ok || !checkPassword(hash, password) {
+		return Identity{}, errors.New("invalid username or password")
+	}
+	return Identity{Username: user, Method: "basic"}, nil
+}
+
+func (a *htpasswdAuthenticator) Challenge() string {
+	return fmt.Sprintf("Basic realm=%q", basicRealm)
+}
+
+func checkPassword(hash, password string) bool {
+	switch {
+	case strings.HasPrefix(hash, "{PLAIN}"):
+		return subtle.ConstantTimeCompare([]byte(hash[len("{PLAIN}"):]), []byte(password)) == 1
+	case strings.HasPrefix(hash, "{SHA}"):
+		sum := sha1.Sum([]byte(password))
+		return subtle.ConstantTimeCompare([]byte(hash[len("{SHA}"):]), []byte(base64.StdEncoding.EncodeToString(sum[:]))) == 1
+	case strings.HasPrefix(hash, "{SSHA}"):
+		decoded, err := base64.StdEncoding.DecodeString(hash[len("{SSHA}"):])
+		if err != nil || len(decoded) <= sha1.Size {
+			return false
+		}
+		digest, salt := decoded[:sha1.Size], decoded[sha1.Size:]
+		sum := sha1.Sum(append([]byte(password), salt...))
+		return subtle.ConstantTimeCompare(digest, sum[:]) == 1
+	default:
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	}
+}
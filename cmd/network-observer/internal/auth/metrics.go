@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Metrics returns middleware for the Prometheus metrics endpoint. Metrics
+// are labelled with the names of processes and count the traffic between
+// them, so they may only be read by identities permitted to see processes
+// and flows, or with the scrape token when there is one.
+func Metrics(logger *slog.Logger, authenticators []Authenticator, scraper Authenticator, roles RoleMapping) func(http.Handler) http.Handler {
+	if scraper != nil {
+		authenticators = append([]Authenticator{scraper}, authenticators...)
+	}
+	authenticate := Authenticate(logger, authenticators, roles)
+	require := Require(TagProcess, TagFlows)
+	return func(next http.Handler) http.Handler {
+		return authenticate(require(next))
+	}
+}
@@ -0,0 +1,434 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	jwksRefreshInterval    = time.Hour
+	jwksMinRefreshInterval = time.Minute
+	jwksRequestTimeout     = 10 * time.Second
+	clockSkew              = time.Minute
+)
+
+type OIDCConfig struct {
+	// Issuer must match the iss claim of accepted tokens.
+	Issuer string
+	// Audience must be one of the aud claims of accepted tokens.
+	Audience string
+	// JWKSURL is the location of the issuer's JSON Web Key Set.
+	JWKSURL string
+	// UsernameClaim is the claim holding the username. Defaults to sub.
+	UsernameClaim string
+	// GroupsClaim is the claim holding the groups. Defaults to groups.
+	GroupsClaim string
+	// HTTPClient is used to fetch the key set.
+	HTTPClient *http.Client
+}
+
+type oidcAuthenticator struct {
+	issuer        string
+	audience      string
+	jwksURL       string
+	usernameClaim string
+	groupsClaim   string
+	client        *http.Client
+	fetches       singleflight.Group
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+// NewOIDC returns an Authenticator for JWT bearer tokens issued by an OIDC
+// provider. Tokens are verified with the provider's key set, which is
+// fetched when first needed and refreshed periodically or when a token is
+// signed by an unknown key.
+func NewOIDC(cfg OIDCConfig) (Authenticator, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("oidc issuer is required")
+	}
+	if cfg.Audience == "" {
+		return nil, errors.New("oidc audience is required")
+	}
+	jwksURL, err := url.Parse(cfg.JWKSURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oidc jwks url: %s", err)
+	}
+	if jwksURL.Scheme != "https" && jwksURL.Scheme != "http" {
+		return nil, fmt.Errorf("invalid oidc jwks url %q: scheme must be http or https", cfg.JWKSURL)
+	}
+	a := &oidcAuthenticator{
+		issuer:        cfg.Issuer,
+		audience:      cfg.Audience,
+		jwksURL:       cfg.JWKSURL,
+		usernameClaim: cfg.UsernameClaim,
+		groupsClaim:   cfg.GroupsClaim,
+		client:        cfg.HTTPClient,
+	}
+	if a.usernameClaim == "" {
+		a.usernameClaim = "sub"
+	}
+	if a.groupsClaim == "" {
+		a.groupsClaim = "groups"
+	}
+	if a.client == nil {
+		a.client = &http.Client{Timeout: jwksRequestTimeout}
+	}
+	return a, nil
+}
+
+func (a *oidcAuthenticator) Authenticate(r *http.Request) (Identity, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return Identity{}, ErrNoCredentials
+	}
+	claims, err := a.verify(r.Context(), token, time.Now())
+	if err != nil {
+		return Identity{}, err
+	}
+	username, _ := claims[a.usernameClaim].(string)
+	if username == "" {
+		return Identity{}, fmt.Errorf("token has no %q claim", a.usernameClaim)
+	}
+	id := Identity{Username: username, Method: "oidc"}
+	switch groups := claims[a.groupsClaim].(type) {
+	case string:
+		id.Groups = []string{groups}
+	case []any:
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				id.Groups = append(id.Groups, g)
+			}
+		}
+	}
+	return id, nil
+}
+
+func (a *oidcAuthenticator) Challenge() string {
+	return "Bearer"
+}
+
+// verify checks the signature and registered claims of a compact
+// serialized JWT and returns its claims.
+func (a *oidcAuthenticator) verify(ctx context.Context, token string, now time.Time) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("bearer token is not a JWT")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("invalid JWT header: %s", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT signature: %s", err)
+	}
+	key, err := a.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid JWT claims: %s", err)
+	}
+	if iss, _ := claims["iss"].(string); iss != a.issuer {
+		return nil, fmt.Errorf("unexpected token issuer %q", iss)
+	}
+	var audiences []string
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = []string{aud}
+	case []any:
+		for _, v := range aud {
+			if s, ok := v.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	if !slices.Contains(audiences, a.audience) {
+		return nil, fmt.Errorf("token audience does not include %q", a.audience)
+	}
+	exp, ok := numericDate(claims["exp"])
+	if !ok {
+		return nil, errors.New("token has no expiry")
+	}
+	if now.After(exp.Add(clockSkew)) {
+		return nil, errors.New("token expired")
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(clockSkew).Before(nbf) {
+		return nil, errors.New("token not yet valid")
+	}
+	return claims, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func numericDate(v any) (time.Time, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(f), 0), true
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	if len(alg) != 5 {
+		return fmt.Errorf("unsupported JWT algorithm %q", alg)
+	}
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported JWT algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "RS", "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match JWT algorithm %q", alg)
+		}
+		var err error
+		if alg[0] == 'R' {
+			err = rsa.VerifyPKCS1v15(pub, hash, digest, signature)
+		} else {
+			err = rsa.VerifyPSS(pub, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		if err != nil {
+			return errors.New("invalid JWT signature")
+		}
+		return nil
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || curveForAlgorithm(alg) != pub.Curve {
+			return fmt.Errorf("key type does not match JWT algorithm %q", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid JWT signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("invalid JWT signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported JWT algorithm %q", alg)
+	}
+}
+
+func curveForAlgorithm(alg string) elliptic.Curve {
+	switch alg {
+	case "ES256":
+		return elliptic.P256()
+	case "ES384":
+		return elliptic.P384()
+	case "ES512":
+		return elliptic.P521()
+	}
+	return nil
+}
+
+// key returns the key with the given ID. A stale key set is refreshed in
+// the background while its keys remain in use. A key set that does not
+// contain the key is refetched, waiting for the fetch. The lock is not held
+// while fetching, and concurrent requests share a single fetch.
+func (a *oidcAuthenticator) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	a.mu.Lock()
+	since := time.Since(a.fetched)
+	key, ok := a.lookup(kid)
+	a.mu.Unlock()
+	if ok {
+		if since > jwksRefreshInterval {
+			a.refresh()
+		}
+		return key, nil
+	}
+	if since <= jwksMinRefreshInterval {
+		return nil, fmt.Errorf("no key found with key ID %q", kid)
+	}
+	select {
+	case result := <-a.refresh():
+		if result.Err != nil {
+			return nil, result.Err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	a.mu.Lock()
+	key, ok = a.lookup(kid)
+	a.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no key found with key ID %q", kid)
+	}
+	return key, nil
+}
+
+// refresh fetches the key set, unless a fetch is already in progress, and
+// replaces the cached keys when the fetch succeeds. The fetch is not tied to
+// the context of any one request, as other requests may be waiting for it.
+func (a *oidcAuthenticator) refresh() <-chan singleflight.Result {
+	return a.fetches.DoChan("jwks", func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), jwksRequestTimeout)
+		defer cancel()
+		keys, err := a.fetchKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		a.mu.Lock()
+		a.keys, a.fetched = keys, time.Now()
+		a.mu.Unlock()
+		return nil, nil
+	})
+}
+
+func (a *oidcAuthenticator) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, true
+		}
+	}
+	key, ok := a.keys[kid]
+	return key, ok
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchKeys returns the signing keys in the key set. Keys of unsupported
+// types are ignored.
+func (a *oidcAuthenticator) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.jwksURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching oidc key set: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching oidc key set: %s", resp.Status)
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("error decoding oidc key set: %s", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err := jwk.publicKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid EC point")
+		}
+		point := append([]byte{4}, x...)
+		return ecdsa.ParseUncompressedPublicKey(curve, append(point, y...))
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
@@ -0,0 +1,282 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type testIssuer struct {
+	rsaKey  *rsa.PrivateKey
+	ecKey   *ecdsa.PrivateKey
+	fetches atomic.Int32
+}
+
+func (i *testIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	i.fetches.Add(1)
+	b64 := base64.RawURLEncoding.EncodeToString
+	ecPub, _ := i.ecKey.PublicKey.Bytes()
+	json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa-1",
+				"use": "sig",
+				"n":   b64(i.rsaKey.N.Bytes()),
+				"e":   b64(big.NewInt(int64(i.rsaKey.E)).Bytes()),
+			}, {
+				"kty": "EC",
+				"kid": "ec-1",
+				"crv": "P-256",
+				"x":   b64(ecPub[1:33]),
+				"y":   b64(ecPub[33:]),
+			}, {
+				"kty": "oct",
+				"kid": "hmac-1",
+				"k":   b64([]byte("secret")),
+			},
+		},
+	})
+}
+
+func (i *testIssuer) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	b64 := base64.RawURLEncoding.EncodeToString
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	assert.NilError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NilError(t, err)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+	var signature []byte
+	switch alg {
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, i.rsaKey, crypto.SHA256, digest[:])
+	case "PS256":
+		signature, err = rsa.SignPSS(rand.Reader, i.rsaKey, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case "ES256":
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, i.ecKey, digest[:])
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	default:
+		signature = []byte("signature")
+	}
+	assert.NilError(t, err)
+	return signed + "." + b64(signature)
+}
+
+// tamper replaces the end of a token's signature.
+func tamper(token string) string {
+	return token[:len(token)-4] + "AAAA"
+}
+
+func TestOIDC(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	issuer := &testIssuer{rsaKey: rsaKey, ecKey: ecKey}
+	srv := httptest.NewServer(issuer)
+	defer srv.Close()
+
+	a, err := NewOIDC(OIDCConfig{
+		Issuer:        "https://issuer.example.com",
+		Audience:      "network-observer",
+		JWKSURL:       srv.URL,
+		UsernameClaim: "email",
+	})
+	assert.NilError(t, err)
+
+	now := time.Now()
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss":    "https://issuer.example.com",
+			"aud":    []string{"console", "network-observer"},
+			"sub":    "1234",
+			"email":  "alice@example.com",
+			"groups": []string{"admins"},
+			"exp":    now.Add(time.Hour).Unix(),
+			"iat":    now.Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+
+	testCases := []struct {
+		Name  string
+		Token string
+		Error string
+	}{
+		{
+			Name:  "rsa",
+			Token: issuer.sign(t, "RS256", "rsa-1", claims(nil)),
+		}, {
+			Name:  "rsa pss",
+			Token: issuer.sign(t, "PS256", "rsa-1", claims(nil)),
+		}, {
+			Name:  "ecdsa",
+			Token: issuer.sign(t, "ES256", "ec-1", claims(map[string]any{"aud": "network-observer"})),
+		}, {
+			Name:  "wrong key type",
+			Token: issuer.sign(t, "RS256", "ec-1", claims(nil)),
+			Error: "key type does not match",
+		}, {
+			Name:  "unsupported algorithm",
+			Token: issuer.sign(t, "HS256", "hmac-1", claims(nil)),
+			Error: `no key found with key ID "hmac-1"`,
+		}, {
+			Name:  "none",
+			Token: issuer.sign(t, "none", "rsa-1", claims(nil)),
+			Error: "unsupported JWT algorithm",
+		}, {
+			Name:  "tampered",
+			Token: tamper(issuer.sign(t, "RS256", "rsa-1", claims(nil))),
+			Error: "invalid JWT signature",
+		}, {
+			Name:  "issuer",
+			Token: issuer.sign(t, "RS256", "rsa-1", claims(map[string]any{"iss": "https://other.example.com"})),
+			Error: "unexpected token issuer",
+		}, {
+			Name:  "audience",
+			Token: issuer.sign(t, "RS256", "rsa-1", claims(map[string]any{"aud": "console"})),
+			Error: "token audience does not include",
+		}, {
+			Name:  "expired",
+			Token: issuer.sign(t, "RS256", "rsa-1", claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})),
+			Error: "token expired",
+		}, {
+			Name:  "no expiry",
+			Token: issuer.sign(t, "RS256", "rsa-1", claims(map[string]any{"exp": nil})),
+			Error: "token has no expiry",
+		}, {
+			Name:  "not yet valid",
+			Token: issuer.sign(t, "RS256", "rsa-1", claims(map[string]any{"nbf": now.Add(time.Hour).Unix()})),
+			Error: "token not yet valid",
+		}, {
+			Name:  "no username",
+			Token: issuer.sign(t, "RS256", "rsa-1", claims(map[string]any{"email": nil})),
+			Error: `token has no "email" claim`,
+		}, {
+			Name:  "opaque token",
+			Token: "token-1",
+			Error: "bearer token is not a JWT",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Authorization", "Bearer "+tc.Token)
+			id, err := a.Authenticate(r)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, id, Identity{Username: "alice@example.com", Groups: []string{"admins"}, Method: "oidc"})
+		})
+	}
+	// the key set is fetched once, and refetched for unknown keys at most
+	// once per refresh interval
+	assert.Equal(t, issuer.fetches.Load(), int32(1))
+
+	_, err = NewOIDC(OIDCConfig{Issuer: "https://issuer.example.com", JWKSURL: srv.URL})
+	assert.ErrorContains(t, err, "oidc audience is required")
+}
+
+func TestOIDCKeyFetch(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	issuer := &testIssuer{rsaKey: rsaKey, ecKey: ecKey}
+	var blocked atomic.Bool
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if blocked.Load() {
+			<-release
+		}
+		issuer.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	authenticator, err := NewOIDC(OIDCConfig{
+		Issuer:   "https://issuer.example.com",
+		Audience: "network-observer",
+		JWKSURL:  srv.URL,
+	})
+	assert.NilError(t, err)
+	a := authenticator.(*oidcAuthenticator)
+	ctx := context.Background()
+	_, err = a.key(ctx, "rsa-1")
+	assert.NilError(t, err)
+
+	// a slow fetch for an unknown key does not hold up requests signed by
+	// cached keys, and concurrent requests share the fetch
+	a.mu.Lock()
+	a.fetched = time.Now().Add(-2 * jwksMinRefreshInterval)
+	a.mu.Unlock()
+	blocked.Store(true)
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := a.key(ctx, "rsa-2")
+			assert.ErrorContains(t, err, "no key found")
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	done := make(chan error)
+	go func() {
+		_, err := a.key(ctx, "ec-1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.NilError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("lookup of a cached key blocked on the key set fetch")
+	}
+
+	// a request that gives up does not wait for the fetch
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = a.key(cancelled, "rsa-2")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	blocked.Store(false)
+	release <- struct{}{}
+	wg.Wait()
+	assert.Equal(t, issuer.fetches.Load(), int32(2))
+
+	// a stale key set is refreshed in the background
+	a.mu.Lock()
+	a.fetched = time.Now().Add(-2 * jwksRefreshInterval)
+	a.mu.Unlock()
+	_, err = a.key(ctx, "rsa-1")
+	assert.NilError(t, err)
+	<-a.refresh()
+	assert.Assert(t, issuer.fetches.Load() >= 3)
+}
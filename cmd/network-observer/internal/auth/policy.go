@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"sigs.k8s.io/yaml"
+)
+
+// OpenAPI tags of the operations restricted to some roles.
+const (
+	TagProcess       = "process"
+	TagFlows         = "flows"
+	TagFlowAggregate = "flow aggregate"
+	TagDeprecated    = "deprecated"
+)
+
+// deniedTags lists, by role, the tags of operations the role may not use.
+var deniedTags = map[Role][]string{
+	RoleTopology: {TagProcess, TagFlows, TagFlowAggregate, TagDeprecated},
+}
+
+// Permits reports whether the identity of an authenticated request may use
+// operations with all of the given tags. Everything is permitted when
+// authentication is not enabled.
+func Permits(r *http.Request, tags ...string) bool {
+	id, ok := IdentityFrom(r.Context())
+	if !ok {
+		return true
+	}
+	if !id.Role.valid() {
+		return false
+	}
+	for _, tag := range tags {
+		if slices.Contains(deniedTags[id.Role], tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// unrestricted reports whether the caller may use every operation.
+func unrestricted(r *http.Request) bool {
+	id, ok := IdentityFrom(r.Context())
+	return !ok || id.Role.valid() && len(deniedTags[id.Role]) == 0
+}
+
+// Require returns middleware that rejects requests unless the caller may
+// use operations with the given tags.
+func Require(tags ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !Permits(r, tags...) {
+				Forbidden(w)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// OperationTags maps API operations, by method and path template, to their
+// OpenAPI tags.
+type OperationTags map[string][]string
+
+// ParseOperationTags reads the tags of each operation from an OpenAPI
+// document.
+func ParseOperationTags(spec []byte) (OperationTags, error) {
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Tags []string `json:"tags"`
+		} `json:"paths"`
+	}
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing openapi spec: %s", err)
+	}
+	tags := make(OperationTags)
+	for path, operations := range doc.Paths {
+		for method, op := range operations {
+			tags[operationKey(method, path)] = op.Tags
+		}
+	}
+	return tags, nil
+}
+
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// Authorize returns middleware for the routes generated from the OpenAPI
+// spec that rejects requests for operations the caller's role does not
+// permit. Operations missing from the spec are only permitted to roles
+// without restrictions.
+func (t OperationTags) Authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags, ok := t.lookup(r)
+		if !ok && !unrestricted(r) || !Permits(r, tags...) {
+			Forbidden(w)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (t OperationTags) lookup(r *http.Request) ([]string, bool) {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return nil, false
+	}
+	path, err := route.GetPathTemplate()
+	if err != nil {
+		return nil, false
+	}
+	tags, ok := t[operationKey(r.Method, path)]
+	return tags, ok
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Role determines which API operations an identity may use.
+type Role string
+
+const (
+	// RoleFull permits every operation.
+	RoleFull Role = "full"
+	// RoleTopology permits operations on the sites, routers, links,
+	// listeners, connectors, components and services making up the
+	// network, but not on processes or flows.
+	RoleTopology Role = "topology"
+)
+
+func (r Role) valid() bool {
+	return r == RoleFull || r == RoleTopology
+}
+
+// rank orders roles from the least to the most privileged.
+func (r Role) rank() int {
+	switch r {
+	case RoleFull:
+		return 2
+	case RoleTopology:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// RoleMapping assigns roles to identities. The zero value assigns the full
+// role to everyone.
+type RoleMapping struct {
+	// Default is the role of identities not otherwise mapped.
+	Default Role `json:"default"`
+	// Users maps usernames to roles.
+	Users map[string]Role `json:"users"`
+	// Groups maps group names to roles.
+	Groups map[string]Role `json:"groups"`
+}
+
+// LoadRoleMapping reads a role mapping from a YAML file. Its default role
+// is topology unless the file sets one.
+func LoadRoleMapping(path string) (RoleMapping, error) {
+	var m RoleMapping
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return m, err
+	}
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return m, fmt.Errorf("error parsing role mapping %s: %s", path, err)
+	}
+	if m.Default == "" {
+		m.Default = RoleTopology
+	}
+	if !m.Default.valid() {
+		return m, fmt.Errorf("invalid default role %q", m.Default)
+	}
+	for user, role := range m.Users {
+		if !role.valid() {
+			return m, fmt.Errorf("invalid role %q for user %q", role, user)
+		}
+	}
+	for group, role := range m.Groups {
+		if !role.valid() {
+			return m, fmt.Errorf("invalid role %q for group %q", role, group)
+		}
+	}
+	return m, nil
+}
+
+// RoleFor returns the role of an identity: the role mapped to its username
+// when there is one, otherwise the most privileged of the default role and
+// the roles mapped to its groups.
+func (m RoleMapping) RoleFor(id Identity) Role {
+	if m.Default == "" {
+		return RoleFull
+	}
+	if role, ok := m.Users[id.Username]; ok {
+		return role
+	}
+	role := m.Default
+	for _, group := range id.Groups {
+		if r, ok := m.Groups[group]; ok && r.rank() > role.rank() {
+			role = r
+		}
+	}
+	return role
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+type tokenAuthenticator struct {
+	// tokens maps the hash of each token to its identity so that lookups
+	// do not depend on the token's contents.
+	tokens map[[sha256.Size]byte]Identity
+}
+
+// NewTokenFile returns an Authenticator for static bearer tokens read from
+// a CSV file in the format used by kubernetes: token,user,uid,"group1,group2".
+// The uid and groups columns are optional.
+func NewTokenFile(path string) (Authenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	a := &tokenAuthenticator{tokens: make(map[[sha256.Size]byte]Identity)}
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing token file %s: %s", path, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 || record[0] == "" || record[1] == "" {
+			return nil, fmt.Errorf("error parsing token file %s: line %d: expected token and user", path, line)
+		}
+		id := Identity{Username: record[1], Method: "token"}
+		if len(record) > 3 {
+			for _, group := range strings.Split(record[3], ",") {
+				if group = strings.TrimSpace(group); group != "" {
+					id.Groups = append(id.Groups, group)
+				}
+			}
+		}
+		a.tokens[sha256.Sum256([]byte(record[0]))] = id
+	}
+	return a, nil
+}
+
+func (a *tokenAuthenticator) Authenticate(r *http.Request) (Identity, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return Identity{}, ErrNoCredentials
+	}
+	id, ok := a.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return Identity{}, errors.New("unknown bearer token")
+	}
+	return id, nil
+}
+
+func (a *tokenAuthenticator) Challenge() string {
+	return "Bearer"
+}
+
+// NewScrapeTokenFile returns an Authenticator for a single bearer token,
+// read from a file, used by Prometheus to scrape metrics. The scraper is
+// given the full role, so the Authenticator must only be used for the
+// metrics endpoint.
+func NewScrapeTokenFile(path string) (Authenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return nil, fmt.Errorf("error reading scrape token file %s: no token", path)
+	}
+	return &tokenAuthenticator{
+		tokens: map[[sha256.Size]byte]Identity{
+			sha256.Sum256([]byte(token)): {Username: "metrics-scraper", Method: "scrape-token", Role: RoleFull},
+		},
+	}, nil
+}
@@ -16,14 +16,16 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	opmetrics "github.com/skupperproject/skupper/cmd/network-observer/internal/collector/metrics"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/eventsource"
 	"github.com/skupperproject/skupper/pkg/vanflow/session"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	bolt "go.etcd.io/bbolt"
 	"golang.org/x/sync/errgroup"
 )
 
-func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.Registry, flowRecordTTL time.Duration, flowLogger func(vanflow.RecordMessage)) *Collector {
+func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.Registry, flowRecordTTL time.Duration, flowLogger func(vanflow.RecordMessage), storage StorageConfig) (*Collector, error) {
 	sessionCtr := factory.Create()
 
 	collector := &Collector{
@@ -38,16 +40,24 @@ func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.
 		metrics:        register(reg),
 		metricsAdaptor: opmetrics.New(reg),
 		flowLogging:    flowLogger,
+		broker:         newBroker(),
 	}
 
-	collector.Records = store.NewSyncMapStore(store.SyncMapStoreConfig{
+	recordsConfig := store.SyncMapStoreConfig{
 		Handlers: store.EventHandlerFuncs{
 			OnAdd:    collector.handleStoreAdd,
 			OnChange: collector.handleStoreChange,
 			OnDelete: collector.handleStoreDelete,
 		},
 		Indexers: RecordIndexers(),
-	})
+	}
+	if storage.Enabled() {
+		if err := collector.openStorage(storage, recordsConfig); err != nil {
+			return nil, err
+		}
+	} else {
+		collector.Records = store.NewSyncMapStore(recordsConfig)
+	}
 	collector.graph = NewGraph(collector.Records).(*graph)
 	collector.processManager = newProcessManager(logger, collector.Records, collector.graph, newStableIdentityProvider(), collector.metrics)
 	collector.addressManager = newAddressManager(collector.logger, collector.Records)
@@ -56,13 +66,15 @@ func New(logger *slog.Logger, factory session.ContainerFactory, reg *prometheus.
 	for _, typ := range standardRecordTypes {
 		routerCfg[typ.String()] = collector.Records
 	}
-	return collector
+	return collector, nil
 }
 
 type Collector struct {
 	logger        *slog.Logger
 	flowRecordTTL time.Duration
 	flowLogging   func(vanflow.RecordMessage)
+	flowHandlers  []func(TerminatedFlow)
+	series        *timeseries.Store
 
 	session   session.Container
 	discovery *eventsource.Discovery
@@ -81,8 +93,18 @@ type Collector struct {
 
 	events     chan changeEvent
 	purgeQueue chan store.SourceRef
+	broker     *broker
 
 	metrics metrics
+
+	// storage is only set up when records are persisted
+	db              *bolt.DB
+	persisted       []*store.BoltStore
+	flowArchive     store.Interface
+	retention       Retention
+	restoredEntries []store.Entry
+	restoredMu      sync.Mutex
+	restored        map[string]struct{}
 }
 
 type eventSource struct {
@@ -105,6 +127,9 @@ func (c *Collector) Run(ctx context.Context) error {
 	g.Go(c.processManager.run(ctx))
 	g.Go(c.addressManager.run(ctx))
 	g.Go(c.pairManager.run(ctx))
+	if c.db != nil {
+		g.Go(c.runStorage(ctx))
+	}
 	return g.Wait()
 }
 
@@ -234,6 +259,9 @@ func (c *Collector) runDiscovery(ctx context.Context) func() error {
 		defer func() {
 			c.logger.Info("discovery shutdown complete")
 		}()
+		// index any records loaded from storage before their sources
+		// start reporting changes to them
+		c.warmStart(ctx)
 		return c.discovery.Run(ctx, eventsource.DiscoveryHandlers{
 			Discovered: c.discoveryHandler(ctx),
 			Forgotten:  c.handleForgotten,
@@ -251,20 +279,39 @@ func (c *Collector) runRecordCleanup(ctx context.Context) func() error {
 		terminatedExemplar := store.Entry{
 			Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("", time.Unix(1, 0), time.Unix(2, 0))},
 		}
+		var restoredExpiry <-chan time.Time
+		if c.db != nil {
+			restoredExpiry = time.After(restoredRecordGracePeriod)
+		}
 		for {
 			select {
 			case <-ctx.Done():
 				return nil
 			case <-ticker.C:
-				terminated := c.Records.Index(IndexByLifecycleStatus, terminatedExemplar)
-				for _, e := range terminated {
+				now := time.Now()
+				var ct int
+				for _, e := range c.Records.Index(IndexByLifecycleStatus, terminatedExemplar) {
+					if c.retained(e, now) {
+						continue
+					}
 					c.Records.Delete(e.Record.Identity())
+					ct++
 				}
-				if ct := len(terminated); ct > 0 {
+				if ct > 0 {
 					c.logger.Info("purged terminated records",
 						slog.Int("count", ct),
 					)
 				}
+				if ct := c.expireFlows(now); ct > 0 {
+					c.logger.Info("purged expired flows",
+						slog.Int("count", ct),
+					)
+				}
+			case <-restoredExpiry:
+				ct := c.purgeUnconfirmed()
+				c.logger.Info("purged restored records not reported by any source",
+					slog.Int("count", ct),
+				)
 			case source := <-c.purgeQueue:
 				ct := c.purge(source)
 				c.logger.Info("purged records from forgotten source",
@@ -283,6 +330,7 @@ func (c *Collector) handleStoreAdd(e store.Entry) {
 	case ConnectionRecord:
 		return
 	}
+	c.broker.publish(RecordAdded, e.Record)
 	select {
 	case c.events <- addEvent{Record: e.Record}:
 	default:
@@ -297,6 +345,7 @@ func (c *Collector) handleStoreChange(p, e store.Entry) {
 	case ConnectionRecord:
 		return
 	}
+	c.broker.publish(RecordUpdated, e.Record)
 	select {
 	case c.events <- updateEvent{Prev: p.Record, Curr: e.Record}:
 	default:
@@ -310,6 +359,7 @@ func (c *Collector) handleStoreDelete(e store.Entry) {
 	case ConnectionRecord:
 		return
 	}
+	c.broker.publish(RecordDeleted, e.Record)
 	select {
 	case c.events <- deleteEvent{Record: e.Record}:
 	default:
@@ -319,10 +369,16 @@ func (c *Collector) handleStoreDelete(e store.Entry) {
 
 func (c *Collector) purge(source store.SourceRef) int {
 	matching := c.Records.Index(store.SourceIndex, store.Entry{Metadata: store.Metadata{Source: source}})
+	now := time.Now()
+	var count int
 	for _, record := range matching {
+		if c.retained(record, now) {
+			continue
+		}
 		c.Records.Delete(record.Record.Identity())
+		count++
 	}
-	return len(matching)
+	return count
 }
 
 func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info) {
@@ -372,6 +428,9 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 				c.graph,
 				c.metrics,
 				c.flowRecordTTL,
+				c.flowArchive,
+				c.flowTerminated(),
+				c.series,
 			)
 
 			// route flow records to source-specific stores
@@ -384,6 +443,9 @@ func (c *Collector) discoveryHandler(ctx context.Context) func(eventsource.Info)
 		if c.flowLogging != nil {
 			client.OnRecord(c.flowLogging)
 		}
+		if c.db != nil {
+			client.OnRecord(c.confirmRestored)
+		}
 		client.OnRecord(router.Route)
 
 		for _, address := range addresses {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 )
@@ -31,6 +32,15 @@ type connectionManager struct {
 	transportMetricsCache map[labelSet]transportMetrics
 
 	ttl time.Duration
+	// archive, when set, holds a copy of flows that outlives the flows
+	// store so that connections and requests can be retained as history
+	archive store.Interface
+	// terminated, when set, is called once for each reconciled flow that
+	// has ended
+	terminated func(TerminatedFlow)
+	// series, when set, holds rolling aggregates of the flows by service,
+	// site pair and process pair
+	series *timeseries.Store
 
 	transportProcessingTime prometheus.Observer
 	appProcessingTime       prometheus.Observer
@@ -47,10 +57,13 @@ type connectionManager struct {
 	routerCache     map[string]routerAttrs
 }
 
-func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.SourceRef, records store.Interface, graph *graph, metrics metrics, ttl time.Duration) *connectionManager {
+func newConnectionmanager(ctx context.Context, log *slog.Logger, source store.SourceRef, records store.Interface, graph *graph, metrics metrics, ttl time.Duration, archive store.Interface, terminated func(TerminatedFlow), series *timeseries.Store) *connectionManager {
 	m := &connectionManager{
 		logger:                  log,
 		records:                 records,
+		archive:                 archive,
+		terminated:              terminated,
+		series:                  series,
 		graph:                   graph,
 		source:                  source,
 		idp:                     newStableIdentityProvider(),
@@ -103,6 +116,7 @@ func (c *connectionManager) handleTransportFlow(record vanflow.TransportBiflowRe
 	if !state.Opened {
 		metrics.opened.Inc()
 		metrics.closed.Add(0)
+		metrics.series.ConnectionOpened()
 		state.Opened = true
 	}
 	if !state.Terminated && record.EndTime != nil {
@@ -110,6 +124,8 @@ func (c *connectionManager) handleTransportFlow(record vanflow.TransportBiflowRe
 		if terminated {
 			state.Terminated = true
 			metrics.closed.Inc()
+			metrics.series.ConnectionClosed()
+			c.notifyTerminated(record)
 		}
 	}
 	if !state.LatencySet && record.Latency != nil && record.LatencyReverse != nil {
@@ -118,18 +134,25 @@ func (c *connectionManager) handleTransportFlow(record vanflow.TransportBiflowRe
 		metrics.latency.Observe(delta.Seconds())
 		metrics.latencyLegacy.Observe(float64(*record.Latency))
 		metrics.latencyLegacyReverse.Observe(float64(*record.LatencyReverse))
+		metrics.series.ObserveLatency(delta.Seconds())
 	}
 	bs, br := dref(record.Octets), dref(record.OctetsReverse)
 	sentInc := float64(bs - state.BytesSent)
 	receivedInc := float64(br - state.BytesReceived)
+	var seriesSent, seriesReceived uint64
 	if receivedInc > 0 {
 		metrics.received.Add(receivedInc)
+		seriesReceived = br - state.BytesReceived
 		state.BytesReceived = br
 	}
 	if sentInc > 0 {
 		metrics.sent.Add(sentInc)
+		seriesSent = bs - state.BytesSent
 		state.BytesSent = bs
 	}
+	if seriesSent > 0 || seriesReceived > 0 {
+		metrics.series.AddBytes(seriesSent, seriesReceived)
+	}
 	c.transportFlows.Push(record.ID, state)
 }
 
@@ -151,10 +174,13 @@ func (c *connectionManager) handleAppFlow(record vanflow.AppBiflowRecord) {
 		terminated := record.EndTime.Compare(dref(record.StartTime).Time) >= 0
 		if terminated {
 			state.Terminated = true
+			class := normalizeHTTPResponseClass(record.Result)
 			metrics.requests.With(prometheus.Labels{
 				"method": normalizeHTTPMethod(record.Method),
-				"code":   normalizeHTTPResponseClass(record.Result),
+				"code":   class,
 			}).Inc()
+			metrics.series.Request(class)
+			c.notifyTerminated(record)
 		}
 	}
 	c.appFlows.Push(record.ID, state)
@@ -166,6 +192,7 @@ func (c *connectionManager) handleAdd(e store.Entry) {
 
 func (c *connectionManager) handleChange(p, e store.Entry) {
 	start := time.Now()
+	c.archiveFlow(e)
 	switch record := e.Record.(type) {
 	case vanflow.TransportBiflowRecord:
 		c.handleTransportFlow(record)
@@ -208,15 +235,56 @@ func (c *connectionManager) handleDelete(e store.Entry) {
 	switch record := e.Record.(type) {
 	case vanflow.TransportBiflowRecord:
 		c.transportFlows.Pop(record.ID)
-		c.records.Delete(record.ID)
+		c.deleteRecord(record.ID)
 	case vanflow.AppBiflowRecord:
 		c.appFlows.Pop(record.ID)
-		c.records.Delete(record.ID)
+		c.deleteRecord(record.ID)
 	default:
 		// ignore
 	}
 }
 
+func (c *connectionManager) archiveFlow(e store.Entry) {
+	if c.archive == nil {
+		return
+	}
+	if !c.archive.Update(e.Record) {
+		c.archive.Add(e.Record, e.Source)
+	}
+}
+
+// deleteRecord removes the connection or request record for a flow no
+// longer held in memory, unless the flow is archived in which case the
+// record is removed when the archived flow expires.
+func (c *connectionManager) deleteRecord(id string) {
+	if c.archive != nil {
+		return
+	}
+	c.records.Delete(id)
+}
+
+// notifyTerminated passes the connection or request record of a flow that
+// has ended to the terminated handler.
+func (c *connectionManager) notifyTerminated(flow vanflow.Record) {
+	if c.terminated == nil {
+		return
+	}
+	entry, ok := c.records.Get(flow.Identity())
+	if !ok {
+		return
+	}
+	c.terminated(TerminatedFlow{Record: entry.Record, Flow: flow})
+}
+
+// flowStore returns the store that connection and request records read
+// their flows from.
+func (c *connectionManager) flowStore() store.Interface {
+	if c.archive != nil {
+		return c.archive
+	}
+	return c.flows
+}
+
 type reconcileReason int
 
 const (
@@ -280,9 +348,19 @@ func (c *connectionManager) reconcileRequest(state appState) (RequestRecord, rec
 		SourceGroup:  connRecord.SourceGroup,
 		DestGroup:    connRecord.DestGroup,
 
-		stor: c.flows,
+		stor: c.flowStore(),
 	}
 	rr.metrics = c.getAppMetricSet(rr.toLabelSet())
+	// requests count towards the series of their connection as well as
+	// those of pairs by application protocol
+	seriesKeys := connRecord.seriesKeys()
+	if rr.Protocol != connRecord.Protocol {
+		seriesKeys = append(seriesKeys,
+			timeseries.SitePairKey(rr.SourceSite.ID, rr.DestSite.ID, rr.Protocol),
+			timeseries.ProcessPairKey(rr.Source.ID, rr.Dest.ID, rr.Protocol),
+		)
+	}
+	rr.metrics.series = c.series.Group(seriesKeys...)
 	return rr, success
 }
 
@@ -389,9 +467,10 @@ func (c *connectionManager) reconcile(state transportState) (ConnectionRecord, r
 			Name: destproc.GroupName,
 		},
 
-		FlowStore: c.flows,
+		FlowStore: c.flowStore(),
 	}
 	cr.metrics = c.getTransportMetricSet(cr.toLabelSet())
+	cr.metrics.series = c.series.Group(cr.seriesKeys()...)
 	return cr, success
 }
 
@@ -528,14 +607,14 @@ func (c *connectionManager) run(ctx context.Context) {
 						c.logger.Debug("purging terminated transport flows", slog.Int("count", ct))
 						for id := range terminated {
 							c.flows.Delete(id)
-							c.records.Delete(id)
+							c.deleteRecord(id)
 						}
 					}
 					if ct := len(stale); ct > 0 {
 						c.logger.Info("purging stale transport flows", slog.Int("count", ct))
 						for id := range stale {
 							c.flows.Delete(id)
-							c.records.Delete(id)
+							c.deleteRecord(id)
 						}
 					}
 				}
@@ -842,9 +921,11 @@ type transportMetrics struct {
 	latency              prometheus.Observer
 	latencyLegacy        prometheus.Observer
 	latencyLegacyReverse prometheus.Observer
+	series               timeseries.Group
 }
 type appMetrics struct {
 	requests *prometheus.CounterVec
+	series   timeseries.Group
 }
 
 type appState struct {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 	"gotest.tools/v3/assert"
@@ -22,7 +23,7 @@ func TestConnectionManager(t *testing.T) {
 	// TODO(ck)  newConnectionmanager starts goroutines that can "steal" work
 	// from manually invoked manager methods (i.e. runReconcile). Write
 	// idempotent assertions.
-	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute)
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute, nil, nil, nil)
 	defer manager.Stop()
 	flowStor := manager.flows
 
@@ -87,13 +88,77 @@ func TestConnectionManager(t *testing.T) {
 	assert.Equal(t, requestRecord.Dest.Name, "server-east-06")
 }
 
+func TestConnectionManagerTerminated(t *testing.T) {
+	tCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tlog := slog.Default()
+	vanStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
+	graf := NewGraph(vanStor).(*graph)
+	terminated := make(chan TerminatedFlow, 8)
+	series := timeseries.New(timeseries.Config{})
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute, nil, func(flow TerminatedFlow) {
+		terminated <- flow
+	}, series)
+	defer manager.Stop()
+	flowStor := manager.flows
+
+	vanStor.Replace(wrapRecords(van...))
+	graf.Reset()
+
+	start := time.Now()
+	flowStor.Add(vanflow.TransportBiflowRecord{
+		BaseRecord:  vanflow.NewBase("tflow-01", start),
+		Parent:      ptrTo("listener-backend"),
+		ConnectorID: ptrTo("connector-backend-1-6"),
+		SourceHost:  ptrTo("10.111.0.111"),
+	}, store.SourceRef{})
+	manager.runReconcile()
+	flowStor.Patch(vanflow.TransportBiflowRecord{
+		BaseRecord: vanflow.NewBase("tflow-01", start, start.Add(time.Second)),
+		Octets:     ptrTo(uint64(64)),
+	}, store.SourceRef{})
+
+	var connection ConnectionRecord
+	select {
+	case flow := <-terminated:
+		var ok bool
+		connection, ok = flow.Record.(ConnectionRecord)
+		assert.Assert(t, ok, "expected a ConnectionRecord, got %T", flow.Record)
+		assert.Equal(t, connection.Source.Name, "client-west-01")
+		transport, ok := flow.Flow.(vanflow.TransportBiflowRecord)
+		assert.Assert(t, ok, "expected a TransportBiflowRecord, got %T", flow.Flow)
+		assert.Equal(t, *transport.Octets, uint64(64))
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for terminated connection")
+	}
+
+	flowStor.Patch(vanflow.TransportBiflowRecord{
+		BaseRecord: vanflow.NewBase("tflow-01"),
+		Octets:     ptrTo(uint64(128)),
+	}, store.SourceRef{})
+	manager.runReconcile()
+	select {
+	case flow := <-terminated:
+		t.Fatalf("unexpected second termination of %s", flow.Record.Identity())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	points, err := series.Query(timeseries.ProcessPairKey(connection.Source.ID, connection.Dest.ID, connection.Protocol), time.Minute, time.Minute)
+	assert.NilError(t, err)
+	assert.Equal(t, len(points), 1)
+	assert.Equal(t, points[0].ConnectionsOpened, uint64(1))
+	assert.Equal(t, points[0].ConnectionsClosed, uint64(1))
+	assert.Equal(t, points[0].BytesSent, uint64(128))
+}
+
 func benchmarkRunReconcile(b *testing.B, connections int) {
 	tCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	tlog := slog.Default()
 	vanStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
 	graf := NewGraph(vanStor).(*graph)
-	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute)
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute, nil, nil, nil)
 	defer manager.Stop()
 	flowStor := manager.flows
 
@@ -132,7 +197,7 @@ func benchmarkRunAppReconcile(b *testing.B, connections int, requests int) {
 	tlog := slog.Default()
 	vanStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: RecordIndexers()})
 	graf := NewGraph(vanStor).(*graph)
-	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute)
+	manager := newConnectionmanager(tCtx, tlog, store.SourceRef{}, vanStor, graf, register(prometheus.NewRegistry()), time.Minute, nil, nil, nil)
 	defer manager.Stop()
 	flowStor := manager.flows
 
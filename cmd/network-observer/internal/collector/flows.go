@@ -3,6 +3,7 @@ package collector
 import (
 	"time"
 
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 )
@@ -33,7 +34,7 @@ type ConnectionRecord struct {
 	// FlowStore is the backing store containing the Biflow records. This was
 	// split from the main record store to keep high volume flow producers from
 	// affecting the rest of the event sources.
-	FlowStore store.Interface
+	FlowStore store.Interface `json:"-"`
 	metrics   transportMetrics
 }
 
@@ -74,6 +75,16 @@ func (r ConnectionRecord) toLabelSet() labelSet {
 	}
 }
 
+// seriesKeys returns the keys of the time series the connection is
+// recorded to.
+func (r ConnectionRecord) seriesKeys() []timeseries.Key {
+	return []timeseries.Key{
+		timeseries.ServiceKey(r.RoutingKey, r.Protocol),
+		timeseries.SitePairKey(r.SourceSite.ID, r.DestSite.ID, r.Protocol),
+		timeseries.ProcessPairKey(r.Source.ID, r.Dest.ID, r.Protocol),
+	}
+}
+
 var _ vanflow.Record = (*RequestRecord)(nil)
 
 type RequestRecord struct {
@@ -151,3 +162,38 @@ type NamedReference struct {
 	ID   string
 	Name string
 }
+
+// TerminatedFlow is a connection or request whose flow has ended.
+type TerminatedFlow struct {
+	// Record is the ConnectionRecord or RequestRecord of the flow.
+	Record vanflow.Record
+	// Flow is the final state of the TransportBiflowRecord or
+	// AppBiflowRecord.
+	Flow vanflow.Record
+}
+
+// OnFlowTerminated registers a handler called once for each connection
+// and request when its flow ends. Handlers are called from the flow
+// processing path and must not block. It must be called before Run.
+func (c *Collector) OnFlowTerminated(handler func(TerminatedFlow)) {
+	c.flowHandlers = append(c.flowHandlers, handler)
+}
+
+// RecordTimeSeries records the traffic of connections and requests to the
+// series of their services, site pairs and process pairs. It must be
+// called before Run.
+func (c *Collector) RecordTimeSeries(series *timeseries.Store) {
+	c.series = series
+}
+
+func (c *Collector) flowTerminated() func(TerminatedFlow) {
+	if len(c.flowHandlers) == 0 {
+		return nil
+	}
+	handlers := c.flowHandlers
+	return func(flow TerminatedFlow) {
+		for _, handler := range handlers {
+			handler(flow)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	recordsBucket = "records"
+	flowsBucket   = "flows"
+
+	storageFlushInterval = time.Second
+	// restoredRecordGracePeriod is how long records loaded from storage
+	// are kept without being reported again by their source.
+	restoredRecordGracePeriod = 2 * time.Minute
+)
+
+// StorageConfig configures the optional persistence of collected records
+// to disk.
+type StorageConfig struct {
+	// Path to the database file. When empty, records are only held in
+	// memory.
+	Path string
+	// Retention is how long records are kept after they were last
+	// updated once they have terminated.
+	Retention Retention
+}
+
+func (s StorageConfig) Enabled() bool {
+	return s.Path != ""
+}
+
+// Retention is a retention period per record type. Connections and
+// requests that do not have a retention period are kept for the flow
+// record TTL. Other terminated records without a retention period are
+// removed as soon as they terminate.
+type Retention map[vanflow.TypeMeta]time.Duration
+
+var retentionTypes = map[string]vanflow.Record{
+	"site":          vanflow.SiteRecord{},
+	"router":        vanflow.RouterRecord{},
+	"link":          vanflow.LinkRecord{},
+	"router-access": vanflow.RouterAccessRecord{},
+	"connector":     vanflow.ConnectorRecord{},
+	"listener":      vanflow.ListenerRecord{},
+	"process":       vanflow.ProcessRecord{},
+	"connection":    ConnectionRecord{},
+	"request":       RequestRecord{},
+}
+
+// ParseRetention parses a comma separated list of type=duration pairs,
+// e.g. "connection=6h,request=1h".
+func ParseRetention(value string) (Retention, error) {
+	retention := Retention{}
+	if value == "" {
+		return retention, nil
+	}
+	for _, item := range strings.Split(value, ",") {
+		name, period, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid retention %q: expected type=duration", item)
+		}
+		exemplar, ok := retentionTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid retention %q: unknown record type %q, expected one of %s", item, name, strings.Join(retentionTypeNames(), ", "))
+		}
+		duration, err := time.ParseDuration(period)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention %q: %w", item, err)
+		}
+		if duration < 0 {
+			return nil, fmt.Errorf("invalid retention %q: duration must not be negative", item)
+		}
+		retention[exemplar.GetTypeMeta()] = duration
+	}
+	return retention, nil
+}
+
+func retentionTypeNames() []string {
+	var names []string
+	for name := range retentionTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func persistedRecordTypes() []vanflow.Record {
+	return []vanflow.Record{
+		vanflow.SiteRecord{},
+		vanflow.RouterRecord{},
+		vanflow.LinkRecord{},
+		vanflow.RouterAccessRecord{},
+		vanflow.ConnectorRecord{},
+		vanflow.ListenerRecord{},
+		vanflow.ProcessRecord{},
+		AddressRecord{},
+		ProcessGroupRecord{},
+		SitePairRecord{},
+		ProcGroupPairRecord{},
+		ProcPairRecord{},
+		FlowSourceRecord{},
+		ConnectionRecord{},
+		RequestRecord{},
+	}
+}
+
+// openStorage opens the database at the configured path and loads the
+// records and flows persisted there by a previous run.
+func (c *Collector) openStorage(cfg StorageConfig, recordsConfig store.SyncMapStoreConfig) error {
+	db, err := bolt.Open(cfg.Path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return fmt.Errorf("error opening storage %s: %w", cfg.Path, err)
+	}
+	records, err := store.NewBoltStore(store.BoltStoreConfig{
+		SyncMapStoreConfig: recordsConfig,
+		DB:                 db,
+		Bucket:             recordsBucket,
+		Types:              persistedRecordTypes(),
+	})
+	if err != nil {
+		db.Close()
+		return err
+	}
+	flows, err := store.NewBoltStore(store.BoltStoreConfig{
+		SyncMapStoreConfig: store.SyncMapStoreConfig{
+			Indexers: map[string]store.Indexer{
+				store.TypeIndex: store.TypeIndexer,
+			},
+		},
+		DB:     db,
+		Bucket: flowsBucket,
+		Types: []vanflow.Record{
+			vanflow.TransportBiflowRecord{},
+			vanflow.AppBiflowRecord{},
+		},
+	})
+	if err != nil {
+		db.Close()
+		return err
+	}
+
+	c.db = db
+	c.persisted = []*store.BoltStore{records, flows}
+	c.Records = records
+	c.flowArchive = flows
+	c.retention = cfg.Retention
+
+	c.restoredEntries = records.List()
+	c.restored = make(map[string]struct{}, len(c.restoredEntries))
+	for _, entry := range c.restoredEntries {
+		switch record := entry.Record.(type) {
+		case ConnectionRecord:
+			record.FlowStore = flows
+			records.Update(record)
+		case RequestRecord:
+			record.stor = flows
+			records.Update(record)
+		}
+		if entry.Source.ID != "self" {
+			c.restored[entry.Record.Identity()] = struct{}{}
+		}
+	}
+	c.logger.Info("loaded records from storage",
+		slog.String("path", cfg.Path),
+		slog.Int("records", len(c.restoredEntries)),
+		slog.Int("flows", len(flows.List())),
+	)
+	return nil
+}
+
+func (c *Collector) runStorage(ctx context.Context) func() error {
+	return func() error {
+		defer func() {
+			c.logger.Info("storage shutdown complete")
+		}()
+		flush := func() {
+			for _, stor := range c.persisted {
+				if err := stor.Flush(); err != nil {
+					c.logger.Error("error persisting records", slog.Any("error", err))
+				}
+			}
+		}
+		ticker := time.NewTicker(storageFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				flush()
+				if err := c.db.Close(); err != nil {
+					c.logger.Error("error closing storage", slog.Any("error", err))
+				}
+				return nil
+			case <-ticker.C:
+				flush()
+			}
+		}
+	}
+}
+
+// warmStart replays the records loaded from storage through the work
+// queue so that the graph and the record managers reflect them before
+// any source is discovered.
+func (c *Collector) warmStart(ctx context.Context) {
+	entries := c.restoredEntries
+	c.restoredEntries = nil
+	var count int
+	for _, entry := range entries {
+		switch entry.Record.(type) {
+		case ConnectionRecord, RequestRecord:
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case c.events <- addEvent{Record: entry.Record}:
+			count++
+		}
+	}
+	if count > 0 {
+		c.logger.Info("replayed records from storage", slog.Int("count", count))
+	}
+}
+
+// confirmRestored marks records loaded from storage as still known to
+// their source.
+func (c *Collector) confirmRestored(msg vanflow.RecordMessage) {
+	c.restoredMu.Lock()
+	defer c.restoredMu.Unlock()
+	if len(c.restored) == 0 {
+		return
+	}
+	for _, record := range msg.Records {
+		delete(c.restored, record.Identity())
+	}
+}
+
+// purgeUnconfirmed removes the records loaded from storage that have not
+// been reported again by their source and are not being retained as
+// history.
+func (c *Collector) purgeUnconfirmed() int {
+	c.restoredMu.Lock()
+	unconfirmed := c.restored
+	c.restored = nil
+	c.restoredMu.Unlock()
+
+	var count int
+	now := time.Now()
+	for id := range unconfirmed {
+		entry, ok := c.Records.Get(id)
+		if !ok || c.retained(entry, now) {
+			continue
+		}
+		c.Records.Delete(id)
+		count++
+	}
+	return count
+}
+
+// retained reports whether a record is kept as history rather than
+// removed along with its source or when it terminates.
+func (c *Collector) retained(e store.Entry, now time.Time) bool {
+	switch e.Record.(type) {
+	case ConnectionRecord, RequestRecord:
+		// these are removed along with their flow by expireFlows
+		return c.flowArchive != nil
+	}
+	retention, ok := c.retention[e.Record.GetTypeMeta()]
+	if !ok || retention == 0 {
+		return false
+	}
+	if !slices.Contains(indexByLifecycleStatus(e), "TERMINATED") {
+		return false
+	}
+	return now.Sub(e.LastUpdate) < retention
+}
+
+func (c *Collector) flowRetention(record vanflow.Record) time.Duration {
+	if retention, ok := c.retention[record.GetTypeMeta()]; ok {
+		return retention
+	}
+	return c.flowRecordTTL
+}
+
+// expireFlows removes archived flows, along with their connection or
+// request records, that have not been updated within the retention
+// period.
+func (c *Collector) expireFlows(now time.Time) int {
+	if c.flowArchive == nil {
+		return 0
+	}
+	connectionCutoff := now.Add(-1 * c.flowRetention(ConnectionRecord{}))
+	requestCutoff := now.Add(-1 * c.flowRetention(RequestRecord{}))
+	var count int
+	for _, entry := range c.flowArchive.List() {
+		cutoff := connectionCutoff
+		if _, ok := entry.Record.(vanflow.AppBiflowRecord); ok {
+			cutoff = requestCutoff
+		}
+		if !entry.LastUpdate.Before(cutoff) {
+			continue
+		}
+		id := entry.Record.Identity()
+		c.flowArchive.Delete(id)
+		c.Records.Delete(id)
+		count++
+	}
+	return count
+}
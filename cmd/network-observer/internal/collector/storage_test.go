@@ -0,0 +1,167 @@
+package collector
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseRetention(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Value    string
+		Expected Retention
+		Error    string
+	}{
+		{
+			Name:     "empty",
+			Expected: Retention{},
+		},
+		{
+			Name:  "multiple types",
+			Value: "connection=6h, request=1h,process=30m",
+			Expected: Retention{
+				ConnectionRecord{}.GetTypeMeta():      6 * time.Hour,
+				RequestRecord{}.GetTypeMeta():         time.Hour,
+				vanflow.ProcessRecord{}.GetTypeMeta(): 30 * time.Minute,
+			},
+		},
+		{
+			Name:  "missing duration",
+			Value: "connection",
+			Error: `invalid retention "connection": expected type=duration`,
+		},
+		{
+			Name:  "unknown type",
+			Value: "flow=1h",
+			Error: `invalid retention "flow=1h": unknown record type "flow", expected one of connection, connector, link, listener, process, request, router, router-access, site`,
+		},
+		{
+			Name:  "bad duration",
+			Value: "site=tomorrow",
+			Error: `invalid retention "site=tomorrow": time: invalid duration "tomorrow"`,
+		},
+		{
+			Name:  "negative duration",
+			Value: "site=-1h",
+			Error: `invalid retention "site=-1h": duration must not be negative`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := ParseRetention(tc.Value)
+			if tc.Error != "" {
+				assert.Error(t, err, tc.Error)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, actual, tc.Expected)
+		})
+	}
+}
+
+func newPersistentCollector(t *testing.T, path string) *Collector {
+	t.Helper()
+	c, err := New(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		session.NewMockContainerFactory(),
+		prometheus.NewRegistry(),
+		time.Minute,
+		nil,
+		StorageConfig{
+			Path: path,
+			Retention: Retention{
+				ConnectionRecord{}.GetTypeMeta(): time.Hour,
+			},
+		},
+	)
+	assert.NilError(t, err)
+	return c
+}
+
+func closeStorage(t *testing.T, c *Collector) {
+	t.Helper()
+	for _, stor := range c.persisted {
+		assert.NilError(t, stor.Flush())
+	}
+	assert.NilError(t, c.db.Close())
+}
+
+func TestStorageRestoresRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "observer.db")
+	source := store.SourceRef{ID: "router-1", Version: "1"}
+	start := time.Now().Add(-1 * time.Minute)
+
+	c := newPersistentCollector(t, path)
+	c.Records.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1", start), Name: ptrTo("west")}, source)
+	c.Records.Add(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1", start), Parent: ptrTo("site-1")}, source)
+	c.Records.Add(ConnectionRecord{ID: "conn-1", RoutingKey: "backend", FlowStore: c.flowArchive}, source)
+	c.flowArchive.Add(vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("conn-1", start, start.Add(time.Second)), Octets: ptrTo(uint64(1024))}, source)
+	closeStorage(t, c)
+
+	c = newPersistentCollector(t, path)
+	defer closeStorage(t, c)
+
+	entry, ok := c.Records.Get("site-1")
+	assert.Assert(t, ok)
+	assert.Equal(t, *entry.Record.(vanflow.SiteRecord).Name, "west")
+	assert.Equal(t, entry.Source, source)
+
+	entry, ok = c.Records.Get("conn-1")
+	assert.Assert(t, ok)
+	connection := entry.Record.(ConnectionRecord)
+	assert.Equal(t, connection.RoutingKey, "backend")
+	flow, ok := connection.GetFlow()
+	assert.Assert(t, ok)
+	assert.Equal(t, *flow.Octets, uint64(1024))
+
+	c.warmStart(context.Background())
+	assert.Equal(t, len(c.events), 2, "expected site and router to be replayed, but not the connection")
+
+	c.confirmRestored(vanflow.RecordMessage{Records: []vanflow.Record{vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")}}})
+	assert.Equal(t, c.purgeUnconfirmed(), 1)
+	_, ok = c.Records.Get("site-1")
+	assert.Assert(t, ok, "confirmed record should be kept")
+	_, ok = c.Records.Get("router-1")
+	assert.Assert(t, !ok, "unconfirmed record should be purged")
+	_, ok = c.Records.Get("conn-1")
+	assert.Assert(t, ok, "archived connection should be kept")
+
+	assert.Equal(t, c.expireFlows(time.Now().Add(30*time.Minute)), 0)
+	assert.Equal(t, c.expireFlows(time.Now().Add(2*time.Hour)), 1)
+	_, ok = c.Records.Get("conn-1")
+	assert.Assert(t, !ok, "expired connection should be purged")
+	_, ok = c.flowArchive.Get("conn-1")
+	assert.Assert(t, !ok, "expired flow should be purged")
+}
+
+func TestRetainedTerminatedRecords(t *testing.T) {
+	c := &Collector{
+		retention: Retention{
+			vanflow.ProcessRecord{}.GetTypeMeta(): time.Hour,
+		},
+	}
+	now := time.Now()
+	start := now.Add(-2 * time.Hour)
+	terminated := func(record vanflow.Record, lastUpdate time.Time) store.Entry {
+		return store.Entry{Metadata: store.Metadata{LastUpdate: lastUpdate}, Record: record}
+	}
+	process := vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("p", start, start.Add(time.Minute))}
+	site := vanflow.SiteRecord{BaseRecord: vanflow.NewBase("s", start, start.Add(time.Minute))}
+	active := vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("a", start)}
+
+	assert.Assert(t, c.retained(terminated(process, now.Add(-30*time.Minute)), now))
+	assert.Assert(t, !c.retained(terminated(process, now.Add(-90*time.Minute)), now))
+	assert.Assert(t, !c.retained(terminated(site, now.Add(-30*time.Minute)), now))
+	assert.Assert(t, !c.retained(terminated(active, now), now))
+	assert.Assert(t, !c.retained(terminated(ConnectionRecord{ID: "c"}, now), now), "connections are only retained when flows are archived")
+}
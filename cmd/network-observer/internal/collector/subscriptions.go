@@ -0,0 +1,128 @@
+package collector
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+// RecordEventAction is the change made to a record in the collector's
+// store.
+type RecordEventAction string
+
+const (
+	RecordAdded   RecordEventAction = "add"
+	RecordUpdated RecordEventAction = "update"
+	RecordDeleted RecordEventAction = "delete"
+)
+
+// RecordEvent describes a change to a record in the collector's store.
+type RecordEvent struct {
+	Action RecordEventAction
+	Record vanflow.Record
+}
+
+// ErrSubscriptionOverflow is the error of a Subscription that was closed
+// because its events were not received as fast as they were published.
+var ErrSubscriptionOverflow = errors.New("subscription closed: events were not consumed fast enough")
+
+const subscriptionBufferSize = 256
+
+// Subscription receives changes to records of a set of types. The
+// channel is closed when the subscription is closed.
+type Subscription struct {
+	C <-chan RecordEvent
+
+	c      chan RecordEvent
+	types  map[vanflow.TypeMeta]struct{}
+	broker *broker
+	err    error
+}
+
+// Close stops delivery of events to the subscription.
+func (s *Subscription) Close() {
+	s.broker.remove(s, nil)
+}
+
+// Err returns the reason the subscription was closed by the collector,
+// or nil.
+func (s *Subscription) Err() error {
+	s.broker.mu.RLock()
+	defer s.broker.mu.RUnlock()
+	return s.err
+}
+
+// broker fans out record events to subscriptions without blocking the
+// publisher. Subscriptions that fall behind are closed rather than
+// dropping individual events.
+type broker struct {
+	mu            sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+func (b *broker) subscribe(types []vanflow.TypeMeta) *Subscription {
+	c := make(chan RecordEvent, subscriptionBufferSize)
+	sub := &Subscription{
+		C:      c,
+		c:      c,
+		types:  make(map[vanflow.TypeMeta]struct{}, len(types)),
+		broker: b,
+	}
+	for _, typ := range types {
+		sub.types[typ] = struct{}{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions[sub] = struct{}{}
+	return sub
+}
+
+func (b *broker) remove(sub *Subscription, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscriptions[sub]; !ok {
+		return
+	}
+	delete(b.subscriptions, sub)
+	sub.err = err
+	close(sub.c)
+}
+
+func (b *broker) publish(action RecordEventAction, record vanflow.Record) {
+	var overflowed []*Subscription
+	func() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		if len(b.subscriptions) == 0 {
+			return
+		}
+		typ := record.GetTypeMeta()
+		event := RecordEvent{Action: action, Record: record}
+		for sub := range b.subscriptions {
+			if _, ok := sub.types[typ]; !ok {
+				continue
+			}
+			select {
+			case sub.c <- event:
+			default:
+				overflowed = append(overflowed, sub)
+			}
+		}
+	}()
+	for _, sub := range overflowed {
+		b.remove(sub, ErrSubscriptionOverflow)
+	}
+}
+
+// Subscribe returns a Subscription to changes made to records of the
+// given types. The subscription must be closed when no longer needed.
+func (c *Collector) Subscribe(types ...vanflow.TypeMeta) *Subscription {
+	return c.broker.subscribe(types)
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"gotest.tools/v3/assert"
+)
+
+func TestBrokerPublish(t *testing.T) {
+	b := newBroker()
+	sites := b.subscribe([]vanflow.TypeMeta{vanflow.SiteRecord{}.GetTypeMeta()})
+	defer sites.Close()
+	all := b.subscribe([]vanflow.TypeMeta{vanflow.SiteRecord{}.GetTypeMeta(), vanflow.ProcessRecord{}.GetTypeMeta()})
+	defer all.Close()
+
+	b.publish(RecordAdded, vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")})
+	b.publish(RecordUpdated, vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("process-1")})
+	b.publish(RecordDeleted, vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")})
+
+	assert.Equal(t, len(sites.C), 2)
+	assert.DeepEqual(t, <-sites.C, RecordEvent{Action: RecordAdded, Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")}})
+	assert.DeepEqual(t, <-sites.C, RecordEvent{Action: RecordDeleted, Record: vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")}})
+
+	assert.Equal(t, len(all.C), 3)
+	assert.Equal(t, (<-all.C).Action, RecordAdded)
+	assert.DeepEqual(t, <-all.C, RecordEvent{Action: RecordUpdated, Record: vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("process-1")}})
+	assert.Equal(t, (<-all.C).Action, RecordDeleted)
+}
+
+func TestBrokerOverflow(t *testing.T) {
+	b := newBroker()
+	slow := b.subscribe([]vanflow.TypeMeta{vanflow.SiteRecord{}.GetTypeMeta()})
+	for i := 0; i <= subscriptionBufferSize; i++ {
+		b.publish(RecordUpdated, vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")})
+	}
+	var received int
+	for range slow.C {
+		received++
+	}
+	assert.Equal(t, received, subscriptionBufferSize)
+	assert.Equal(t, slow.Err(), ErrSubscriptionOverflow)
+	assert.Equal(t, len(b.subscriptions), 0)
+
+	// closing after the broker has closed the subscription is a no-op
+	slow.Close()
+	assert.Equal(t, slow.Err(), ErrSubscriptionOverflow)
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	b := newBroker()
+	sub := b.subscribe([]vanflow.TypeMeta{vanflow.SiteRecord{}.GetTypeMeta()})
+	sub.Close()
+	_, ok := <-sub.C
+	assert.Assert(t, !ok)
+	assert.NilError(t, sub.Err())
+	b.publish(RecordAdded, vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1")})
+}
@@ -0,0 +1,251 @@
+package ipfix
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"time"
+)
+
+const (
+	version            = 10
+	messageHeaderLen   = 16
+	setHeaderLen       = 4
+	templateSetID      = 2
+	variableLength     = 0xffff
+	enterpriseBit      = 0x8000
+	maxShortStringSize = 254
+
+	// reversePEN is the private enterprise number used for the reverse
+	// direction of biflow information elements (RFC 5103).
+	reversePEN = 29305
+
+	templateIDv4 = 256
+	templateIDv6 = 257
+)
+
+// IANA information elements.
+const (
+	ieOctetDeltaCount          = 1
+	ieProtocolIdentifier       = 4
+	ieSourceTransportPort      = 7
+	ieSourceIPv4Address        = 8
+	ieDestinationTransportPort = 11
+	ieDestinationIPv4Address   = 12
+	ieSourceIPv6Address        = 27
+	ieDestinationIPv6Address   = 28
+	ieFlowStartMilliseconds    = 152
+	ieFlowEndMilliseconds      = 153
+)
+
+// Enterprise-specific information elements.
+const (
+	ieSourceSiteName = iota + 1
+	ieDestSiteName
+	ieRoutingKey
+	ieSourceProcessName
+	ieDestProcessName
+	ieConnectorHost
+)
+
+const protocolTCP = 6
+
+// flowRecord is a terminated connection in the form it is exported.
+type flowRecord struct {
+	SourceAddr    netip.Addr
+	SourcePort    uint16
+	DestAddr      netip.Addr
+	DestPort      uint16
+	Octets        uint64
+	OctetsReverse uint64
+	Start         time.Time
+	End           time.Time
+
+	SourceSite    string
+	DestSite      string
+	RoutingKey    string
+	SourceProcess string
+	DestProcess   string
+	ConnectorHost string
+}
+
+type fieldSpec struct {
+	ID         uint16
+	Length     uint16
+	Enterprise uint32
+}
+
+type field struct {
+	fieldSpec
+	encode func(b []byte, r *flowRecord) []byte
+}
+
+type template struct {
+	ID     uint16
+	Fields []field
+}
+
+// templates returns the IPv4 and IPv6 templates, which differ only in the
+// size of their address fields.
+func templates(enterprise uint32) map[uint16]template {
+	return map[uint16]template{
+		templateIDv4: newTemplate(templateIDv4, false, enterprise),
+		templateIDv6: newTemplate(templateIDv6, true, enterprise),
+	}
+}
+
+func newTemplate(id uint16, v6 bool, enterprise uint32) template {
+	sourceAddr := field{fieldSpec{ID: ieSourceIPv4Address, Length: 4}, func(b []byte, r *flowRecord) []byte {
+		a := r.SourceAddr.As4()
+		return append(b, a[:]...)
+	}}
+	destAddr := field{fieldSpec{ID: ieDestinationIPv4Address, Length: 4}, func(b []byte, r *flowRecord) []byte {
+		a := r.DestAddr.As4()
+		return append(b, a[:]...)
+	}}
+	if v6 {
+		sourceAddr = field{fieldSpec{ID: ieSourceIPv6Address, Length: 16}, func(b []byte, r *flowRecord) []byte {
+			a := r.SourceAddr.As16()
+			return append(b, a[:]...)
+		}}
+		destAddr = field{fieldSpec{ID: ieDestinationIPv6Address, Length: 16}, func(b []byte, r *flowRecord) []byte {
+			a := r.DestAddr.As16()
+			return append(b, a[:]...)
+		}}
+	}
+	str := func(id uint16, value func(r *flowRecord) string) field {
+		return field{fieldSpec{ID: id, Length: variableLength, Enterprise: enterprise}, func(b []byte, r *flowRecord) []byte {
+			return appendString(b, value(r))
+		}}
+	}
+	return template{
+		ID: id,
+		Fields: []field{
+			sourceAddr,
+			{fieldSpec{ID: ieSourceTransportPort, Length: 2}, func(b []byte, r *flowRecord) []byte {
+				return binary.BigEndian.AppendUint16(b, r.SourcePort)
+			}},
+			destAddr,
+			{fieldSpec{ID: ieDestinationTransportPort, Length: 2}, func(b []byte, r *flowRecord) []byte {
+				return binary.BigEndian.AppendUint16(b, r.DestPort)
+			}},
+			{fieldSpec{ID: ieProtocolIdentifier, Length: 1}, func(b []byte, r *flowRecord) []byte {
+				return append(b, protocolTCP)
+			}},
+			{fieldSpec{ID: ieOctetDeltaCount, Length: 8}, func(b []byte, r *flowRecord) []byte {
+				return binary.BigEndian.AppendUint64(b, r.Octets)
+			}},
+			{fieldSpec{ID: ieOctetDeltaCount, Length: 8, Enterprise: reversePEN}, func(b []byte, r *flowRecord) []byte {
+				return binary.BigEndian.AppendUint64(b, r.OctetsReverse)
+			}},
+			{fieldSpec{ID: ieFlowStartMilliseconds, Length: 8}, func(b []byte, r *flowRecord) []byte {
+				return binary.BigEndian.AppendUint64(b, uint64(r.Start.UnixMilli()))
+			}},
+			{fieldSpec{ID: ieFlowEndMilliseconds, Length: 8}, func(b []byte, r *flowRecord) []byte {
+				return binary.BigEndian.AppendUint64(b, uint64(r.End.UnixMilli()))
+			}},
+			str(ieSourceSiteName, func(r *flowRecord) string { return r.SourceSite }),
+			str(ieDestSiteName, func(r *flowRecord) string { return r.DestSite }),
+			str(ieRoutingKey, func(r *flowRecord) string { return r.RoutingKey }),
+			str(ieSourceProcessName, func(r *flowRecord) string { return r.SourceProcess }),
+			str(ieDestProcessName, func(r *flowRecord) string { return r.DestProcess }),
+			str(ieConnectorHost, func(r *flowRecord) string { return r.ConnectorHost }),
+		},
+	}
+}
+
+func (t template) appendTemplateRecord(b []byte) []byte {
+	b = binary.BigEndian.AppendUint16(b, t.ID)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(t.Fields)))
+	for _, f := range t.Fields {
+		if f.Enterprise == 0 {
+			b = binary.BigEndian.AppendUint16(b, f.ID)
+			b = binary.BigEndian.AppendUint16(b, f.Length)
+			continue
+		}
+		b = binary.BigEndian.AppendUint16(b, f.ID|enterpriseBit)
+		b = binary.BigEndian.AppendUint16(b, f.Length)
+		b = binary.BigEndian.AppendUint32(b, f.Enterprise)
+	}
+	return b
+}
+
+func (t template) appendDataRecord(b []byte, r *flowRecord) []byte {
+	for _, f := range t.Fields {
+		b = f.encode(b, r)
+	}
+	return b
+}
+
+// appendString encodes a variable length string, truncated so that its
+// length always fits the single byte form.
+func appendString(b []byte, s string) []byte {
+	if len(s) > maxShortStringSize {
+		s = s[:maxShortStringSize]
+	}
+	b = append(b, byte(len(s)))
+	return append(b, s...)
+}
+
+// message accumulates the sets of an IPFIX message.
+type message struct {
+	buf      []byte
+	setStart int
+	setID    uint16
+	records  uint32
+}
+
+func newMessage(size int) *message {
+	return &message{
+		buf:      make([]byte, messageHeaderLen, size),
+		setStart: -1,
+	}
+}
+
+func (m *message) empty() bool {
+	return len(m.buf) == messageHeaderLen
+}
+
+// fits reports whether n more bytes in a set with the given ID fit within
+// the size limit.
+func (m *message) fits(setID uint16, n int, limit int) bool {
+	if m.setStart < 0 || m.setID != setID {
+		n += setHeaderLen
+	}
+	return len(m.buf)+n <= limit
+}
+
+// append adds a record to the open set, starting a new set when the open
+// set has a different ID.
+func (m *message) append(setID uint16, record []byte) {
+	if m.setStart < 0 || m.setID != setID {
+		m.closeSet()
+		m.setStart = len(m.buf)
+		m.setID = setID
+		m.buf = append(m.buf, 0, 0, 0, 0)
+	}
+	m.buf = append(m.buf, record...)
+	if setID != templateSetID {
+		m.records++
+	}
+}
+
+func (m *message) closeSet() {
+	if m.setStart < 0 {
+		return
+	}
+	binary.BigEndian.PutUint16(m.buf[m.setStart:], m.setID)
+	binary.BigEndian.PutUint16(m.buf[m.setStart+2:], uint16(len(m.buf)-m.setStart))
+	m.setStart = -1
+}
+
+// finish completes the message header. The sequence number is the number
+// of data records sent in the observation domain before this message.
+func (m *message) finish(exportTime time.Time, sequence uint32, domain uint32) []byte {
+	m.closeSet()
+	binary.BigEndian.PutUint16(m.buf[0:], version)
+	binary.BigEndian.PutUint16(m.buf[2:], uint16(len(m.buf)))
+	binary.BigEndian.PutUint32(m.buf[4:], uint32(exportTime.Unix()))
+	binary.BigEndian.PutUint32(m.buf[8:], sequence)
+	binary.BigEndian.PutUint32(m.buf[12:], domain)
+	return m.buf
+}
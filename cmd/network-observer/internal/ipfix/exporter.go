@@ -0,0 +1,262 @@
+// Package ipfix exports terminated connections collected by the network
+// observer as IPFIX (RFC 7011) flow records over UDP.
+//
+// Each connection is exported once it has terminated, with its source host
+// and port, the connector target host and port, the octets sent in each
+// direction and enterprise-specific information elements for the sites,
+// processes and routing key. The router does not report packet counts, so
+// none are exported.
+package ipfix
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/netip"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+const (
+	// DefaultEnterpriseNumber is the private enterprise number used for
+	// the skupper specific information elements.
+	DefaultEnterpriseNumber = 2312
+
+	defaultExportInterval  = 10 * time.Second
+	defaultTemplateRefresh = 10 * time.Minute
+	defaultMaxMessageSize  = 1472
+	recordQueueSize        = 4096
+)
+
+type Config struct {
+	// Address of the IPFIX collector, as host:port.
+	Address string
+	// ExportInterval is how often queued records are sent.
+	ExportInterval time.Duration
+	// TemplateRefreshInterval is how often templates are resent so that
+	// collectors that restart, or missed them, can decode records.
+	TemplateRefreshInterval time.Duration
+	// ObservationDomainID identifies this exporter to the collector.
+	ObservationDomainID uint32
+	// EnterpriseNumber is the private enterprise number of the site,
+	// process and routing key information elements.
+	EnterpriseNumber uint32
+	// MaxMessageSize limits the size of each UDP datagram.
+	MaxMessageSize int
+}
+
+// Exporter sends terminated connections to an IPFIX collector.
+type Exporter struct {
+	logger          *slog.Logger
+	address         string
+	exportInterval  time.Duration
+	templateRefresh time.Duration
+	domain          uint32
+	maxMessageSize  int
+	templates       map[uint16]template
+
+	records      chan flowRecord
+	dropped      atomic.Int64
+	conn         net.Conn
+	sequence     uint32
+	lastTemplate time.Time
+}
+
+func New(logger *slog.Logger, cfg Config) *Exporter {
+	e := &Exporter{
+		logger:          logger,
+		address:         cfg.Address,
+		exportInterval:  cfg.ExportInterval,
+		templateRefresh: cfg.TemplateRefreshInterval,
+		domain:          cfg.ObservationDomainID,
+		maxMessageSize:  cfg.MaxMessageSize,
+		records:         make(chan flowRecord, recordQueueSize),
+	}
+	if e.exportInterval <= 0 {
+		e.exportInterval = defaultExportInterval
+	}
+	if e.templateRefresh <= 0 {
+		e.templateRefresh = defaultTemplateRefresh
+	}
+	if e.maxMessageSize <= 0 {
+		e.maxMessageSize = defaultMaxMessageSize
+	}
+	enterprise := cfg.EnterpriseNumber
+	if enterprise == 0 {
+		enterprise = DefaultEnterpriseNumber
+	}
+	e.templates = templates(enterprise)
+	return e
+}
+
+// HandleFlow queues a terminated connection for export. Requests are
+// ignored. It does not block: records are dropped when the queue is full.
+func (e *Exporter) HandleFlow(flow collector.TerminatedFlow) {
+	connection, ok := flow.Record.(collector.ConnectionRecord)
+	if !ok {
+		return
+	}
+	transport, ok := flow.Flow.(vanflow.TransportBiflowRecord)
+	if !ok {
+		return
+	}
+	select {
+	case e.records <- toFlowRecord(connection, transport):
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Run sends queued records every export interval until the context is
+// cancelled, then sends anything remaining before returning.
+func (e *Exporter) Run(ctx context.Context) error {
+	e.logger.Info("Starting IPFIX exporter", slog.String("address", e.address))
+	defer func() {
+		if e.conn != nil {
+			e.conn.Close()
+		}
+	}()
+	ticker := time.NewTicker(e.exportInterval)
+	defer ticker.Stop()
+	var pending []flowRecord
+	for {
+		select {
+		case <-ctx.Done():
+			for drained := false; !drained; {
+				select {
+				case record := <-e.records:
+					pending = append(pending, record)
+				default:
+					drained = true
+				}
+			}
+			e.flush(pending)
+			e.logger.Info("IPFIX exporter shutdown complete")
+			return nil
+		case record := <-e.records:
+			pending = append(pending, record)
+		case <-ticker.C:
+			e.flush(pending)
+			pending = pending[:0]
+		}
+	}
+}
+
+func (e *Exporter) flush(records []flowRecord) {
+	if dropped := e.dropped.Swap(0); dropped > 0 {
+		e.logger.Warn("IPFIX record queue full: records dropped", slog.Int64("count", dropped))
+	}
+	if len(records) == 0 {
+		return
+	}
+	if e.conn == nil {
+		conn, err := net.Dial("udp", e.address)
+		if err != nil {
+			e.logger.Error("failed to connect to IPFIX collector", slog.String("address", e.address), slog.Any("error", err))
+			return
+		}
+		e.conn = conn
+	}
+	for _, msg := range e.encode(records, time.Now()) {
+		if _, err := e.conn.Write(msg); err != nil {
+			e.logger.Error("failed to send IPFIX message", slog.Any("error", err))
+		}
+	}
+}
+
+// encode packs records into messages no larger than the maximum message
+// size, preceded by the templates when they are due to be refreshed.
+func (e *Exporter) encode(records []flowRecord, now time.Time) [][]byte {
+	var (
+		out     [][]byte
+		scratch []byte
+	)
+	msg := newMessage(e.maxMessageSize)
+	finish := func() {
+		out = append(out, msg.finish(now, e.sequence, e.domain))
+		e.sequence += msg.records
+		msg = newMessage(e.maxMessageSize)
+	}
+	if e.lastTemplate.IsZero() || now.Sub(e.lastTemplate) >= e.templateRefresh {
+		for _, id := range []uint16{templateIDv4, templateIDv6} {
+			msg.append(templateSetID, e.templates[id].appendTemplateRecord(scratch[:0]))
+		}
+		e.lastTemplate = now
+	}
+	for i := range records {
+		record := &records[i]
+		tmpl := e.templates[templateIDv4]
+		if record.SourceAddr.Is6() {
+			tmpl = e.templates[templateIDv6]
+		}
+		scratch = tmpl.appendDataRecord(scratch[:0], record)
+		if !msg.empty() && !msg.fits(tmpl.ID, len(scratch), e.maxMessageSize) {
+			finish()
+		}
+		msg.append(tmpl.ID, scratch)
+	}
+	if !msg.empty() {
+		finish()
+	}
+	return out
+}
+
+func toFlowRecord(connection collector.ConnectionRecord, flow vanflow.TransportBiflowRecord) flowRecord {
+	record := flowRecord{
+		SourcePort:    parsePort(flow.SourcePort),
+		DestPort:      parsePort(&connection.ConnectorPort),
+		Octets:        dref(flow.Octets),
+		OctetsReverse: dref(flow.OctetsReverse),
+		Start:         dref(flow.StartTime).Time,
+		End:           dref(flow.EndTime).Time,
+		SourceSite:    connection.SourceSite.Name,
+		DestSite:      connection.DestSite.Name,
+		RoutingKey:    connection.RoutingKey,
+		SourceProcess: connection.Source.Name,
+		DestProcess:   connection.Dest.Name,
+		ConnectorHost: connection.ConnectorHost,
+	}
+	// the template is chosen by the source address family. A destination
+	// that is not an address of the same family, such as a host name, is
+	// only reported by the connector host element.
+	record.SourceAddr = parseAddr(dref(flow.SourceHost))
+	if !record.SourceAddr.IsValid() {
+		record.SourceAddr = netip.IPv4Unspecified()
+	}
+	record.DestAddr = parseAddr(connection.ConnectorHost)
+	if !record.DestAddr.IsValid() || record.DestAddr.Is6() != record.SourceAddr.Is6() {
+		record.DestAddr = netip.IPv4Unspecified()
+		if record.SourceAddr.Is6() {
+			record.DestAddr = netip.IPv6Unspecified()
+		}
+	}
+	return record
+}
+
+func parseAddr(host string) netip.Addr {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr.Unmap()
+}
+
+func parsePort(port *string) uint16 {
+	p, err := strconv.ParseUint(dref(port), 10, 16)
+	if err != nil {
+		return 0
+	}
+	return uint16(p)
+}
+
+func dref[T any](p *T) T {
+	var t T
+	if p != nil {
+		return *p
+	}
+	return t
+}
@@ -0,0 +1,224 @@
+package ipfix
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"gotest.tools/v3/assert"
+)
+
+// decoder is a minimal IPFIX collector used to verify the encoding.
+type decoder struct {
+	templates map[uint16][]fieldSpec
+}
+
+type decodedMessage struct {
+	Sequence  uint32
+	Domain    uint32
+	Templates []uint16
+	Records   []map[fieldSpec][]byte
+}
+
+func (d *decoder) decode(t *testing.T, b []byte) decodedMessage {
+	t.Helper()
+	assert.Assert(t, len(b) >= messageHeaderLen)
+	assert.Equal(t, binary.BigEndian.Uint16(b[0:]), uint16(version))
+	assert.Equal(t, int(binary.BigEndian.Uint16(b[2:])), len(b))
+	msg := decodedMessage{
+		Sequence: binary.BigEndian.Uint32(b[8:]),
+		Domain:   binary.BigEndian.Uint32(b[12:]),
+	}
+	for rest := b[messageHeaderLen:]; len(rest) > 0; {
+		setID := binary.BigEndian.Uint16(rest[0:])
+		setLen := int(binary.BigEndian.Uint16(rest[2:]))
+		set := rest[setHeaderLen:setLen]
+		rest = rest[setLen:]
+		if setID == templateSetID {
+			for len(set) > 0 {
+				id := binary.BigEndian.Uint16(set[0:])
+				count := int(binary.BigEndian.Uint16(set[2:]))
+				set = set[4:]
+				var fields []fieldSpec
+				for i := 0; i < count; i++ {
+					spec := fieldSpec{ID: binary.BigEndian.Uint16(set[0:]), Length: binary.BigEndian.Uint16(set[2:])}
+					set = set[4:]
+					if spec.ID&enterpriseBit != 0 {
+						spec.ID &^= enterpriseBit
+						spec.Enterprise = binary.BigEndian.Uint32(set[0:])
+						set = set[4:]
+					}
+					fields = append(fields, spec)
+				}
+				d.templates[id] = fields
+				msg.Templates = append(msg.Templates, id)
+			}
+			continue
+		}
+		fields, ok := d.templates[setID]
+		assert.Assert(t, ok, "data set for unknown template %d", setID)
+		for len(set) > 0 {
+			record := make(map[fieldSpec][]byte)
+			for _, spec := range fields {
+				n := int(spec.Length)
+				if spec.Length == variableLength {
+					n = int(set[0])
+					set = set[1:]
+				}
+				key := spec
+				key.Length = 0
+				record[key] = set[:n]
+				set = set[n:]
+			}
+			msg.Records = append(msg.Records, record)
+		}
+	}
+	return msg
+}
+
+func str(record map[fieldSpec][]byte, id uint16) string {
+	return string(record[fieldSpec{ID: id, Enterprise: DefaultEnterpriseNumber}])
+}
+
+func uint64Field(record map[fieldSpec][]byte, id uint16, enterprise uint32) uint64 {
+	return binary.BigEndian.Uint64(record[fieldSpec{ID: id, Enterprise: enterprise}])
+}
+
+func uint16Field(record map[fieldSpec][]byte, id uint16) uint16 {
+	return binary.BigEndian.Uint16(record[fieldSpec{ID: id}])
+}
+
+func addrField(record map[fieldSpec][]byte, id uint16) netip.Addr {
+	addr, _ := netip.AddrFromSlice(record[fieldSpec{ID: id}])
+	return addr
+}
+
+func terminatedConnection(id, sourceHost, connectorHost string, start time.Time) collector.TerminatedFlow {
+	return collector.TerminatedFlow{
+		Record: collector.ConnectionRecord{
+			ID:            id,
+			RoutingKey:    "backend",
+			ConnectorHost: connectorHost,
+			ConnectorPort: "8080",
+			Source:        collector.NamedReference{Name: "frontend"},
+			SourceSite:    collector.NamedReference{Name: "west"},
+			Dest:          collector.NamedReference{Name: "backend-0"},
+			DestSite:      collector.NamedReference{Name: "east"},
+		},
+		Flow: vanflow.TransportBiflowRecord{
+			BaseRecord:    vanflow.NewBase(id, start, start.Add(time.Second)),
+			SourceHost:    ptrTo(sourceHost),
+			SourcePort:    ptrTo("41000"),
+			Octets:        ptrTo(uint64(100)),
+			OctetsReverse: ptrTo(uint64(2000)),
+		},
+	}
+}
+
+func TestExporter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	exporter := New(slog.New(slog.NewTextHandler(io.Discard, nil)), Config{
+		Address:             conn.LocalAddr().String(),
+		ExportInterval:      20 * time.Millisecond,
+		ObservationDomainID: 7,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- exporter.Run(ctx)
+	}()
+
+	start := time.UnixMilli(1700000000123)
+	exporter.HandleFlow(terminatedConnection("conn-1", "10.0.0.1", "10.0.0.2", start))
+	exporter.HandleFlow(terminatedConnection("conn-2", "fd00::1", "backend.svc", start))
+	// requests are not exported
+	exporter.HandleFlow(collector.TerminatedFlow{Record: collector.RequestRecord{ID: "req-1"}, Flow: vanflow.AppBiflowRecord{}})
+
+	d := &decoder{templates: make(map[uint16][]fieldSpec)}
+	var records []map[fieldSpec][]byte
+	buf := make([]byte, 65535)
+	for len(records) < 2 {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		assert.NilError(t, err)
+		msg := d.decode(t, buf[:n])
+		assert.Equal(t, msg.Domain, uint32(7))
+		if len(records) == 0 {
+			assert.DeepEqual(t, msg.Templates, []uint16{templateIDv4, templateIDv6})
+			assert.Equal(t, msg.Sequence, uint32(0))
+		}
+		records = append(records, msg.Records...)
+	}
+	cancel()
+	assert.NilError(t, <-done)
+
+	v4 := records[0]
+	assert.Equal(t, addrField(v4, ieSourceIPv4Address), netip.MustParseAddr("10.0.0.1"))
+	assert.Equal(t, uint16Field(v4, ieSourceTransportPort), uint16(41000))
+	assert.Equal(t, addrField(v4, ieDestinationIPv4Address), netip.MustParseAddr("10.0.0.2"))
+	assert.Equal(t, uint16Field(v4, ieDestinationTransportPort), uint16(8080))
+	assert.DeepEqual(t, v4[fieldSpec{ID: ieProtocolIdentifier}], []byte{protocolTCP})
+	assert.Equal(t, uint64Field(v4, ieOctetDeltaCount, 0), uint64(100))
+	assert.Equal(t, uint64Field(v4, ieOctetDeltaCount, reversePEN), uint64(2000))
+	assert.Equal(t, uint64Field(v4, ieFlowStartMilliseconds, 0), uint64(start.UnixMilli()))
+	assert.Equal(t, uint64Field(v4, ieFlowEndMilliseconds, 0), uint64(start.Add(time.Second).UnixMilli()))
+	assert.Equal(t, str(v4, ieSourceSiteName), "west")
+	assert.Equal(t, str(v4, ieDestSiteName), "east")
+	assert.Equal(t, str(v4, ieRoutingKey), "backend")
+	assert.Equal(t, str(v4, ieSourceProcessName), "frontend")
+	assert.Equal(t, str(v4, ieDestProcessName), "backend-0")
+	assert.Equal(t, str(v4, ieConnectorHost), "10.0.0.2")
+
+	v6 := records[1]
+	assert.Equal(t, addrField(v6, ieSourceIPv6Address), netip.MustParseAddr("fd00::1"))
+	assert.Equal(t, addrField(v6, ieDestinationIPv6Address), netip.IPv6Unspecified())
+	assert.Equal(t, str(v6, ieConnectorHost), "backend.svc")
+}
+
+func TestEncodeMessageSize(t *testing.T) {
+	exporter := New(slog.Default(), Config{MaxMessageSize: 512, TemplateRefreshInterval: time.Minute})
+	start := time.Now()
+	var records []flowRecord
+	for i := 0; i < 20; i++ {
+		flow := terminatedConnection(fmt.Sprintf("conn-%d", i), "10.0.0.1", "10.0.0.2", start)
+		record := toFlowRecord(flow.Record.(collector.ConnectionRecord), flow.Flow.(vanflow.TransportBiflowRecord))
+		record.RoutingKey = strings.Repeat("k", 300)
+		records = append(records, record)
+	}
+
+	d := &decoder{templates: make(map[uint16][]fieldSpec)}
+	messages := exporter.encode(records, start)
+	assert.Assert(t, len(messages) > 1)
+	var count uint32
+	for i, msg := range messages {
+		assert.Assert(t, len(msg) <= 512, "message %d exceeds the maximum size: %d", i, len(msg))
+		decoded := d.decode(t, msg)
+		assert.Equal(t, decoded.Sequence, count)
+		assert.Equal(t, len(decoded.Templates) > 0, i == 0, "templates should only be sent in the first message")
+		for _, record := range decoded.Records {
+			assert.Equal(t, len(str(record, ieRoutingKey)), maxShortStringSize)
+		}
+		count += uint32(len(decoded.Records))
+	}
+	assert.Equal(t, count, uint32(20))
+
+	// templates are resent once the refresh interval has passed
+	assert.Assert(t, len(d.decode(t, exporter.encode(records[:1], start.Add(30*time.Second))[0]).Templates) == 0)
+	assert.Assert(t, len(d.decode(t, exporter.encode(records[:1], start.Add(time.Minute))[0]).Templates) == 2)
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
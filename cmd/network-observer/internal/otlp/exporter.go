@@ -0,0 +1,266 @@
+// Package otlp exports connections, requests and service metrics collected
+// by the network observer to an OpenTelemetry Protocol (OTLP) receiver over
+// HTTP.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/internal/version"
+	collectormetricspb "go.opentelemetry.io/proto/slim/otlp/collector/metrics/v1"
+	collectortracepb "go.opentelemetry.io/proto/slim/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/slim/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/slim/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/slim/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/slim/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	tracesPath  = "v1/traces"
+	metricsPath = "v1/metrics"
+
+	scopeName = "github.com/skupperproject/skupper/cmd/network-observer"
+
+	defaultServiceName     = "skupper-network-observer"
+	defaultMetricsInterval = time.Minute
+	defaultBatchTimeout    = 5 * time.Second
+	defaultMaxBatchSize    = 512
+	spanQueueSize          = 4096
+	exportTimeout          = 10 * time.Second
+)
+
+type Config struct {
+	// Endpoint is the base URL of the OTLP/HTTP receiver, e.g.
+	// http://otel-collector:4318. Traces and metrics are sent to the
+	// v1/traces and v1/metrics paths relative to it.
+	Endpoint string
+	// Headers are added to each export request, e.g. for authentication.
+	Headers map[string]string
+	// TLSConfig is used for https endpoints.
+	TLSConfig *tls.Config
+	// ServiceName is the service.name resource attribute.
+	ServiceName string
+	// MetricsInterval is how often metrics are exported.
+	MetricsInterval time.Duration
+	// BatchTimeout is the longest spans are held before being exported.
+	BatchTimeout time.Duration
+	// MaxBatchSize is the number of spans that triggers an export.
+	MaxBatchSize int
+}
+
+// Exporter sends terminated flows as spans and the collector's per-service
+// metrics to an OTLP receiver.
+type Exporter struct {
+	logger   *slog.Logger
+	client   *http.Client
+	gatherer prometheus.Gatherer
+
+	headers         map[string]string
+	tracesURL       string
+	metricsURL      string
+	metricsInterval time.Duration
+	batchTimeout    time.Duration
+	maxBatchSize    int
+
+	resource  *resourcepb.Resource
+	startTime time.Time
+
+	spans   chan *tracepb.Span
+	dropped atomic.Int64
+}
+
+func New(logger *slog.Logger, cfg Config, gatherer prometheus.Gatherer) (*Exporter, error) {
+	base, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid otlp endpoint: %s", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid otlp endpoint %q: scheme must be http or https", cfg.Endpoint)
+	}
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	e := &Exporter{
+		logger:          logger,
+		gatherer:        gatherer,
+		headers:         cfg.Headers,
+		tracesURL:       base.JoinPath(tracesPath).String(),
+		metricsURL:      base.JoinPath(metricsPath).String(),
+		metricsInterval: cfg.MetricsInterval,
+		batchTimeout:    cfg.BatchTimeout,
+		maxBatchSize:    cfg.MaxBatchSize,
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				stringAttr("service.name", serviceName),
+				stringAttr("service.version", version.Version),
+			},
+		},
+		startTime: time.Now(),
+		spans:     make(chan *tracepb.Span, spanQueueSize),
+	}
+	if e.metricsInterval <= 0 {
+		e.metricsInterval = defaultMetricsInterval
+	}
+	if e.batchTimeout <= 0 {
+		e.batchTimeout = defaultBatchTimeout
+	}
+	if e.maxBatchSize <= 0 {
+		e.maxBatchSize = defaultMaxBatchSize
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg.TLSConfig
+	e.client = &http.Client{
+		Transport: transport,
+		Timeout:   exportTimeout,
+	}
+	return e, nil
+}
+
+// HandleFlow queues a span for a terminated connection or request. It
+// does not block: spans are dropped when the queue is full.
+func (e *Exporter) HandleFlow(flow collector.TerminatedFlow) {
+	span, ok := flowSpan(flow)
+	if !ok {
+		return
+	}
+	select {
+	case e.spans <- span:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Run exports queued spans and metrics until the context is cancelled,
+// then exports anything remaining before returning.
+func (e *Exporter) Run(ctx context.Context) error {
+	e.logger.Info("Starting OTLP exporter",
+		slog.String("traces", e.tracesURL),
+		slog.String("metrics", e.metricsURL))
+	batchTicker := time.NewTicker(e.batchTimeout)
+	defer batchTicker.Stop()
+	metricsTicker := time.NewTicker(e.metricsInterval)
+	defer metricsTicker.Stop()
+
+	batch := make([]*tracepb.Span, 0, e.maxBatchSize)
+	flushSpans := func(ctx context.Context) {
+		if dropped := e.dropped.Swap(0); dropped > 0 {
+			e.logger.Warn("OTLP span queue full: spans dropped", slog.Int64("count", dropped))
+		}
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.exportSpans(ctx, batch); err != nil {
+			e.logger.Error("failed to export spans", slog.Int("count", len(batch)), slog.Any("error", err))
+		}
+		batch = make([]*tracepb.Span, 0, e.maxBatchSize)
+	}
+	exportMetrics := func(ctx context.Context) {
+		if err := e.exportMetrics(ctx); err != nil {
+			e.logger.Error("failed to export metrics", slog.Any("error", err))
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+			defer cancel()
+			for pending := true; pending; {
+				select {
+				case span := <-e.spans:
+					batch = append(batch, span)
+				default:
+					pending = false
+				}
+			}
+			flushSpans(shutdownCtx)
+			exportMetrics(shutdownCtx)
+			e.logger.Info("OTLP exporter shutdown complete")
+			return nil
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) >= e.maxBatchSize {
+				flushSpans(ctx)
+			}
+		case <-batchTicker.C:
+			flushSpans(ctx)
+		case <-metricsTicker.C:
+			exportMetrics(ctx)
+		}
+	}
+}
+
+func (e *Exporter) exportSpans(ctx context.Context, spans []*tracepb.Span) error {
+	return e.export(ctx, e.tracesURL, &collectortracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			Resource: e.resource,
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Scope: &commonpb.InstrumentationScope{
+					Name:    scopeName,
+					Version: version.Version,
+				},
+				Spans: spans,
+			}},
+		}},
+	})
+}
+
+func (e *Exporter) exportMetrics(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("error gathering metrics: %s", err)
+	}
+	metrics := serviceMetrics(families, e.startTime, time.Now())
+	if len(metrics) == 0 {
+		return nil
+	}
+	return e.export(ctx, e.metricsURL, &collectormetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: e.resource,
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope: &commonpb.InstrumentationScope{
+					Name:    scopeName,
+					Version: version.Version,
+				},
+				Metrics: metrics,
+			}},
+		}},
+	})
+}
+
+func (e *Exporter) export(ctx context.Context, target string, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error encoding request: %s", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response from %s: %s", target, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package otlp
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	collectormetricspb "go.opentelemetry.io/proto/slim/otlp/collector/metrics/v1"
+	collectortracepb "go.opentelemetry.io/proto/slim/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/slim/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/slim/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/slim/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+)
+
+// receiver is an in-process OTLP/HTTP receiver.
+type receiver struct {
+	spans   chan *tracepb.Span
+	metrics chan *metricspb.Metric
+	headers chan http.Header
+}
+
+func newReceiver(t *testing.T) (*receiver, *httptest.Server) {
+	t.Helper()
+	r := &receiver{
+		spans:   make(chan *tracepb.Span, 32),
+		metrics: make(chan *metricspb.Metric, 32),
+		headers: make(chan http.Header, 32),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/traces", func(w http.ResponseWriter, req *http.Request) {
+		var msg collectortracepb.ExportTraceServiceRequest
+		if !decode(t, w, req, &msg) {
+			return
+		}
+		r.headers <- req.Header
+		for _, rs := range msg.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				for _, span := range ss.GetSpans() {
+					r.spans <- span
+				}
+			}
+		}
+	})
+	mux.HandleFunc("POST /v1/metrics", func(w http.ResponseWriter, req *http.Request) {
+		var msg collectormetricspb.ExportMetricsServiceRequest
+		if !decode(t, w, req, &msg) {
+			return
+		}
+		for _, rm := range msg.GetResourceMetrics() {
+			for _, sm := range rm.GetScopeMetrics() {
+				for _, metric := range sm.GetMetrics() {
+					r.metrics <- metric
+				}
+			}
+		}
+	})
+	return r, httptest.NewServer(mux)
+}
+
+func decode(t *testing.T, w http.ResponseWriter, req *http.Request, msg proto.Message) bool {
+	if ct := req.Header.Get("Content-Type"); ct != "application/x-protobuf" {
+		t.Errorf("unexpected content type %q", ct)
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return false
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Errorf("error reading request: %s", err)
+		return false
+	}
+	if err := proto.Unmarshal(body, msg); err != nil {
+		t.Errorf("error decoding request: %s", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func receive[T any](t *testing.T, c <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-c:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for export")
+		var v T
+		return v
+	}
+}
+
+func attrs(kvs []*commonpb.KeyValue) map[string]any {
+	out := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		switch v := kv.GetValue().GetValue().(type) {
+		case *commonpb.AnyValue_StringValue:
+			out[kv.GetKey()] = v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			out[kv.GetKey()] = v.IntValue
+		}
+	}
+	return out
+}
+
+func TestExporter(t *testing.T) {
+	recv, srv := newReceiver(t)
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+	opened := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "skupper",
+		Name:      "connections_opened_total",
+		Help:      "Number of connections opened",
+	}, []string{"routing_key", "source_site_name"})
+	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "skupper",
+		Name:      "request_seconds",
+		Buckets:   []float64{0.1, 1},
+	}, []string{"routing_key"})
+	internal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "skupper",
+		Subsystem: "internal",
+		Name:      "jobs_total",
+	}, []string{"routing_key"})
+	reg.MustRegister(opened, latency, internal, prometheus.NewCounter(prometheus.CounterOpts{Name: "skupper_sites_total"}))
+	opened.WithLabelValues("backend", "west").Add(3)
+	internal.WithLabelValues("backend").Inc()
+	latency.WithLabelValues("backend").Observe(0.05)
+	latency.WithLabelValues("backend").Observe(0.5)
+	latency.WithLabelValues("backend").Observe(5)
+
+	exporter, err := New(slog.New(slog.NewTextHandler(io.Discard, nil)), Config{
+		Endpoint:        srv.URL,
+		Headers:         map[string]string{"Authorization": "Bearer token"},
+		MetricsInterval: 50 * time.Millisecond,
+		BatchTimeout:    50 * time.Millisecond,
+	}, reg)
+	assert.NilError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- exporter.Run(ctx)
+	}()
+
+	start := time.Unix(1700000000, 0)
+	connection := collector.ConnectionRecord{
+		ID:            "conn-1",
+		RoutingKey:    "backend",
+		Protocol:      "tcp",
+		ConnectorHost: "10.0.0.2",
+		ConnectorPort: "8080",
+		Source:        collector.NamedReference{ID: "p-1", Name: "frontend"},
+		SourceSite:    collector.NamedReference{ID: "s-1", Name: "west"},
+		Dest:          collector.NamedReference{ID: "p-2", Name: "backend-0"},
+		DestSite:      collector.NamedReference{ID: "s-2", Name: "east"},
+	}
+	exporter.HandleFlow(collector.TerminatedFlow{
+		Record: connection,
+		Flow: vanflow.TransportBiflowRecord{
+			BaseRecord:    vanflow.NewBase("conn-1", start, start.Add(time.Second)),
+			SourceHost:    ptrTo("10.0.0.1"),
+			Octets:        ptrTo(uint64(100)),
+			OctetsReverse: ptrTo(uint64(2000)),
+		},
+	})
+	exporter.HandleFlow(collector.TerminatedFlow{
+		Record: collector.RequestRecord{
+			ID:          "req-1",
+			TransportID: "conn-1",
+			RoutingKey:  "backend",
+			Protocol:    "http1",
+		},
+		Flow: vanflow.AppBiflowRecord{
+			BaseRecord: vanflow.NewBase("req-1", start, start.Add(time.Millisecond)),
+			Method:     ptrTo("GET"),
+			Result:     ptrTo("503"),
+		},
+	})
+	// records of other types are ignored
+	exporter.HandleFlow(collector.TerminatedFlow{Record: vanflow.SiteRecord{}})
+
+	connSpan := receive(t, recv.spans)
+	reqSpan := receive(t, recv.spans)
+	assert.Equal(t, receive(t, recv.headers).Get("Authorization"), "Bearer token")
+
+	assert.Equal(t, connSpan.GetName(), "connection backend")
+	assert.Equal(t, connSpan.GetStartTimeUnixNano(), uint64(start.UnixNano()))
+	assert.Equal(t, connSpan.GetEndTimeUnixNano(), uint64(start.Add(time.Second).UnixNano()))
+	assert.DeepEqual(t, attrs(connSpan.GetAttributes()), map[string]any{
+		"skupper.routing_key":         "backend",
+		"skupper.protocol":            "tcp",
+		"skupper.source.process.id":   "p-1",
+		"skupper.source.process.name": "frontend",
+		"skupper.source.site.id":      "s-1",
+		"skupper.source.site.name":    "west",
+		"skupper.dest.process.id":     "p-2",
+		"skupper.dest.process.name":   "backend-0",
+		"skupper.dest.site.id":        "s-2",
+		"skupper.dest.site.name":      "east",
+		"client.address":              "10.0.0.1",
+		"server.address":              "10.0.0.2",
+		"server.port":                 "8080",
+		"skupper.bytes_sent":          int64(100),
+		"skupper.bytes_received":      int64(2000),
+	})
+	assert.Assert(t, connSpan.GetStatus() == nil)
+
+	assert.Equal(t, reqSpan.GetName(), "GET backend")
+	assert.DeepEqual(t, reqSpan.GetTraceId(), connSpan.GetTraceId())
+	assert.DeepEqual(t, reqSpan.GetParentSpanId(), connSpan.GetSpanId())
+	assert.Equal(t, attrs(reqSpan.GetAttributes())["http.response.status_code"], int64(503))
+	assert.Equal(t, reqSpan.GetStatus().GetCode(), tracepb.Status_STATUS_CODE_ERROR)
+
+	metrics := map[string]*metricspb.Metric{}
+	for len(metrics) < 2 {
+		metric := receive(t, recv.metrics)
+		metrics[metric.GetName()] = metric
+	}
+	assert.Equal(t, len(metrics), 2, "only per-service metrics should be exported")
+	sum := metrics["skupper_connections_opened_total"].GetSum()
+	assert.Assert(t, sum.GetIsMonotonic())
+	assert.Equal(t, len(sum.GetDataPoints()), 1)
+	assert.Equal(t, sum.GetDataPoints()[0].GetAsDouble(), 3.0)
+	assert.DeepEqual(t, attrs(sum.GetDataPoints()[0].GetAttributes()), map[string]any{
+		"routing_key":      "backend",
+		"source_site_name": "west",
+	})
+	histogram := metrics["skupper_request_seconds"].GetHistogram().GetDataPoints()[0]
+	assert.Equal(t, histogram.GetCount(), uint64(3))
+	assert.DeepEqual(t, histogram.GetExplicitBounds(), []float64{0.1, 1})
+	assert.DeepEqual(t, histogram.GetBucketCounts(), []uint64{1, 1, 1})
+
+	cancel()
+	assert.NilError(t, receive(t, done))
+}
+
+func TestNewInvalidEndpoint(t *testing.T) {
+	_, err := New(slog.Default(), Config{Endpoint: "otel-collector:4318"}, prometheus.NewRegistry())
+	assert.ErrorContains(t, err, "scheme must be http or https")
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
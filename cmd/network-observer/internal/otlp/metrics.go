@@ -0,0 +1,119 @@
+package otlp
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	commonpb "go.opentelemetry.io/proto/slim/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/slim/otlp/metrics/v1"
+)
+
+const (
+	metricPrefix         = "skupper_"
+	internalMetricPrefix = "skupper_internal_"
+	serviceLabel         = "routing_key"
+)
+
+// serviceMetrics converts the per-service metric families, those labelled
+// by routing key, to cumulative OTLP metrics.
+func serviceMetrics(families []*dto.MetricFamily, start, now time.Time) []*metricspb.Metric {
+	var out []*metricspb.Metric
+	for _, family := range families {
+		name := family.GetName()
+		if !strings.HasPrefix(name, metricPrefix) || strings.HasPrefix(name, internalMetricPrefix) {
+			continue
+		}
+		if !hasServiceLabel(family) {
+			continue
+		}
+		metric := &metricspb.Metric{
+			Name:        name,
+			Description: family.GetHelp(),
+		}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			sum := &metricspb.Sum{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+			}
+			for _, m := range family.GetMetric() {
+				sum.DataPoints = append(sum.DataPoints, numberPoint(m, m.GetCounter().GetValue(), start, now))
+			}
+			metric.Data = &metricspb.Metric_Sum{Sum: sum}
+		case dto.MetricType_GAUGE:
+			gauge := &metricspb.Gauge{}
+			for _, m := range family.GetMetric() {
+				gauge.DataPoints = append(gauge.DataPoints, numberPoint(m, m.GetGauge().GetValue(), start, now))
+			}
+			metric.Data = &metricspb.Metric_Gauge{Gauge: gauge}
+		case dto.MetricType_HISTOGRAM:
+			histogram := &metricspb.Histogram{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			}
+			for _, m := range family.GetMetric() {
+				histogram.DataPoints = append(histogram.DataPoints, histogramPoint(m, start, now))
+			}
+			metric.Data = &metricspb.Metric_Histogram{Histogram: histogram}
+		default:
+			continue
+		}
+		out = append(out, metric)
+	}
+	return out
+}
+
+func hasServiceLabel(family *dto.MetricFamily) bool {
+	if len(family.GetMetric()) == 0 {
+		return false
+	}
+	for _, label := range family.GetMetric()[0].GetLabel() {
+		if label.GetName() == serviceLabel {
+			return true
+		}
+	}
+	return false
+}
+
+func numberPoint(m *dto.Metric, value float64, start, now time.Time) *metricspb.NumberDataPoint {
+	return &metricspb.NumberDataPoint{
+		Attributes:        labelAttrs(m),
+		StartTimeUnixNano: uint64(start.UnixNano()),
+		TimeUnixNano:      uint64(now.UnixNano()),
+		Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+	}
+}
+
+// histogramPoint converts the cumulative bucket counts of a prometheus
+// histogram to the per bucket counts used by OTLP.
+func histogramPoint(m *dto.Metric, start, now time.Time) *metricspb.HistogramDataPoint {
+	h := m.GetHistogram()
+	sum := h.GetSampleSum()
+	point := &metricspb.HistogramDataPoint{
+		Attributes:        labelAttrs(m),
+		StartTimeUnixNano: uint64(start.UnixNano()),
+		TimeUnixNano:      uint64(now.UnixNano()),
+		Count:             h.GetSampleCount(),
+		Sum:               &sum,
+	}
+	var prev uint64
+	for _, bucket := range h.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-prev)
+		prev = bucket.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, h.GetSampleCount()-prev)
+	return point
+}
+
+func labelAttrs(m *dto.Metric) []*commonpb.KeyValue {
+	attrs := make([]*commonpb.KeyValue, 0, len(m.GetLabel()))
+	for _, label := range m.GetLabel() {
+		attrs = append(attrs, stringAttr(label.GetName(), label.GetValue()))
+	}
+	return attrs
+}
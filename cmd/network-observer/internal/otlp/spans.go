@@ -0,0 +1,166 @@
+package otlp
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"strings"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	commonpb "go.opentelemetry.io/proto/slim/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/slim/otlp/trace/v1"
+)
+
+// flowSpan converts a terminated connection or request to a span. Trace
+// and span IDs are derived from the flow IDs so that requests are children
+// of the span of the connection that carried them.
+func flowSpan(flow collector.TerminatedFlow) (*tracepb.Span, bool) {
+	switch record := flow.Record.(type) {
+	case collector.ConnectionRecord:
+		transport, ok := flow.Flow.(vanflow.TransportBiflowRecord)
+		if !ok {
+			return nil, false
+		}
+		return connectionSpan(record, transport), true
+	case collector.RequestRecord:
+		app, ok := flow.Flow.(vanflow.AppBiflowRecord)
+		if !ok {
+			return nil, false
+		}
+		return requestSpan(record, app), true
+	default:
+		return nil, false
+	}
+}
+
+func connectionSpan(record collector.ConnectionRecord, flow vanflow.TransportBiflowRecord) *tracepb.Span {
+	traceID, spanID := flowIDs(record.ID)
+	attrs := endpointAttrs(record.RoutingKey, record.Protocol,
+		record.Source, record.SourceSite, record.Dest, record.DestSite)
+	attrs = appendString(attrs, "client.address", dref(flow.SourceHost))
+	attrs = appendString(attrs, "client.port", dref(flow.SourcePort))
+	attrs = appendString(attrs, "server.address", record.ConnectorHost)
+	attrs = appendString(attrs, "server.port", record.ConnectorPort)
+	attrs = append(attrs,
+		intAttr("skupper.bytes_sent", int64(dref(flow.Octets))),
+		intAttr("skupper.bytes_received", int64(dref(flow.OctetsReverse))),
+	)
+	attrs = appendString(attrs, "skupper.router_trace", dref(flow.Trace))
+
+	span := &tracepb.Span{
+		TraceId:           traceID,
+		SpanId:            spanID,
+		Name:              "connection " + record.RoutingKey,
+		Kind:              tracepb.Span_SPAN_KIND_INTERNAL,
+		StartTimeUnixNano: unixNano(flow.StartTime),
+		EndTimeUnixNano:   unixNano(flow.EndTime),
+		Attributes:        attrs,
+	}
+	var errs []string
+	for _, err := range []*string{flow.ErrorListener, flow.ErrorConnector} {
+		if msg := dref(err); msg != "" {
+			errs = append(errs, msg)
+		}
+	}
+	if len(errs) > 0 {
+		span.Status = &tracepb.Status{
+			Code:    tracepb.Status_STATUS_CODE_ERROR,
+			Message: strings.Join(errs, "; "),
+		}
+	}
+	return span
+}
+
+func requestSpan(record collector.RequestRecord, flow vanflow.AppBiflowRecord) *tracepb.Span {
+	traceID, parentID := flowIDs(record.TransportID)
+	_, spanID := flowIDs(record.ID)
+	attrs := endpointAttrs(record.RoutingKey, record.Protocol,
+		record.Source, record.SourceSite, record.Dest, record.DestSite)
+	method := dref(flow.Method)
+	attrs = appendString(attrs, "http.request.method", method)
+	status, statusErr := strconv.Atoi(dref(flow.Result))
+	if statusErr == nil {
+		attrs = append(attrs, intAttr("http.response.status_code", int64(status)))
+	}
+	attrs = append(attrs,
+		intAttr("skupper.bytes_sent", int64(dref(flow.Octets))),
+		intAttr("skupper.bytes_received", int64(dref(flow.OctetsReverse))),
+	)
+
+	name := "request " + record.RoutingKey
+	if method != "" {
+		name = method + " " + record.RoutingKey
+	}
+	span := &tracepb.Span{
+		TraceId:           traceID,
+		SpanId:            spanID,
+		ParentSpanId:      parentID,
+		Name:              name,
+		Kind:              tracepb.Span_SPAN_KIND_INTERNAL,
+		StartTimeUnixNano: unixNano(flow.StartTime),
+		EndTimeUnixNano:   unixNano(flow.EndTime),
+		Attributes:        attrs,
+	}
+	if statusErr == nil && status >= 500 {
+		span.Status = &tracepb.Status{Code: tracepb.Status_STATUS_CODE_ERROR}
+	}
+	return span
+}
+
+func endpointAttrs(routingKey, protocol string, source, sourceSite, dest, destSite collector.NamedReference) []*commonpb.KeyValue {
+	var attrs []*commonpb.KeyValue
+	attrs = appendString(attrs, "skupper.routing_key", routingKey)
+	attrs = appendString(attrs, "skupper.protocol", protocol)
+	attrs = appendString(attrs, "skupper.source.process.id", source.ID)
+	attrs = appendString(attrs, "skupper.source.process.name", source.Name)
+	attrs = appendString(attrs, "skupper.source.site.id", sourceSite.ID)
+	attrs = appendString(attrs, "skupper.source.site.name", sourceSite.Name)
+	attrs = appendString(attrs, "skupper.dest.process.id", dest.ID)
+	attrs = appendString(attrs, "skupper.dest.process.name", dest.Name)
+	attrs = appendString(attrs, "skupper.dest.site.id", destSite.ID)
+	attrs = appendString(attrs, "skupper.dest.site.name", destSite.Name)
+	return attrs
+}
+
+// flowIDs derives a trace ID and span ID from a flow ID.
+func flowIDs(id string) (traceID []byte, spanID []byte) {
+	sum := sha256.Sum256([]byte(id))
+	return sum[:16], sum[16:24]
+}
+
+func unixNano(t *vanflow.Time) uint64 {
+	if t == nil || t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func intAttr(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}},
+	}
+}
+
+// appendString appends a string attribute unless its value is empty.
+func appendString(attrs []*commonpb.KeyValue, key, value string) []*commonpb.KeyValue {
+	if value == "" {
+		return attrs
+	}
+	return append(attrs, stringAttr(key, value))
+}
+
+func dref[T any](p *T) T {
+	var t T
+	if p != nil {
+		return *p
+	}
+	return t
+}
@@ -17,7 +17,7 @@ func TestAddresses(t *testing.T) {
 	tlog := slog.Default()
 	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
 	graph := collector.NewGraph(stor)
-	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil, nil, nil))
 	defer srv.Close()
 
 	begin := time.Now()
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+)
+
+// (GET /api/v2alpha1/alerts)
+func (s *server) Alerts(w http.ResponseWriter, r *http.Request) {
+	current := s.alerts.Alerts()
+	results := make([]api.AlertRecord, len(current))
+	for i, alert := range current {
+		results[i] = api.AlertRecord{
+			Identity:  alert.ID,
+			StartTime: uint64(alert.StartsAt.UnixMicro()),
+			Rule:      alert.Rule,
+			Severity:  alert.Severity,
+			State:     api.AlertState(alert.State),
+			Summary:   alert.Summary,
+			Value:     alert.Value,
+			Labels:    alert.Labels,
+		}
+		if !alert.EndsAt.IsZero() {
+			results[i].EndTime = uint64(alert.EndsAt.UnixMicro())
+		}
+	}
+	if err := handleCollection(w, r, &api.AlertListResponse{}, results); err != nil {
+		s.logWriteError(r, err)
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/alerts"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func TestAlerts(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	stor.Replace(wrapRecords(
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site1"), Name: ptrTo("west")},
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site2"), Name: ptrTo("east")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router2"), Parent: ptrTo("site2")},
+	))
+	graph.(reset).Reset()
+
+	t.Run("disabled", func(t *testing.T) {
+		srv, c := requireTestClient(t, New(tlog, stor, graph, nil, nil, nil))
+		defer srv.Close()
+		resp, err := c.AlertsWithResponse(context.TODO())
+		assert.Assert(t, err)
+		assert.Equal(t, resp.StatusCode(), http.StatusOK)
+		assert.Equal(t, resp.JSON200.Count, int64(0))
+	})
+
+	manager, err := alerts.New(tlog, alerts.Config{Rules: []alerts.Rule{
+		{Name: "RouterMissing", Type: alerts.RuleRouterMissing, Severity: "critical"},
+	}}, stor, graph, nil, nil)
+	assert.Assert(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go manager.Run(ctx)
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		if len(manager.Alerts()) == 0 {
+			return poll.Continue("no alerts")
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second))
+
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil, nil, manager))
+	defer srv.Close()
+	resp, err := c.AlertsWithResponse(context.TODO(), withParameters(map[string][]string{"severity": {"critical"}}))
+	assert.Assert(t, err)
+	assert.Equal(t, resp.StatusCode(), http.StatusOK)
+	assert.Equal(t, resp.JSON200.Count, int64(1))
+	alert := resp.JSON200.Results[0]
+	assert.Equal(t, alert.Rule, "RouterMissing")
+	assert.Equal(t, alert.State, api.Firing)
+	assert.Equal(t, alert.Labels["site_id"], "site1")
+	assert.Equal(t, alert.Summary, `site "west" has no running router`)
+	assert.Assert(t, alert.StartTime > 0)
+	assert.Equal(t, alert.EndTime, uint64(0))
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+// TestTopologyRoleProcessDetails checks that no operation permitted to the
+// topology role discloses the identity or address of a process.
+func TestTopologyRoleProcessDetails(t *testing.T) {
+	const (
+		processID   = "process-secret-id"
+		processName = "process-secret-name"
+		processHost = "10.1.2.3"
+	)
+	spec, err := os.ReadFile("../../spec/openapi.yaml")
+	assert.NilError(t, err)
+	operations, err := auth.ParseOperationTags(spec)
+	assert.NilError(t, err)
+
+	tlog := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c, err := collector.New(tlog, session.NewMockContainerFactory(), prometheus.NewRegistry(), time.Minute, nil, collector.StorageConfig{})
+	assert.NilError(t, err)
+	router := mux.NewRouter()
+	api.HandlerWithOptions(New(tlog, c.Records, c.GetGraph(), c, nil, nil), api.GorillaServerOptions{
+		BaseRouter:  router,
+		Middlewares: []api.MiddlewareFunc{operations.Authorize},
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := auth.WithIdentity(r.Context(), auth.Identity{Username: "viewer", Role: auth.RoleTopology})
+		router.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	t.Cleanup(srv.Close)
+
+	subscribe := func(t *testing.T, types string) *bufio.Reader {
+		t.Helper()
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v2alpha1/events?types="+types, nil)
+		assert.NilError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NilError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		assert.Equal(t, resp.StatusCode, http.StatusOK)
+		return bufio.NewReader(resp.Body)
+	}
+	stream := subscribe(t, "connector,listener,service,component")
+
+	source := store.SourceRef{ID: "test"}
+	records := []vanflow.Record{
+		vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-1"), Name: ptrTo("west")},
+		vanflow.RouterRecord{BaseRecord: vanflow.NewBase("router-1"), Parent: ptrTo("site-1"), Name: ptrTo("west-router")},
+		vanflow.ProcessRecord{BaseRecord: vanflow.NewBase(processID), Parent: ptrTo("site-1"), Name: ptrTo(processName),
+			SourceHost: ptrTo(processHost), Group: ptrTo("backend")},
+		vanflow.ListenerRecord{BaseRecord: vanflow.NewBase("listener-1"), Parent: ptrTo("router-1"), Name: ptrTo("backend"),
+			Address: ptrTo("backend:8080"), Protocol: ptrTo("tcp"), DestHost: ptrTo("0.0.0.0"), DestPort: ptrTo("8080")},
+		vanflow.ConnectorRecord{BaseRecord: vanflow.NewBase("connector-1"), Parent: ptrTo("router-1"), Name: ptrTo("backend"),
+			Address: ptrTo("backend:8080"), Protocol: ptrTo("tcp"), DestHost: ptrTo(processHost), DestPort: ptrTo("8080"),
+			ProcessID: ptrTo(processID)},
+		vanflow.LinkRecord{BaseRecord: vanflow.NewBase("link-1"), Parent: ptrTo("router-1"), Name: ptrTo("west-east")},
+		vanflow.RouterAccessRecord{BaseRecord: vanflow.NewBase("access-1"), Parent: ptrTo("router-1")},
+	}
+	for _, record := range records {
+		c.Records.Add(record, source)
+	}
+	ids := []string{"site-1", "router-1", processID, "listener-1", "connector-1", "link-1", "access-1"}
+	for _, entry := range c.Records.List() {
+		ids = append(ids, entry.Record.Identity())
+	}
+
+	assertRedacted := func(t *testing.T, what string, body string) {
+		t.Helper()
+		for _, secret := range []string{processID, processName, processHost} {
+			assert.Assert(t, !strings.Contains(body, secret), "%s discloses %q: %s", what, secret, body)
+		}
+	}
+
+	t.Run("operations", func(t *testing.T) {
+		var permitted int
+		for key := range operations {
+			method, path, _ := strings.Cut(key, " ")
+			if method != http.MethodGet || path == "/api/v2alpha1/events" {
+				continue
+			}
+			urls := []string{path}
+			if strings.Contains(path, "{id}") {
+				urls = nil
+				for _, id := range ids {
+					urls = append(urls, strings.ReplaceAll(path, "{id}", id))
+				}
+			}
+			for _, url := range urls {
+				resp, err := http.Get(srv.URL + url)
+				assert.NilError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				assert.NilError(t, err)
+				if resp.StatusCode == http.StatusForbidden {
+					continue
+				}
+				permitted++
+				assertRedacted(t, url, string(body))
+			}
+		}
+		assert.Assert(t, permitted > 0)
+	})
+
+	t.Run("hosts by site is deprecated", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/api/v2alpha1/sites/site-1/hosts")
+		assert.NilError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, resp.StatusCode, http.StatusForbidden)
+	})
+
+	t.Run("connectors", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/api/v2alpha1/connectors?processId=" + processID)
+		assert.NilError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NilError(t, err)
+		assert.Equal(t, resp.StatusCode, http.StatusOK)
+		assert.Assert(t, strings.Contains(string(body), `"count":0`), "connectors filtered by redacted process: %s", body)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		var connectors int
+		for connectors == 0 {
+			event := readEvent(t, stream)
+			record, err := json.Marshal(event.Data.Record)
+			assert.NilError(t, err)
+			assertRedacted(t, "event "+string(event.Data.Type), string(record))
+			if event.Data.Type == api.Connector {
+				connectors++
+			}
+		}
+	})
+}
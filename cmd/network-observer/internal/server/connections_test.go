@@ -19,7 +19,7 @@ func TestConnections(t *testing.T) {
 	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
 	flowStor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
 	graph := collector.NewGraph(stor)
-	srv, c := requireTestClient(t, New(tlog, stor, graph))
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil, nil, nil))
 	defer srv.Close()
 
 	van := []vanflow.Record{
@@ -140,6 +140,49 @@ func TestConnections(t *testing.T) {
 			ExpectOK:             true,
 			ExpectCount:          0,
 			ExpectTimeRangeCount: 3,
+		}, {
+			Records: wrapRecords(
+				collector.ConnectionRecord{ID: "flow:1", SourceSite: collector.NamedReference{Name: "prod-east"}, Protocol: "http1", FlowStore: flowStor},
+				collector.ConnectionRecord{ID: "flow:2", SourceSite: collector.NamedReference{Name: "dev"}, Protocol: "tcp", FlowStore: flowStor},
+				collector.ConnectionRecord{ID: "flow:3", SourceSite: collector.NamedReference{Name: "prod-west"}, Protocol: "tcp", FlowStore: flowStor},
+			),
+			Flows: wrapRecords(
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:1"), Octets: ptrTo(uint64(2_000_000))},
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:2"), Octets: ptrTo(uint64(5_000_000))},
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:3"), Octets: ptrTo(uint64(10))},
+			),
+			Parameters: map[string][]string{
+				"filter": {`octetCount>1000000 AND (sourceSiteName=~"^prod" OR protocol=tcp)`},
+			},
+			ExpectOK:    true,
+			ExpectCount: 2,
+			ExpectResults: func(t *testing.T, results []api.ConnectionRecord) {
+				assert.Equal(t, results[0].Identity, "flow:1")
+				assert.Equal(t, results[1].Identity, "flow:2")
+			},
+		}, {
+			Records: wrapRecords(
+				collector.ConnectionRecord{ID: "flow:1", SourceSite: collector.NamedReference{Name: "prod-east"}, Protocol: "http1", FlowStore: flowStor},
+				collector.ConnectionRecord{ID: "flow:2", SourceSite: collector.NamedReference{Name: "dev"}, Protocol: "tcp", FlowStore: flowStor},
+				collector.ConnectionRecord{ID: "flow:3", SourceSite: collector.NamedReference{Name: "prod-west"}, Protocol: "tcp", FlowStore: flowStor},
+			),
+			Flows: wrapRecords(
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:1")},
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:2")},
+				vanflow.TransportBiflowRecord{BaseRecord: vanflow.NewBase("flow:3")},
+			),
+			Parameters: map[string][]string{
+				"filter":   {`sourceSiteName IN (dev, "prod-west")`, "protocol=tcp"},
+				"protocol": {"tcp"},
+			},
+			ExpectOK:    true,
+			ExpectCount: 2,
+		}, {
+			Parameters:  map[string][]string{"filter": {"octetCount>lots"}},
+			ExpectError: `invalid filter expression at position 1: field "octetCount": invalid value "lots"`,
+		}, {
+			Parameters:  map[string][]string{"filter": {"protocol=tcp AND (octetCount>1"}},
+			ExpectError: `invalid filter expression at position 31: expected ")" but found end of expression`,
 		},
 	}
 
@@ -160,6 +203,9 @@ func TestConnections(t *testing.T) {
 				if tc.ExpectResults != nil {
 					tc.ExpectResults(t, resp.JSON200.Results)
 				}
+			} else {
+				assert.Assert(t, resp.JSON400 != nil)
+				assert.Equal(t, resp.JSON400.Message, tc.ExpectError)
 			}
 		})
 	}
@@ -0,0 +1,220 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/server/views"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+)
+
+// EventSource provides subscriptions to changes in the records served by
+// the API.
+type EventSource interface {
+	Subscribe(types ...vanflow.TypeMeta) *collector.Subscription
+}
+
+const eventStreamKeepAlive = 15 * time.Second
+
+// eventView renders records of one type as they are returned by the list
+// endpoint for that type.
+type eventView struct {
+	// tag is the OpenAPI tag of the operations listing records of the
+	// type, used to check whether the caller may receive them.
+	tag      string
+	exemplar vanflow.Record
+	// renderer returns a function rendering records that match the
+	// field filters and filter expressions, or an error if they do not
+	// apply to the type.
+	renderer func(filters map[string][]string, exprs []string) (func(vanflow.Record) (any, bool), error)
+}
+
+func newEventView[V vanflow.Record, R any](tag string, mapping func(V) (R, bool)) eventView {
+	var exemplar V
+	return eventView{
+		tag:      tag,
+		exemplar: exemplar,
+		renderer: func(filters map[string][]string, exprs []string) (func(vanflow.Record) (any, bool), error) {
+			indexes := make(map[string]fieldIndex[R], len(filters))
+			for path := range filters {
+				index, err := indexerForField[R](path)
+				if err != nil {
+					var r R
+					return nil, fmt.Errorf("invalid filter parameter %q for record type %T", path, r)
+				}
+				indexes[path] = index
+			}
+			matchers := make([]func(R) bool, 0, len(exprs))
+			for _, expr := range exprs {
+				match, err := compileFilter[R](expr)
+				if err != nil {
+					return nil, err
+				}
+				matchers = append(matchers, match)
+			}
+			return func(in vanflow.Record) (any, bool) {
+				record, ok := in.(V)
+				if !ok {
+					return nil, false
+				}
+				out, ok := mapping(record)
+				if !ok {
+					return nil, false
+				}
+				for path, values := range filters {
+					if !indexes[path].MatchesFilter(out, values) {
+						return nil, false
+					}
+				}
+				for _, match := range matchers {
+					if !match(out) {
+						return nil, false
+					}
+				}
+				return out, true
+			}, nil
+		},
+	}
+}
+
+func always[V, R any](mapping func(V) R) func(V) (R, bool) {
+	return func(v V) (R, bool) {
+		return mapping(v), true
+	}
+}
+
+func (s *server) eventViews(r *http.Request) map[api.RecordEventType]eventView {
+	return map[api.RecordEventType]eventView{
+		api.Site:         newEventView("site", always(views.NewSiteProvider(s.graph))),
+		api.Router:       newEventView("router", always(views.Router)),
+		api.Link:         newEventView("link", views.NewRouterLinkProvider(s.graph)),
+		api.Routeraccess: newEventView("link", always(views.RouterAccess)),
+		api.Listener:     newEventView("listener", always(views.NewListenerProvider(s.graph))),
+		api.Connector:    newEventView("connector", always(s.connectorProvider(r))),
+		api.Process:      newEventView(auth.TagProcess, views.NewProcessProvider(s.records, s.graph)),
+		api.Component:    newEventView("component", always(views.NewComponentProvider(s.records))),
+		api.Service:      newEventView("service", always(views.NewServiceProvider(s.records, s.graph))),
+	}
+}
+
+// (GET /api/v2alpha1/events)
+func (s *server) Events(w http.ResponseWriter, r *http.Request, params api.EventsParams) {
+	badRequest := func(message string) {
+		if err := encodeResponse(w, http.StatusBadRequest, api.ErrorBadRequest{Message: message}); err != nil {
+			s.logWriteError(r, err)
+		}
+	}
+	if s.events == nil {
+		badRequest("event streaming is not enabled")
+		return
+	}
+	if len(params.Types) == 0 {
+		badRequest("at least one record type is required")
+		return
+	}
+
+	qp := getQueryParams(r)
+	filters := qp.FilterFields
+	delete(filters, "Types")
+
+	available := s.eventViews(r)
+	renderers := make(map[vanflow.TypeMeta]func(vanflow.Record) (any, bool), len(params.Types))
+	eventTypes := make(map[vanflow.TypeMeta]api.RecordEventType, len(params.Types))
+	var types []vanflow.TypeMeta
+	for _, typ := range params.Types {
+		view, ok := available[typ]
+		if !ok {
+			badRequest(fmt.Sprintf("unsupported record type %q", typ))
+			return
+		}
+		if !auth.Permits(r, view.tag) {
+			auth.Forbidden(w)
+			return
+		}
+		render, err := view.renderer(filters, qp.Filters)
+		if err != nil {
+			badRequest(err.Error())
+			return
+		}
+		meta := view.exemplar.GetTypeMeta()
+		if _, ok := renderers[meta]; !ok {
+			types = append(types, meta)
+		}
+		renderers[meta] = render
+		eventTypes[meta] = typ
+	}
+
+	rc := http.NewResponseController(w)
+	// the stream is expected to outlive the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		requestLogger(s.logger, r).Debug("unable to clear write deadline for event stream", slog.Any("error", err))
+	}
+
+	sub := s.events.Subscribe(types...)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		requestLogger(s.logger, r).Error("event stream not supported by response writer", slog.Any("error", err))
+		return
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		case event, ok := <-sub.C:
+			if !ok {
+				err = writeEvent(w, "error", api.ErrorResponse{
+					Code:    "ErrOverflow",
+					Message: sub.Err().Error(),
+				})
+				if err != nil {
+					s.logWriteError(r, err)
+				}
+				rc.Flush()
+				return
+			}
+			typ := event.Record.GetTypeMeta()
+			record, ok := renderers[typ](event.Record)
+			if !ok {
+				continue
+			}
+			err = writeEvent(w, string(event.Action), api.RecordEvent{
+				Action: api.RecordEventAction(event.Action),
+				Type:   eventTypes[typ],
+				Record: record,
+			})
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			s.logWriteError(r, err)
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("json encoding error: %s", err)
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	"github.com/skupperproject/skupper/pkg/vanflow/session"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+)
+
+type streamedEvent struct {
+	Name string
+	Data api.RecordEvent
+}
+
+func readEvent(t *testing.T, r *bufio.Reader) streamedEvent {
+	t.Helper()
+	var event streamedEvent
+	for {
+		line, err := r.ReadString('\n')
+		assert.NilError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if event.Name != "" {
+				return event
+			}
+		case strings.HasPrefix(line, "event: "):
+			event.Name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			assert.NilError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event.Data))
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	tlog := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c, err := collector.New(tlog, session.NewMockContainerFactory(), prometheus.NewRegistry(), time.Minute, nil, collector.StorageConfig{})
+	assert.NilError(t, err)
+	srv := httptest.NewServer(api.Handler(New(tlog, c.Records, c.GetGraph(), c, nil, nil)))
+	defer srv.Close()
+
+	t.Run("bad request", func(t *testing.T) {
+		for _, query := range []string{"types=site&notAField=x", "types=flow"} {
+			resp, err := http.Get(srv.URL + "/api/v2alpha1/events?" + query)
+			assert.NilError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, resp.StatusCode, http.StatusBadRequest, query)
+		}
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		disabled := httptest.NewServer(api.Handler(New(tlog, c.Records, c.GetGraph(), nil, nil, nil)))
+		defer disabled.Close()
+		resp, err := http.Get(disabled.URL + "/api/v2alpha1/events?types=site")
+		assert.NilError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, resp.StatusCode, http.StatusBadRequest)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		topology := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := auth.WithIdentity(r.Context(), auth.Identity{Username: "viewer", Role: auth.RoleTopology})
+			srv.Config.Handler.ServeHTTP(w, r.WithContext(ctx))
+		}))
+		defer topology.Close()
+		resp, err := http.Get(topology.URL + "/api/v2alpha1/events?types=site,process")
+		assert.NilError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, resp.StatusCode, http.StatusForbidden)
+	})
+
+	resp, err := http.Get(srv.URL + "/api/v2alpha1/events?types=site,router&name=west")
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("Content-Type"), "text/event-stream")
+	stream := bufio.NewReader(resp.Body)
+
+	source := store.SourceRef{ID: "test"}
+	c.Records.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-e"), Name: ptrTo("east")}, source)
+	c.Records.Add(vanflow.ProcessRecord{BaseRecord: vanflow.NewBase("process-1"), Name: ptrTo("west")}, source)
+	c.Records.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-w"), Name: ptrTo("west")}, source)
+	c.Records.Update(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("site-w"), Name: ptrTo("west"), Platform: ptrTo("kubernetes")})
+	c.Records.Delete("site-w")
+
+	event := readEvent(t, stream)
+	assert.Equal(t, event.Name, "add")
+	assert.Equal(t, event.Data.Action, api.Add)
+	assert.Equal(t, event.Data.Type, api.Site)
+	assert.Equal(t, event.Data.Record.(map[string]any)["identity"], "site-w")
+
+	event = readEvent(t, stream)
+	assert.Equal(t, event.Name, "update")
+	assert.Equal(t, event.Data.Record.(map[string]any)["platform"], "kubernetes")
+
+	event = readEvent(t, stream)
+	assert.Equal(t, event.Name, "delete")
+	assert.Equal(t, event.Data.Record.(map[string]any)["identity"], "site-w")
+}
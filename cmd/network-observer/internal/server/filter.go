@@ -0,0 +1,467 @@
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+)
+
+type filterTokenKind int
+
+const (
+	filterEOF filterTokenKind = iota
+	filterWord
+	filterString
+	filterOperator
+	filterLParen
+	filterRParen
+	filterComma
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func (t filterToken) String() string {
+	if t.kind == filterEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+type filterError struct {
+	pos     int
+	message string
+}
+
+func (e filterError) Error() string {
+	return fmt.Sprintf("invalid filter expression at position %d: %s", e.pos+1, e.message)
+}
+
+func lexFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, filterToken{filterLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{filterRParen, ")", i})
+			i++
+		case c == ',':
+			tokens = append(tokens, filterToken{filterComma, ",", i})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(expr) && expr[end] != '"'; end++ {
+				if expr[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expr) {
+				return nil, filterError{i, "unterminated string"}
+			}
+			s, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				return nil, filterError{i, "invalid string " + expr[i:end+1]}
+			}
+			tokens = append(tokens, filterToken{filterString, s, i})
+			i = end + 1
+		case strings.IndexByte("=!<>~", c) >= 0:
+			end := i + 1
+			if end < len(expr) && strings.IndexByte("=~", expr[end]) >= 0 {
+				end++
+			}
+			op := expr[i:end]
+			switch op {
+			case "=", "==", "!=", ">", ">=", "<", "<=", "=~", "!~":
+			default:
+				return nil, filterError{i, fmt.Sprintf("unknown operator %q", op)}
+			}
+			tokens = append(tokens, filterToken{filterOperator, op, i})
+			i = end
+		default:
+			end := i
+			for end < len(expr) && !strings.ContainsRune(" \t\n\r(),\"=!<>~", rune(expr[end])) {
+				end++
+			}
+			tokens = append(tokens, filterToken{filterWord, expr[i:end], i})
+			i = end
+		}
+	}
+	return append(tokens, filterToken{kind: filterEOF, pos: len(expr)}), nil
+}
+
+// compileFilter returns a function reporting whether records of type T
+// match the filter expression.
+//
+// Filter expressions select records with comparisons on their fields,
+// combined with AND, OR, NOT and parentheses. For example:
+//
+//	octets>1000000 AND (sourceSiteName=~"^prod" OR protocol=tcp)
+//
+// Comparisons are a field name followed by one of the operators =, !=, >,
+// >=, <, <=, =~ (regular expression match), !~, CONTAINS, IN or NOT IN and
+// a value, or for IN a parenthesized, comma separated list of values.
+// Values are double quoted strings or unquoted words. Comparisons on list
+// fields match when any element matches, and those on unset fields only
+// match when negated.
+func compileFilter[T any](expr string) (func(T) bool, error) {
+	tokens, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser[T]{tokens: tokens}
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != filterEOF {
+		return nil, filterError{tok.pos, "unexpected " + tok.String()}
+	}
+	return match, nil
+}
+
+type filterParser[T any] struct {
+	tokens []filterToken
+	next   int
+}
+
+func (p *filterParser[T]) peek() filterToken {
+	return p.tokens[p.next]
+}
+
+func (p *filterParser[T]) advance() filterToken {
+	tok := p.tokens[p.next]
+	if tok.kind != filterEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *filterParser[T]) keyword(word string) bool {
+	tok := p.peek()
+	if tok.kind == filterWord && strings.EqualFold(tok.text, word) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser[T]) parseOr() (func(T) bool, error) {
+	match, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		lhs := match
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		match = func(t T) bool { return lhs(t) || rhs(t) }
+	}
+	return match, nil
+}
+
+func (p *filterParser[T]) parseAnd() (func(T) bool, error) {
+	match, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		lhs := match
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		match = func(t T) bool { return lhs(t) && rhs(t) }
+	}
+	return match, nil
+}
+
+func (p *filterParser[T]) parseUnary() (func(T) bool, error) {
+	if p.keyword("not") {
+		match, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(t T) bool { return !match(t) }, nil
+	}
+	if p.peek().kind == filterLParen {
+		p.advance()
+		match, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.advance(); tok.kind != filterRParen {
+			return nil, filterError{tok.pos, "expected \")\" but found " + tok.String()}
+		}
+		return match, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser[T]) parseComparison() (func(T) bool, error) {
+	field := p.advance()
+	if field.kind != filterWord {
+		return nil, filterError{field.pos, "expected field name but found " + field.String()}
+	}
+	index, err := indexerForField[T](field.text)
+	if err != nil {
+		return nil, filterError{field.pos, err.Error()}
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem().FieldByIndex(index.index).Type
+
+	var (
+		op     string
+		negate bool
+		values []filterToken
+	)
+	switch tok := p.advance(); {
+	case tok.kind == filterOperator:
+		op = tok.text
+		switch op {
+		case "==":
+			op = "="
+		case "!=":
+			op, negate = "=", true
+		case "!~":
+			op, negate = "=~", true
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	case tok.kind == filterWord && strings.EqualFold(tok.text, "contains"):
+		op = "contains"
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	case tok.kind == filterWord && strings.EqualFold(tok.text, "not"):
+		if !p.keyword("in") {
+			tok := p.peek()
+			return nil, filterError{tok.pos, "expected IN but found " + tok.String()}
+		}
+		negate = true
+		fallthrough
+	case tok.kind == filterWord && strings.EqualFold(tok.text, "in"):
+		op = "="
+		values, err = p.parseList()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, filterError{tok.pos, "expected operator but found " + tok.String()}
+	}
+
+	match, err := newValueMatcher(typ, op, values)
+	if err != nil {
+		return nil, filterError{field.pos, fmt.Sprintf("field %q: %s", field.text, err)}
+	}
+	return func(t T) bool {
+		return matchField(reflect.ValueOf(t).FieldByIndex(index.index), match) != negate
+	}, nil
+}
+
+func (p *filterParser[T]) parseValue() (filterToken, error) {
+	tok := p.advance()
+	if tok.kind != filterWord && tok.kind != filterString {
+		return tok, filterError{tok.pos, "expected value but found " + tok.String()}
+	}
+	return tok, nil
+}
+
+func (p *filterParser[T]) parseList() ([]filterToken, error) {
+	if tok := p.advance(); tok.kind != filterLParen {
+		return nil, filterError{tok.pos, "expected \"(\" but found " + tok.String()}
+	}
+	var values []filterToken
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		switch tok := p.advance(); tok.kind {
+		case filterComma:
+		case filterRParen:
+			return values, nil
+		default:
+			return nil, filterError{tok.pos, "expected \",\" or \")\" but found " + tok.String()}
+		}
+	}
+}
+
+// matchField applies a matcher to a field value, to each element of list
+// fields, and never matches unset fields.
+func matchField(val reflect.Value, match func(reflect.Value) bool) bool {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return false
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice {
+		return match(val)
+	}
+	for i := 0; i < val.Len(); i++ {
+		elem := val.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+		if match(elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// newValueMatcher returns a function matching values of the field type
+// against the operands of an operator.
+func newValueMatcher(typ reflect.Type, op string, operands []filterToken) (func(reflect.Value) bool, error) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+	}
+	values := make([]string, len(operands))
+	for i, operand := range operands {
+		values[i] = operand.text
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		switch op {
+		case "contains":
+			return func(v reflect.Value) bool {
+				return strings.Contains(v.String(), values[0])
+			}, nil
+		case "=~":
+			re, err := regexp.Compile(values[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression: %s", err)
+			}
+			return func(v reflect.Value) bool {
+				return re.MatchString(v.String())
+			}, nil
+		case "=":
+			if typ == atmarkSplitStringTyp {
+				return func(v reflect.Value) bool {
+					for _, part := range v.Interface().(api.AtmarkDelimitedString).Parts() {
+						for _, value := range values {
+							if strings.EqualFold(part, value) {
+								return true
+							}
+						}
+					}
+					return false
+				}, nil
+			}
+		}
+		return orderedMatcher(typ, op, values, reflect.Value.String)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		numbers, err := parseOperands(operands, func(s string) (int64, error) {
+			return strconv.ParseInt(s, 10, 64)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return orderedMatcher(typ, op, numbers, reflect.Value.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		numbers, err := parseOperands(operands, func(s string) (uint64, error) {
+			return strconv.ParseUint(s, 10, 64)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return orderedMatcher(typ, op, numbers, reflect.Value.Uint)
+	case reflect.Float32, reflect.Float64:
+		numbers, err := parseOperands(operands, func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return orderedMatcher(typ, op, numbers, reflect.Value.Float)
+	case reflect.Bool:
+		if op != "=" {
+			return nil, fmt.Errorf("operator %s is not supported for fields of type %s", op, typ)
+		}
+		bools, err := parseOperands(operands, strconv.ParseBool)
+		if err != nil {
+			return nil, err
+		}
+		return equalMatcher(bools, reflect.Value.Bool), nil
+	default:
+		return nil, fmt.Errorf("fields of type %s cannot be filtered", typ)
+	}
+}
+
+func parseOperands[V any](operands []filterToken, parse func(string) (V, error)) ([]V, error) {
+	out := make([]V, len(operands))
+	for i, operand := range operands {
+		v, err := parse(operand.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %s", operand)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// equalMatcher returns a matcher for values equal to any of the operands.
+func equalMatcher[V comparable](values []V, get func(reflect.Value) V) func(reflect.Value) bool {
+	return func(v reflect.Value) bool {
+		x := get(v)
+		for _, value := range values {
+			if x == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// orderedMatcher returns a matcher for the equality and ordering operators.
+func orderedMatcher[V cmp.Ordered](typ reflect.Type, op string, values []V, get func(reflect.Value) V) (func(reflect.Value) bool, error) {
+	var test func(c int) bool
+	switch op {
+	case "=":
+		return equalMatcher(values, get), nil
+	case ">":
+		test = func(c int) bool { return c > 0 }
+	case ">=":
+		test = func(c int) bool { return c >= 0 }
+	case "<":
+		test = func(c int) bool { return c < 0 }
+	case "<=":
+		test = func(c int) bool { return c <= 0 }
+	default:
+		return nil, fmt.Errorf("operator %s is not supported for fields of type %s", op, typ)
+	}
+	value := values[0]
+	return func(v reflect.Value) bool {
+		return test(cmp.Compare(get(v), value))
+	}, nil
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"gotest.tools/v3/assert"
+)
+
+type filterTestRecord struct {
+	Name     string
+	Count    uint64
+	Delta    int64
+	Ratio    float64
+	Active   bool
+	Parent   *string
+	Tags     []string
+	Address  api.AtmarkDelimitedString
+	Nested   struct{ Name string }
+	Children []filterTestRecord
+}
+
+func TestCompileFilter(t *testing.T) {
+	records := []filterTestRecord{
+		{Name: "prod-east", Count: 2_000_000, Delta: -5, Ratio: 0.5, Active: true, Parent: ptrTo("p1"), Tags: []string{"a", "b"}, Address: "10.0.0.1:8080@tcp"},
+		{Name: "prod-west", Count: 10, Delta: 3, Ratio: 1.5, Tags: []string{"c"}},
+		{Name: "dev", Count: 5_000_000, Delta: 0, Ratio: 2, Active: true, Parent: ptrTo("p2")},
+	}
+	testCases := []struct {
+		Expr   string
+		Expect []string
+	}{
+		{Expr: `count>1000000 AND (name=~"^prod" OR active=true)`, Expect: []string{"prod-east", "dev"}},
+		{Expr: `count>1000000 and name=~"^prod" or active=true`, Expect: []string{"prod-east", "dev"}},
+		{Expr: `count>=10 AND count<=2000000`, Expect: []string{"prod-east", "prod-west"}},
+		{Expr: `delta<0 OR ratio>1.75`, Expect: []string{"prod-east", "dev"}},
+		{Expr: `name!=dev`, Expect: []string{"prod-east", "prod-west"}},
+		{Expr: `name!~west$`, Expect: []string{"prod-east", "dev"}},
+		{Expr: `name CONTAINS "d-"`, Expect: []string{"prod-east", "prod-west"}},
+		{Expr: `name>"prod-east"`, Expect: []string{"prod-west"}},
+		{Expr: `name IN (dev, "prod-west")`, Expect: []string{"prod-west", "dev"}},
+		{Expr: `name not in (dev)`, Expect: []string{"prod-east", "prod-west"}},
+		{Expr: `NOT (name=dev OR name=prod-west)`, Expect: []string{"prod-east"}},
+		{Expr: `NOT NOT name==dev`, Expect: []string{"dev"}},
+		{Expr: `parent=p1`, Expect: []string{"prod-east"}},
+		{Expr: `parent!=p1`, Expect: []string{"prod-west", "dev"}},
+		{Expr: `tags=c OR tags=b`, Expect: []string{"prod-east", "prod-west"}},
+		{Expr: `address=TCP`, Expect: []string{"prod-east"}},
+		{Expr: `nested.name=""`, Expect: []string{"prod-east", "prod-west", "dev"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Expr, func(t *testing.T) {
+			match, err := compileFilter[filterTestRecord](tc.Expr)
+			assert.NilError(t, err)
+			var matched []string
+			for _, r := range records {
+				if match(r) {
+					matched = append(matched, r.Name)
+				}
+			}
+			assert.DeepEqual(t, matched, tc.Expect)
+		})
+	}
+}
+
+func TestCompileFilterErrors(t *testing.T) {
+	testCases := []struct {
+		Expr  string
+		Error string
+	}{
+		{Expr: ``, Error: "position 1: expected field name but found end of expression"},
+		{Expr: `name`, Error: "position 5: expected operator but found end of expression"},
+		{Expr: `name=`, Error: "position 6: expected value but found end of expression"},
+		{Expr: `name=dev AND`, Error: "position 13: expected field name but found end of expression"},
+		{Expr: `name=dev count=1`, Error: `position 10: unexpected "count"`},
+		{Expr: `(name=dev`, Error: `position 10: expected ")" but found end of expression`},
+		{Expr: `name=dev)`, Error: `position 9: unexpected ")"`},
+		{Expr: `name="dev`, Error: "position 6: unterminated string"},
+		{Expr: `name~dev`, Error: `position 5: unknown operator "~"`},
+		{Expr: `name NOT dev`, Error: `position 10: expected IN but found "dev"`},
+		{Expr: `name IN dev`, Error: `position 9: expected "(" but found "dev"`},
+		{Expr: `name IN (dev prod)`, Error: `position 14: expected "," or ")" but found "prod"`},
+		{Expr: `missing=1`, Error: `position 1: unknown field "Missing"`},
+		{Expr: `name=~"("`, Error: `position 1: field "name": invalid regular expression`},
+		{Expr: `count>many`, Error: `position 1: field "count": invalid value "many"`},
+		{Expr: `count=~"1"`, Error: `position 1: field "count": operator =~ is not supported for fields of type uint64`},
+		{Expr: `active>false`, Error: `position 1: field "active": operator > is not supported for fields of type bool`},
+		{Expr: `children=x`, Error: `position 1: field "children": fields of type server.filterTestRecord cannot be filtered`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Expr, func(t *testing.T) {
+			_, err := compileFilter[filterTestRecord](tc.Expr)
+			assert.ErrorContains(t, err, "invalid filter expression at "+tc.Error)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/server/views"
 	"github.com/skupperproject/skupper/pkg/vanflow"
@@ -122,9 +123,27 @@ func (s *server) ProcessPairsByService(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
+// connectorProvider maps connector records for the caller. Connectors
+// identify the process they target, which is redacted for callers that
+// may not see processes.
+func (s *server) connectorProvider(r *http.Request) func(vanflow.ConnectorRecord) api.ConnectorRecord {
+	provider := views.NewConnectorProvider(s.graph)
+	if auth.Permits(r, auth.TagProcess) {
+		return provider
+	}
+	return func(record vanflow.ConnectorRecord) api.ConnectorRecord {
+		return views.RedactConnector(provider(record))
+	}
+}
+
 // (GET /api/v2alpha1/connectors)
 func (s *server) Connectors(w http.ResponseWriter, r *http.Request) {
 	results := views.NewConnectorSliceProvider(s.graph)(listByType[vanflow.ConnectorRecord](s.records))
+	if !auth.Permits(r, auth.TagProcess) {
+		for i := range results {
+			results[i] = views.RedactConnector(results[i])
+		}
+	}
 	if err := handleCollection(w, r, &api.ConnectorListResponse{}, results); err != nil {
 		s.logWriteError(r, err)
 	}
@@ -132,7 +151,7 @@ func (s *server) Connectors(w http.ResponseWriter, r *http.Request) {
 
 // (GET /api/v2alpha1/connectors/{id})
 func (s *server) ConnectorByID(w http.ResponseWriter, r *http.Request, id string) {
-	getRecord := fetchAndMap(s.records, views.NewConnectorProvider(s.graph), id)
+	getRecord := fetchAndMap(s.records, s.connectorProvider(r), id)
 	if err := handleSingle(w, r, &api.ConnectorResponse{}, getRecord); err != nil {
 		s.logWriteError(r, err)
 	}
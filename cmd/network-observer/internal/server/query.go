@@ -31,6 +31,14 @@ func filterAndOrderResults[T api.Record](r *http.Request, results []T) ([]T, int
 		}
 		filterFields[path] = m
 	}
+	filters := make([]func(T) bool, 0, len(qp.Filters))
+	for _, expr := range qp.Filters {
+		filter, err := compileFilter[T](expr)
+		if err != nil {
+			return nil, 0, err
+		}
+		filters = append(filters, filter)
+	}
 
 	for i, item := range results {
 		matches := true
@@ -40,6 +48,12 @@ func filterAndOrderResults[T api.Record](r *http.Request, results []T) ([]T, int
 				break
 			}
 		}
+		for _, filter := range filters {
+			if !matches {
+				break
+			}
+			matches = filter(item)
+		}
 		switch {
 		case matches && !isCopy:
 			continue
@@ -289,6 +303,7 @@ type queryParams struct {
 	SortField          string
 	SortDescending     bool
 	FilterFields       map[string][]string
+	Filters            []string
 	TimeRangeStart     uint64
 	TimeRangeEnd       uint64
 	TimeRangeOperation timeRangeRelation
@@ -358,6 +373,8 @@ func getQueryParams(r *http.Request) queryParams {
 			default:
 				qp.TimeRangeOperation = intersects
 			}
+		case "filter":
+			qp.Filters = v
 		case "state":
 			recordState := v[0]
 			switch recordState {
@@ -7,16 +7,21 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/alerts"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
 	"github.com/skupperproject/skupper/pkg/vanflow/store"
 )
 
-func New(logger *slog.Logger, records store.Interface, graph collector.Graph) api.ServerInterface {
+func New(logger *slog.Logger, records store.Interface, graph collector.Graph, events EventSource, series *timeseries.Store, alerts *alerts.Manager) api.ServerInterface {
 	return &server{
 		logger:  logger,
 		records: records,
 		graph:   graph,
+		events:  events,
+		series:  series,
+		alerts:  alerts,
 	}
 }
 
@@ -24,6 +29,9 @@ type server struct {
 	logger  *slog.Logger
 	records store.Interface
 	graph   collector.Graph
+	events  EventSource
+	series  *timeseries.Store
+	alerts  *alerts.Manager
 }
 
 func (c *server) logWriteError(r *http.Request, err error) {
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
+)
+
+// (GET /api/v2alpha1/services/{id}/timeseries)
+func (s *server) TimeSeriesByService(w http.ResponseWriter, r *http.Request, id string, params api.TimeSeriesByServiceParams) {
+	getKey := fetchAndMap(s.records, func(record collector.AddressRecord) timeseries.Key {
+		return timeseries.ServiceKey(record.Name, record.Protocol)
+	}, id)
+	if err := s.handleTimeSeries(w, getKey, params.Range, params.Step); err != nil {
+		s.logWriteError(r, err)
+	}
+}
+
+// (GET /api/v2alpha1/sitepairs/{id}/timeseries)
+func (s *server) TimeSeriesBySitepair(w http.ResponseWriter, r *http.Request, id string, params api.TimeSeriesBySitepairParams) {
+	getKey := fetchAndMap(s.records, func(record collector.SitePairRecord) timeseries.Key {
+		return timeseries.SitePairKey(record.Source, record.Dest, record.Protocol)
+	}, id)
+	if err := s.handleTimeSeries(w, getKey, params.Range, params.Step); err != nil {
+		s.logWriteError(r, err)
+	}
+}
+
+// (GET /api/v2alpha1/processpairs/{id}/timeseries)
+func (s *server) TimeSeriesByProcesspair(w http.ResponseWriter, r *http.Request, id string, params api.TimeSeriesByProcesspairParams) {
+	getKey := fetchAndMap(s.records, func(record collector.ProcPairRecord) timeseries.Key {
+		return timeseries.ProcessPairKey(record.Source, record.Dest, record.Protocol)
+	}, id)
+	if err := s.handleTimeSeries(w, getKey, params.Range, params.Step); err != nil {
+		s.logWriteError(r, err)
+	}
+}
+
+func (s *server) handleTimeSeries(w http.ResponseWriter, getKey func() (timeseries.Key, bool), rangeParam, stepParam *string) error {
+	var (
+		out    any
+		status = http.StatusOK
+	)
+	key, ok := getKey()
+	switch {
+	case s.series == nil:
+		status = http.StatusNotFound
+		out = api.ErrorNotFound{
+			Code:    "ErrNotFound",
+			Message: "time series are not enabled",
+		}
+	case !ok:
+		status = http.StatusNotFound
+		out = api.ErrorNotFound{
+			Code: "ErrNotFound",
+		}
+	default:
+		series, err := s.querySeries(key, rangeParam, stepParam)
+		if err != nil {
+			status = http.StatusBadRequest
+			out = api.ErrorBadRequest{
+				Message: err.Error(),
+			}
+			break
+		}
+		out = api.TimeSeriesResponse{Results: series}
+	}
+	if err := encodeResponse(w, status, out); err != nil {
+		return fmt.Errorf("response write error: %s", err)
+	}
+	return nil
+}
+
+func (s *server) querySeries(key timeseries.Key, rangeParam, stepParam *string) (api.TimeSeries, error) {
+	var series api.TimeSeries
+	rng, err := parseDurationParam("range", rangeParam)
+	if err != nil {
+		return series, err
+	}
+	step, err := parseDurationParam("step", stepParam)
+	if err != nil {
+		return series, err
+	}
+	points, err := s.series.Query(key, rng, step)
+	if err != nil {
+		return series, err
+	}
+	series.LatencyBuckets = timeseries.LatencyBuckets
+	series.Points = make([]api.TimeSeriesPoint, len(points))
+	for i, p := range points {
+		series.Points[i] = api.TimeSeriesPoint{
+			Timestamp:         uint64(p.Start.UnixMicro()),
+			BytesSent:         p.BytesSent,
+			BytesReceived:     p.BytesReceived,
+			ConnectionsOpened: p.ConnectionsOpened,
+			ConnectionsClosed: p.ConnectionsClosed,
+			Latency: api.LatencyHistogram{
+				Counts: p.Latency.Counts,
+				Count:  p.Latency.Count,
+				Sum:    p.Latency.Sum,
+			},
+			Requests: p.Requests,
+		}
+	}
+	series.Step = uint64(s.series.Step(step).Microseconds())
+	return series, nil
+}
+
+func parseDurationParam(name string, param *string) (time.Duration, error) {
+	if param == nil || *param == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(*param)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %s", name, *param, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must not be negative", name, *param)
+	}
+	return d, nil
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
+	"github.com/skupperproject/skupper/pkg/vanflow/store"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+func TestTimeSeries(t *testing.T) {
+	tlog := slog.Default()
+	stor := store.NewSyncMapStore(store.SyncMapStoreConfig{Indexers: collector.RecordIndexers()})
+	graph := collector.NewGraph(stor)
+	series := timeseries.New(timeseries.Config{Resolution: time.Minute, Retention: time.Hour})
+	srv, c := requireTestClient(t, New(tlog, stor, graph, nil, series, nil))
+	defer srv.Close()
+
+	stor.Replace(wrapRecords(
+		collector.AddressRecord{ID: "address1", Name: "backend:8080", Protocol: "tcp"},
+		collector.SitePairRecord{ID: "sitepair1", Source: "site1", Dest: "site2", Protocol: "tcp"},
+		collector.ProcPairRecord{ID: "processpair1", Source: "process1", Dest: "process2", Protocol: "tcp"},
+	))
+	traffic := series.Group(
+		timeseries.ServiceKey("backend:8080", "tcp"),
+		timeseries.SitePairKey("site1", "site2", "tcp"),
+		timeseries.ProcessPairKey("process1", "process2", "tcp"),
+	)
+	traffic.ConnectionOpened()
+	traffic.AddBytes(64, 1024)
+	traffic.ObserveLatency(0.02)
+	traffic.Request("2xx")
+
+	testCases := []struct {
+		Name         string
+		Get          func(params map[string][]string) (*http.Response, *api.TimeSeriesResponse, *api.ErrorResponse, *api.ErrorResponse, error)
+		Parameters   map[string][]string
+		ExpectStatus int
+		ExpectPoints int
+		ExpectStep   time.Duration
+		ExpectError  string
+	}{
+		{
+			Name:         "service",
+			Get:          getServiceSeries(c, "address1"),
+			ExpectStatus: http.StatusOK,
+			ExpectPoints: 60,
+			ExpectStep:   time.Minute,
+		}, {
+			Name:         "sitepair",
+			Get:          getSitePairSeries(c, "sitepair1"),
+			Parameters:   map[string][]string{"range": {"30m"}, "step": {"5m"}},
+			ExpectStatus: http.StatusOK,
+			ExpectPoints: 6,
+			ExpectStep:   5 * time.Minute,
+		}, {
+			Name:         "processpair",
+			Get:          getProcessPairSeries(c, "processpair1"),
+			Parameters:   map[string][]string{"range": {"10m"}, "step": {"90s"}},
+			ExpectStatus: http.StatusOK,
+			ExpectPoints: 5,
+			ExpectStep:   2 * time.Minute,
+		}, {
+			Name:         "not found",
+			Get:          getServiceSeries(c, "address2"),
+			ExpectStatus: http.StatusNotFound,
+		}, {
+			Name:         "wrong record type",
+			Get:          getProcessPairSeries(c, "sitepair1"),
+			ExpectStatus: http.StatusNotFound,
+		}, {
+			Name:         "invalid range",
+			Get:          getServiceSeries(c, "address1"),
+			Parameters:   map[string][]string{"range": {"an hour"}},
+			ExpectStatus: http.StatusBadRequest,
+			ExpectError:  `invalid range "an hour"`,
+		}, {
+			Name:         "negative step",
+			Get:          getSitePairSeries(c, "sitepair1"),
+			Parameters:   map[string][]string{"step": {"-1m"}},
+			ExpectStatus: http.StatusBadRequest,
+			ExpectError:  `invalid step "-1m": must not be negative`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			resp, ok, badRequest, notFound, err := tc.Get(tc.Parameters)
+			assert.NilError(t, err)
+			assert.Equal(t, resp.StatusCode, tc.ExpectStatus)
+			switch tc.ExpectStatus {
+			case http.StatusOK:
+				assert.Assert(t, ok != nil)
+				assert.Equal(t, ok.Results.Step, uint64(tc.ExpectStep.Microseconds()))
+				assert.DeepEqual(t, ok.Results.LatencyBuckets, timeseries.LatencyBuckets)
+				assert.Equal(t, len(ok.Results.Points), tc.ExpectPoints)
+				var (
+					total    api.TimeSeriesPoint
+					requests uint64
+				)
+				for _, p := range ok.Results.Points {
+					total.ConnectionsOpened += p.ConnectionsOpened
+					total.BytesSent += p.BytesSent
+					total.BytesReceived += p.BytesReceived
+					total.Latency.Count += p.Latency.Count
+					total.Latency.Sum += p.Latency.Sum
+					assert.Equal(t, len(p.Requests), len(timeseries.StatusClasses))
+					requests += p.Requests["2xx"]
+				}
+				assert.Equal(t, total.ConnectionsOpened, uint64(1))
+				assert.Equal(t, total.BytesSent, uint64(64))
+				assert.Equal(t, total.BytesReceived, uint64(1024))
+				assert.Equal(t, total.Latency.Count, uint64(1))
+				assert.Equal(t, total.Latency.Sum, 0.02)
+				assert.Equal(t, requests, uint64(1))
+				first, last := ok.Results.Points[0], ok.Results.Points[tc.ExpectPoints-1]
+				assert.Equal(t, first.Timestamp+uint64(tc.ExpectStep.Microseconds())*uint64(tc.ExpectPoints-1), last.Timestamp)
+			case http.StatusBadRequest:
+				assert.Assert(t, badRequest != nil)
+				assert.Check(t, cmp.Contains(badRequest.Message, tc.ExpectError))
+			case http.StatusNotFound:
+				assert.Assert(t, notFound != nil)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		srv, c := requireTestClient(t, New(tlog, stor, graph, nil, nil, nil))
+		defer srv.Close()
+		resp, err := c.TimeSeriesByServiceWithResponse(context.TODO(), "address1", nil)
+		assert.NilError(t, err)
+		assert.Equal(t, resp.StatusCode(), http.StatusNotFound)
+		assert.Equal(t, resp.JSON404.Message, "time series are not enabled")
+	})
+}
+
+func getServiceSeries(c api.ClientWithResponsesInterface, id string) func(map[string][]string) (*http.Response, *api.TimeSeriesResponse, *api.ErrorResponse, *api.ErrorResponse, error) {
+	return func(params map[string][]string) (*http.Response, *api.TimeSeriesResponse, *api.ErrorResponse, *api.ErrorResponse, error) {
+		resp, err := c.TimeSeriesByServiceWithResponse(context.TODO(), id, nil, withParameters(params))
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		return resp.HTTPResponse, resp.JSON200, resp.JSON400, resp.JSON404, nil
+	}
+}
+
+func getSitePairSeries(c api.ClientWithResponsesInterface, id string) func(map[string][]string) (*http.Response, *api.TimeSeriesResponse, *api.ErrorResponse, *api.ErrorResponse, error) {
+	return func(params map[string][]string) (*http.Response, *api.TimeSeriesResponse, *api.ErrorResponse, *api.ErrorResponse, error) {
+		resp, err := c.TimeSeriesBySitepairWithResponse(context.TODO(), id, nil, withParameters(params))
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		return resp.HTTPResponse, resp.JSON200, resp.JSON400, resp.JSON404, nil
+	}
+}
+
+func getProcessPairSeries(c api.ClientWithResponsesInterface, id string) func(map[string][]string) (*http.Response, *api.TimeSeriesResponse, *api.ErrorResponse, *api.ErrorResponse, error) {
+	return func(params map[string][]string) (*http.Response, *api.TimeSeriesResponse, *api.ErrorResponse, *api.ErrorResponse, error) {
+		resp, err := c.TimeSeriesByProcesspairWithResponse(context.TODO(), id, nil, withParameters(params))
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		return resp.HTTPResponse, resp.JSON200, resp.JSON400, resp.JSON404, nil
+	}
+}
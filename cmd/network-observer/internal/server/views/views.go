@@ -215,6 +215,15 @@ func NewConnectorProvider(graph collector.Graph) func(vanflow.ConnectorRecord) a
 	}
 }
 
+// RedactConnector removes the details of the process a connector targets,
+// for callers that may not see processes.
+func RedactConnector(out api.ConnectorRecord) api.ConnectorRecord {
+	out.ProcessId = ""
+	out.Target = nil
+	out.DestHost = unknownStr
+	return out
+}
+
 func defaultConnector(id string) api.ConnectorRecord {
 	return api.ConnectorRecord{
 		Identity:   id,
@@ -0,0 +1,405 @@
+// Package timeseries keeps rolling windows of application network traffic
+// aggregates in memory, so that service and pair trends can be served
+// without an external metrics store.
+//
+// Each series holds one bucket per resolution interval for the retention
+// period, counting bytes, opened and closed connections, connection
+// latencies and requests by HTTP status class. Buckets are allocated as
+// traffic is recorded and series without traffic for the retention period
+// are dropped.
+package timeseries
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultResolution = 15 * time.Second
+	DefaultRetention  = time.Hour
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the latency histogram
+// buckets. Histograms have one more bucket for greater latencies.
+var LatencyBuckets = []float64{0.001, 0.002, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}
+
+// StatusClasses are the HTTP response classes requests are counted by.
+var StatusClasses = []string{"1xx", "2xx", "3xx", "4xx", "5xx", "unknown"}
+
+type Kind string
+
+const (
+	KindService     Kind = "service"
+	KindSitePair    Kind = "sitepair"
+	KindProcessPair Kind = "processpair"
+)
+
+// Key identifies a series.
+type Key struct {
+	Kind     Kind
+	Source   string
+	Dest     string
+	Protocol string
+}
+
+// ServiceKey is the key of the series for a service, by its routing key
+// and protocol.
+func ServiceKey(address, protocol string) Key {
+	return Key{Kind: KindService, Source: address, Protocol: protocol}
+}
+
+// SitePairKey is the key of the series for traffic between two sites.
+func SitePairKey(source, dest, protocol string) Key {
+	return Key{Kind: KindSitePair, Source: source, Dest: dest, Protocol: protocol}
+}
+
+// ProcessPairKey is the key of the series for traffic between two
+// processes.
+func ProcessPairKey(source, dest, protocol string) Key {
+	return Key{Kind: KindProcessPair, Source: source, Dest: dest, Protocol: protocol}
+}
+
+type Config struct {
+	// Resolution is the interval covered by each bucket.
+	Resolution time.Duration
+	// Retention is how long buckets are kept.
+	Retention time.Duration
+}
+
+// Store holds the series of the network.
+type Store struct {
+	resolution time.Duration
+	slots      int
+	now        func() time.Time
+
+	mu     sync.RWMutex
+	series map[Key]*series
+}
+
+func New(cfg Config) *Store {
+	resolution, retention := cfg.Resolution, cfg.Retention
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	slots := int((retention + resolution - 1) / resolution)
+	return &Store{
+		resolution: resolution,
+		slots:      slots,
+		now:        time.Now,
+		series:     make(map[Key]*series),
+	}
+}
+
+// Resolution returns the interval covered by each bucket.
+func (s *Store) Resolution() time.Duration {
+	return s.resolution
+}
+
+// Retention returns how long buckets are kept.
+func (s *Store) Retention() time.Duration {
+	return s.resolution * time.Duration(s.slots)
+}
+
+// Step returns the interval aggregated by each point of a query with the
+// given step: the resolution when zero, otherwise the step rounded up to a
+// multiple of the resolution.
+func (s *Store) Step(step time.Duration) time.Duration {
+	return s.resolution * time.Duration(s.stepSlots(step))
+}
+
+func (s *Store) stepSlots(step time.Duration) int64 {
+	if step <= 0 {
+		return 1
+	}
+	return int64((step + s.resolution - 1) / s.resolution)
+}
+
+// Group returns a Group recording to the series of the given keys. A nil
+// Store returns a Group that records nothing.
+func (s *Store) Group(keys ...Key) Group {
+	if s == nil {
+		return Group{}
+	}
+	return Group{store: s, keys: keys}
+}
+
+// Run drops series without traffic for the retention period until the
+// context is cancelled.
+func (s *Store) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.Retention() / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.prune()
+		}
+	}
+}
+
+func (s *Store) prune() {
+	oldest := s.slot(s.now()) - int64(s.slots) + 1
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, ts := range s.series {
+		ts.mu.Lock()
+		idle := ts.latest < oldest
+		ts.mu.Unlock()
+		if idle {
+			delete(s.series, key)
+		}
+	}
+}
+
+func (s *Store) slot(t time.Time) int64 {
+	return t.UnixNano() / int64(s.resolution)
+}
+
+func (s *Store) getOrCreate(key Key) *series {
+	s.mu.RLock()
+	ts, ok := s.series[key]
+	s.mu.RUnlock()
+	if ok {
+		return ts
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ts, ok := s.series[key]; ok {
+		return ts
+	}
+	ts = &series{buckets: make([]*bucket, s.slots)}
+	s.series[key] = ts
+	return ts
+}
+
+func (s *Store) record(keys []Key, fn func(b *bucket)) {
+	slot := s.slot(s.now())
+	for _, key := range keys {
+		s.getOrCreate(key).record(slot, fn)
+	}
+}
+
+// Histogram counts latency observations by LatencyBuckets.
+type Histogram struct {
+	// Counts has the number of observations in each bucket, not
+	// cumulative, with a final bucket for observations greater than the
+	// last bound.
+	Counts []uint64
+	Count  uint64
+	// Sum of the observed latencies in seconds.
+	Sum float64
+}
+
+// Quantile estimates the q-quantile of the observations in seconds by
+// linear interpolation within the bucket containing it. Observations in the
+// final bucket are estimated at the last bound. It returns 0 when there are
+// no observations.
+func (h Histogram) Quantile(q float64) float64 {
+	if h.Count == 0 {
+		return 0
+	}
+	rank := q * float64(h.Count)
+	var cumulative uint64
+	for i, n := range h.Counts {
+		if n == 0 || float64(cumulative+n) < rank {
+			cumulative += n
+			continue
+		}
+		if i >= len(LatencyBuckets) {
+			break
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = LatencyBuckets[i-1]
+		}
+		return lower + (LatencyBuckets[i]-lower)*(rank-float64(cumulative))/float64(n)
+	}
+	return LatencyBuckets[len(LatencyBuckets)-1]
+}
+
+// Point is the aggregate of a series over one step.
+type Point struct {
+	Start             time.Time
+	BytesSent         uint64
+	BytesReceived     uint64
+	ConnectionsOpened uint64
+	ConnectionsClosed uint64
+	Latency           Histogram
+	// Requests counts requests by StatusClasses.
+	Requests map[string]uint64
+}
+
+var errInvalidQuery = errors.New("range and step must not be negative")
+
+// Query returns the points of a series, one per step, over the range
+// ending with the current bucket. A zero range or step defaults to the
+// retention period and the resolution. The step is rounded as by Step and
+// the range is limited to the retention period. Series without traffic return points with zero values.
+func (s *Store) Query(key Key, rng, step time.Duration) ([]Point, error) {
+	if rng < 0 || step < 0 {
+		return nil, errInvalidQuery
+	}
+	stepSlots := s.stepSlots(step)
+	rangeSlots := int64(s.slots)
+	if rng > 0 {
+		rangeSlots = min(int64((rng+s.resolution-1)/s.resolution), rangeSlots)
+	}
+	count := max((rangeSlots+stepSlots-1)/stepSlots, 1)
+	end := s.slot(s.now()) + 1
+	first := end - count*stepSlots
+
+	points := make([]Point, count)
+	for i := range points {
+		points[i] = Point{
+			Start:    time.Unix(0, (first+int64(i)*stepSlots)*int64(s.resolution)),
+			Latency:  Histogram{Counts: make([]uint64, len(LatencyBuckets)+1)},
+			Requests: make(map[string]uint64, len(StatusClasses)),
+		}
+		for _, class := range StatusClasses {
+			points[i].Requests[class] = 0
+		}
+	}
+	s.mu.RLock()
+	ts, ok := s.series[key]
+	s.mu.RUnlock()
+	if !ok {
+		return points, nil
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for slot := max(first, end-int64(s.slots)); slot < end; slot++ {
+		b := ts.buckets[slot%int64(len(ts.buckets))]
+		if b == nil || b.slot != slot {
+			continue
+		}
+		b.addTo(&points[(slot-first)/stepSlots])
+	}
+	return points, nil
+}
+
+type series struct {
+	mu      sync.Mutex
+	latest  int64
+	buckets []*bucket
+}
+
+func (ts *series) record(slot int64, fn func(b *bucket)) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	i := slot % int64(len(ts.buckets))
+	b := ts.buckets[i]
+	if b == nil {
+		b = &bucket{
+			latency:  make([]uint64, len(LatencyBuckets)+1),
+			requests: make([]uint64, len(StatusClasses)),
+		}
+		ts.buckets[i] = b
+	}
+	if b.slot != slot {
+		b.reset(slot)
+	}
+	ts.latest = max(ts.latest, slot)
+	fn(b)
+}
+
+type bucket struct {
+	slot          int64
+	bytesSent     uint64
+	bytesReceived uint64
+	opened        uint64
+	closed        uint64
+	latency       []uint64
+	latencySum    float64
+	requests      []uint64
+}
+
+func (b *bucket) reset(slot int64) {
+	b.slot = slot
+	b.bytesSent, b.bytesReceived = 0, 0
+	b.opened, b.closed = 0, 0
+	clear(b.latency)
+	b.latencySum = 0
+	clear(b.requests)
+}
+
+func (b *bucket) addTo(p *Point) {
+	p.BytesSent += b.bytesSent
+	p.BytesReceived += b.bytesReceived
+	p.ConnectionsOpened += b.opened
+	p.ConnectionsClosed += b.closed
+	for i, n := range b.latency {
+		p.Latency.Counts[i] += n
+		p.Latency.Count += n
+	}
+	p.Latency.Sum += b.latencySum
+	for i, n := range b.requests {
+		p.Requests[StatusClasses[i]] += n
+	}
+}
+
+// Group records traffic to a set of series. The zero Group records
+// nothing.
+type Group struct {
+	store *Store
+	keys  []Key
+}
+
+func (g Group) record(fn func(b *bucket)) {
+	if g.store == nil {
+		return
+	}
+	g.store.record(g.keys, fn)
+}
+
+// AddBytes records bytes sent from client to server and received back.
+func (g Group) AddBytes(sent, received uint64) {
+	g.record(func(b *bucket) {
+		b.bytesSent += sent
+		b.bytesReceived += received
+	})
+}
+
+// ConnectionOpened records a new connection.
+func (g Group) ConnectionOpened() {
+	g.record(func(b *bucket) {
+		b.opened++
+	})
+}
+
+// ConnectionClosed records a terminated connection.
+func (g Group) ConnectionClosed() {
+	g.record(func(b *bucket) {
+		b.closed++
+	})
+}
+
+// ObserveLatency records the latency of a connection in seconds.
+func (g Group) ObserveLatency(seconds float64) {
+	i := sort.SearchFloat64s(LatencyBuckets, seconds)
+	g.record(func(b *bucket) {
+		b.latency[i]++
+		b.latencySum += seconds
+	})
+}
+
+// Request records a request with a response in the given status class,
+// one of StatusClasses.
+func (g Group) Request(class string) {
+	i := len(StatusClasses) - 1
+	for j, c := range StatusClasses {
+		if c == class {
+			i = j
+			break
+		}
+	}
+	g.record(func(b *bucket) {
+		b.requests[i]++
+	})
+}
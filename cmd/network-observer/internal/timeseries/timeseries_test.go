@@ -0,0 +1,97 @@
+package timeseries
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestStore(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := New(Config{Resolution: 10 * time.Second, Retention: time.Minute})
+	s.now = func() time.Time { return now }
+	assert.Equal(t, s.Retention(), time.Minute)
+	assert.Equal(t, s.Step(0), 10*time.Second)
+	assert.Equal(t, s.Step(15*time.Second), 20*time.Second)
+
+	service := ServiceKey("backend:8080", "tcp")
+	pair := ProcessPairKey("p1", "p2", "tcp")
+	g := s.Group(service, pair)
+
+	g.ConnectionOpened()
+	g.AddBytes(100, 1000)
+	g.ObserveLatency(0.003)
+	now = now.Add(10 * time.Second)
+	g.AddBytes(50, 500)
+	g.ObserveLatency(5)
+	g.ConnectionClosed()
+	s.Group(service).Request("2xx")
+	s.Group(service).Request("5xx")
+	s.Group(service).Request("bogus")
+
+	points, err := s.Query(service, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(points), 6)
+	last, prev := points[5], points[4]
+	assert.Equal(t, last.Start, now)
+	assert.Equal(t, prev.Start, now.Add(-10*time.Second))
+	assert.Equal(t, prev.BytesSent, uint64(100))
+	assert.Equal(t, prev.BytesReceived, uint64(1000))
+	assert.Equal(t, prev.ConnectionsOpened, uint64(1))
+	assert.Equal(t, last.BytesSent, uint64(50))
+	assert.Equal(t, last.ConnectionsClosed, uint64(1))
+	assert.DeepEqual(t, prev.Latency.Counts, []uint64{0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	assert.DeepEqual(t, last.Latency.Counts, []uint64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	assert.Equal(t, last.Latency.Count, uint64(1))
+	assert.Equal(t, last.Latency.Sum, 5.0)
+	assert.Equal(t, last.Latency.Quantile(0.5), 2.5)
+	assert.Equal(t, prev.Latency.Quantile(0.5), 0.0035)
+	assert.Equal(t, points[0].Latency.Quantile(0.5), 0.0)
+	assert.DeepEqual(t, last.Requests, map[string]uint64{"1xx": 0, "2xx": 1, "3xx": 0, "4xx": 0, "5xx": 1, "unknown": 1})
+	assert.DeepEqual(t, points[0].Requests, map[string]uint64{"1xx": 0, "2xx": 0, "3xx": 0, "4xx": 0, "5xx": 0, "unknown": 0})
+
+	// steps are rounded up to the resolution and aggregate their buckets
+	points, err = s.Query(pair, 30*time.Second, 15*time.Second)
+	assert.NilError(t, err)
+	assert.Equal(t, len(points), 2)
+	assert.Equal(t, points[1].Start, now.Add(-10*time.Second))
+	assert.Equal(t, points[1].BytesSent, uint64(150))
+	assert.Equal(t, points[1].Latency.Count, uint64(2))
+	assert.Equal(t, points[1].Requests["2xx"], uint64(0))
+
+	// ranges are limited to the retention period
+	points, err = s.Query(pair, time.Hour, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(points), 6)
+
+	// series without traffic have zero values
+	points, err = s.Query(SitePairKey("s1", "s2", "tcp"), time.Minute, time.Minute)
+	assert.NilError(t, err)
+	assert.Equal(t, len(points), 1)
+	assert.Equal(t, points[0].BytesSent, uint64(0))
+
+	_, err = s.Query(service, -time.Minute, 0)
+	assert.ErrorContains(t, err, "must not be negative")
+
+	// buckets older than the retention period are reused
+	now = now.Add(time.Minute)
+	g.AddBytes(1, 1)
+	points, err = s.Query(service, 0, 0)
+	assert.NilError(t, err)
+	var sent uint64
+	for _, p := range points {
+		sent += p.BytesSent
+	}
+	assert.Equal(t, sent, uint64(1))
+
+	// idle series are dropped
+	s.Group(SitePairKey("s1", "s2", "tcp")).ConnectionOpened()
+	now = now.Add(time.Minute)
+	s.prune()
+	assert.Equal(t, len(s.series), 0)
+
+	// a nil store records nothing
+	var disabled *Store
+	disabled.Group(service).AddBytes(1, 1)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"net/http"
 	_ "net/http/pprof"
@@ -17,11 +18,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/alerts"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/api"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/auth"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/cmd"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/collector"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/flowlog"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/ipfix"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/otlp"
 	"github.com/skupperproject/skupper/cmd/network-observer/internal/server"
+	"github.com/skupperproject/skupper/cmd/network-observer/internal/timeseries"
 	"github.com/skupperproject/skupper/internal/version"
 	"github.com/skupperproject/skupper/pkg/vanflow"
 	"github.com/skupperproject/skupper/pkg/vanflow/session"
@@ -42,6 +48,32 @@ func run(cfg Config) error {
 		return fmt.Errorf("could not load spec filesystem: %s", err)
 	}
 
+	authenticators, err := cfg.authenticators()
+	if err != nil {
+		return fmt.Errorf("failed to configure api authentication: %s", err)
+	}
+	authEnabled := len(authenticators) > 0
+	var (
+		roles         auth.RoleMapping
+		operationTags auth.OperationTags
+	)
+	if len(cfg.AuthRolesFile) > 0 {
+		roles, err = auth.LoadRoleMapping(cfg.AuthRolesFile)
+		if err != nil {
+			return fmt.Errorf("failed to load auth-roles: %s", err)
+		}
+	}
+	if authEnabled {
+		spec, err := fs.ReadFile(specFS, "openapi.yaml")
+		if err != nil {
+			return fmt.Errorf("could not read api spec: %s", err)
+		}
+		operationTags, err = auth.ParseOperationTags(spec)
+		if err != nil {
+			return err
+		}
+	}
+
 	sessionConfig, err := configureSession(cfg.RouterTLS)
 	if err != nil {
 		return fmt.Errorf("failed to load router tls configuration: %s", err)
@@ -61,30 +93,131 @@ func run(cfg Config) error {
 		return fmt.Errorf("unknown logging profile: %s", cfg.VanflowLoggingProfile)
 	}
 
-	collector := collector.New(
+	retention, err := collector.ParseRetention(cfg.StorageRetention)
+	if err != nil {
+		return fmt.Errorf("error parsing storage-retention: %s", err)
+	}
+
+	collector, err := collector.New(
 		logger.With(slog.String("component", "collector")),
 		session.NewContainerFactory(cfg.RouterURL, sessionConfig),
 		reg,
 		cfg.FlowRecordTTL,
 		flowLogger,
+		collector.StorageConfig{
+			Path:      cfg.StoragePath,
+			Retention: retention,
+		},
 	)
+	if err != nil {
+		return fmt.Errorf("could not start collector: %s", err)
+	}
+
+	var otlpExporter *otlp.Exporter
+	if cfg.OTLPEndpoint != "" {
+		headers, err := parseOTLPHeaders(cfg.OTLPHeaders)
+		if err != nil {
+			return fmt.Errorf("error parsing otlp-headers: %s", err)
+		}
+		tlsConfig, err := cfg.OTLPTLS.config()
+		if err != nil {
+			return fmt.Errorf("failed to load otlp tls configuration: %s", err)
+		}
+		otlpExporter, err = otlp.New(
+			logger.With(slog.String("component", "otlp")),
+			otlp.Config{
+				Endpoint:        cfg.OTLPEndpoint,
+				Headers:         headers,
+				TLSConfig:       tlsConfig,
+				MetricsInterval: cfg.OTLPMetricsInterval,
+			},
+			reg,
+		)
+		if err != nil {
+			return fmt.Errorf("could not start otlp exporter: %s", err)
+		}
+		collector.OnFlowTerminated(otlpExporter.HandleFlow)
+	}
+
+	var ipfixExporter *ipfix.Exporter
+	if cfg.IPFIXCollector != "" {
+		ipfixExporter = ipfix.New(
+			logger.With(slog.String("component", "ipfix")),
+			ipfix.Config{
+				Address:                 cfg.IPFIXCollector,
+				ExportInterval:          cfg.IPFIXExportInterval,
+				TemplateRefreshInterval: cfg.IPFIXTemplateRefresh,
+				ObservationDomainID:     uint32(cfg.IPFIXObservationDomainID),
+				EnterpriseNumber:        uint32(cfg.IPFIXEnterpriseNumber),
+			},
+		)
+		collector.OnFlowTerminated(ipfixExporter.HandleFlow)
+	}
+
+	var series *timeseries.Store
+	if cfg.TimeSeriesRetention > 0 {
+		series = timeseries.New(timeseries.Config{
+			Resolution: cfg.TimeSeriesResolution,
+			Retention:  cfg.TimeSeriesRetention,
+		})
+		collector.RecordTimeSeries(series)
+	}
+
+	var alertManager *alerts.Manager
+	if cfg.AlertRulesFile != "" {
+		alertConfig, err := alerts.LoadConfig(cfg.AlertRulesFile)
+		if err != nil {
+			return err
+		}
+		alertManager, err = alerts.New(
+			logger.With(slog.String("component", "alerts")),
+			alertConfig,
+			collector.Records,
+			collector.GetGraph(),
+			series,
+			collector,
+		)
+		if err != nil {
+			return fmt.Errorf("could not start alert manager: %s", err)
+		}
+	}
 
 	collectorAPI := server.New(
 		logger.With(slog.String("component", "api")),
 		collector.Records,
 		collector.GetGraph(),
+		collector,
+		series,
+		alertManager,
 	)
 
 	var mux = mux.NewRouter().StrictSlash(true)
 	promSubrouter := mux.PathPrefix("/api/v2alpha1/internal/prom")
-	mux.Handle("/metrics", handleMetrics(reg))
+	metricsHandler := handleMetrics(reg)
+	if authEnabled {
+		scraper, err := cfg.scraper()
+		if err != nil {
+			return fmt.Errorf("failed to configure metrics authentication: %s", err)
+		}
+		metricsHandler = auth.Metrics(logger.With(slog.String("component", "auth")), authenticators, scraper, roles)(metricsHandler)
+	}
+	// the api spec remains unauthenticated
+	mux.Handle("/metrics", metricsHandler)
 	mux.PathPrefix("/swagger").Handler(handleSwagger("/swagger", specFS))
 	apiMux := mux.PathPrefix("/").Subrouter()
 	if cfg.CORSAllowAll {
 		apiMux.Use(handlers.CORS())
 	}
+	authenticate := func(next http.Handler) http.Handler { return next }
+	var apiMiddlewares []api.MiddlewareFunc
+	if authEnabled {
+		authenticate = auth.Authenticate(logger.With(slog.String("component", "auth")), authenticators, roles)
+		apiMux.Use(authenticate)
+		apiMiddlewares = append(apiMiddlewares, operationTags.Authorize)
+	}
 	api.HandlerWithOptions(collectorAPI, api.GorillaServerOptions{
-		BaseRouter: apiMux,
+		BaseRouter:  apiMux,
+		Middlewares: apiMiddlewares,
 	})
 
 	if cfg.EnableConsole {
@@ -95,7 +228,9 @@ func run(cfg Config) error {
 		// add unspec'd api routes
 		apiMux.Path("/api/v2alpha1/user").Handler(handleGetUser())
 		apiMux.Path("/api/v2alpha1/logout").Handler(handleUserLogout())
-		promSubrouter.Handler(handleProxyPrometheusAPI("/api/v2alpha1/internal/prom", promAPI))
+		promSubrouter.Handler(authenticate(auth.Require(auth.TagFlows)(
+			handleProxyPrometheusAPI("/api/v2alpha1/internal/prom", promAPI),
+		)))
 
 		apiMux.PathPrefix("/").Handler(handleSecuredConsoleAssets(cfg.ConsoleLocation))
 	}
@@ -114,7 +249,7 @@ func run(cfg Config) error {
 	}
 	tlsEnabled := cfg.APITLS.hasCert()
 	if tlsEnabled {
-		s.TLSConfig, err = cfg.APITLS.config()
+		s.TLSConfig, err = cfg.apiTLSConfig()
 		if err != nil {
 			return fmt.Errorf("could not set up certs for api server: %s", err)
 		}
@@ -125,6 +260,7 @@ func run(cfg Config) error {
 		logger.Info("Starting Network Console API Server",
 			slog.String("address", cfg.APIListenAddress),
 			slog.Bool("tls", tlsEnabled),
+			slog.Bool("auth", authEnabled),
 			slog.Bool("console", cfg.EnableConsole))
 		var err error
 		if tlsEnabled {
@@ -181,7 +317,7 @@ func run(cfg Config) error {
 	// serve metrics on a separate server if listen-metrics is set
 	if cfg.MetricsListenAddress != "" {
 		metricsMux := http.NewServeMux()
-		metricsMux.Handle("/metrics", handleMetrics(reg))
+		metricsMux.Handle("/metrics", metricsHandler)
 		metricsSrv := &http.Server{
 			Addr:         cfg.MetricsListenAddress,
 			Handler:      metricsMux,
@@ -211,6 +347,27 @@ func run(cfg Config) error {
 		})
 	}
 
+	if otlpExporter != nil {
+		g.Go(func() error {
+			return otlpExporter.Run(runCtx)
+		})
+	}
+	if ipfixExporter != nil {
+		g.Go(func() error {
+			return ipfixExporter.Run(runCtx)
+		})
+	}
+	if series != nil {
+		g.Go(func() error {
+			return series.Run(runCtx)
+		})
+	}
+	if alertManager != nil {
+		g.Go(func() error {
+			return alertManager.Run(runCtx)
+		})
+	}
+
 	g.Go(func() error {
 		logger.Debug("Starting Network Observer Collector")
 		if err := collector.Run(runCtx); err != nil {
@@ -241,12 +398,41 @@ func main() {
 	flags.BoolVar(&cfg.APIEnableAccessLogs, "enable-access-logs", false, "Enable access logging for the API Server")
 	flags.StringVar(&cfg.APITLS.Cert, "tls-cert", "", "Path to the API Server certificate file")
 	flags.StringVar(&cfg.APITLS.Key, "tls-key", "", "Path to the API Server certificate key file matching tls-cert")
+	flags.StringVar(&cfg.APIClientCA, "tls-client-ca", "", "Path to the CA certificate file used to verify API Server client certificates")
+
+	flags.BoolVar(&cfg.AuthClientCert, "auth-client-cert", false, "Authenticate API clients by TLS client certificate, with the subject common name as the username and organizations as groups. Requires tls-cert and tls-client-ca")
+	flags.StringVar(&cfg.AuthTokenFile, "auth-token-file", "", "Path to a CSV file of static bearer tokens used to authenticate API clients, with lines of the form token,user,uid,\"group1,group2\"")
+	flags.StringVar(&cfg.AuthHtpasswdFile, "auth-htpasswd-file", "", "Path to an htpasswd file used to authenticate API clients with basic auth. Supports bcrypt, {SHA}, {SSHA} and {PLAIN} passwords")
+	flags.StringVar(&cfg.OIDCIssuer, "auth-oidc-issuer", "", "Issuer of OIDC JWT bearer tokens used to authenticate API clients")
+	flags.StringVar(&cfg.OIDCAudience, "auth-oidc-audience", "", "Audience that OIDC tokens must be issued for. Required with auth-oidc-issuer")
+	flags.StringVar(&cfg.OIDCJWKSURL, "auth-oidc-jwks-url", "", "URL of the JSON Web Key Set used to verify OIDC tokens. Required with auth-oidc-issuer")
+	flags.StringVar(&cfg.OIDCUsernameClaim, "auth-oidc-username-claim", "sub", "OIDC token claim holding the username")
+	flags.StringVar(&cfg.OIDCGroupsClaim, "auth-oidc-groups-claim", "groups", "OIDC token claim holding the user's groups")
+	flags.StringVar(&cfg.AuthRolesFile, "auth-roles", "", "Path to a YAML file mapping users and groups to the full or topology role. Topology users cannot see processes or flows. All authenticated users have the full role when not set")
+	flags.StringVar(&cfg.AuthScrapeToken, "auth-metrics-token-file", "", "Path to a file holding a bearer token that can only be used to read /metrics, for Prometheus to scrape when authentication is enabled")
 
 	flags.BoolVar(&cfg.EnableConsole, "enable-console", true, "Enables the web console")
 	flags.StringVar(&cfg.ConsoleLocation, "console-location", "/app/console", "Location where the console assets are installed")
 	flags.StringVar(&cfg.PrometheusAPI, "prometheus-api", "http://127.0.0.1:9090", "Prometheus API HTTP endpoint for console")
 
 	flags.DurationVar(&cfg.FlowRecordTTL, "flow-record-ttl", 15*time.Minute, "How long to retain flow records in memory")
+	flags.StringVar(&cfg.StoragePath, "storage-path", "", "Path to a database file used to persist records across restarts. Records are only held in memory when not set")
+	flags.StringVar(&cfg.StorageRetention, "storage-retention", "", "Comma separated list of type=duration pairs setting how long terminated records of each type are retained when storage-path is set, e.g. connection=6h,request=1h. Connections and requests default to flow-record-ttl")
+	flags.DurationVar(&cfg.TimeSeriesResolution, "timeseries-resolution", timeseries.DefaultResolution, "Interval aggregated by each bucket of the in memory service, site pair and process pair time series")
+	flags.DurationVar(&cfg.TimeSeriesRetention, "timeseries-retention", timeseries.DefaultRetention, "How long in memory time series are retained. Set to 0 to disable the time series API")
+	flags.StringVar(&cfg.AlertRulesFile, "alert-rules", "", "Path to a YAML file of alert rules and the webhooks notified when their alerts fire and resolve. Alerting is disabled when not set")
+	flags.StringVar(&cfg.OTLPEndpoint, "otlp-endpoint", "", "Base URL of an OTLP/HTTP receiver to export connections, requests and service metrics to, e.g. http://otel-collector:4318. Export is disabled when not set")
+	flags.StringVar(&cfg.OTLPHeaders, "otlp-headers", "", "Comma separated list of key=value headers added to OTLP export requests")
+	flags.StringVar(&cfg.OTLPTLS.Cert, "otlp-tls-cert", "", "Path to the client certificate for the OTLP endpoint")
+	flags.StringVar(&cfg.OTLPTLS.Key, "otlp-tls-key", "", "Path to the client key for the OTLP endpoint")
+	flags.StringVar(&cfg.OTLPTLS.CA, "otlp-tls-ca", "", "Path to the CA certificate file for the OTLP endpoint")
+	flags.BoolVar(&cfg.OTLPTLS.SkipVerify, "otlp-tls-insecure", false, "Set to skip verification of the OTLP endpoint certificate and host name")
+	flags.DurationVar(&cfg.OTLPMetricsInterval, "otlp-metrics-interval", time.Minute, "How often service metrics are exported to the OTLP endpoint")
+	flags.StringVar(&cfg.IPFIXCollector, "ipfix-collector", "", "Address (host:port) of an IPFIX collector to export terminated connections to over UDP. Export is disabled when not set")
+	flags.DurationVar(&cfg.IPFIXExportInterval, "ipfix-export-interval", 10*time.Second, "How often queued IPFIX records are sent")
+	flags.DurationVar(&cfg.IPFIXTemplateRefresh, "ipfix-template-refresh", 10*time.Minute, "How often IPFIX templates are resent to the collector")
+	flags.UintVar(&cfg.IPFIXObservationDomainID, "ipfix-observation-domain", 0, "IPFIX observation domain ID identifying this network observer")
+	flags.UintVar(&cfg.IPFIXEnterpriseNumber, "ipfix-enterprise-number", ipfix.DefaultEnterpriseNumber, "Private enterprise number of the site, process and routing key IPFIX information elements")
 	flags.BoolVar(&cfg.CORSAllowAll, "cors-allow-all", false, "Development option to allow all origins")
 	flags.BoolVar(&cfg.EnableProfile, "profile", false, "Exposes the runtime profiling facilities from net/http/pprof on http://localhost:9970")
 
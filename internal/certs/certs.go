@@ -15,6 +15,9 @@ limitations under the License.
 package certs
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -30,24 +33,157 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// KeyAlgorithmSetting is the name of the setting, on either a
+// Certificate or a Site, through which the algorithm used to generate
+// private keys is chosen.
+const KeyAlgorithmSetting = "keyAlgorithm"
+
+const (
+	KeyAlgorithmRSA2048   = "rsa2048"
+	KeyAlgorithmRSA4096   = "rsa4096"
+	KeyAlgorithmECDSAP256 = "ecdsa-p256"
+	KeyAlgorithmECDSAP384 = "ecdsa-p384"
+	KeyAlgorithmEd25519   = "ed25519"
+
+	DefaultKeyAlgorithm = KeyAlgorithmRSA2048
+)
+
+var KeyAlgorithms = []string{
+	KeyAlgorithmRSA2048,
+	KeyAlgorithmRSA4096,
+	KeyAlgorithmECDSAP256,
+	KeyAlgorithmECDSAP384,
+	KeyAlgorithmEd25519,
+}
+
+// KeyAlgorithmFromSettings returns the key algorithm from the first of
+// the supplied settings that specifies one, e.g. a Certificate's
+// settings followed by those of its Site.
+func KeyAlgorithmFromSettings(settings ...map[string]string) string {
+	for _, s := range settings {
+		if value := s[KeyAlgorithmSetting]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func ValidateKeyAlgorithm(algorithm string) error {
+	if algorithm == "" {
+		return nil
+	}
+	for _, a := range KeyAlgorithms {
+		if a == algorithm {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported key algorithm %q, must be one of %s", algorithm, strings.Join(KeyAlgorithms, ", "))
+}
+
+// KeyAlgorithmOf returns the name of the algorithm used to generate the
+// key for the supplied certificate, or an empty string if it is not one
+// of those supported.
+func KeyAlgorithmOf(cert *x509.Certificate) string {
+	switch k := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		switch k.N.BitLen() {
+		case 2048:
+			return KeyAlgorithmRSA2048
+		case 4096:
+			return KeyAlgorithmRSA4096
+		}
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return KeyAlgorithmECDSAP256
+		case elliptic.P384():
+			return KeyAlgorithmECDSAP384
+		}
+	case ed25519.PublicKey:
+		return KeyAlgorithmEd25519
+	}
+	return ""
+}
+
+func generateKey(algorithm string) (interface{}, error) {
+	switch algorithm {
+	case "", KeyAlgorithmRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case KeyAlgorithmRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case KeyAlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case KeyAlgorithmEd25519:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	default:
+		return nil, ValidateKeyAlgorithm(algorithm)
+	}
+}
+
 func publicKey(priv interface{}) interface{} {
 	switch k := priv.(type) {
 	case *rsa.PrivateKey:
 		return &k.PublicKey
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey
+	case ed25519.PrivateKey:
+		return k.Public()
 	default:
 		return nil
 	}
 }
 
-func pemBlockForKey(priv interface{}) *pem.Block {
+func pemBlockForKey(priv interface{}) (*pem.Block, error) {
 	switch k := priv.(type) {
 	case *rsa.PrivateKey:
-		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}, nil
+	case *ecdsa.PrivateKey:
+		b, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: b}, nil
+	case ed25519.PrivateKey:
+		b, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "PRIVATE KEY", Bytes: b}, nil
 	default:
-		return nil
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
 	}
 }
 
+func parsePrivateKey(der []byte) (interface{}, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// The key encipherment usage only applies to RSA keys.
+func keyUsage(priv interface{}) x509.KeyUsage {
+	if _, ok := priv.(*rsa.PrivateKey); ok {
+		return x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature
+	}
+	return x509.KeyUsageDigitalSignature
+}
+
 type CertificateAuthority struct {
 	Certificate *x509.Certificate
 	Key         interface{}
@@ -83,7 +219,7 @@ func getCAFromSecret(secret *corev1.Secret) (*CertificateAuthority, error) {
 	if err != nil {
 		return nil, err
 	}
-	key, err := x509.ParsePKCS1PrivateKey(privateKeyBytes)
+	key, err := parsePrivateKey(privateKeyBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CA private key from secret %s", err)
 	}
@@ -101,14 +237,22 @@ func getCAFromSecret(secret *corev1.Secret) (*CertificateAuthority, error) {
 // hosts are the host names in the x509 certificate subject alternative names extension.
 // expiration is when the secret expires, if zero is passed in, the expiration is set to 5 years from now
 // ca is the certificate authority, if nil a ca cert will be created.
+// The private key is a 2048 bit RSA key.
 func GenerateSecret(name string, subject string, hosts []string, expiration time.Duration, ca *corev1.Secret) (*corev1.Secret, error) {
+	return GenerateSecretWithKeyAlgorithm(name, subject, hosts, expiration, ca, DefaultKeyAlgorithm)
+}
+
+// GenerateSecretWithKeyAlgorithm generates a kubernetes secret as for
+// GenerateSecret, using the named algorithm (one of KeyAlgorithms) for
+// the private key. An empty algorithm selects the default.
+func GenerateSecretWithKeyAlgorithm(name string, subject string, hosts []string, expiration time.Duration, ca *corev1.Secret, keyAlgorithm string) (*corev1.Secret, error) {
 	caCert, err := getCAFromSecret(ca)
 
 	if err != nil {
 		return nil, fmt.Errorf("error reading CA Certificate from Secret %q: %s", ca.Name, err)
 	}
 
-	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	priv, err := generateKey(keyAlgorithm)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate private key: %v", err)
 
@@ -133,7 +277,7 @@ func GenerateSecret(name string, subject string, hosts []string, expiration time
 		},
 		NotBefore:             notBefore,
 		NotAfter:              notAfter,
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		KeyUsage:              keyUsage(priv),
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
 		BasicConstraintsValid: true,
 	}
@@ -177,8 +321,12 @@ func GenerateSecret(name string, subject string, hosts []string, expiration time
 		Data: map[string][]byte{},
 	}
 
+	keyBlock, err := pemBlockForKey(priv)
+	if err != nil {
+		return nil, err
+	}
 	certString := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
-	keyString := pem.EncodeToMemory(pemBlockForKey(priv))
+	keyString := pem.EncodeToMemory(keyBlock)
 
 	secret.Data["tls.crt"] = []byte(certString)
 	secret.Data["tls.key"] = []byte(keyString)
@@ -191,6 +339,66 @@ func GenerateSecret(name string, subject string, hosts []string, expiration time
 	return &secret, nil
 }
 
+// RenewCASecret re-issues the self-signed CA certificate held in the
+// supplied secret with a new expiration. The private key, subject and
+// key identifier are retained, so certificates signed by the original
+// CA can still be verified against the renewed one.
+func RenewCASecret(ca *corev1.Secret, expiration time.Duration) (*corev1.Secret, error) {
+	caCert, err := getCAFromSecret(ca)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA Certificate from Secret %q: %s", ca.Name, err)
+	}
+	if caCert == nil {
+		return nil, fmt.Errorf("no CA Certificate found in Secret")
+	}
+	if !caCert.Certificate.IsCA {
+		return nil, fmt.Errorf("certificate in Secret %q is not a CA", ca.Name)
+	}
+
+	notBefore := time.Now()
+	if expiration == 0 {
+		expiration = 5 * 365 * 24 * time.Hour
+	}
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, err
+	}
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               caCert.Certificate.Subject,
+		SubjectKeyId:          caCert.Certificate.SubjectKeyId,
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(expiration),
+		KeyUsage:              caCert.Certificate.KeyUsage,
+		ExtKeyUsage:           caCert.Certificate.ExtKeyUsage,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, publicKey(caCert.Key), caCert.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	certString := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ca.Name,
+		},
+		Type: "kubernetes.io/tls",
+		Data: map[string][]byte{
+			"tls.crt": certString,
+			"tls.key": ca.Data["tls.key"],
+			"ca.crt":  certString,
+		},
+	}
+	return &secret, nil
+}
+
 func DecodeCertificate(data []byte) (*x509.Certificate, error) {
 	b, _ := pem.Decode(data)
 	if b == nil {
@@ -15,7 +15,10 @@ limitations under the License.
 package certs
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -86,6 +89,85 @@ func TestGenerateSecret(t *testing.T) {
 	assert.Equal(t, errorText, "error reading CA Certificate from Secret \"emptyCASecret\": failed to read PEM encoded data from \"tls.crt\"")
 }
 
+func TestRenewCASecret(t *testing.T) {
+	ca, err := GenerateSecret("my-ca", "my-ca", nil, time.Hour, nil)
+	assert.Assert(t, err)
+	leaf, err := GenerateSecret("my-cert", "my-cert", []string{"my-host"}, time.Hour*24, ca)
+	assert.Assert(t, err)
+
+	renewed, err := RenewCASecret(ca, time.Hour*48)
+	assert.Assert(t, err)
+	assert.Equal(t, renewed.Name, "my-ca")
+	assert.DeepEqual(t, renewed.Data["tls.key"], ca.Data["tls.key"])
+	assert.DeepEqual(t, renewed.Data["ca.crt"], renewed.Data["tls.crt"])
+
+	original, err := DecodeCertificate(ca.Data["tls.crt"])
+	assert.Assert(t, err)
+	renewedCert, err := DecodeCertificate(renewed.Data["tls.crt"])
+	assert.Assert(t, err)
+	assert.Assert(t, renewedCert.IsCA)
+	assert.Equal(t, renewedCert.Subject.CommonName, original.Subject.CommonName)
+	assert.DeepEqual(t, renewedCert.SubjectKeyId, original.SubjectKeyId)
+	assert.Assert(t, renewedCert.NotAfter.After(original.NotAfter))
+	assert.Assert(t, renewedCert.SerialNumber.Cmp(original.SerialNumber) != 0)
+
+	// certificates issued by the original CA are trusted by the renewed one
+	leafCert, err := DecodeCertificate(leaf.Data["tls.crt"])
+	assert.Assert(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(renewedCert)
+	_, err = leafCert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "my-host"})
+	assert.Assert(t, err)
+
+	_, err = RenewCASecret(leaf, time.Hour)
+	assert.ErrorContains(t, err, "is not a CA")
+}
+
+func TestGenerateSecretWithKeyAlgorithm(t *testing.T) {
+	for _, algorithm := range []string{"", KeyAlgorithmRSA2048, KeyAlgorithmRSA4096, KeyAlgorithmECDSAP256, KeyAlgorithmECDSAP384, KeyAlgorithmEd25519} {
+		t.Run("algorithm "+algorithm, func(t *testing.T) {
+			ca, err := GenerateSecretWithKeyAlgorithm("my-ca", "my-ca", nil, time.Hour, nil, algorithm)
+			assert.Assert(t, err)
+			leaf, err := GenerateSecretWithKeyAlgorithm("my-cert", "my-cert", []string{"my-host"}, time.Hour, ca, algorithm)
+			assert.Assert(t, err)
+
+			expected := algorithm
+			if expected == "" {
+				expected = DefaultKeyAlgorithm
+			}
+			caCert, err := DecodeCertificate(ca.Data["tls.crt"])
+			assert.Assert(t, err)
+			assert.Equal(t, KeyAlgorithmOf(caCert), expected)
+			leafCert, err := DecodeCertificate(leaf.Data["tls.crt"])
+			assert.Assert(t, err)
+			assert.Equal(t, KeyAlgorithmOf(leafCert), expected)
+
+			roots := x509.NewCertPool()
+			roots.AddCert(caCert)
+			_, err = leafCert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "my-host"})
+			assert.Assert(t, err)
+
+			// the key can be loaded for use in TLS and for signing
+			_, err = tls.X509KeyPair(leaf.Data["tls.crt"], leaf.Data["tls.key"])
+			assert.Assert(t, err)
+			renewed, err := RenewCASecret(ca, time.Hour*2)
+			assert.Assert(t, err)
+			renewedCert, err := DecodeCertificate(renewed.Data["tls.crt"])
+			assert.Assert(t, err)
+			assert.Equal(t, KeyAlgorithmOf(renewedCert), expected)
+		})
+	}
+	_, err := GenerateSecretWithKeyAlgorithm("my-ca", "my-ca", nil, time.Hour, nil, "dsa")
+	assert.ErrorContains(t, err, "unsupported key algorithm \"dsa\"")
+}
+
+func TestKeyAlgorithmFromSettings(t *testing.T) {
+	assert.Equal(t, KeyAlgorithmFromSettings(), "")
+	assert.Equal(t, KeyAlgorithmFromSettings(nil, map[string]string{"keyAlgorithm": "ed25519"}), "ed25519")
+	assert.Equal(t, KeyAlgorithmFromSettings(map[string]string{"keyAlgorithm": "ecdsa-p256"}, map[string]string{"keyAlgorithm": "ed25519"}), "ecdsa-p256")
+	assert.Equal(t, KeyAlgorithmFromSettings(map[string]string{"keyAlgorithm": ""}, map[string]string{"keyAlgorithm": "ed25519"}), "ed25519")
+}
+
 // TestGH2284 exercises the ability to generate and parse x509 certificates
 // with invalid empty DNS names.
 //
@@ -3,12 +3,13 @@ package common
 var (
 	LinkAccessTypes = []string{"route", "loadbalancer", "default"}
 	OutputTypes     = []string{"json", "yaml"}
-	ListenerTypes   = []string{"tcp"}
-	ConnectorTypes  = []string{"tcp"}
+	ListenerTypes   = []string{"tcp", "udp", "http", "http2"}
+	ConnectorTypes  = []string{"tcp", "udp", "http", "http2"}
 	WorkloadTypes   = []string{"deployment", "service", "daemonset", "statefulset"}
 	WaitStatusTypes = []string{"ready", "configured", "none"}
 	BundleTypes     = []string{"tarball", "shell-script"}
 	ReloadTypes     = []string{"manual", "auto"}
+	NetworkFormats  = []string{"tree", "table", "dot", "mermaid", "json", "yaml"}
 )
 
 const (
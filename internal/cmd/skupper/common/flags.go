@@ -47,7 +47,7 @@ for other Kubernetes flavors, loadbalancer is the default.`
 	FlagNameHost                = "host"
 	FlagDescHost                = "The hostname or IP address of the local connector"
 	FlagNameConnectorType       = "type"
-	FlagDescConnectorType       = "The connector type. Choices: [tcp]."
+	FlagDescConnectorType       = "The connector type. Choices: [tcp|udp|http|http2]."
 	FlagNameIncludeNotReadyPods = "include-not-ready"
 	FlagDescIncludeNotRead      = "If true, include server pods that are not in the ready state."
 	FlagNameSelector            = "selector"
@@ -61,13 +61,49 @@ for other Kubernetes flavors, loadbalancer is the default.`
 	FlagNameConnectorStatusOutput = "output"
 	FlagDescConnectorStatusOutput = "print status of connectors Choices: json, yaml"
 
+	FlagNameTokenOutput = "output"
+	FlagDescTokenOutput = "print details of tokens Choices: json, yaml"
+	FlagDescRevokeWait  = "wait for the revocation to be acknowledged by the grant server before returning"
+
+	FlagNameNetworkStatusOutput = "output"
+	FlagDescNetworkStatusOutput = "render the network status. Choices: tree, table, dot, mermaid, json, yaml"
+
+	FlagNameSiteNamespace      = "site-namespace"
+	FlagDescSiteNamespace      = "The namespace of the site the connector is attached to."
+	FlagNameConnectorNamespace = "connector-namespace"
+	FlagDescConnectorNamespace = "The namespace in which the attached connector is defined."
+	FlagNameUseClientCert      = "use-client-cert"
+	FlagDescUseClientCert      = "If true, the client certificate in the TLS credentials is presented to the target server pods."
+	FlagNameExposePodsByName   = "expose-pods-by-name"
+	FlagDescExposePodsByName   = "If true, expose each selected pod individually, using the pod name as the routing key suffix."
+
 	FlagNameListenerType = "type"
-	FlagDescListenerType = "The listener type. Choices: [tcp]."
+	FlagDescListenerType = "The listener type. Choices: [tcp|udp|http|http2]."
 	FlagNameListenerPort = "port"
 	FlagDescListenerPort = "The port of the local listener"
 	FlagNameListenerHost = "host"
 	FlagDescListenerHost = "The hostname or IP address of the local listener. Clients at this site use the listener host and port to establish connections to the remote service."
 
+	FlagNamePriority = "priority"
+	FlagDescPriority = "Routing keys to route traffic to, in order of highest to lowest priority."
+	FlagNameWeight   = "weight"
+	FlagDescWeight   = "Routing keys to route traffic to and their weights, expressed as key=weight."
+	FlagNameClosest  = "closest"
+	FlagDescClosest  = "Routing keys to route traffic to, preferring the ones with the lowest link cost from this site."
+
+	FlagNameRoles                   = "roles"
+	FlagDescRoles                   = "The roles through which the router can be accessed, expressed as name or name:port. Choices for name: [inter-router|edge]."
+	FlagNameAccessType              = "access-type"
+	FlagDescAccessType              = "The access type used to expose the router endpoints. Available access types are configured on the Skupper controller."
+	FlagNameGenerateTlsCredentials  = "generate-tls-credentials"
+	FlagDescGenerateTlsCredentials  = "generate the TLS credentials stored in the secret given by --tls-credentials."
+	FlagNameIssuer                  = "issuer"
+	FlagDescIssuer                  = "The name of the signing CA used to generate the TLS credentials."
+	FlagNameBindHost                = "bind-host"
+	FlagDescBindHost                = "The hostname or IP address of the network interface to bind to. By default, all the interfaces on the host are used."
+	FlagNameSubjectAlternativeNames = "subject-alternative-names"
+	FlagDescSubjectAlternativeNames = "The hostnames and IPs secured by the router TLS certificate."
+
 	FlagNameForce = "force"
 
 	FlagNameWait       = "wait"
@@ -164,6 +200,23 @@ type CommandTokenRedeemFlags struct {
 	Timeout time.Duration
 }
 
+type CommandNetworkStatusFlags struct {
+	Output string
+}
+
+type CommandTokenListFlags struct {
+	Output string
+}
+
+type CommandTokenInspectFlags struct {
+	Output string
+}
+
+type CommandTokenRevokeFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
 type CommandConnectorCreateFlags struct {
 	RoutingKey          string
 	Host                string
@@ -209,6 +262,37 @@ type CommandConnectorGenerateFlags struct {
 	Output              string
 }
 
+type CommandConnectorAttachFlags struct {
+	SiteNamespace       string
+	Selector            string
+	Workload            string
+	TlsCredentials      string
+	UseClientCert       bool
+	ConnectorType       string
+	IncludeNotReadyPods bool
+	Timeout             time.Duration
+	Wait                string
+}
+
+type CommandConnectorBindFlags struct {
+	ConnectorNamespace string
+	RoutingKey         string
+	ExposePodsByName   bool
+	Timeout            time.Duration
+	Wait               string
+}
+
+type CommandConnectorAttachStatusFlags struct {
+	SiteNamespace string
+	Output        string
+}
+
+type CommandConnectorDetachFlags struct {
+	SiteNamespace string
+	Timeout       time.Duration
+	Wait          bool
+}
+
 type CommandListenerCreateFlags struct {
 	RoutingKey     string
 	Host           string
@@ -245,6 +329,89 @@ type CommandListenerGenerateFlags struct {
 	Output         string
 }
 
+type CommandMultiKeyListenerCreateFlags struct {
+	Host           string
+	TlsCredentials string
+	Priority       []string
+	Weight         map[string]int
+	Closest        []string
+	Timeout        time.Duration
+	Wait           string
+}
+
+type CommandMultiKeyListenerUpdateFlags struct {
+	Host           string
+	TlsCredentials string
+	Priority       []string
+	Weight         map[string]int
+	Closest        []string
+	Timeout        time.Duration
+	Port           int
+	Wait           string
+}
+
+type CommandMultiKeyListenerStatusFlags struct {
+	Output string
+}
+
+type CommandMultiKeyListenerDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandMultiKeyListenerGenerateFlags struct {
+	Host           string
+	TlsCredentials string
+	Priority       []string
+	Weight         map[string]int
+	Closest        []string
+	Output         string
+}
+
+type CommandRouterAccessCreateFlags struct {
+	Roles                   []string
+	AccessType              string
+	TlsCredentials          string
+	GenerateTlsCredentials  bool
+	Issuer                  string
+	BindHost                string
+	SubjectAlternativeNames []string
+	Timeout                 time.Duration
+	Wait                    string
+}
+
+type CommandRouterAccessUpdateFlags struct {
+	Roles                   []string
+	AccessType              string
+	TlsCredentials          string
+	GenerateTlsCredentials  bool
+	Issuer                  string
+	BindHost                string
+	SubjectAlternativeNames []string
+	Timeout                 time.Duration
+	Wait                    string
+}
+
+type CommandRouterAccessStatusFlags struct {
+	Output string
+}
+
+type CommandRouterAccessDeleteFlags struct {
+	Timeout time.Duration
+	Wait    bool
+}
+
+type CommandRouterAccessGenerateFlags struct {
+	Roles                   []string
+	AccessType              string
+	TlsCredentials          string
+	GenerateTlsCredentials  bool
+	Issuer                  string
+	BindHost                string
+	SubjectAlternativeNames []string
+	Output                  string
+}
+
 type CommandVersionFlags struct {
 	Output string
 }
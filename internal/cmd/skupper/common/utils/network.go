@@ -0,0 +1,298 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/network"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// NetworkStatus is the view of the network rendered by "skupper network
+// status", as seen from one site.
+type NetworkStatus struct {
+	LocalSite                 string                `json:"localSite,omitempty"`
+	Sites                     []v2alpha1.SiteRecord `json:"sites"`
+	ServicesWithoutConnectors []string              `json:"servicesWithoutConnectors,omitempty"`
+	LinksNotOperational       []string              `json:"linksNotOperational,omitempty"`
+}
+
+// NewNetworkStatus returns the status of the network reported by the site
+// with the given id. Sites are sorted by name so that the output is
+// stable between invocations.
+func NewNetworkStatus(localSiteId string, sites []v2alpha1.SiteRecord) *NetworkStatus {
+	sorted := make([]v2alpha1.SiteRecord, len(sites))
+	copy(sorted, sites)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return siteLabel(sorted[i]) < siteLabel(sorted[j])
+	})
+	status := &NetworkStatus{
+		LocalSite:                 localSiteId,
+		Sites:                     sorted,
+		ServicesWithoutConnectors: network.ServicesWithoutConnectors(sorted),
+	}
+	for _, site := range sorted {
+		for _, link := range site.Links {
+			if !link.Operational {
+				status.LinksNotOperational = append(status.LinksNotOperational, fmt.Sprintf("%s/%s", siteLabel(site), link.Name))
+			}
+		}
+	}
+	return status
+}
+
+// RenderNetworkStatus writes the network status in the given format, one
+// of tree, table, dot, mermaid, json or yaml.
+func RenderNetworkStatus(w io.Writer, format string, status *NetworkStatus) error {
+	switch format {
+	case "", "tree":
+		renderNetworkTree(w, status)
+	case "table":
+		renderNetworkTable(w, status)
+	case "dot":
+		renderNetworkDot(w, status)
+	case "mermaid":
+		renderNetworkMermaid(w, status)
+	default:
+		encoded, err := Encode(format, status)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, encoded)
+	}
+	return nil
+}
+
+func siteLabel(site v2alpha1.SiteRecord) string {
+	if site.Namespace != "" && site.Namespace != site.Name {
+		return site.Name + "." + site.Namespace
+	}
+	if site.Name != "" {
+		return site.Name
+	}
+	return site.Id
+}
+
+func remoteSiteLabel(link v2alpha1.LinkRecord, sites map[string]v2alpha1.SiteRecord) string {
+	if site, ok := sites[link.RemoteSiteId]; ok {
+		return siteLabel(site)
+	}
+	if link.RemoteSiteName != "" {
+		return link.RemoteSiteName
+	}
+	return "unknown"
+}
+
+func sitesById(sites []v2alpha1.SiteRecord) map[string]v2alpha1.SiteRecord {
+	byId := map[string]v2alpha1.SiteRecord{}
+	for _, site := range sites {
+		byId[site.Id] = site
+	}
+	return byId
+}
+
+func linkState(link v2alpha1.LinkRecord) string {
+	if link.Operational {
+		return "operational"
+	}
+	return "not operational"
+}
+
+func renderNetworkTree(w io.Writer, status *NetworkStatus) {
+	byId := sitesById(status.Sites)
+	unmatched := map[string]bool{}
+	for _, key := range status.ServicesWithoutConnectors {
+		unmatched[key] = true
+	}
+
+	fmt.Fprintf(w, "Network: %d site(s)\n", len(status.Sites))
+	for i, site := range status.Sites {
+		branch, indent := "├── ", "│   "
+		if i == len(status.Sites)-1 {
+			branch, indent = "└── ", "    "
+		}
+		local := ""
+		if site.Id == status.LocalSite {
+			local = " (local)"
+		}
+		details := ""
+		if platform := strings.TrimSpace(site.Platform + " " + site.Version); platform != "" {
+			details = " [" + platform + "]"
+		}
+		fmt.Fprintf(w, "%s%s%s%s\n", branch, siteLabel(site), local, details)
+
+		var children []string
+		for _, link := range site.Links {
+			children = append(children, fmt.Sprintf("link %s -> %s (cost %d, %s)", link.Name, remoteSiteLabel(link, byId), link.Cost, linkState(link)))
+		}
+		for _, service := range site.Services {
+			key := network.ServiceKey(service.RoutingKey, service.Protocol)
+			child := fmt.Sprintf("service %s: %d listener(s), %d connector(s)", key, len(service.Listeners), len(service.Connectors))
+			if unmatched[key] && len(service.Listeners) > 0 {
+				child += " (no connectors in network)"
+			}
+			children = append(children, child)
+		}
+		for j, child := range children {
+			if j == len(children)-1 {
+				fmt.Fprintf(w, "%s└── %s\n", indent, child)
+			} else {
+				fmt.Fprintf(w, "%s├── %s\n", indent, child)
+			}
+		}
+	}
+	renderNetworkProblems(w, status)
+}
+
+func renderNetworkProblems(w io.Writer, status *NetworkStatus) {
+	if len(status.ServicesWithoutConnectors) > 0 {
+		fmt.Fprintln(w, "\nRouting keys with listeners but no connectors:")
+		for _, key := range status.ServicesWithoutConnectors {
+			fmt.Fprintf(w, "  - %s\n", key)
+		}
+	}
+	if len(status.LinksNotOperational) > 0 {
+		fmt.Fprintln(w, "\nLinks not operational:")
+		for _, link := range status.LinksNotOperational {
+			fmt.Fprintf(w, "  - %s\n", link)
+		}
+	}
+}
+
+func renderNetworkTable(w io.Writer, status *NetworkStatus) {
+	byId := sitesById(status.Sites)
+	tw := tabwriter.NewWriter(w, 8, 8, 1, '\t', tabwriter.TabIndent)
+
+	fmt.Fprintln(tw, "SITE\tNAMESPACE\tPLATFORM\tVERSION\tLINKS\tSERVICES")
+	for _, site := range status.Sites {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\n", site.Name, site.Namespace, site.Platform, site.Version, len(site.Links), len(site.Services))
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "SITE\tLINK\tREMOTE-SITE\tCOST\tOPERATIONAL")
+	for _, site := range status.Sites {
+		for _, link := range site.Links {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%t\n", siteLabel(site), link.Name, remoteSiteLabel(link, byId), link.Cost, link.Operational)
+		}
+	}
+	fmt.Fprintln(tw)
+
+	type service struct {
+		listeners  []string
+		connectors []string
+	}
+	services := map[string]*service{}
+	var keys []string
+	for _, site := range status.Sites {
+		for _, record := range site.Services {
+			key := network.ServiceKey(record.RoutingKey, record.Protocol)
+			if _, ok := services[key]; !ok {
+				services[key] = &service{}
+				keys = append(keys, key)
+			}
+			if len(record.Listeners) > 0 {
+				services[key].listeners = append(services[key].listeners, siteLabel(site))
+			}
+			if len(record.Connectors) > 0 {
+				services[key].connectors = append(services[key].connectors, siteLabel(site))
+			}
+		}
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(tw, "ROUTING-KEY\tLISTENER-SITES\tCONNECTOR-SITES\tMATCHED")
+	for _, key := range keys {
+		s := services[key]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", key, strings.Join(s.listeners, ","), strings.Join(s.connectors, ","),
+			len(s.listeners) > 0 && len(s.connectors) > 0)
+	}
+	_ = tw.Flush()
+}
+
+// networkNodes assigns a stable identifier to each site, and to each
+// remote site that is only known by name from a link.
+func networkNodes(status *NetworkStatus) (map[string]string, []string) {
+	ids := map[string]string{}
+	var order []string
+	add := func(key string) {
+		if _, ok := ids[key]; !ok {
+			ids[key] = fmt.Sprintf("site%d", len(ids))
+			order = append(order, key)
+		}
+	}
+	byId := sitesById(status.Sites)
+	for _, site := range status.Sites {
+		add(site.Id)
+	}
+	for _, site := range status.Sites {
+		for _, link := range site.Links {
+			if _, ok := byId[link.RemoteSiteId]; !ok {
+				add(remoteSiteLabel(link, byId))
+			}
+		}
+	}
+	return ids, order
+}
+
+func linkTarget(link v2alpha1.LinkRecord, byId map[string]v2alpha1.SiteRecord) string {
+	if _, ok := byId[link.RemoteSiteId]; ok {
+		return link.RemoteSiteId
+	}
+	return remoteSiteLabel(link, byId)
+}
+
+func nodeLabel(key string, byId map[string]v2alpha1.SiteRecord) string {
+	if site, ok := byId[key]; ok {
+		return siteLabel(site)
+	}
+	return key
+}
+
+func renderNetworkDot(w io.Writer, status *NetworkStatus) {
+	byId := sitesById(status.Sites)
+	ids, order := networkNodes(status)
+
+	fmt.Fprintln(w, "digraph network {")
+	for _, key := range order {
+		attributes := ""
+		if key == status.LocalSite {
+			attributes = ", style=bold"
+		}
+		fmt.Fprintf(w, "  %s [label=%q%s];\n", ids[key], nodeLabel(key, byId), attributes)
+	}
+	for _, site := range status.Sites {
+		for _, link := range site.Links {
+			attributes := ""
+			if !link.Operational {
+				attributes = ", style=dashed, color=red"
+			}
+			fmt.Fprintf(w, "  %s -> %s [label=%q%s];\n", ids[site.Id], ids[linkTarget(link, byId)],
+				fmt.Sprintf("%s (cost %d)", link.Name, link.Cost), attributes)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func renderNetworkMermaid(w io.Writer, status *NetworkStatus) {
+	byId := sitesById(status.Sites)
+	ids, order := networkNodes(status)
+
+	fmt.Fprintln(w, "graph LR")
+	for _, key := range order {
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", ids[key], strings.ReplaceAll(nodeLabel(key, byId), "\"", "#quot;"))
+		if key == status.LocalSite {
+			fmt.Fprintf(w, "  style %s stroke-width:3px\n", ids[key])
+		}
+	}
+	for _, site := range status.Sites {
+		for _, link := range site.Links {
+			arrow := "-->"
+			if !link.Operational {
+				arrow = "-.->"
+			}
+			fmt.Fprintf(w, "  %s %s|\"%s (cost %d)\"| %s\n", ids[site.Id], arrow, link.Name, link.Cost, ids[linkTarget(link, byId)])
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+)
+
+func testNetwork() []v2alpha1.SiteRecord {
+	return []v2alpha1.SiteRecord{
+		{
+			Id:        "east-id",
+			Name:      "east",
+			Namespace: "east",
+			Platform:  "kubernetes",
+			Version:   "2.0.0",
+			Services: []v2alpha1.ServiceRecord{
+				{RoutingKey: "backend", Connectors: []string{"10.0.0.1"}},
+			},
+		},
+		{
+			Id:        "west-id",
+			Name:      "west",
+			Namespace: "west",
+			Platform:  "podman",
+			Version:   "2.0.0",
+			Links: []v2alpha1.LinkRecord{
+				{Name: "to-east", RemoteSiteId: "east-id", RemoteSiteName: "east", Operational: true, Cost: 1},
+				{Name: "to-north", RemoteSiteName: "north", Cost: 5},
+			},
+			Services: []v2alpha1.ServiceRecord{
+				{RoutingKey: "backend", Listeners: []string{"backend"}},
+				{RoutingKey: "frontend", Listeners: []string{"frontend"}},
+			},
+		},
+	}
+}
+
+func TestNewNetworkStatus(t *testing.T) {
+	status := NewNetworkStatus("west-id", testNetwork())
+	assert.Equal(t, status.LocalSite, "west-id")
+	assert.Equal(t, status.Sites[0].Name, "east")
+	assert.DeepEqual(t, status.ServicesWithoutConnectors, []string{"frontend"})
+	assert.DeepEqual(t, status.LinksNotOperational, []string{"west/to-north"})
+}
+
+func TestRenderNetworkStatus(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected []string
+	}{
+		{
+			format: "tree",
+			expected: []string{
+				"Network: 2 site(s)",
+				"├── east [kubernetes 2.0.0]",
+				"│   └── service backend: 0 listener(s), 1 connector(s)",
+				"└── west (local) [podman 2.0.0]",
+				"    ├── link to-east -> east (cost 1, operational)",
+				"    ├── link to-north -> north (cost 5, not operational)",
+				"    └── service frontend: 1 listener(s), 0 connector(s) (no connectors in network)",
+				"Routing keys with listeners but no connectors:\n  - frontend",
+				"Links not operational:\n  - west/to-north",
+			},
+		},
+		{
+			format: "table",
+			expected: []string{
+				"ROUTING-KEY",
+				"to-north",
+				"frontend\twest",
+			},
+		},
+		{
+			format: "dot",
+			expected: []string{
+				"digraph network {",
+				"site0 [label=\"east\"];",
+				"site1 [label=\"west\", style=bold];",
+				"site2 [label=\"north\"];",
+				"site1 -> site0 [label=\"to-east (cost 1)\"];",
+				"site1 -> site2 [label=\"to-north (cost 5)\", style=dashed, color=red];",
+			},
+		},
+		{
+			format: "mermaid",
+			expected: []string{
+				"graph LR",
+				"site1[\"west\"]\n  style site1 stroke-width:3px",
+				"site1 -->|\"to-east (cost 1)\"| site0",
+				"site1 -.->|\"to-north (cost 5)\"| site2",
+			},
+		},
+		{
+			format: "yaml",
+			expected: []string{
+				"localSite: west-id",
+				"servicesWithoutConnectors:\n- frontend",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			var out bytes.Buffer
+			assert.Assert(t, RenderNetworkStatus(&out, test.format, NewNetworkStatus("west-id", testNetwork())))
+			for _, expected := range test.expected {
+				assert.Assert(t, strings.Contains(out.String(), expected), "expected %q in:\n%s", expected, out.String())
+			}
+		})
+	}
+
+	var out bytes.Buffer
+	assert.Error(t, RenderNetworkStatus(&out, "xml", NewNetworkStatus("", nil)), "format xml not supported")
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+var routerAccessRoles = []string{"inter-router", "edge"}
+
+// RouterAccessRoles parses the values given to the roles flag, each one
+// expressed as name or name:port, into the roles of a RouterAccess. When
+// the port is omitted the default port for the role is used.
+func RouterAccessRoles(roles []string) ([]v2alpha1.RouterAccessRole, error) {
+	var result []v2alpha1.RouterAccessRole
+	var validationErrors []error
+	roleValidator := validator.NewOptionValidator(routerAccessRoles)
+	numberValidator := validator.NumberValidator{PositiveInt: true}
+
+	for _, value := range roles {
+		name, port, hasPort := strings.Cut(value, ":")
+		role := v2alpha1.RouterAccessRole{Name: name}
+		if ok, err := roleValidator.Evaluate(name); !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("role %q is not valid: %s", name, err))
+			continue
+		}
+		if slices.ContainsFunc(result, func(r v2alpha1.RouterAccessRole) bool { return r.Name == name }) {
+			validationErrors = append(validationErrors, fmt.Errorf("role %q is specified more than once", name))
+			continue
+		}
+		if hasPort {
+			var err error
+			role.Port, err = strconv.Atoi(port)
+			if err != nil {
+				validationErrors = append(validationErrors, fmt.Errorf("port for role %q is not valid: %s", name, err))
+				continue
+			}
+			if ok, err := numberValidator.Evaluate(role.Port); !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("port for role %q is not valid: %s", name, err))
+				continue
+			}
+		} else {
+			role.Port = int(role.GetPort())
+		}
+		result = append(result, role)
+	}
+
+	if len(validationErrors) > 0 {
+		return nil, errors.Join(validationErrors...)
+	}
+	return result, nil
+}
+
+// RouterAccessRolesString renders the roles of a RouterAccess as a comma
+// separated list of name:port values.
+func RouterAccessRolesString(roles []v2alpha1.RouterAccessRole) string {
+	var values []string
+	for _, role := range roles {
+		values = append(values, fmt.Sprintf("%s:%d", role.Name, role.GetPort()))
+	}
+	return strings.Join(values, ",")
+}
+
+// RouterAccessEndpoints renders the endpoints provisioned for a
+// RouterAccess as a comma separated list of name=host:port values.
+func RouterAccessEndpoints(endpoints []v2alpha1.Endpoint) string {
+	var values []string
+	for _, endpoint := range endpoints {
+		values = append(values, fmt.Sprintf("%s=%s:%s", endpoint.Name, endpoint.Host, endpoint.Port))
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+)
+
+func TestRouterAccessRoles(t *testing.T) {
+	tests := []struct {
+		name          string
+		roles         []string
+		expected      []v2alpha1.RouterAccessRole
+		expectedError string
+	}{
+		{
+			name:  "default ports",
+			roles: []string{"inter-router", "edge"},
+			expected: []v2alpha1.RouterAccessRole{
+				{Name: "inter-router", Port: 55671},
+				{Name: "edge", Port: 45671},
+			},
+		},
+		{
+			name:  "explicit ports",
+			roles: []string{"inter-router:9090", "edge:9091"},
+			expected: []v2alpha1.RouterAccessRole{
+				{Name: "inter-router", Port: 9090},
+				{Name: "edge", Port: 9091},
+			},
+		},
+		{
+			name:          "unknown role",
+			roles:         []string{"normal"},
+			expectedError: "role \"normal\" is not valid: value normal not allowed. It should be one of this options: [inter-router edge]",
+		},
+		{
+			name:          "duplicate role",
+			roles:         []string{"edge", "edge:1234"},
+			expectedError: "role \"edge\" is specified more than once",
+		},
+		{
+			name:          "port not a number",
+			roles:         []string{"edge:abc"},
+			expectedError: "port for role \"edge\" is not valid: strconv.Atoi: parsing \"abc\": invalid syntax",
+		},
+		{
+			name:          "port not positive",
+			roles:         []string{"edge:-1"},
+			expectedError: "port for role \"edge\" is not valid: value is not positive",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roles, err := RouterAccessRoles(test.roles)
+			if test.expectedError != "" {
+				assert.Error(t, err, test.expectedError)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, roles, test.expected)
+			}
+		})
+	}
+}
+
+func TestRouterAccessEndpoints(t *testing.T) {
+	endpoints := []v2alpha1.Endpoint{
+		{Name: "inter-router", Host: "10.0.0.1", Port: "55671"},
+		{Name: "edge", Host: "10.0.0.1", Port: "45671"},
+	}
+	assert.Equal(t, RouterAccessEndpoints(nil), "")
+	assert.Equal(t, RouterAccessEndpoints(endpoints), "inter-router=10.0.0.1:55671,edge=10.0.0.1:45671")
+	assert.Equal(t, RouterAccessRolesString([]v2alpha1.RouterAccessRole{{Name: "edge"}, {Name: "inter-router", Port: 1}}), "edge:45671,inter-router:1")
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// MultiKeyListenerStrategy builds the strategy of a MultiKeyListener from
+// the routing keys given to the priority, weight and closest flags. Exactly
+// one of them must be set.
+func MultiKeyListenerStrategy(priority []string, weight map[string]int, closest []string) (v2alpha1.MultiKeyListenerStrategy, error) {
+	var strategy v2alpha1.MultiKeyListenerStrategy
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+
+	count := 0
+	for _, set := range []bool{len(priority) > 0, len(weight) > 0, len(closest) > 0} {
+		if set {
+			count++
+		}
+	}
+	if count == 0 {
+		return strategy, fmt.Errorf("one of --priority, --weight or --closest must be specified")
+	} else if count > 1 {
+		return strategy, fmt.Errorf("only one of --priority, --weight or --closest can be specified")
+	}
+
+	validateKeys := func(keys []string) {
+		for i, key := range keys {
+			ok, err := resourceStringValidator.Evaluate(key)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("routing key %q is not valid: %s", key, err))
+			} else if slices.Contains(keys[:i], key) {
+				validationErrors = append(validationErrors, fmt.Errorf("routing key %q is specified more than once", key))
+			}
+		}
+	}
+
+	if len(priority) > 0 {
+		validateKeys(priority)
+		strategy.Priority = &v2alpha1.PriorityStrategySpec{
+			RoutingKeys: priority,
+		}
+	} else if len(closest) > 0 {
+		validateKeys(closest)
+		strategy.Closest = &v2alpha1.ClosestStrategySpec{
+			RoutingKeys: closest,
+		}
+	} else {
+		routingKeys := map[string]uint{}
+		for key, value := range weight {
+			ok, err := resourceStringValidator.Evaluate(key)
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("routing key %q is not valid: %s", key, err))
+			} else if value < 0 {
+				validationErrors = append(validationErrors, fmt.Errorf("weight for routing key %q is not valid: must not be negative", key))
+			} else {
+				routingKeys[key] = uint(value)
+			}
+		}
+		strategy.Weighted = &v2alpha1.WeightedStrategySpec{
+			RoutingKeys: routingKeys,
+		}
+	}
+
+	return strategy, errors.Join(validationErrors...)
+}
+
+// MultiKeyListenerStrategyName returns the name of the strategy in use.
+func MultiKeyListenerStrategyName(strategy v2alpha1.MultiKeyListenerStrategy) string {
+	if strategy.Priority != nil {
+		return "priority"
+	} else if strategy.Weighted != nil {
+		return "weighted"
+	} else if strategy.Closest != nil {
+		return "closest"
+	}
+	return ""
+}
+
+// MultiKeyListenerReachableKeys returns the reachable routing keys reported
+// in the status as a comma separated list. Keys for the priority and closest
+// strategies are kept in order, weighted keys are sorted and shown with
+// their weight.
+func MultiKeyListenerReachableKeys(status *v2alpha1.StrategyStatus) string {
+	if status == nil {
+		return ""
+	}
+	if status.Priority != nil {
+		return strings.Join(status.Priority.RoutingKeysReachable, ",")
+	} else if status.Closest != nil {
+		return strings.Join(status.Closest.RoutingKeysReachable, ",")
+	} else if status.Weighted != nil {
+		var keys []string
+		for key, weight := range status.Weighted.RoutingKeysReachable {
+			keys = append(keys, fmt.Sprintf("%s=%d", key, weight))
+		}
+		sort.Strings(keys)
+		return strings.Join(keys, ",")
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+)
+
+func TestMultiKeyListenerStrategy(t *testing.T) {
+	tests := []struct {
+		name          string
+		priority      []string
+		weight        map[string]int
+		closest       []string
+		expected      v2alpha1.MultiKeyListenerStrategy
+		expectedError string
+	}{
+		{
+			name:          "no strategy",
+			expectedError: "one of --priority, --weight or --closest must be specified",
+		},
+		{
+			name:          "more than one strategy",
+			priority:      []string{"a"},
+			weight:        map[string]int{"b": 1},
+			expectedError: "only one of --priority, --weight or --closest can be specified",
+		},
+		{
+			name:     "priority",
+			priority: []string{"a", "b"},
+			expected: v2alpha1.MultiKeyListenerStrategy{
+				Priority: &v2alpha1.PriorityStrategySpec{RoutingKeys: []string{"a", "b"}},
+			},
+		},
+		{
+			name:          "priority duplicated key",
+			priority:      []string{"a", "a"},
+			expectedError: "routing key \"a\" is specified more than once",
+		},
+		{
+			name:          "priority invalid key",
+			priority:      []string{"a", "B@d"},
+			expectedError: "routing key \"B@d\" is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:   "weighted",
+			weight: map[string]int{"a": 3, "b": 1},
+			expected: v2alpha1.MultiKeyListenerStrategy{
+				Weighted: &v2alpha1.WeightedStrategySpec{RoutingKeys: map[string]uint{"a": 3, "b": 1}},
+			},
+		},
+		{
+			name:          "weighted negative weight",
+			weight:        map[string]int{"a": -1},
+			expectedError: "weight for routing key \"a\" is not valid: must not be negative",
+		},
+		{
+			name:    "closest",
+			closest: []string{"east", "west"},
+			expected: v2alpha1.MultiKeyListenerStrategy{
+				Closest: &v2alpha1.ClosestStrategySpec{RoutingKeys: []string{"east", "west"}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			strategy, err := MultiKeyListenerStrategy(test.priority, test.weight, test.closest)
+			if test.expectedError != "" {
+				assert.Error(t, err, test.expectedError)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, strategy, test.expected)
+			}
+		})
+	}
+}
+
+func TestMultiKeyListenerReachableKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   *v2alpha1.StrategyStatus
+		expected string
+	}{
+		{
+			name: "no status",
+		},
+		{
+			name: "priority",
+			status: &v2alpha1.StrategyStatus{
+				Priority: &v2alpha1.PriorityStrategyStatus{RoutingKeysReachable: []string{"b", "a"}},
+			},
+			expected: "b,a",
+		},
+		{
+			name: "weighted",
+			status: &v2alpha1.StrategyStatus{
+				Weighted: &v2alpha1.WeightedStrategyStatus{RoutingKeysReachable: map[string]uint{"b": 1, "a": 2}},
+			},
+			expected: "a=2,b=1",
+		},
+		{
+			name: "closest",
+			status: &v2alpha1.StrategyStatus{
+				Closest: &v2alpha1.ClosestStrategyStatus{RoutingKeysReachable: []string{"west", "east"}},
+			},
+			expected: "west,east",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, MultiKeyListenerReachableKeys(test.status), test.expected)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+)
+
+// TokenDetails summarises an access token as shown by "skupper token
+// inspect". Expiry is only known when the AccessGrant the token was
+// issued from can be found.
+type TokenDetails struct {
+	Name          string `json:"name"`
+	Url           string `json:"url"`
+	CaFingerprint string `json:"caFingerprint"`
+	CaExpiry      string `json:"caExpiry,omitempty"`
+	Expiry        string `json:"expiry,omitempty"`
+	Redemptions   string `json:"redemptions,omitempty"`
+	Revoked       string `json:"revoked,omitempty"`
+}
+
+// ReadAccessToken decodes the AccessToken written to fileName by
+// "skupper token issue".
+func ReadAccessToken(fileName string) (*v2alpha1.AccessToken, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token file - %v", err)
+	}
+	var accessToken v2alpha1.AccessToken
+	serializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, json.SerializerOptions{Yaml: true})
+	if _, _, err := serializer.Decode(data, nil, &accessToken); err != nil {
+		return nil, fmt.Errorf("unable to decode token file - %v", err)
+	}
+	if accessToken.Kind != "AccessToken" {
+		return nil, fmt.Errorf("token file does not contain an AccessToken")
+	}
+	return &accessToken, nil
+}
+
+// NewTokenDetails returns the details of a token with the given url
+// and PEM encoded CA certificate.
+func NewTokenDetails(name string, url string, ca string) (*TokenDetails, error) {
+	details := &TokenDetails{
+		Name: name,
+		Url:  url,
+	}
+	block, _ := pem.Decode([]byte(ca))
+	if block == nil {
+		return nil, fmt.Errorf("token does not contain a valid CA certificate")
+	}
+	details.CaFingerprint = fingerprint(block.Bytes)
+	if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+		details.CaExpiry = cert.NotAfter.UTC().Format(time.RFC3339)
+	}
+	return details, nil
+}
+
+// PrintTokenDetails writes the details of a token to the console, either
+// as a table or encoded in the given output format.
+func PrintTokenDetails(details *TokenDetails, output string) error {
+	if output != "" {
+		encodedOutput, err := Encode(output, details)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+		return nil
+	}
+	expiry := details.Expiry
+	if expiry == "" {
+		expiry = "unknown, the token was not issued from this site"
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+	fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nURL:\t%s\nCA Fingerprint (SHA-256):\t%s\nCA Expiry:\t%s\nExpiry:\t%s",
+		details.Name, details.Url, details.CaFingerprint, details.CaExpiry, expiry))
+	if details.Redemptions != "" {
+		fmt.Fprintln(tw, fmt.Sprintf("Redemptions:\t%s", details.Redemptions))
+	}
+	if details.Revoked != "" {
+		fmt.Fprintln(tw, fmt.Sprintf("Revoked:\t%s", details.Revoked))
+	}
+	return tw.Flush()
+}
+
+// fingerprint returns the SHA-256 fingerprint of a DER encoded
+// certificate, formatted as colon separated hex bytes.
+func fingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/certs"
+	"gotest.tools/v3/assert"
+)
+
+func TestReadAccessToken(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+		expectedUrl   string
+	}{
+		{
+			name: "access token",
+			content: `apiVersion: skupper.io/v2alpha1
+kind: AccessToken
+metadata:
+  name: my-token
+spec:
+  url: https://10.0.0.1:9090/abc
+  code: secret
+  ca: CA
+`,
+			expectedUrl: "https://10.0.0.1:9090/abc",
+		},
+		{
+			name: "not an access token",
+			content: `apiVersion: skupper.io/v2alpha1
+kind: Link
+metadata:
+  name: my-link
+`,
+			expectedError: "token file does not contain an AccessToken",
+		},
+		{
+			name:          "not yaml",
+			content:       "{{{",
+			expectedError: "unable to decode token file",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := filepath.Join(dir, strings.ReplaceAll(test.name, " ", "-")+".yaml")
+			assert.Assert(t, os.WriteFile(fileName, []byte(test.content), 0644))
+			token, err := ReadAccessToken(fileName)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+			} else {
+				assert.Assert(t, err)
+				assert.Equal(t, token.Spec.Url, test.expectedUrl)
+			}
+		})
+	}
+
+	_, err := ReadAccessToken(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "unable to read token file")
+}
+
+func TestNewTokenDetails(t *testing.T) {
+	ca, err := certs.GenerateSecret("my-ca", "my-ca", nil, 0, nil)
+	assert.Assert(t, err)
+	block, _ := pem.Decode(ca.Data["tls.crt"])
+	assert.Assert(t, block != nil)
+	sum := sha256.Sum256(block.Bytes)
+
+	details, err := NewTokenDetails("my-token", "https://10.0.0.1:9090/abc", string(ca.Data["tls.crt"]))
+	assert.Assert(t, err)
+	assert.Equal(t, details.Name, "my-token")
+	assert.Equal(t, details.Url, "https://10.0.0.1:9090/abc")
+	assert.Equal(t, len(details.CaFingerprint), 95)
+	assert.Assert(t, strings.HasPrefix(details.CaFingerprint, fmt.Sprintf("%02X:%02X", sum[0], sum[1])))
+	assert.Assert(t, details.CaExpiry != "")
+
+	_, err = NewTokenDetails("my-token", "https://10.0.0.1:9090/abc", "not a certificate")
+	assert.Error(t, err, "token does not contain a valid CA certificate")
+}
@@ -17,7 +17,9 @@ func NewCmdConnector() *cobra.Command {
 		Short: "Binds target workloads in the local site to listeners in remote sites.",
 		Long:  `A connector is a endpoint in the local site and binds to listeners in remote sites`,
 		Example: `skupper connector create my-connector 8080
-skupper connector status my-connector`,
+skupper connector status my-connector
+skupper connector attach backend 8080 --site-namespace skupper-site
+skupper connector bind backend --connector-namespace app-namespace`,
 	}
 
 	platform := common.Platform(config.GetPlatform())
@@ -26,6 +28,10 @@ skupper connector status my-connector`,
 	cmd.AddCommand(CmdConnectorUpdateFactory(platform))
 	cmd.AddCommand(CmdConnectorDeleteFactory(platform))
 	cmd.AddCommand(CmdConnectorGenerateFactory(platform))
+	cmd.AddCommand(CmdConnectorAttachFactory(platform))
+	cmd.AddCommand(CmdConnectorBindFactory(platform))
+	cmd.AddCommand(CmdConnectorAttachStatusFactory(platform))
+	cmd.AddCommand(CmdConnectorDetachFactory(platform))
 
 	return cmd
 }
@@ -192,3 +198,123 @@ skupper connector generate backend 8080 --workload deployment/backend`,
 
 	return cmd
 }
+
+func CmdConnectorAttachFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdConnectorAttach()
+	nonKubeCommand := nonkube.NewCmdConnectorAttach()
+
+	cmdConnectorAttachDesc := common.SkupperCmdDescription{
+		Use:   "attach <name> <port>",
+		Short: "attach the workloads in this namespace to a site in another namespace",
+		Long: `Create an attached connector in the current namespace, which provides the target workloads for
+a connector bound to a site in another namespace. The attached connector is only used by the site
+once it is bound there with "skupper connector bind".`,
+		Example: `skupper connector attach backend 8080 --site-namespace skupper-site
+skupper connector attach backend 8080 --site-namespace skupper-site --workload deployment/backend`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdConnectorAttachDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandConnectorAttachFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.SiteNamespace, common.FlagNameSiteNamespace, "", common.FlagDescSiteNamespace)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().BoolVar(&cmdFlags.UseClientCert, common.FlagNameUseClientCert, false, common.FlagDescUseClientCert)
+	cmd.Flags().StringVar(&cmdFlags.ConnectorType, common.FlagNameConnectorType, "tcp", common.FlagDescConnectorType)
+	cmd.Flags().BoolVar(&cmdFlags.IncludeNotReadyPods, common.FlagNameIncludeNotReadyPods, false, common.FlagDescIncludeNotRead)
+	cmd.Flags().StringVar(&cmdFlags.Selector, common.FlagNameSelector, "", common.FlagDescSelector)
+	cmd.Flags().StringVar(&cmdFlags.Workload, common.FlagNameWorkload, "", common.FlagDescWorkload)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	// the attached connector is not configured until it is bound in the site namespace
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "none", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdConnectorBindFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdConnectorBind()
+	nonKubeCommand := nonkube.NewCmdConnectorBind()
+
+	cmdConnectorBindDesc := common.SkupperCmdDescription{
+		Use:   "bind <name>",
+		Short: "bind a connector attached from another namespace to the site in this namespace",
+		Long: `Create an attached connector binding in the current site namespace, which allows the attached
+connector of the same name in the given connector namespace to provide targets for the site.`,
+		Example: `skupper connector bind backend --connector-namespace app-namespace
+skupper connector bind backend --connector-namespace app-namespace --routing-key backend-v2`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdConnectorBindDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandConnectorBindFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.ConnectorNamespace, common.FlagNameConnectorNamespace, "", common.FlagDescConnectorNamespace)
+	cmd.Flags().StringVarP(&cmdFlags.RoutingKey, common.FlagNameRoutingKey, "r", "", common.FlagDescRoutingKey)
+	cmd.Flags().BoolVar(&cmdFlags.ExposePodsByName, common.FlagNameExposePodsByName, false, common.FlagDescExposePodsByName)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdConnectorAttachStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdConnectorAttachStatus()
+	nonKubeCommand := nonkube.NewCmdConnectorAttachStatus()
+
+	cmdConnectorAttachStatusDesc := common.SkupperCmdDescription{
+		Use:   "attach-status <name>",
+		Short: "get status of attached connectors",
+		Long: `Display status of all attached connectors in the current namespace or a specific attached connector,
+together with the status of their binding in the site namespace`,
+		Example: "skupper connector attach-status backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdConnectorAttachStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandConnectorAttachStatusFlags{}
+	cmd.Flags().StringVar(&cmdFlags.SiteNamespace, common.FlagNameSiteNamespace, "", common.FlagDescSiteNamespace)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameConnectorStatusOutput, "o", "", common.FlagDescConnectorStatusOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdConnectorDetachFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdConnectorDetach()
+	nonKubeCommand := nonkube.NewCmdConnectorDetach()
+
+	cmdConnectorDetachDesc := common.SkupperCmdDescription{
+		Use:     "detach <name>",
+		Short:   "delete an attached connector and its binding",
+		Long:    "Delete the attached connector <name> in the current namespace and its binding in the site namespace",
+		Example: "skupper connector detach backend",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdConnectorDetachDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandConnectorDetachFlags{}
+	cmd.Flags().StringVar(&cmdFlags.SiteNamespace, common.FlagNameSiteNamespace, "", common.FlagDescSiteNamespace)
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
@@ -79,6 +79,49 @@ func TestCmdConnectorFactory(t *testing.T) {
 			},
 			command: CmdConnectorGenerateFactory(common.PlatformKubernetes),
 		},
+		{
+			name: "CmdConnectorAttachFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameSiteNamespace:       "",
+				common.FlagNameTlsCredentials:      "",
+				common.FlagNameUseClientCert:       "false",
+				common.FlagNameConnectorType:       "tcp",
+				common.FlagNameIncludeNotReadyPods: "false",
+				common.FlagNameSelector:            "",
+				common.FlagNameWorkload:            "",
+				common.FlagNameTimeout:             "1m0s",
+				common.FlagNameWait:                "none",
+			},
+			command: CmdConnectorAttachFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdConnectorBindFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameConnectorNamespace: "",
+				common.FlagNameRoutingKey:         "",
+				common.FlagNameExposePodsByName:   "false",
+				common.FlagNameTimeout:            "1m0s",
+				common.FlagNameWait:               "configured",
+			},
+			command: CmdConnectorBindFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdConnectorAttachStatusFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameSiteNamespace:         "",
+				common.FlagNameConnectorStatusOutput: "",
+			},
+			command: CmdConnectorAttachStatusFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdConnectorDetachFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameSiteNamespace: "",
+				common.FlagNameTimeout:       "1m0s",
+				common.FlagNameWait:          "true",
+			},
+			command: CmdConnectorDetachFactory(common.PlatformKubernetes),
+		},
 	}
 
 	for _, test := range testTable {
@@ -0,0 +1,310 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	pkgUtils "github.com/skupperproject/skupper/internal/utils"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdConnectorAttach struct {
+	client              skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandConnectorAttachFlags
+	namespace           string
+	name                string
+	port                int
+	siteNamespace       string
+	selector            string
+	tlsCredentials      string
+	useClientCert       bool
+	connectorType       string
+	includeNotReadyPods bool
+	timeout             time.Duration
+	KubeClient          kubernetes.Interface
+	status              string
+}
+
+func NewCmdConnectorAttach() *CmdConnectorAttach {
+
+	return &CmdConnectorAttach{}
+}
+
+func (cmd *CmdConnectorAttach) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+	cmd.KubeClient = cli.Kube
+}
+
+func (cmd *CmdConnectorAttach) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	namespaceStringValidator := validator.NamespaceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	connectorTypeValidator := validator.NewOptionValidator(common.ConnectorTypes)
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	workloadStringValidator := validator.NewWorkloadStringValidator(common.WorkloadTypes)
+	selectorStringValidator := validator.NewSelectorStringValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if AttachedConnector CRD is installed
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name and port
+	if len(args) < 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name and port must be configured"))
+	} else if len(args) > 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("only two arguments are allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must not be empty"))
+	} else if args[1] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector port must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+
+		cmd.port, err = strconv.Atoi(args[1])
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("connector port is not valid: %s", err))
+		}
+		ok, err = numberValidator.Evaluate(cmd.port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector port is not valid: %s", err))
+		}
+	}
+
+	// Validate if there is already an AttachedConnector with this name in the namespace
+	if cmd.name != "" {
+		connector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if connector != nil && !k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("There is already an attached connector %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && cmd.Flags.SiteNamespace == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("site namespace must be configured"))
+	} else if cmd.Flags != nil {
+		ok, err := namespaceStringValidator.Evaluate(cmd.Flags.SiteNamespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("site namespace is not valid: %s", err))
+		} else if cmd.Flags.SiteNamespace == cmd.namespace {
+			validationErrors = append(validationErrors, fmt.Errorf("site namespace must be different from the connector namespace, use \"skupper connector create\" instead"))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		// check that the secret exists
+		_, err := cmd.KubeClient.CoreV1().Secrets(cmd.namespace).Get(context.TODO(), cmd.Flags.TlsCredentials, metav1.GetOptions{})
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-secret is not valid: does not exist"))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.ConnectorType != "" {
+		ok, err := connectorTypeValidator.Evaluate(cmd.Flags.ConnectorType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector type is not valid: %s", err))
+		}
+	}
+	// only one of workload or selector can be specified
+	if cmd.Flags != nil && cmd.Flags.Selector != "" {
+		if cmd.Flags.Workload != "" {
+			validationErrors = append(validationErrors, fmt.Errorf("If selector is configured, cannot configure workload"))
+		}
+		ok, err := selectorStringValidator.Evaluate(cmd.Flags.Selector)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("selector is not valid: %s", err))
+		}
+		cmd.selector = cmd.Flags.Selector
+	}
+	if cmd.Flags != nil && cmd.Flags.Workload != "" && cmd.Flags.Selector == "" {
+		//workload get resource-type/resource-name and find selector labels
+		resourceType, resourceName, ok, err := workloadStringValidator.Evaluate(cmd.Flags.Workload)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("workload is not valid: %s", err))
+		} else {
+			switch resourceType {
+			case "deployment":
+				deployment, err := cmd.KubeClient.AppsV1().Deployments(cmd.namespace).Get(context.TODO(), resourceName, metav1.GetOptions{})
+				if err != nil {
+					validationErrors = append(validationErrors, fmt.Errorf("failed trying to get Deployment specified by workload: %s", err))
+				} else {
+					if deployment.Spec.Selector.MatchLabels != nil {
+						cmd.selector = pkgUtils.StringifySelector(deployment.Spec.Selector.MatchLabels)
+					} else {
+						validationErrors = append(validationErrors, fmt.Errorf("workload, no selector Matchlabels found"))
+					}
+				}
+			case "service":
+				service, err := cmd.KubeClient.CoreV1().Services(cmd.namespace).Get(context.TODO(), resourceName, metav1.GetOptions{})
+				if err != nil {
+					validationErrors = append(validationErrors, fmt.Errorf("failed trying to get Service specified by workload: %s", err))
+				} else {
+					if service.Spec.Selector != nil {
+						cmd.selector = pkgUtils.StringifySelector(service.Spec.Selector)
+					} else {
+						validationErrors = append(validationErrors, fmt.Errorf("workload, no selector labels found"))
+					}
+				}
+			case "daemonset":
+				daemonSet, err := cmd.KubeClient.AppsV1().DaemonSets(cmd.namespace).Get(context.TODO(), resourceName, metav1.GetOptions{})
+				if err != nil {
+					validationErrors = append(validationErrors, fmt.Errorf("failed trying to get DaemonSet specified by workload: %s", err))
+				} else {
+					if daemonSet.Spec.Selector.MatchLabels != nil {
+						cmd.selector = pkgUtils.StringifySelector(daemonSet.Spec.Selector.MatchLabels)
+					} else {
+						validationErrors = append(validationErrors, fmt.Errorf("workload, no selector Matchlabels found"))
+					}
+				}
+			case "statefulset":
+				statefulSet, err := cmd.KubeClient.AppsV1().StatefulSets(cmd.namespace).Get(context.TODO(), resourceName, metav1.GetOptions{})
+				if err != nil {
+					validationErrors = append(validationErrors, fmt.Errorf("failed trying to get StatefulSet specified by workload: %s", err))
+				} else {
+					if statefulSet.Spec.Selector.MatchLabels != nil {
+						cmd.selector = pkgUtils.StringifySelector(statefulSet.Spec.Selector.MatchLabels)
+					} else {
+						validationErrors = append(validationErrors, fmt.Errorf("workload, no selector Matchlabels found"))
+					}
+				}
+			}
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdConnectorAttach) InputToOptions() {
+
+	// default selector to name of connector
+	if cmd.selector == "" {
+		cmd.selector = "app=" + cmd.name
+	}
+
+	cmd.siteNamespace = cmd.Flags.SiteNamespace
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	cmd.useClientCert = cmd.Flags.UseClientCert
+	cmd.connectorType = cmd.Flags.ConnectorType
+	cmd.includeNotReadyPods = cmd.Flags.IncludeNotReadyPods
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdConnectorAttach) Run() error {
+
+	resource := v2alpha1.AttachedConnector{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnector",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AttachedConnectorSpec{
+			SiteNamespace:       cmd.siteNamespace,
+			Selector:            cmd.selector,
+			Port:                cmd.port,
+			TlsCredentials:      cmd.tlsCredentials,
+			UseClientCert:       cmd.useClientCert,
+			Type:                cmd.connectorType,
+			IncludeNotReadyPods: cmd.includeNotReadyPods,
+		},
+	}
+
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+	return err
+}
+
+func (cmd *CmdConnectorAttach) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+
+	var connectorCondition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for attach to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			connectorCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			connectorCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if connectorCondition != nil {
+			isConditionFound = true
+			isConditionTrue = connectorCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && connectorCondition == nil {
+		return fmt.Errorf("AttachedConnector %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && connectorCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("AttachedConnector %q is not yet %s: %s\n", cmd.name, cmd.status, connectorCondition.Message)
+	}
+
+	fmt.Printf("AttachedConnector %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
@@ -0,0 +1,201 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdConnectorAttachStatus struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandConnectorAttachStatusFlags
+	namespace string
+	name      string
+	output    string
+}
+
+func NewCmdConnectorAttachStatus() *CmdConnectorAttachStatus {
+
+	return &CmdConnectorAttachStatus{}
+}
+
+func (cmd *CmdConnectorAttachStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdConnectorAttachStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	namespaceStringValidator := validator.NamespaceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Check if AttachedConnector CRD is installed
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("connector name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("connector name is not valid: %s", err))
+			} else {
+				cmd.name = args[0]
+			}
+		}
+	}
+
+	// Validate that there is an attached connector with this name in the namespace
+	if cmd.name != "" {
+		connector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if connector == nil || k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector %s does not exist in namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.SiteNamespace != "" {
+		ok, err := namespaceStringValidator.Evaluate(cmd.Flags.SiteNamespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("site namespace is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdConnectorAttachStatus) Run() error {
+	if cmd.name == "" {
+		resources, err := cmd.client.AttachedConnectors(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil || resources == nil || len(resources.Items) == 0 {
+			fmt.Println("No attached connectors found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, resource := range resources.Items {
+				if err := cmd.encode(&resource, cmd.binding(&resource)); err != nil {
+					return err
+				}
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "SITE-NAMESPACE", "ROUTING-KEY", "SELECTED-PODS", "HAS MATCHING LISTENER", "MESSAGE"))
+			for _, resource := range resources.Items {
+				routingKey := ""
+				hasMatchingListener := false
+				if binding := cmd.binding(&resource); binding != nil {
+					routingKey = binding.Spec.RoutingKey
+					hasMatchingListener = binding.Status.HasMatchingListener
+				}
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%d\t%t\t%s",
+					resource.Name, resource.Status.StatusType, cmd.siteNamespace(&resource), routingKey,
+					len(resource.Status.SelectedPods), hasMatchingListener, resource.Status.Message))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		resource, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil || resource == nil {
+			fmt.Println("No attached connectors found")
+			return err
+		}
+		binding := cmd.binding(resource)
+		if cmd.output != "" {
+			return cmd.encode(resource, binding)
+		}
+		var pods []string
+		for _, pod := range resource.Status.SelectedPods {
+			pods = append(pods, fmt.Sprintf("%s(%s)", pod.Name, pod.IP))
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+		fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nSite Namespace:\t%s\nSelector:\t%s\nPort:\t%d\nSelected Pods:\t%s\nMessage:\t%s",
+			resource.Name, resource.Status.StatusType, cmd.siteNamespace(resource), resource.Spec.Selector,
+			resource.Spec.Port, strings.Join(pods, ", "), resource.Status.Message))
+		if binding != nil {
+			fmt.Fprintln(tw, fmt.Sprintf("Binding Status:\t%s\nRouting key:\t%s\nHas Matching Listener:\t%t\nBinding Message:\t%s",
+				binding.Status.StatusType, binding.Spec.RoutingKey, binding.Status.HasMatchingListener, binding.Status.Message))
+		} else {
+			fmt.Fprintln(tw, fmt.Sprintf("Binding Status:\tnot found in namespace %s", cmd.siteNamespace(resource)))
+		}
+		_ = tw.Flush()
+	}
+
+	return nil
+}
+
+func (cmd *CmdConnectorAttachStatus) InputToOptions()  {}
+func (cmd *CmdConnectorAttachStatus) WaitUntil() error { return nil }
+
+// siteNamespace returns the namespace in which the binding for the
+// attached connector is expected, unless overridden by the user.
+func (cmd *CmdConnectorAttachStatus) siteNamespace(connector *v2alpha1.AttachedConnector) string {
+	if cmd.Flags != nil && cmd.Flags.SiteNamespace != "" {
+		return cmd.Flags.SiteNamespace
+	}
+	return connector.Spec.SiteNamespace
+}
+
+// binding returns the binding for the attached connector, or nil if it
+// cannot be retrieved from the site namespace.
+func (cmd *CmdConnectorAttachStatus) binding(connector *v2alpha1.AttachedConnector) *v2alpha1.AttachedConnectorBinding {
+	siteNamespace := cmd.siteNamespace(connector)
+	if siteNamespace == "" {
+		return nil
+	}
+	binding, err := cmd.client.AttachedConnectorBindings(siteNamespace).Get(context.TODO(), connector.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	return binding
+}
+
+func (cmd *CmdConnectorAttachStatus) encode(connector *v2alpha1.AttachedConnector, binding *v2alpha1.AttachedConnectorBinding) error {
+	encodedOutput, err := utils.Encode(cmd.output, connector)
+	if err != nil {
+		return err
+	}
+	fmt.Println(encodedOutput)
+	if binding != nil {
+		encodedOutput, err = utils.Encode(cmd.output, binding)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encodedOutput)
+	}
+	return nil
+}
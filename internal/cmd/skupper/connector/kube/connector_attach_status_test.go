@@ -0,0 +1,212 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func attachedConnectorAndBinding() []runtime.Object {
+	return []runtime.Object{
+		&v2alpha1.AttachedConnector{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-connector",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.AttachedConnectorSpec{
+				SiteNamespace: "site",
+				Selector:      "app=backend",
+				Port:          8080,
+			},
+			Status: v2alpha1.AttachedConnectorStatus{
+				Status: v2alpha1.Status{StatusType: v2alpha1.StatusReady},
+				SelectedPods: []v2alpha1.PodDetails{
+					{Name: "backend-1", IP: "10.0.0.1"},
+					{Name: "backend-2", IP: "10.0.0.2"},
+				},
+			},
+		},
+		&v2alpha1.AttachedConnectorBinding{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "my-connector",
+				Namespace: "site",
+			},
+			Spec: v2alpha1.AttachedConnectorBindingSpec{
+				ConnectorNamespace: "test",
+				RoutingKey:         "backend",
+			},
+			Status: v2alpha1.AttachedConnectorBindingStatus{
+				Status:              v2alpha1.Status{StatusType: v2alpha1.StatusReady},
+				HasMatchingListener: true,
+			},
+		},
+	}
+}
+
+func TestCmdConnectorAttachStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandConnectorAttachStatusFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-connector"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "attached connector does not exist in the namespace",
+			args:          []string{"my-connector"},
+			expectedError: "attached connector my-connector does not exist in namespace test",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "connector"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			expectedError: "",
+		},
+		{
+			name:           "site namespace is not valid",
+			args:           []string{"my-connector"},
+			flags:          common.CommandConnectorAttachStatusFlags{SiteNamespace: "Not_Valid"},
+			skupperObjects: attachedConnectorAndBinding(),
+			expectedError:  "site namespace is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$",
+		},
+		{
+			name:           "bad output status",
+			args:           []string{"my-connector"},
+			flags:          common.CommandConnectorAttachStatusFlags{Output: "not-supported"},
+			skupperObjects: attachedConnectorAndBinding(),
+			expectedError:  "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:           "good output status",
+			args:           []string{"my-connector"},
+			flags:          common.CommandConnectorAttachStatusFlags{Output: "json"},
+			skupperObjects: attachedConnectorAndBinding(),
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdConnectorAttachStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdConnectorAttachStatus_Run(t *testing.T) {
+	type test struct {
+		name           string
+		connectorName  string
+		output         string
+		skupperObjects []runtime.Object
+		skupperError   string
+		errorMessage   string
+	}
+
+	testTable := []test{
+		{
+			name:          "run fails",
+			connectorName: "my-connector",
+			skupperError:  "error getting the resource",
+			errorMessage:  "error getting the resource",
+		},
+		{
+			name:           "runs ok, returns all attached connectors",
+			skupperObjects: attachedConnectorAndBinding(),
+		},
+		{
+			name:           "runs ok, returns one attached connector",
+			connectorName:  "my-connector",
+			skupperObjects: attachedConnectorAndBinding(),
+		},
+		{
+			name:           "runs ok, returns one attached connector without binding",
+			connectorName:  "my-connector",
+			skupperObjects: attachedConnectorAndBinding()[:1],
+		},
+		{
+			name:           "runs ok, returns one attached connector yaml",
+			connectorName:  "my-connector",
+			output:         "yaml",
+			skupperObjects: attachedConnectorAndBinding(),
+		},
+		{
+			name:           "runs ok, returns all attached connectors json",
+			output:         "json",
+			skupperObjects: attachedConnectorAndBinding(),
+		},
+		{
+			name: "returns no attached connectors",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorAttachStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+		assert.Assert(t, err)
+
+		cmd.name = test.connectorName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdConnectorAttachStatus_Binding(t *testing.T) {
+	cmd, err := newCmdConnectorAttachStatusWithMocks("test", nil, attachedConnectorAndBinding(), "")
+	assert.Assert(t, err)
+	cmd.Flags = &common.CommandConnectorAttachStatusFlags{}
+
+	connector := attachedConnectorAndBinding()[0].(*v2alpha1.AttachedConnector)
+	binding := cmd.binding(connector)
+	assert.Assert(t, binding != nil)
+	assert.Equal(t, binding.Status.HasMatchingListener, true)
+
+	cmd.Flags.SiteNamespace = "other"
+	assert.Assert(t, cmd.binding(connector) == nil)
+}
+
+// --- helper methods
+
+func newCmdConnectorAttachStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdConnectorAttachStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdConnectorAttachStatus := &CmdConnectorAttachStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdConnectorAttachStatus, nil
+}
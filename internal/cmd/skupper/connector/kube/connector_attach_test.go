@@ -0,0 +1,300 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v12 "k8s.io/api/apps/v1"
+	v11 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdConnectorAttach_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandConnectorAttachFlags
+		k8sObjects          []runtime.Object
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-connector", "8080"},
+			flags:               common.CommandConnectorAttachFlags{SiteNamespace: "site"},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:  "attached connector already exists",
+			args:  []string{"my-connector", "8080"},
+			flags: common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "There is already an attached connector my-connector created for namespace test",
+		},
+		{
+			name:          "connector name and port are not specified",
+			args:          []string{},
+			flags:         common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			expectedError: "connector name and port must be configured",
+		},
+		{
+			name:          "connector port is not valid",
+			args:          []string{"my-connector", "abcd"},
+			flags:         common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			expectedError: "connector port is not valid: strconv.Atoi: parsing \"abcd\": invalid syntax",
+		},
+		{
+			name:          "site namespace is not specified",
+			args:          []string{"my-connector", "8080"},
+			flags:         common.CommandConnectorAttachFlags{Timeout: time.Minute},
+			expectedError: "site namespace must be configured",
+		},
+		{
+			name:          "site namespace is the connector namespace",
+			args:          []string{"my-connector", "8080"},
+			flags:         common.CommandConnectorAttachFlags{SiteNamespace: "test", Timeout: time.Minute},
+			expectedError: "site namespace must be different from the connector namespace, use \"skupper connector create\" instead",
+		},
+		{
+			name:          "site namespace is not valid",
+			args:          []string{"my-connector", "8080"},
+			flags:         common.CommandConnectorAttachFlags{SiteNamespace: "Not_Valid", Timeout: time.Minute},
+			expectedError: "site namespace is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$",
+		},
+		{
+			name:          "selector and workload are both specified",
+			args:          []string{"my-connector", "8080"},
+			flags:         common.CommandConnectorAttachFlags{SiteNamespace: "site", Selector: "app=backend", Workload: "deployment/backend", Timeout: time.Minute},
+			expectedError: "If selector is configured, cannot configure workload",
+		},
+		{
+			name:          "tls secret does not exist",
+			args:          []string{"my-connector", "8080"},
+			flags:         common.CommandConnectorAttachFlags{SiteNamespace: "site", TlsCredentials: "not-there", Timeout: time.Minute},
+			expectedError: "tls-secret is not valid: does not exist",
+		},
+		{
+			name:          "connector type is not valid",
+			args:          []string{"my-connector", "8080"},
+			flags:         common.CommandConnectorAttachFlags{SiteNamespace: "site", ConnectorType: "not-valid", Timeout: time.Minute},
+			expectedError: "connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp http http2]",
+		},
+		{
+			name:          "wait status is not valid",
+			args:          []string{"my-connector", "8080"},
+			flags:         common.CommandConnectorAttachFlags{SiteNamespace: "site", Timeout: time.Minute, Wait: "created"},
+			expectedError: "status is not valid: value created not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-connector", "8080"},
+			flags: common.CommandConnectorAttachFlags{
+				SiteNamespace:  "site",
+				TlsCredentials: "secretname",
+				UseClientCert:  true,
+				ConnectorType:  "tcp",
+				Workload:       "deployment/backend",
+				Timeout:        time.Minute,
+				Wait:           "none",
+			},
+			k8sObjects: []runtime.Object{
+				&v11.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "secretname",
+						Namespace: "test",
+					},
+				},
+				&v12.Deployment{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "backend",
+						Namespace: "test",
+					},
+					Spec: v12.DeploymentSpec{
+						Selector: &v1.LabelSelector{
+							MatchLabels: map[string]string{"app": "backend"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdConnectorAttachWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdConnectorAttach_InputToOptions(t *testing.T) {
+	cmd, err := newCmdConnectorAttachWithMocks("test", nil, nil, "")
+	assert.Assert(t, err)
+
+	cmd.name = "backend"
+	cmd.Flags = &common.CommandConnectorAttachFlags{
+		SiteNamespace: "site",
+		ConnectorType: "tcp",
+		Timeout:       20 * time.Second,
+		Wait:          "configured",
+	}
+
+	cmd.InputToOptions()
+
+	assert.Equal(t, cmd.selector, "app=backend")
+	assert.Equal(t, cmd.siteNamespace, "site")
+	assert.Equal(t, cmd.connectorType, "tcp")
+	assert.Equal(t, cmd.timeout, 20*time.Second)
+	assert.Equal(t, cmd.status, "configured")
+}
+
+func TestCmdConnectorAttach_Run(t *testing.T) {
+	cmd, err := newCmdConnectorAttachWithMocks("test", nil, nil, "")
+	assert.Assert(t, err)
+
+	cmd.name = "backend"
+	cmd.port = 8080
+	cmd.siteNamespace = "site"
+	cmd.selector = "app=backend"
+	cmd.connectorType = "tcp"
+
+	assert.Assert(t, cmd.Run())
+
+	created, err := cmd.client.AttachedConnectors("test").Get(context.TODO(), "backend", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, created.Spec.SiteNamespace, "site")
+	assert.Equal(t, created.Spec.Selector, "app=backend")
+	assert.Equal(t, created.Spec.Port, 8080)
+}
+
+func TestCmdConnectorAttach_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:        "attached connector is not returned",
+			status:      "configured",
+			expectError: true,
+		},
+		{
+			name:   "attached connector is not bound yet",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AttachedConnectorStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Message: "No matching AttachedConnectorBinding in site namespace",
+									Reason:  "Error",
+									Status:  "False",
+									Type:    "Configured",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:   "attached connector is configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AttachedConnectorStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Status: "True",
+									Type:   "Configured",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorAttachWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-connector"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdConnectorAttachWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdConnectorAttach, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdConnectorAttach := &CmdConnectorAttach{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdConnectorAttach, nil
+}
@@ -0,0 +1,208 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdConnectorBind struct {
+	client             skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd           *cobra.Command
+	Flags              *common.CommandConnectorBindFlags
+	namespace          string
+	name               string
+	connectorNamespace string
+	routingKey         string
+	exposePodsByName   bool
+	timeout            time.Duration
+	status             string
+}
+
+func NewCmdConnectorBind() *CmdConnectorBind {
+
+	return &CmdConnectorBind{}
+}
+
+func (cmd *CmdConnectorBind) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdConnectorBind) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	namespaceStringValidator := validator.NamespaceStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if AttachedConnectorBinding CRD is installed
+	_, err := cmd.client.AttachedConnectorBindings(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate if there is already a binding with this name in the namespace
+	if cmd.name != "" {
+		binding, err := cmd.client.AttachedConnectorBindings(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if binding != nil && !k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("There is already an attached connector binding %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && cmd.Flags.ConnectorNamespace == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector namespace must be configured"))
+	} else if cmd.Flags != nil {
+		ok, err := namespaceStringValidator.Evaluate(cmd.Flags.ConnectorNamespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector namespace is not valid: %s", err))
+		} else if cmd.Flags.ConnectorNamespace == cmd.namespace {
+			validationErrors = append(validationErrors, fmt.Errorf("connector namespace must be different from the site namespace, use \"skupper connector create\" instead"))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.RoutingKey != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.RoutingKey)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("routing key is not valid: %s", err))
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdConnectorBind) InputToOptions() {
+
+	// default routingkey to name of connector
+	if cmd.Flags.RoutingKey == "" {
+		cmd.routingKey = cmd.name
+	} else {
+		cmd.routingKey = cmd.Flags.RoutingKey
+	}
+	cmd.connectorNamespace = cmd.Flags.ConnectorNamespace
+	cmd.exposePodsByName = cmd.Flags.ExposePodsByName
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdConnectorBind) Run() error {
+
+	resource := v2alpha1.AttachedConnectorBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AttachedConnectorBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.AttachedConnectorBindingSpec{
+			ConnectorNamespace: cmd.connectorNamespace,
+			RoutingKey:         cmd.routingKey,
+			ExposePodsByName:   cmd.exposePodsByName,
+		},
+	}
+
+	_, err := cmd.client.AttachedConnectorBindings(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+	return err
+}
+
+func (cmd *CmdConnectorBind) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+
+	var bindingCondition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for bind to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.AttachedConnectorBindings(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			bindingCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			bindingCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if bindingCondition != nil {
+			isConditionFound = true
+			isConditionTrue = bindingCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && bindingCondition == nil {
+		return fmt.Errorf("AttachedConnectorBinding %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && bindingCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("AttachedConnectorBinding %q is not yet %s: %s\n", cmd.name, cmd.status, bindingCondition.Message)
+	}
+
+	fmt.Printf("AttachedConnectorBinding %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
@@ -0,0 +1,269 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdConnectorBind_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandConnectorBindFlags
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			args:                []string{"my-connector"},
+			flags:               common.CommandConnectorBindFlags{ConnectorNamespace: "app"},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:  "binding already exists",
+			args:  []string{"my-connector"},
+			flags: common.CommandConnectorBindFlags{ConnectorNamespace: "app", Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "There is already an attached connector binding my-connector created for namespace test",
+		},
+		{
+			name:          "connector name is not specified",
+			args:          []string{},
+			flags:         common.CommandConnectorBindFlags{ConnectorNamespace: "app", Timeout: time.Minute},
+			expectedError: "connector name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "connector"},
+			flags:         common.CommandConnectorBindFlags{ConnectorNamespace: "app", Timeout: time.Minute},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "connector namespace is not specified",
+			args:          []string{"my-connector"},
+			flags:         common.CommandConnectorBindFlags{Timeout: time.Minute},
+			expectedError: "connector namespace must be configured",
+		},
+		{
+			name:          "connector namespace is the site namespace",
+			args:          []string{"my-connector"},
+			flags:         common.CommandConnectorBindFlags{ConnectorNamespace: "test", Timeout: time.Minute},
+			expectedError: "connector namespace must be different from the site namespace, use \"skupper connector create\" instead",
+		},
+		{
+			name:          "routing key is not valid",
+			args:          []string{"my-connector"},
+			flags:         common.CommandConnectorBindFlags{ConnectorNamespace: "app", RoutingKey: "not-valid$", Timeout: time.Minute},
+			expectedError: "routing key is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "timeout is not valid",
+			args:          []string{"my-connector"},
+			flags:         common.CommandConnectorBindFlags{ConnectorNamespace: "app", Timeout: 0 * time.Second},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-connector"},
+			flags: common.CommandConnectorBindFlags{
+				ConnectorNamespace: "app",
+				RoutingKey:         "backend",
+				ExposePodsByName:   true,
+				Timeout:            time.Minute,
+				Wait:               "ready",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdConnectorBindWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdConnectorBind_InputToOptions(t *testing.T) {
+	type test struct {
+		name               string
+		flags              common.CommandConnectorBindFlags
+		expectedRoutingKey string
+	}
+
+	testTable := []test{
+		{
+			name:               "routing key defaults to name",
+			flags:              common.CommandConnectorBindFlags{ConnectorNamespace: "app"},
+			expectedRoutingKey: "my-connector",
+		},
+		{
+			name:               "routing key is set",
+			flags:              common.CommandConnectorBindFlags{ConnectorNamespace: "app", RoutingKey: "backend"},
+			expectedRoutingKey: "backend",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd, err := newCmdConnectorBindWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+
+			cmd.name = "my-connector"
+			cmd.Flags = &test.flags
+
+			cmd.InputToOptions()
+
+			assert.Equal(t, cmd.routingKey, test.expectedRoutingKey)
+			assert.Equal(t, cmd.connectorNamespace, "app")
+		})
+	}
+}
+
+func TestCmdConnectorBind_Run(t *testing.T) {
+	cmd, err := newCmdConnectorBindWithMocks("test", nil, nil, "")
+	assert.Assert(t, err)
+
+	cmd.name = "backend"
+	cmd.connectorNamespace = "app"
+	cmd.routingKey = "backend"
+	cmd.exposePodsByName = true
+
+	assert.Assert(t, cmd.Run())
+
+	created, err := cmd.client.AttachedConnectorBindings("test").Get(context.TODO(), "backend", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, created.Spec.ConnectorNamespace, "app")
+	assert.Equal(t, created.Spec.RoutingKey, "backend")
+	assert.Equal(t, created.Spec.ExposePodsByName, true)
+}
+
+func TestCmdConnectorBind_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:        "binding is not returned",
+			status:      "configured",
+			expectError: true,
+		},
+		{
+			name:   "binding is configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AttachedConnectorBindingStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Status: "True",
+									Type:   "Configured",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:   "binding is configured but not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AttachedConnectorBinding{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-connector",
+						Namespace: "test",
+					},
+					Status: v2alpha1.AttachedConnectorBindingStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Status: "True",
+									Type:   "Configured",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorBindWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-connector"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdConnectorBindWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdConnectorBind, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdConnectorBind := &CmdConnectorBind{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdConnectorBind, nil
+}
@@ -0,0 +1,161 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdConnectorDetach struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandConnectorDetachFlags
+	namespace       string
+	name            string
+	siteNamespace   string
+	connectorExists bool
+	bindingExists   bool
+	wait            bool
+}
+
+func NewCmdConnectorDetach() *CmdConnectorDetach {
+
+	return &CmdConnectorDetach{}
+}
+
+func (cmd *CmdConnectorDetach) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdConnectorDetach) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	namespaceStringValidator := validator.NamespaceStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+
+	// Check if AttachedConnector CRD is installed
+	_, err := cmd.client.AttachedConnectors(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	if cmd.Flags != nil && cmd.Flags.SiteNamespace != "" {
+		ok, err := namespaceStringValidator.Evaluate(cmd.Flags.SiteNamespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("site namespace is not valid: %s", err))
+		} else {
+			cmd.siteNamespace = cmd.Flags.SiteNamespace
+		}
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("connector name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("connector name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is an attached connector or a binding with this name
+	if cmd.name != "" {
+		connector, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err == nil && connector != nil {
+			cmd.connectorExists = true
+			if cmd.siteNamespace == "" {
+				cmd.siteNamespace = connector.Spec.SiteNamespace
+			}
+		}
+		if cmd.siteNamespace != "" {
+			binding, err := cmd.client.AttachedConnectorBindings(cmd.siteNamespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+			if err == nil && binding != nil {
+				cmd.bindingExists = true
+			}
+		}
+		if !cmd.connectorExists && !cmd.bindingExists {
+			validationErrors = append(validationErrors, fmt.Errorf("attached connector %s does not exist in namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdConnectorDetach) Run() error {
+	var errs []error
+	if cmd.connectorExists {
+		err := cmd.client.AttachedConnectors(cmd.namespace).Delete(context.TODO(), cmd.name, metav1.DeleteOptions{})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if cmd.bindingExists {
+		err := cmd.client.AttachedConnectorBindings(cmd.siteNamespace).Delete(context.TODO(), cmd.name, metav1.DeleteOptions{})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (cmd *CmdConnectorDetach) WaitUntil() error {
+
+	if cmd.wait {
+		waitTime := int(cmd.Flags.Timeout.Seconds())
+		err := utils.NewSpinnerWithTimeout("Waiting for deletion to complete...", waitTime, func() error {
+
+			if cmd.connectorExists {
+				resource, err := cmd.client.AttachedConnectors(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+				if err == nil && resource != nil {
+					return fmt.Errorf("error deleting the attached connector")
+				}
+			}
+			if cmd.bindingExists {
+				resource, err := cmd.client.AttachedConnectorBindings(cmd.siteNamespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+				if err == nil && resource != nil {
+					return fmt.Errorf("error deleting the attached connector binding")
+				}
+			}
+			return nil
+		})
+
+		if err != nil {
+			return fmt.Errorf("AttachedConnector %q not deleted yet, check the status for more information %s\n", cmd.name, err)
+		}
+
+		fmt.Printf("AttachedConnector %q deleted\n", cmd.name)
+
+	}
+	return nil
+}
+
+func (cmd *CmdConnectorDetach) InputToOptions() {
+	cmd.wait = cmd.Flags.Wait
+}
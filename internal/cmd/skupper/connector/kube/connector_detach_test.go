@@ -0,0 +1,170 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdConnectorDetach_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandConnectorDetachFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-connector"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "attached connector does not exist",
+			args:          []string{"my-connector"},
+			flags:         common.CommandConnectorDetachFlags{Timeout: time.Minute},
+			expectedError: "attached connector my-connector does not exist in namespace test",
+		},
+		{
+			name:          "connector name is not specified",
+			args:          []string{},
+			flags:         common.CommandConnectorDetachFlags{Timeout: time.Minute},
+			expectedError: "connector name must be specified",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "connector"},
+			flags:         common.CommandConnectorDetachFlags{Timeout: time.Minute},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-connector"},
+			flags:          common.CommandConnectorDetachFlags{Timeout: 0 * time.Second},
+			skupperObjects: attachedConnectorAndBinding(),
+			expectedError:  "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:           "attached connector and binding exist",
+			args:           []string{"my-connector"},
+			flags:          common.CommandConnectorDetachFlags{Timeout: time.Minute},
+			skupperObjects: attachedConnectorAndBinding(),
+		},
+		{
+			name:           "only the binding exists",
+			args:           []string{"my-connector"},
+			flags:          common.CommandConnectorDetachFlags{SiteNamespace: "site", Timeout: time.Minute},
+			skupperObjects: attachedConnectorAndBinding()[1:],
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdConnectorDetachWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdConnectorDetach_Run(t *testing.T) {
+	cmd, err := newCmdConnectorDetachWithMocks("test", nil, attachedConnectorAndBinding(), "")
+	assert.Assert(t, err)
+	cmd.Flags = &common.CommandConnectorDetachFlags{Timeout: time.Minute}
+
+	assert.Assert(t, cmd.ValidateInput([]string{"my-connector"}))
+	assert.Equal(t, cmd.siteNamespace, "site")
+	assert.Assert(t, cmd.Run())
+
+	_, err = cmd.client.AttachedConnectors("test").Get(context.TODO(), "my-connector", v1.GetOptions{})
+	assert.Assert(t, k8serrs.IsNotFound(err))
+	_, err = cmd.client.AttachedConnectorBindings("site").Get(context.TODO(), "my-connector", v1.GetOptions{})
+	assert.Assert(t, k8serrs.IsNotFound(err))
+}
+
+func TestCmdConnectorDetach_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "error deleting attached connector",
+			wait:           true,
+			skupperObjects: attachedConnectorAndBinding(),
+			expectError:    true,
+		},
+		{
+			name:        "attached connector is deleted",
+			wait:        true,
+			expectError: false,
+		},
+		{
+			name:           "attached connector is not deleted but user does not want to wait",
+			wait:           false,
+			skupperObjects: attachedConnectorAndBinding(),
+			expectError:    false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdConnectorDetachWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-connector"
+		cmd.siteNamespace = "site"
+		cmd.connectorExists = true
+		cmd.bindingExists = true
+		cmd.Flags = &common.CommandConnectorDetachFlags{Timeout: 1 * time.Second}
+		cmd.wait = test.wait
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdConnectorDetachWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdConnectorDetach, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdConnectorDetach := &CmdConnectorDetach{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdConnectorDetach, nil
+}
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdConnectorAttach struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandConnectorAttachFlags
+}
+
+func NewCmdConnectorAttach() *CmdConnectorAttach {
+	return &CmdConnectorAttach{}
+}
+
+func (cmd *CmdConnectorAttach) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdConnectorAttach) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdConnectorAttach) InputToOptions()                                      {}
+func (cmd *CmdConnectorAttach) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdConnectorAttach) WaitUntil() error { return nil }
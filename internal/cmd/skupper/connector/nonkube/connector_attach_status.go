@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdConnectorAttachStatus struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandConnectorAttachStatusFlags
+}
+
+func NewCmdConnectorAttachStatus() *CmdConnectorAttachStatus {
+	return &CmdConnectorAttachStatus{}
+}
+
+func (cmd *CmdConnectorAttachStatus) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdConnectorAttachStatus) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdConnectorAttachStatus) InputToOptions()                                      {}
+func (cmd *CmdConnectorAttachStatus) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdConnectorAttachStatus) WaitUntil() error { return nil }
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdConnectorBind struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandConnectorBindFlags
+}
+
+func NewCmdConnectorBind() *CmdConnectorBind {
+	return &CmdConnectorBind{}
+}
+
+func (cmd *CmdConnectorBind) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdConnectorBind) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdConnectorBind) InputToOptions()                                      {}
+func (cmd *CmdConnectorBind) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdConnectorBind) WaitUntil() error { return nil }
@@ -0,0 +1,25 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdConnectorDetach struct {
+	CobraCmd *cobra.Command
+	Flags    *common.CommandConnectorDetachFlags
+}
+
+func NewCmdConnectorDetach() *CmdConnectorDetach {
+	return &CmdConnectorDetach{}
+}
+
+func (cmd *CmdConnectorDetach) NewClient(cobraCommand *cobra.Command, args []string) {}
+func (cmd *CmdConnectorDetach) ValidateInput(args []string) error                    { return nil }
+func (cmd *CmdConnectorDetach) InputToOptions()                                      {}
+func (cmd *CmdConnectorDetach) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdConnectorDetach) WaitUntil() error { return nil }
@@ -78,7 +78,7 @@ func TestNonKubeCmdConnectorGenerate_ValidateInput(t *testing.T) {
 			name:          "type is not valid",
 			args:          []string{"my-connector", "8080"},
 			flags:         &common.CommandConnectorGenerateFlags{ConnectorType: "not-valid", Host: "1.2.3.4"},
-			expectedError: "connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp]",
+			expectedError: "connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp http http2]",
 		},
 		{
 			name:          "routing key is not valid",
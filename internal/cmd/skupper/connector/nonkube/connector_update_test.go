@@ -70,7 +70,7 @@ func TestCmdConnectorUpdate_ValidateInput(t *testing.T) {
 			name:          "connector type is not valid",
 			args:          []string{"my-connector"},
 			flags:         &common.CommandConnectorUpdateFlags{ConnectorType: "not-valid", Host: "localhost"},
-			expectedError: "connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp]",
+			expectedError: "connector type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp http http2]",
 		},
 		{
 			name:          "routing key is not valid",
@@ -83,7 +83,7 @@ func TestCmdListenerGenerate_ValidateInput(t *testing.T) {
 			name:          "listener type is not valid",
 			args:          []string{"my-listener-type", "8080"},
 			flags:         common.CommandListenerGenerateFlags{ListenerType: "not-valid"},
-			expectedError: "listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp]",
+			expectedError: "listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp http http2]",
 		},
 		{
 			name:          "routing key is not valid",
@@ -74,7 +74,7 @@ func TestNonKubeCmdListenerCreate_ValidateInput(t *testing.T) {
 			name:          "type is not valid",
 			args:          []string{"my-listener", "8080"},
 			flags:         &common.CommandListenerCreateFlags{ListenerType: "not-valid", Host: "1.2.3.4"},
-			expectedError: "listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp]",
+			expectedError: "listener type is not valid: value not-valid not allowed. It should be one of this options: [tcp udp http http2]",
 		},
 		{
 			name:          "routing key is not valid",
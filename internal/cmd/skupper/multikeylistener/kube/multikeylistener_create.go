@@ -0,0 +1,224 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdMultiKeyListenerCreate struct {
+	client         skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd       *cobra.Command
+	Flags          *common.CommandMultiKeyListenerCreateFlags
+	namespace      string
+	name           string
+	port           int
+	host           string
+	tlsCredentials string
+	strategy       v2alpha1.MultiKeyListenerStrategy
+	timeout        time.Duration
+	KubeClient     kubernetes.Interface
+	status         string
+}
+
+func NewCmdMultiKeyListenerCreate() *CmdMultiKeyListenerCreate {
+
+	return &CmdMultiKeyListenerCreate{}
+
+}
+
+func (cmd *CmdMultiKeyListenerCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+	cmd.KubeClient = cli.Kube
+}
+
+func (cmd *CmdMultiKeyListenerCreate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if MultiKeyListener CRD is installed
+	_, err := cmd.client.MultiKeyListeners(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name and port
+	if len(args) < 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name and port must be configured"))
+	} else if len(args) > 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("only two arguments are allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must not be empty"))
+	} else if args[1] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+
+		cmd.port, err = strconv.Atoi(args[1])
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port is not valid: %s", err))
+		}
+		ok, err = numberValidator.Evaluate(cmd.port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port is not valid: %s", err))
+		}
+	}
+
+	// Validate if there is already a multikeylistener with this name in the namespace
+	if cmd.name != "" {
+		mkl, err := cmd.client.MultiKeyListeners(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if mkl != nil && !k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("There is already a multikeylistener %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		strategy, err := utils.MultiKeyListenerStrategy(cmd.Flags.Priority, cmd.Flags.Weight, cmd.Flags.Closest)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("strategy is not valid: %s", err))
+		} else {
+			cmd.strategy = strategy
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		// check that the secret exists
+		_, err := cmd.KubeClient.CoreV1().Secrets(cmd.namespace).Get(context.TODO(), cmd.Flags.TlsCredentials, metav1.GetOptions{})
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-secret is not valid: does not exist"))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdMultiKeyListenerCreate) InputToOptions() {
+	// default host to name of multikeylistener
+	if cmd.Flags.Host == "" {
+		cmd.host = cmd.name
+	} else {
+		cmd.host = cmd.Flags.Host
+	}
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdMultiKeyListenerCreate) Run() error {
+
+	resource := v2alpha1.MultiKeyListener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "MultiKeyListener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.MultiKeyListenerSpec{
+			Host:           cmd.host,
+			Port:           cmd.port,
+			TlsCredentials: cmd.tlsCredentials,
+			Strategy:       cmd.strategy,
+		},
+	}
+
+	_, err := cmd.client.MultiKeyListeners(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+	return err
+}
+
+func (cmd *CmdMultiKeyListenerCreate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+	var mklCondition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for create to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.MultiKeyListeners(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			mklCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			mklCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if mklCondition != nil {
+			isConditionFound = true
+			isConditionTrue = mklCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && mklCondition == nil {
+		return fmt.Errorf("MultiKeyListener %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && mklCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("MultiKeyListener %q is not yet %s: %s\n", cmd.name, cmd.status, mklCondition.Message)
+	}
+
+	fmt.Printf("MultiKeyListener %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
@@ -0,0 +1,330 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdMultiKeyListenerCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandMultiKeyListenerCreateFlags
+		k8sObjects          []runtime.Object
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			flags:               common.CommandMultiKeyListenerCreateFlags{},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:  "multikeylistener is not created because there is already the same multikeylistener in the namespace",
+			args:  []string{"my-mkl", "8080"},
+			flags: common.CommandMultiKeyListenerCreateFlags{Timeout: 1 * time.Minute, Priority: []string{"a"}},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.MultiKeyListener{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-mkl",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "There is already a multikeylistener my-mkl created for namespace test",
+		},
+		{
+			name:          "multikeylistener name and port are not specified",
+			args:          []string{},
+			flags:         common.CommandMultiKeyListenerCreateFlags{Timeout: 1 * time.Minute, Priority: []string{"a"}},
+			expectedError: "multikeylistener name and port must be configured",
+		},
+		{
+			name:          "more than two arguments are specified",
+			args:          []string{"my", "mkl", "8080"},
+			flags:         common.CommandMultiKeyListenerCreateFlags{Timeout: 1 * time.Minute, Priority: []string{"a"}},
+			expectedError: "only two arguments are allowed for this command",
+		},
+		{
+			name:          "multikeylistener port not positive",
+			args:          []string{"my-port-positive", "-45"},
+			flags:         common.CommandMultiKeyListenerCreateFlags{Timeout: 1 * time.Minute, Priority: []string{"a"}},
+			expectedError: "multikeylistener port is not valid: value is not positive",
+		},
+		{
+			name:          "strategy is not specified",
+			args:          []string{"my-mkl", "8080"},
+			flags:         common.CommandMultiKeyListenerCreateFlags{Timeout: 1 * time.Minute},
+			expectedError: "strategy is not valid: one of --priority, --weight or --closest must be specified",
+		},
+		{
+			name: "more than one strategy is specified",
+			args: []string{"my-mkl", "8080"},
+			flags: common.CommandMultiKeyListenerCreateFlags{
+				Timeout:  1 * time.Minute,
+				Priority: []string{"a"},
+				Weight:   map[string]int{"b": 1},
+			},
+			expectedError: "strategy is not valid: only one of --priority, --weight or --closest can be specified",
+		},
+		{
+			name: "tls-secret does not exist",
+			args: []string{"my-mkl", "8080"},
+			flags: common.CommandMultiKeyListenerCreateFlags{
+				Timeout:        1 * time.Minute,
+				Weight:         map[string]int{"a": 1},
+				TlsCredentials: "not-valid",
+			},
+			expectedError: "tls-secret is not valid: does not exist",
+		},
+		{
+			name:          "timeout is not valid",
+			args:          []string{"bad-timeout", "8080"},
+			flags:         common.CommandMultiKeyListenerCreateFlags{Timeout: 0 * time.Second, Closest: []string{"a"}},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:          "wait status is not valid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         common.CommandMultiKeyListenerCreateFlags{Timeout: time.Minute, Closest: []string{"a"}, Wait: "created"},
+			expectedError: "status is not valid: value created not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-mkl-flags", "8080"},
+			flags: common.CommandMultiKeyListenerCreateFlags{
+				Host:           "hostname",
+				TlsCredentials: "secretname",
+				Weight:         map[string]int{"backend-east": 3, "backend-west": 1},
+				Timeout:        1 * time.Minute,
+			},
+			k8sObjects: []runtime.Object{
+				&v12.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "secretname",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdMultiKeyListenerCreateWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerCreate_InputToOptions(t *testing.T) {
+
+	type test struct {
+		name                   string
+		flags                  common.CommandMultiKeyListenerCreateFlags
+		expectedTlsCredentials string
+		expectedHost           string
+		expectedTimeout        time.Duration
+		expectedStatus         string
+	}
+
+	testTable := []test{
+		{
+			name: "test1",
+			flags: common.CommandMultiKeyListenerCreateFlags{
+				Host:           "backend",
+				TlsCredentials: "secret",
+				Timeout:        20 * time.Second,
+				Wait:           "configured",
+			},
+			expectedTlsCredentials: "secret",
+			expectedHost:           "backend",
+			expectedTimeout:        20 * time.Second,
+			expectedStatus:         "configured",
+		},
+		{
+			name: "test2",
+			flags: common.CommandMultiKeyListenerCreateFlags{
+				Timeout: 30 * time.Second,
+				Wait:    "ready",
+			},
+			expectedHost:    "test2",
+			expectedTimeout: 30 * time.Second,
+			expectedStatus:  "ready",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			cmd, err := newCmdMultiKeyListenerCreateWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+
+			cmd.Flags = &test.flags
+			cmd.name = test.name
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.tlsCredentials == test.expectedTlsCredentials)
+			assert.Check(t, cmd.host == test.expectedHost)
+			assert.Check(t, cmd.timeout == test.expectedTimeout)
+			assert.Check(t, cmd.status == test.expectedStatus)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerCreate_Run(t *testing.T) {
+	cmd, err := newCmdMultiKeyListenerCreateWithMocks("test", nil, nil, "")
+	assert.Assert(t, err)
+	cmd.name = "run-mkl"
+	cmd.port = 8080
+	cmd.host = "hostname"
+	cmd.strategy = v2alpha1.MultiKeyListenerStrategy{
+		Priority: &v2alpha1.PriorityStrategySpec{RoutingKeys: []string{"a", "b"}},
+	}
+
+	assert.Assert(t, cmd.Run())
+
+	created, err := cmd.client.MultiKeyListeners("test").Get(context.TODO(), "run-mkl", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, created.Spec.Port, 8080)
+	assert.Equal(t, created.Spec.Host, "hostname")
+	assert.DeepEqual(t, created.Spec.Strategy.Priority.RoutingKeys, []string{"a", "b"})
+}
+
+func TestCmdMultiKeyListenerCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:        "multikeylistener is not returned",
+			expectError: true,
+		},
+		{
+			name:   "multikeylistener is not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.MultiKeyListener{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-mkl",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:   "multikeylistener is ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.MultiKeyListener{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-mkl",
+						Namespace: "test",
+					},
+					Status: v2alpha1.MultiKeyListenerStatus{
+						Conditions: []v1.Condition{
+							{
+								Type:   "Ready",
+								Status: "True",
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+		{
+			name:   "user waits for configured, but multikeylistener had some errors while being configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.MultiKeyListener{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-mkl",
+						Namespace: "test",
+					},
+					Status: v2alpha1.MultiKeyListenerStatus{
+						Conditions: []v1.Condition{
+							{
+								Message: "Error",
+								Reason:  "Error",
+								Status:  "False",
+								Type:    "Configured",
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdMultiKeyListenerCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-mkl"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdMultiKeyListenerCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdMultiKeyListenerCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdMultiKeyListenerCreate := &CmdMultiKeyListenerCreate{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdMultiKeyListenerCreate, nil
+}
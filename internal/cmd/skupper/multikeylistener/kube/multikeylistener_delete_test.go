@@ -0,0 +1,180 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdMultiKeyListenerDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandMultiKeyListenerDeleteFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-mkl"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "multikeylistener is not deleted because multikeylistener does not exist in the namespace",
+			args:          []string{"my-mkl"},
+			flags:         common.CommandMultiKeyListenerDeleteFlags{Timeout: 1 * time.Minute},
+			expectedError: "multikeylistener my-mkl does not exist in namespace test",
+		},
+		{
+			name:          "multikeylistener name is not specified",
+			args:          []string{},
+			flags:         common.CommandMultiKeyListenerDeleteFlags{Timeout: 1 * time.Minute},
+			expectedError: "multikeylistener name must be specified",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "mkl"},
+			flags:         common.CommandMultiKeyListenerDeleteFlags{Timeout: 1 * time.Minute},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-mkl"},
+			flags:          common.CommandMultiKeyListenerDeleteFlags{Timeout: 0 * time.Second},
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+			expectedError:  "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:           "multikeylistener is deleted",
+			args:           []string{"my-mkl"},
+			flags:          common.CommandMultiKeyListenerDeleteFlags{Timeout: 1 * time.Minute},
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdMultiKeyListenerDeleteWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerDelete_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:           "runs ok",
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdMultiKeyListenerDeleteWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+
+		cmd.name = "my-mkl"
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerDelete_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "error deleting multikeylistener",
+			wait:           true,
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+			expectError:    true,
+		},
+		{
+			name:        "multikeylistener is deleted",
+			wait:        true,
+			expectError: false,
+		},
+		{
+			name:           "multikeylistener is not deleted but user does not want to wait",
+			wait:           false,
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+			expectError:    false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdMultiKeyListenerDeleteWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-mkl"
+		cmd.Flags = &common.CommandMultiKeyListenerDeleteFlags{Timeout: 1 * time.Second}
+		cmd.wait = test.wait
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdMultiKeyListenerDeleteWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdMultiKeyListenerDelete, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdMultiKeyListenerDelete := &CmdMultiKeyListenerDelete{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdMultiKeyListenerDelete, nil
+}
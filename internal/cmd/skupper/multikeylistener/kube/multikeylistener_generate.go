@@ -0,0 +1,137 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdMultiKeyListenerGenerate struct {
+	client         skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd       *cobra.Command
+	Flags          *common.CommandMultiKeyListenerGenerateFlags
+	namespace      string
+	name           string
+	port           int
+	host           string
+	tlsCredentials string
+	strategy       v2alpha1.MultiKeyListenerStrategy
+	output         string
+}
+
+func NewCmdMultiKeyListenerGenerate() *CmdMultiKeyListenerGenerate {
+
+	return &CmdMultiKeyListenerGenerate{}
+
+}
+
+func (cmd *CmdMultiKeyListenerGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
+
+	cmd.namespace = cobraCommand.Flag("namespace").Value.String()
+}
+
+func (cmd *CmdMultiKeyListenerGenerate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name and port
+	if len(args) < 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name and port must be configured"))
+	} else if len(args) > 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("only two arguments are allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must not be empty"))
+	} else if args[1] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+
+		cmd.port, err = strconv.Atoi(args[1])
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port is not valid: %s", err))
+		}
+		ok, err = numberValidator.Evaluate(cmd.port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port is not valid: %s", err))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		strategy, err := utils.MultiKeyListenerStrategy(cmd.Flags.Priority, cmd.Flags.Weight, cmd.Flags.Closest)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("strategy is not valid: %s", err))
+		} else {
+			cmd.strategy = strategy
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tlsCredentials is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdMultiKeyListenerGenerate) InputToOptions() {
+	// default host to name of multikeylistener
+	if cmd.Flags.Host == "" {
+		cmd.host = cmd.name
+	} else {
+		cmd.host = cmd.Flags.Host
+	}
+
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdMultiKeyListenerGenerate) Run() error {
+
+	resource := v2alpha1.MultiKeyListener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "MultiKeyListener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.MultiKeyListenerSpec{
+			Host:           cmd.host,
+			Port:           cmd.port,
+			TlsCredentials: cmd.tlsCredentials,
+			Strategy:       cmd.strategy,
+		},
+	}
+
+	encodedOutput, err := utils.Encode(cmd.output, resource)
+	fmt.Println(encodedOutput)
+	return err
+}
+
+func (cmd *CmdMultiKeyListenerGenerate) WaitUntil() error { return nil }
@@ -0,0 +1,111 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdMultiKeyListenerGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandMultiKeyListenerGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "multikeylistener name and port are not specified",
+			args:          []string{},
+			flags:         common.CommandMultiKeyListenerGenerateFlags{Priority: []string{"a"}},
+			expectedError: "multikeylistener name and port must be configured",
+		},
+		{
+			name:          "port is not valid",
+			args:          []string{"my-mkl", "abcd"},
+			flags:         common.CommandMultiKeyListenerGenerateFlags{Priority: []string{"a"}},
+			expectedError: "multikeylistener port is not valid: strconv.Atoi: parsing \"abcd\": invalid syntax",
+		},
+		{
+			name:          "routing key is not valid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         common.CommandMultiKeyListenerGenerateFlags{Closest: []string{"not-valid$"}},
+			expectedError: "strategy is not valid: routing key \"not-valid$\" is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         common.CommandMultiKeyListenerGenerateFlags{Priority: []string{"a"}, Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-mkl", "8080"},
+			flags: common.CommandMultiKeyListenerGenerateFlags{
+				Host:           "hostname",
+				TlsCredentials: "secretname",
+				Weight:         map[string]int{"a": 2, "b": 1},
+				Output:         "yaml",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command := &CmdMultiKeyListenerGenerate{namespace: "test"}
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerGenerate_InputToOptions(t *testing.T) {
+	command := &CmdMultiKeyListenerGenerate{name: "my-mkl"}
+	command.Flags = &common.CommandMultiKeyListenerGenerateFlags{Output: "json"}
+
+	command.InputToOptions()
+
+	assert.Equal(t, command.host, "my-mkl")
+	assert.Equal(t, command.output, "json")
+}
+
+func TestCmdMultiKeyListenerGenerate_Run(t *testing.T) {
+	type test struct {
+		name   string
+		output string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok yaml",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok json",
+			output: "json",
+		},
+	}
+
+	for _, test := range testTable {
+		command := &CmdMultiKeyListenerGenerate{
+			namespace: "test",
+			name:      "my-mkl",
+			port:      8080,
+			host:      "my-mkl",
+			strategy: v2alpha1.MultiKeyListenerStrategy{
+				Closest: &v2alpha1.ClosestStrategySpec{RoutingKeys: []string{"east", "west"}},
+			},
+			output: test.output,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			assert.Assert(t, command.Run())
+		})
+	}
+}
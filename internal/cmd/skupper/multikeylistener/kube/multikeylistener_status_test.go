@@ -0,0 +1,162 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdMultiKeyListenerStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandMultiKeyListenerStatusFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-mkl"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "multikeylistener is not shown because multikeylistener does not exist in the namespace",
+			args:          []string{"my-mkl"},
+			expectedError: "multikeylistener my-mkl does not exist in namespace test",
+		},
+		{
+			name:          "multikeylistener name is nil",
+			args:          []string{""},
+			expectedError: "multikeylistener name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "mkl"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			expectedError: "",
+		},
+		{
+			name:           "bad output status",
+			args:           []string{"my-mkl"},
+			flags:          common.CommandMultiKeyListenerStatusFlags{Output: "not-supported"},
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+			expectedError:  "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:           "good output status",
+			args:           []string{"my-mkl"},
+			flags:          common.CommandMultiKeyListenerStatusFlags{Output: "json"},
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdMultiKeyListenerStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerStatus_Run(t *testing.T) {
+	type test struct {
+		name           string
+		mklName        string
+		output         string
+		skupperObjects []runtime.Object
+		skupperError   string
+		errorMessage   string
+	}
+
+	reachable := existingMultiKeyListener()
+	reachable.Status = v2alpha1.MultiKeyListenerStatus{
+		StatusType:     v2alpha1.StatusReady,
+		HasDestination: true,
+		Strategy: &v2alpha1.StrategyStatus{
+			Priority: &v2alpha1.PriorityStrategyStatus{RoutingKeysReachable: []string{"b"}},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:         "run fails",
+			mklName:      "my-mkl",
+			skupperError: "error getting the resource",
+			errorMessage: "error getting the resource",
+		},
+		{
+			name:           "runs ok, returns all multikeylisteners",
+			skupperObjects: []runtime.Object{reachable},
+		},
+		{
+			name:           "runs ok, returns one multikeylistener",
+			mklName:        "my-mkl",
+			skupperObjects: []runtime.Object{reachable},
+		},
+		{
+			name:           "runs ok, returns all multikeylisteners yaml",
+			output:         "yaml",
+			skupperObjects: []runtime.Object{reachable},
+		},
+		{
+			name:           "runs ok, returns one multikeylistener json",
+			mklName:        "my-mkl",
+			output:         "json",
+			skupperObjects: []runtime.Object{reachable},
+		},
+		{
+			name:    "returns no multikeylisteners",
+			mklName: "",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdMultiKeyListenerStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+		assert.Assert(t, err)
+
+		cmd.name = test.mklName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdMultiKeyListenerStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdMultiKeyListenerStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdMultiKeyListenerStatus := &CmdMultiKeyListenerStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdMultiKeyListenerStatus, nil
+}
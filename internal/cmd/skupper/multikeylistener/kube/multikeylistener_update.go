@@ -0,0 +1,211 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdMultiKeyListenerUpdate struct {
+	client          skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd        *cobra.Command
+	Flags           *common.CommandMultiKeyListenerUpdateFlags
+	namespace       string
+	name            string
+	resourceVersion string
+	newSettings     v2alpha1.MultiKeyListenerSpec
+	KubeClient      kubernetes.Interface
+	status          string
+}
+
+func NewCmdMultiKeyListenerUpdate() *CmdMultiKeyListenerUpdate {
+
+	return &CmdMultiKeyListenerUpdate{}
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+	cmd.KubeClient = cli.Kube
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if MultiKeyListener CRD is installed
+	_, err := cmd.client.MultiKeyListeners(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate that there is already a multikeylistener with this name in the namespace
+	existing := false
+	if cmd.name != "" {
+		mkl, err := cmd.client.MultiKeyListeners(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if mkl == nil || k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener %s must exist in namespace %s to be updated", cmd.name, cmd.namespace))
+		} else {
+			// save existing values
+			existing = true
+			cmd.resourceVersion = mkl.ResourceVersion
+			cmd.newSettings = mkl.Spec
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil && (len(cmd.Flags.Priority) > 0 || len(cmd.Flags.Weight) > 0 || len(cmd.Flags.Closest) > 0) {
+		strategy, err := utils.MultiKeyListenerStrategy(cmd.Flags.Priority, cmd.Flags.Weight, cmd.Flags.Closest)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("strategy is not valid: %s", err))
+		} else if current := utils.MultiKeyListenerStrategyName(cmd.newSettings.Strategy); existing && current != utils.MultiKeyListenerStrategyName(strategy) {
+			validationErrors = append(validationErrors, fmt.Errorf("strategy is not valid: the %s strategy of multikeylistener %s cannot be changed", current, cmd.name))
+		} else {
+			cmd.newSettings.Strategy = strategy
+		}
+	}
+	// TBD what validation should be done
+	if cmd.Flags != nil && cmd.Flags.Host != "" {
+		cmd.newSettings.Host = cmd.Flags.Host
+	}
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		_, err := cmd.KubeClient.CoreV1().Secrets(cmd.namespace).Get(context.TODO(), cmd.Flags.TlsCredentials, metav1.GetOptions{})
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-secret is not valid: does not exist"))
+		} else {
+			cmd.newSettings.TlsCredentials = cmd.Flags.TlsCredentials
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Port != 0 {
+		ok, err := numberValidator.Evaluate(cmd.Flags.Port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port is not valid: %s", err))
+		} else {
+			cmd.newSettings.Port = cmd.Flags.Port
+		}
+	}
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) Run() error {
+
+	resource := v2alpha1.MultiKeyListener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "MultiKeyListener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cmd.name,
+			Namespace:       cmd.namespace,
+			ResourceVersion: cmd.resourceVersion},
+		Spec: cmd.newSettings,
+	}
+
+	_, err := cmd.client.MultiKeyListeners(cmd.namespace).Update(context.TODO(), &resource, metav1.UpdateOptions{})
+	return err
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.Flags.Timeout.Seconds())
+	var mklCondition *metav1.Condition
+	err := utils.NewSpinnerWithTimeout("Waiting for update to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.MultiKeyListeners(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			mklCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			mklCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if mklCondition != nil {
+			isConditionFound = true
+			isConditionTrue = mklCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && mklCondition == nil {
+		return fmt.Errorf("MultiKeyListener %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && mklCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("MultiKeyListener %q is not yet %s: %s\n", cmd.name, cmd.status, mklCondition.Message)
+	}
+
+	fmt.Printf("MultiKeyListener %q is updated\n", cmd.name)
+	return nil
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) InputToOptions() {
+	cmd.status = cmd.Flags.Wait
+}
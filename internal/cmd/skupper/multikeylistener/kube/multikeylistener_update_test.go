@@ -0,0 +1,224 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func existingMultiKeyListener() *v2alpha1.MultiKeyListener {
+	return &v2alpha1.MultiKeyListener{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-mkl",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.MultiKeyListenerSpec{
+			Host: "my-mkl",
+			Port: 8080,
+			Strategy: v2alpha1.MultiKeyListenerStrategy{
+				Priority: &v2alpha1.PriorityStrategySpec{RoutingKeys: []string{"a", "b"}},
+			},
+		},
+	}
+}
+
+func TestCmdMultiKeyListenerUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandMultiKeyListenerUpdateFlags
+		k8sObjects     []runtime.Object
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-mkl"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "multikeylistener is not updated because multikeylistener does not exist in the namespace",
+			args:          []string{"my-mkl"},
+			flags:         common.CommandMultiKeyListenerUpdateFlags{Timeout: 1 * time.Minute},
+			expectedError: "multikeylistener my-mkl must exist in namespace test to be updated",
+		},
+		{
+			name:          "multikeylistener name is not specified",
+			args:          []string{},
+			flags:         common.CommandMultiKeyListenerUpdateFlags{Timeout: 1 * time.Minute},
+			expectedError: "multikeylistener name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "mkl"},
+			flags:         common.CommandMultiKeyListenerUpdateFlags{Timeout: 1 * time.Minute},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:           "strategy type cannot be changed",
+			args:           []string{"my-mkl"},
+			flags:          common.CommandMultiKeyListenerUpdateFlags{Timeout: 1 * time.Minute, Weight: map[string]int{"a": 1}},
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+			expectedError:  "strategy is not valid: the priority strategy of multikeylistener my-mkl cannot be changed",
+		},
+		{
+			name:           "port is not valid",
+			args:           []string{"my-mkl"},
+			flags:          common.CommandMultiKeyListenerUpdateFlags{Timeout: 1 * time.Minute, Port: -1},
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+			expectedError:  "multikeylistener port is not valid: value is not positive",
+		},
+		{
+			name:           "tls-secret does not exist",
+			args:           []string{"my-mkl"},
+			flags:          common.CommandMultiKeyListenerUpdateFlags{Timeout: 1 * time.Minute, TlsCredentials: "not-valid"},
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+			expectedError:  "tls-secret is not valid: does not exist",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-mkl"},
+			flags: common.CommandMultiKeyListenerUpdateFlags{
+				Host:           "hostname",
+				TlsCredentials: "secretname",
+				Priority:       []string{"b", "a"},
+				Port:           9090,
+				Timeout:        1 * time.Minute,
+			},
+			skupperObjects: []runtime.Object{existingMultiKeyListener()},
+			k8sObjects: []runtime.Object{
+				&v12.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "secretname",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdMultiKeyListenerUpdateWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerUpdate_Run(t *testing.T) {
+	cmd, err := newCmdMultiKeyListenerUpdateWithMocks("test", nil, []runtime.Object{existingMultiKeyListener()}, "")
+	assert.Assert(t, err)
+	cmd.Flags = &common.CommandMultiKeyListenerUpdateFlags{
+		Priority: []string{"b", "a"},
+		Port:     9090,
+		Timeout:  1 * time.Minute,
+	}
+
+	assert.Assert(t, cmd.ValidateInput([]string{"my-mkl"}))
+	assert.Assert(t, cmd.Run())
+
+	updated, err := cmd.client.MultiKeyListeners("test").Get(context.TODO(), "my-mkl", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, updated.Spec.Port, 9090)
+	assert.Equal(t, updated.Spec.Host, "my-mkl")
+	assert.DeepEqual(t, updated.Spec.Strategy.Priority.RoutingKeys, []string{"b", "a"})
+}
+
+func TestCmdMultiKeyListenerUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	configured := existingMultiKeyListener()
+	configured.Status.Conditions = []v1.Condition{
+		{
+			Type:   "Configured",
+			Status: "True",
+		},
+	}
+
+	testTable := []test{
+		{
+			name:        "multikeylistener is not returned",
+			status:      "configured",
+			expectError: true,
+		},
+		{
+			name:           "multikeylistener is configured",
+			status:         "configured",
+			skupperObjects: []runtime.Object{configured},
+			expectError:    false,
+		},
+		{
+			name:           "multikeylistener is not ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{configured},
+			expectError:    true,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdMultiKeyListenerUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-mkl"
+		cmd.Flags = &common.CommandMultiKeyListenerUpdateFlags{Timeout: 1 * time.Second}
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdMultiKeyListenerUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdMultiKeyListenerUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdMultiKeyListenerUpdate := &CmdMultiKeyListenerUpdate{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdMultiKeyListenerUpdate, nil
+}
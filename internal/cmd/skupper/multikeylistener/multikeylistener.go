@@ -0,0 +1,188 @@
+package multikeylistener
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/multikeylistener/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/multikeylistener/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdMultiKeyListener() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "multikeylistener",
+		Short: "Binds a connection endpoint in the local site to target workloads in remote sites using more than one routing key.",
+		Long: `A multikeylistener is a connection endpoint in the local site and binds to target workloads in remote sites.
+A strategy determines how traffic is routed to the connectors matching each routing key.`,
+		Example: `skupper multikeylistener create my-listener 8080 --priority backend-east,backend-west
+skupper multikeylistener status my-listener`,
+	}
+
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdMultiKeyListenerCreateFactory(platform))
+	cmd.AddCommand(CmdMultiKeyListenerStatusFactory(platform))
+	cmd.AddCommand(CmdMultiKeyListenerUpdateFactory(platform))
+	cmd.AddCommand(CmdMultiKeyListenerDeleteFactory(platform))
+	cmd.AddCommand(CmdMultiKeyListenerGenerateFactory(platform))
+
+	return cmd
+}
+
+func CmdMultiKeyListenerCreateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdMultiKeyListenerCreate()
+	nonKubeCommand := nonkube.NewCmdMultiKeyListenerCreate()
+
+	cmdMultiKeyListenerCreateDesc := common.SkupperCmdDescription{
+		Use:   "create <name> <port>",
+		Short: "create a multikeylistener",
+		Long: `Clients at this site use the multikeylistener host and port to establish connections to the remote services.
+	One of --priority, --weight or --closest must be specified to select the routing strategy.`,
+		Example: `skupper multikeylistener create database 5432 --priority db-primary,db-replica
+skupper multikeylistener create database 5432 --weight db-east=3,db-west=1`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdMultiKeyListenerCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandMultiKeyListenerCreateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Host, common.FlagNameListenerHost, "", common.FlagDescListenerHost)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringSliceVar(&cmdFlags.Priority, common.FlagNamePriority, nil, common.FlagDescPriority)
+	cmd.Flags().StringToIntVar(&cmdFlags.Weight, common.FlagNameWeight, nil, common.FlagDescWeight)
+	cmd.Flags().StringSliceVar(&cmdFlags.Closest, common.FlagNameClosest, nil, common.FlagDescClosest)
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdMultiKeyListenerUpdateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdMultiKeyListenerUpdate()
+	nonKubeCommand := nonkube.NewCmdMultiKeyListenerUpdate()
+
+	cmdMultiKeyListenerUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update a multikeylistener",
+		Long: `Clients at this site use the multikeylistener host and port to establish connections to the remote services.
+	The user can change port, host name, TLS credentials and the routing keys of the strategy.
+	The type of strategy cannot be changed.`,
+		Example: "skupper multikeylistener update database --priority db-replica,db-primary",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdMultiKeyListenerUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandMultiKeyListenerUpdateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Host, common.FlagNameListenerHost, "", common.FlagDescListenerHost)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringSliceVar(&cmdFlags.Priority, common.FlagNamePriority, nil, common.FlagDescPriority)
+	cmd.Flags().StringToIntVar(&cmdFlags.Weight, common.FlagNameWeight, nil, common.FlagDescWeight)
+	cmd.Flags().StringSliceVar(&cmdFlags.Closest, common.FlagNameClosest, nil, common.FlagDescClosest)
+	cmd.Flags().IntVar(&cmdFlags.Port, common.FlagNameListenerPort, 0, common.FlagDescListenerPort)
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdMultiKeyListenerStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdMultiKeyListenerStatus()
+	nonKubeCommand := nonkube.NewCmdMultiKeyListenerStatus()
+
+	cmdMultiKeyListenerStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of multikeylisteners",
+		Long:    "Display status of all multikeylisteners or a specific multikeylistener",
+		Example: "skupper multikeylistener status database",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdMultiKeyListenerStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandMultiKeyListenerStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdMultiKeyListenerDeleteFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdMultiKeyListenerDelete()
+	nonKubeCommand := nonkube.NewCmdMultiKeyListenerDelete()
+
+	cmdMultiKeyListenerDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete a multikeylistener",
+		Long:    "Delete a multikeylistener <name>",
+		Example: "skupper multikeylistener delete database",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdMultiKeyListenerDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandMultiKeyListenerDeleteFlags{}
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdMultiKeyListenerGenerateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdMultiKeyListenerGenerate()
+	nonKubeCommand := nonkube.NewCmdMultiKeyListenerGenerate()
+
+	cmdMultiKeyListenerGenerateDesc := common.SkupperCmdDescription{
+		Use:   "generate <name> <port>",
+		Short: "generate a multikeylistener resource and output it to a file or screen",
+		Long: `Clients at this site use the multikeylistener host and port to establish connections to the remote services.
+	generate a multikeylistener to evaluate what will be created with multikeylistener create command`,
+		Example: "skupper multikeylistener generate database 5432 --closest db-east,db-west",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdMultiKeyListenerGenerateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandMultiKeyListenerGenerateFlags{}
+
+	cmd.Flags().StringVar(&cmdFlags.Host, common.FlagNameListenerHost, "", common.FlagDescListenerHost)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringSliceVar(&cmdFlags.Priority, common.FlagNamePriority, nil, common.FlagDescPriority)
+	cmd.Flags().StringToIntVar(&cmdFlags.Weight, common.FlagNameWeight, nil, common.FlagDescWeight)
+	cmd.Flags().StringSliceVar(&cmdFlags.Closest, common.FlagNameClosest, nil, common.FlagDescClosest)
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "yaml", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
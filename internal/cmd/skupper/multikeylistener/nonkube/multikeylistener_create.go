@@ -0,0 +1,157 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdMultiKeyListenerCreate struct {
+	multiKeyListenerHandler *fs.MultiKeyListenerHandler
+	CobraCmd                *cobra.Command
+	Flags                   *common.CommandMultiKeyListenerCreateFlags
+	namespace               string
+	multiKeyListenerName    string
+	port                    int
+	host                    string
+	tlsCredentials          string
+	strategy                v2alpha1.MultiKeyListenerStrategy
+}
+
+func NewCmdMultiKeyListenerCreate() *CmdMultiKeyListenerCreate {
+	return &CmdMultiKeyListenerCreate{}
+}
+
+func (cmd *CmdMultiKeyListenerCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(cmd.namespace)
+}
+
+func (cmd *CmdMultiKeyListenerCreate) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	namespaceStringValidator := validator.NamespaceStringValidator()
+
+	if cmd.namespace != "" {
+		ok, err := namespaceStringValidator.Evaluate(cmd.namespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("namespace is not valid: %s", err))
+		}
+	}
+
+	// Validate arguments name and port
+	if len(args) < 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name and port must be configured"))
+	} else if len(args) > 2 {
+		validationErrors = append(validationErrors, fmt.Errorf("only two arguments are allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must not be empty"))
+	} else if args[1] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name is not valid: %s", err))
+		} else {
+			cmd.multiKeyListenerName = args[0]
+		}
+
+		cmd.port, err = strconv.Atoi(args[1])
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port is not valid: %s", err))
+		}
+		ok, err = numberValidator.Evaluate(cmd.port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port is not valid: %s", err))
+		}
+	}
+
+	// Validate flags
+	strategy, err := utils.MultiKeyListenerStrategy(cmd.Flags.Priority, cmd.Flags.Weight, cmd.Flags.Closest)
+	if err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("strategy is not valid: %s", err))
+	} else {
+		cmd.strategy = strategy
+	}
+
+	if cmd.Flags.Host != "" {
+		ip := net.ParseIP(cmd.Flags.Host)
+		ok, _ := hostStringValidator.Evaluate(cmd.Flags.Host)
+		if !ok && ip == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("host is not valid: a valid IP address or hostname is expected"))
+		}
+	}
+
+	if cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tlsCredentials value is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdMultiKeyListenerCreate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+
+	if cmd.Flags.Host == "" {
+		cmd.host = "0.0.0.0"
+	} else {
+		cmd.host = cmd.Flags.Host
+	}
+
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+}
+
+func (cmd *CmdMultiKeyListenerCreate) Run() error {
+	multiKeyListenerResource := v2alpha1.MultiKeyListener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "MultiKeyListener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.multiKeyListenerName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.MultiKeyListenerSpec{
+			Host:           cmd.host,
+			Port:           cmd.port,
+			TlsCredentials: cmd.tlsCredentials,
+			Strategy:       cmd.strategy,
+		},
+	}
+
+	err := cmd.multiKeyListenerHandler.Add(multiKeyListenerResource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *CmdMultiKeyListenerCreate) WaitUntil() error { return nil }
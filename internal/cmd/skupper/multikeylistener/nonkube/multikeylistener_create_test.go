@@ -0,0 +1,185 @@
+package nonkube
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdMultiKeyListenerCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		namespace         string
+		args              []string
+		flags             *common.CommandMultiKeyListenerCreateFlags
+		cobraGenericFlags map[string]string
+		expectedError     string
+	}
+
+	testTable := []test{
+		{
+			name:          "multikeylistener name and port are not specified",
+			namespace:     "test",
+			args:          []string{},
+			flags:         &common.CommandMultiKeyListenerCreateFlags{Priority: []string{"a"}},
+			expectedError: "multikeylistener name and port must be configured",
+		},
+		{
+			name:          "multikeylistener name is not valid",
+			namespace:     "test",
+			args:          []string{"my new mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerCreateFlags{Priority: []string{"a"}},
+			expectedError: "multikeylistener name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "port is not valid",
+			args:          []string{"my-mkl", "abcd"},
+			flags:         &common.CommandMultiKeyListenerCreateFlags{Priority: []string{"a"}},
+			expectedError: "multikeylistener port is not valid: strconv.Atoi: parsing \"abcd\": invalid syntax",
+		},
+		{
+			name:          "strategy is not specified",
+			args:          []string{"my-mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerCreateFlags{},
+			expectedError: "strategy is not valid: one of --priority, --weight or --closest must be specified",
+		},
+		{
+			name:          "weight is not valid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerCreateFlags{Weight: map[string]int{"a": -2}},
+			expectedError: "strategy is not valid: weight for routing key \"a\" is not valid: must not be negative",
+		},
+		{
+			name:          "host is not valid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerCreateFlags{Priority: []string{"a"}, Host: "not-valid$"},
+			expectedError: "host is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:          "TlsCredentials key is not valid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerCreateFlags{Priority: []string{"a"}, TlsCredentials: "not-valid$"},
+			expectedError: "tlsCredentials value is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:  "kubernetes flags are not valid on this platform",
+			args:  []string{"my-mkl", "8080"},
+			flags: &common.CommandMultiKeyListenerCreateFlags{Priority: []string{"a"}},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+		{
+			name:          "invalid namespace",
+			namespace:     "TestInvalid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerCreateFlags{Priority: []string{"a"}},
+			expectedError: "namespace is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-mkl", "8080"},
+			flags: &common.CommandMultiKeyListenerCreateFlags{
+				TlsCredentials: "secretname",
+				Host:           "1.2.3.4",
+				Closest:        []string{"east", "west"},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdMultiKeyListenerCreate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.namespace = test.namespace
+
+			if len(test.cobraGenericFlags) > 0 {
+				for name, value := range test.cobraGenericFlags {
+					command.CobraCmd.Flags().String(name, value, "")
+				}
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestNonKubeCmdMultiKeyListenerCreate_InputToOptions(t *testing.T) {
+	type test struct {
+		name                   string
+		namespace              string
+		flags                  common.CommandMultiKeyListenerCreateFlags
+		expectedTlsCredentials string
+		expectedHost           string
+		expectedNamespace      string
+	}
+
+	testTable := []test{
+		{
+			name:              "defaults",
+			expectedHost:      "0.0.0.0",
+			expectedNamespace: "default",
+		},
+		{
+			name:      "all set",
+			namespace: "test",
+			flags: common.CommandMultiKeyListenerCreateFlags{
+				Host:           "1.2.3.4",
+				TlsCredentials: "secret",
+			},
+			expectedTlsCredentials: "secret",
+			expectedHost:           "1.2.3.4",
+			expectedNamespace:      "test",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := CmdMultiKeyListenerCreate{}
+			cmd.Flags = &test.flags
+			cmd.multiKeyListenerName = "my-mkl"
+			cmd.namespace = test.namespace
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.tlsCredentials == test.expectedTlsCredentials)
+			assert.Check(t, cmd.host == test.expectedHost)
+			assert.Check(t, cmd.namespace == test.expectedNamespace)
+		})
+	}
+}
+
+func TestNonKubeCmdMultiKeyListenerCreate_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdMultiKeyListenerCreate{}
+	command.multiKeyListenerName = "my-mkl"
+	command.port = 8080
+	command.host = "0.0.0.0"
+	command.namespace = "test"
+	command.strategy = v2alpha1.MultiKeyListenerStrategy{
+		Weighted: &v2alpha1.WeightedStrategySpec{RoutingKeys: map[string]uint{"a": 3, "b": 1}},
+	}
+	command.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(command.namespace)
+	defer command.multiKeyListenerHandler.Delete("my-mkl")
+
+	assert.Assert(t, command.Run())
+
+	mkl, err := command.multiKeyListenerHandler.Get("my-mkl", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, mkl.Spec.Port, 8080)
+	assert.DeepEqual(t, mkl.Spec.Strategy.Weighted.RoutingKeys, map[string]uint{"a": 3, "b": 1})
+}
@@ -0,0 +1,97 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdMultiKeyListenerDelete struct {
+	multiKeyListenerHandler *fs.MultiKeyListenerHandler
+	CobraCmd                *cobra.Command
+	Flags                   *common.CommandMultiKeyListenerDeleteFlags
+	namespace               string
+	multiKeyListenerName    string
+}
+
+func NewCmdMultiKeyListenerDelete() *CmdMultiKeyListenerDelete {
+	return &CmdMultiKeyListenerDelete{}
+}
+
+func (cmd *CmdMultiKeyListenerDelete) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(cmd.namespace)
+
+}
+
+func (cmd *CmdMultiKeyListenerDelete) ValidateInput(args []string) error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: false, LogWarning: false}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+	namespaceStringValidator := validator.NamespaceStringValidator()
+
+	if cmd.namespace != "" {
+		ok, err := namespaceStringValidator.Evaluate(cmd.namespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("namespace is not valid: %s", err))
+		}
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must be specified"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name is not valid: %s", err))
+		} else {
+			cmd.multiKeyListenerName = args[0]
+		}
+	}
+
+	if cmd.multiKeyListenerName != "" {
+		// Validate that there is already a multikeylistener with this name
+		mkl, err := cmd.multiKeyListenerHandler.Get(cmd.multiKeyListenerName, opts)
+		if mkl == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener %s does not exist", cmd.multiKeyListenerName))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdMultiKeyListenerDelete) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+}
+
+func (cmd *CmdMultiKeyListenerDelete) Run() error {
+	err := cmd.multiKeyListenerHandler.Delete(cmd.multiKeyListenerName)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *CmdMultiKeyListenerDelete) WaitUntil() error { return nil }
@@ -0,0 +1,123 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdMultiKeyListenerDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		expectedError string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test/", string(api.InputSiteStatePath))
+
+	testTable := []test{
+		{
+			name:          "multikeylistener is not deleted because it does not exist",
+			namespace:     "test",
+			args:          []string{"no-mkl"},
+			expectedError: "multikeylistener no-mkl does not exist",
+		},
+		{
+			name:          "multikeylistener name is not specified",
+			namespace:     "test",
+			args:          []string{},
+			expectedError: "multikeylistener name must be specified",
+		},
+		{
+			name:          "more than one argument is specified",
+			namespace:     "test",
+			args:          []string{"my", "mkl"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "invalid namespace",
+			namespace:     "TestInvalid",
+			args:          []string{"my-mkl"},
+			expectedError: "namespace is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$\nmultikeylistener my-mkl does not exist",
+		},
+		{
+			name:      "multikeylistener exists",
+			namespace: "test",
+			args:      []string{"my-mkl"},
+		},
+	}
+
+	//Add a temp file so multikeylistener exists for delete tests
+	mklResource := v2alpha1.MultiKeyListener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "MultiKeyListener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-mkl",
+			Namespace: "test",
+		},
+	}
+
+	command := &CmdMultiKeyListenerDelete{Flags: &common.CommandMultiKeyListenerDeleteFlags{}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.namespace = "test"
+	command.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(command.namespace)
+
+	defer command.multiKeyListenerHandler.Delete("my-mkl")
+	content, err := command.multiKeyListenerHandler.EncodeToYaml(mklResource)
+	assert.Check(t, err == nil)
+	err = command.multiKeyListenerHandler.WriteFile(path, "my-mkl.yaml", content, common.MultiKeyListeners)
+	assert.Check(t, err == nil)
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command.multiKeyListenerName = ""
+			command.namespace = test.namespace
+			command.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(command.namespace)
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerDelete_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdMultiKeyListenerDelete{}
+	command.namespace = "test"
+	command.multiKeyListenerName = "my-mkl"
+	command.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(command.namespace)
+
+	err := command.Run()
+	assert.Check(t, err != nil)
+
+	err = command.multiKeyListenerHandler.Add(v2alpha1.MultiKeyListener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-mkl",
+			Namespace: "test",
+		},
+	})
+	assert.Assert(t, err)
+	assert.Assert(t, command.Run())
+}
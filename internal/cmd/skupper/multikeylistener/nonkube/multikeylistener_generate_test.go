@@ -0,0 +1,112 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdMultiKeyListenerGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		flags         *common.CommandMultiKeyListenerGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "multikeylistener name and port are not specified",
+			args:          []string{},
+			flags:         &common.CommandMultiKeyListenerGenerateFlags{Priority: []string{"a"}},
+			expectedError: "multikeylistener name and port must be configured",
+		},
+		{
+			name:          "more than one strategy is specified",
+			args:          []string{"my-mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerGenerateFlags{Priority: []string{"a"}, Closest: []string{"b"}},
+			expectedError: "strategy is not valid: only one of --priority, --weight or --closest can be specified",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerGenerateFlags{Priority: []string{"a"}, Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:          "invalid namespace",
+			namespace:     "TestInvalid",
+			args:          []string{"my-mkl", "8080"},
+			flags:         &common.CommandMultiKeyListenerGenerateFlags{Priority: []string{"a"}},
+			expectedError: "namespace is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-mkl", "8080"},
+			flags: &common.CommandMultiKeyListenerGenerateFlags{
+				Host:     "1.2.3.4",
+				Priority: []string{"a", "b"},
+				Output:   "yaml",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdMultiKeyListenerGenerate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.namespace = test.namespace
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerGenerate_Run(t *testing.T) {
+	type test struct {
+		name         string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok yaml",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok json",
+			output: "json",
+		},
+		{
+			name:         "output is not supported",
+			output:       "bad-value",
+			errorMessage: "format bad-value not supported",
+		},
+	}
+
+	for _, test := range testTable {
+		command := &CmdMultiKeyListenerGenerate{}
+		command.Flags = &common.CommandMultiKeyListenerGenerateFlags{Output: test.output}
+		command.multiKeyListenerName = "my-mkl"
+		command.port = 8080
+		command.strategy = v2alpha1.MultiKeyListenerStrategy{
+			Priority: &v2alpha1.PriorityStrategySpec{RoutingKeys: []string{"a", "b"}},
+		}
+		command.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
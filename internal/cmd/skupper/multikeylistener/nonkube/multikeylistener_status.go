@@ -0,0 +1,133 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type CmdMultiKeyListenerStatus struct {
+	multiKeyListenerHandler *fs.MultiKeyListenerHandler
+	CobraCmd                *cobra.Command
+	Flags                   *common.CommandMultiKeyListenerStatusFlags
+	namespace               string
+	multiKeyListenerName    string
+	output                  string
+}
+
+func NewCmdMultiKeyListenerStatus() *CmdMultiKeyListenerStatus {
+	return &CmdMultiKeyListenerStatus{}
+}
+
+func (cmd *CmdMultiKeyListenerStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(cmd.namespace)
+}
+
+func (cmd *CmdMultiKeyListenerStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: true, LogWarning: false}
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name is not valid: %s", err))
+			} else {
+				cmd.multiKeyListenerName = args[0]
+			}
+		}
+	}
+	// Validate that there is a multikeylistener with this name in the namespace
+	if cmd.multiKeyListenerName != "" {
+		mkl, err := cmd.multiKeyListenerHandler.Get(cmd.multiKeyListenerName, opts)
+		if mkl == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener %s does not exist in namespace %s", cmd.multiKeyListenerName, cmd.namespace))
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdMultiKeyListenerStatus) Run() error {
+	opts := fs.GetOptions{RuntimeFirst: true, LogWarning: true}
+	if cmd.multiKeyListenerName == "" {
+		resources, err := cmd.multiKeyListenerHandler.List()
+		if err != nil || resources == nil || len(resources) == 0 {
+			fmt.Println("No multikeylisteners found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, resource := range resources {
+				encodedOutput, err := utils.Encode(cmd.output, resource)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "HOST", "PORT", "STRATEGY", "HAS-DESTINATION", "REACHABLE-KEYS", "MESSAGE"))
+			for _, resource := range resources {
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%d\t%s\t%t\t%s\t%s",
+					resource.Name, resource.Status.StatusType, resource.Spec.Host, resource.Spec.Port,
+					utils.MultiKeyListenerStrategyName(resource.Spec.Strategy), resource.Status.HasDestination,
+					utils.MultiKeyListenerReachableKeys(resource.Status.Strategy), resource.Status.Message))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		resource, err := cmd.multiKeyListenerHandler.Get(cmd.multiKeyListenerName, opts)
+		if err != nil || resource == nil || k8serrs.IsNotFound(err) {
+			fmt.Println("No multikeylisteners found")
+			return err
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, resource)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nHost:\t%s\nPort:\t%d\nStrategy:\t%s\nHas Destination:\t%t\nReachable Routing Keys:\t%s\nMessage:\t%s\n",
+				resource.Name, resource.Status.StatusType, resource.Spec.Host, resource.Spec.Port,
+				utils.MultiKeyListenerStrategyName(resource.Spec.Strategy), resource.Status.HasDestination,
+				utils.MultiKeyListenerReachableKeys(resource.Status.Strategy), resource.Status.Message))
+			_ = tw.Flush()
+		}
+	}
+
+	return nil
+}
+
+func (cmd *CmdMultiKeyListenerStatus) InputToOptions()  {}
+func (cmd *CmdMultiKeyListenerStatus) WaitUntil() error { return nil }
@@ -0,0 +1,213 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdMultiKeyListenerStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandMultiKeyListenerStatusFlags
+		expectedError string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test/", string(api.RuntimeSiteStatePath))
+
+	testTable := []test{
+		{
+			name:          "multikeylistener is not shown because multikeylistener does not exist in the namespace",
+			args:          []string{"no-mkl"},
+			flags:         &common.CommandMultiKeyListenerStatusFlags{},
+			expectedError: "multikeylistener no-mkl does not exist in namespace test",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "mkl"},
+			flags:         &common.CommandMultiKeyListenerStatusFlags{},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			flags:         &common.CommandMultiKeyListenerStatusFlags{},
+			expectedError: "",
+		},
+		{
+			name:          "bad output status",
+			args:          []string{"my-mkl"},
+			flags:         &common.CommandMultiKeyListenerStatusFlags{Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:          "good output status",
+			args:          []string{"my-mkl"},
+			flags:         &common.CommandMultiKeyListenerStatusFlags{Output: "json"},
+			expectedError: "",
+		},
+	}
+
+	//Add a temp file so multikeylistener exists for status tests
+	mklResource := v2alpha1.MultiKeyListener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "MultiKeyListener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-mkl",
+			Namespace: "test",
+		},
+	}
+
+	command := &CmdMultiKeyListenerStatus{}
+	command.namespace = "test"
+	command.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(command.namespace)
+
+	defer command.multiKeyListenerHandler.Delete("my-mkl")
+	content, err := command.multiKeyListenerHandler.EncodeToYaml(mklResource)
+	assert.Check(t, err == nil)
+	err = command.multiKeyListenerHandler.WriteFile(path, "my-mkl.yaml", content, common.MultiKeyListeners)
+	assert.Check(t, err == nil)
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command.multiKeyListenerName = ""
+			command.Flags = test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerStatus_Run(t *testing.T) {
+	type test struct {
+		name         string
+		mklName      string
+		flags        common.CommandMultiKeyListenerStatusFlags
+		errorMessage string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test/", string(api.RuntimeSiteStatePath))
+
+	testTable := []test{
+		{
+			name:         "run fails multikeylistener doesn't exist",
+			mklName:      "no-mkl",
+			errorMessage: "no such file or directory",
+		},
+		{
+			name:    "runs ok, returns 1 multikeylistener",
+			mklName: "my-mkl",
+		},
+		{
+			name:    "runs ok, returns 1 multikeylistener yaml",
+			mklName: "my-mkl",
+			flags:   common.CommandMultiKeyListenerStatusFlags{Output: "yaml"},
+		},
+		{
+			name: "runs ok, returns all multikeylisteners",
+		},
+		{
+			name:  "runs ok, returns all multikeylisteners json",
+			flags: common.CommandMultiKeyListenerStatusFlags{Output: "json"},
+		},
+		{
+			name:         "runs ok, returns all multikeylisteners output bad",
+			flags:        common.CommandMultiKeyListenerStatusFlags{Output: "bad-value"},
+			errorMessage: "format bad-value not supported",
+		},
+	}
+
+	// add two multikeylisteners in runtime directory
+	mklResources := []v2alpha1.MultiKeyListener{
+		{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "skupper.io/v2alpha1",
+				Kind:       "MultiKeyListener",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-mkl",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.MultiKeyListenerSpec{
+				Host: "1.2.3.4",
+				Port: 8080,
+				Strategy: v2alpha1.MultiKeyListenerStrategy{
+					Priority: &v2alpha1.PriorityStrategySpec{RoutingKeys: []string{"a", "b"}},
+				},
+			},
+			Status: v2alpha1.MultiKeyListenerStatus{
+				HasDestination: true,
+				Strategy: &v2alpha1.StrategyStatus{
+					Priority: &v2alpha1.PriorityStrategyStatus{RoutingKeysReachable: []string{"b"}},
+				},
+			},
+		},
+		{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "skupper.io/v2alpha1",
+				Kind:       "MultiKeyListener",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-mkl2",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.MultiKeyListenerSpec{
+				Host: "1.1.1.1",
+				Port: 9999,
+				Strategy: v2alpha1.MultiKeyListenerStrategy{
+					Weighted: &v2alpha1.WeightedStrategySpec{RoutingKeys: map[string]uint{"a": 1, "b": 2}},
+				},
+			},
+		},
+	}
+
+	command := &CmdMultiKeyListenerStatus{}
+	command.namespace = "test"
+	command.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(command.namespace)
+
+	for _, resource := range mklResources {
+		defer command.multiKeyListenerHandler.Delete(resource.Name)
+		content, err := command.multiKeyListenerHandler.EncodeToYaml(resource)
+		assert.Check(t, err == nil)
+		err = command.multiKeyListenerHandler.WriteFile(path, resource.Name+".yaml", content, common.MultiKeyListeners)
+		assert.Check(t, err == nil)
+	}
+
+	for _, test := range testTable {
+		command.multiKeyListenerName = test.mklName
+		command.Flags = &test.flags
+		command.output = command.Flags.Output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := command.Run()
+			if err != nil {
+				assert.Check(t, strings.HasSuffix(err.Error(), test.errorMessage))
+			} else {
+				assert.Check(t, test.errorMessage == "")
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdMultiKeyListenerUpdate struct {
+	multiKeyListenerHandler *fs.MultiKeyListenerHandler
+	CobraCmd                *cobra.Command
+	Flags                   *common.CommandMultiKeyListenerUpdateFlags
+	namespace               string
+	multiKeyListenerName    string
+	newSettings             v2alpha1.MultiKeyListenerSpec
+}
+
+func NewCmdMultiKeyListenerUpdate() *CmdMultiKeyListenerUpdate {
+	return &CmdMultiKeyListenerUpdate{}
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(cmd.namespace)
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) ValidateInput(args []string) error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: false, LogWarning: false}
+	resourceStringValidator := validator.NewResourceStringValidator()
+	numberValidator := validator.NewNumberValidator()
+	hostStringValidator := validator.NewHostStringValidator()
+	namespaceStringValidator := validator.NamespaceStringValidator()
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	if cmd.namespace != "" {
+		ok, err := namespaceStringValidator.Evaluate(cmd.namespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("namespace is not valid: %s", err))
+		}
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener name is not valid: %s", err))
+		} else {
+			cmd.multiKeyListenerName = args[0]
+		}
+	}
+
+	// Validate that there is already a multikeylistener with this name in the namespace
+	existing := false
+	if cmd.multiKeyListenerName != "" {
+		mkl, err := cmd.multiKeyListenerHandler.Get(cmd.multiKeyListenerName, opts)
+		if mkl == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener %s must exist in namespace %s to be updated", cmd.multiKeyListenerName, cmd.namespace))
+		} else {
+			// save existing values
+			existing = true
+			cmd.newSettings = mkl.Spec
+		}
+	}
+
+	// Validate flags
+	if len(cmd.Flags.Priority) > 0 || len(cmd.Flags.Weight) > 0 || len(cmd.Flags.Closest) > 0 {
+		strategy, err := utils.MultiKeyListenerStrategy(cmd.Flags.Priority, cmd.Flags.Weight, cmd.Flags.Closest)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("strategy is not valid: %s", err))
+		} else if current := utils.MultiKeyListenerStrategyName(cmd.newSettings.Strategy); existing && current != utils.MultiKeyListenerStrategyName(strategy) {
+			validationErrors = append(validationErrors, fmt.Errorf("strategy is not valid: the %s strategy of multikeylistener %s cannot be changed", current, cmd.multiKeyListenerName))
+		} else {
+			cmd.newSettings.Strategy = strategy
+		}
+	}
+	if cmd.Flags.Host != "" {
+		ip := net.ParseIP(cmd.Flags.Host)
+		ok, _ := hostStringValidator.Evaluate(cmd.Flags.Host)
+		if !ok && ip == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("host is not valid: a valid IP address or hostname is expected"))
+		} else {
+			cmd.newSettings.Host = cmd.Flags.Host
+		}
+	}
+	if cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tlsCredentials value is not valid: %s", err))
+		} else {
+			cmd.newSettings.TlsCredentials = cmd.Flags.TlsCredentials
+		}
+	}
+	if cmd.Flags.Port != 0 {
+		ok, err := numberValidator.Evaluate(cmd.Flags.Port)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("multikeylistener port is not valid: %s", err))
+		} else {
+			cmd.newSettings.Port = cmd.Flags.Port
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+	// user wants to clear TlsCredentials
+	if cmd.CobraCmd.Flags().Changed(common.FlagNameTlsCredentials) && cmd.Flags.TlsCredentials == "" {
+		cmd.newSettings.TlsCredentials = ""
+	}
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) Run() error {
+	multiKeyListenerResource := v2alpha1.MultiKeyListener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "MultiKeyListener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.multiKeyListenerName,
+			Namespace: cmd.namespace,
+		},
+		Spec: cmd.newSettings,
+	}
+
+	err := cmd.multiKeyListenerHandler.Add(multiKeyListenerResource)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (cmd *CmdMultiKeyListenerUpdate) WaitUntil() error { return nil }
@@ -0,0 +1,160 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdMultiKeyListenerUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		flags         *common.CommandMultiKeyListenerUpdateFlags
+		expectedError string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test/", string(api.InputSiteStatePath))
+
+	testTable := []test{
+		{
+			name:          "multikeylistener is not updated because get multikeylistener returned error",
+			namespace:     "test",
+			args:          []string{"no-mkl"},
+			flags:         &common.CommandMultiKeyListenerUpdateFlags{},
+			expectedError: "multikeylistener no-mkl must exist in namespace test to be updated",
+		},
+		{
+			name:          "multikeylistener name is not specified",
+			namespace:     "test",
+			args:          []string{},
+			flags:         &common.CommandMultiKeyListenerUpdateFlags{},
+			expectedError: "multikeylistener name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			namespace:     "test",
+			args:          []string{"my", "mkl"},
+			flags:         &common.CommandMultiKeyListenerUpdateFlags{},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "strategy type cannot be changed",
+			namespace:     "test",
+			args:          []string{"my-mkl"},
+			flags:         &common.CommandMultiKeyListenerUpdateFlags{Closest: []string{"a"}},
+			expectedError: "strategy is not valid: the priority strategy of multikeylistener my-mkl cannot be changed",
+		},
+		{
+			name:          "port is not valid",
+			namespace:     "test",
+			args:          []string{"my-mkl"},
+			flags:         &common.CommandMultiKeyListenerUpdateFlags{Port: -1},
+			expectedError: "multikeylistener port is not valid: value is not positive",
+		},
+		{
+			name:          "host is not valid",
+			namespace:     "test",
+			args:          []string{"my-mkl"},
+			flags:         &common.CommandMultiKeyListenerUpdateFlags{Host: "not-valid$"},
+			expectedError: "host is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:      "flags all valid",
+			namespace: "test",
+			args:      []string{"my-mkl"},
+			flags: &common.CommandMultiKeyListenerUpdateFlags{
+				TlsCredentials: "secretname",
+				Port:           1234,
+				Host:           "1.2.3.4",
+				Priority:       []string{"b", "a"},
+			},
+		},
+	}
+
+	// Add a temp file so multikeylistener exists for update tests to pass
+	mklResource := v2alpha1.MultiKeyListener{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "MultiKeyListener",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-mkl",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.MultiKeyListenerSpec{
+			Host: "0.0.0.0",
+			Port: 8080,
+			Strategy: v2alpha1.MultiKeyListenerStrategy{
+				Priority: &v2alpha1.PriorityStrategySpec{RoutingKeys: []string{"a", "b"}},
+			},
+		},
+	}
+
+	command := &CmdMultiKeyListenerUpdate{Flags: &common.CommandMultiKeyListenerUpdateFlags{}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.namespace = "test"
+	command.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(command.namespace)
+
+	defer command.multiKeyListenerHandler.Delete("my-mkl")
+	content, err := command.multiKeyListenerHandler.EncodeToYaml(mklResource)
+	assert.Check(t, err == nil)
+	err = command.multiKeyListenerHandler.WriteFile(path, "my-mkl.yaml", content, common.MultiKeyListeners)
+	assert.Check(t, err == nil)
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command.multiKeyListenerName = ""
+			command.newSettings = v2alpha1.MultiKeyListenerSpec{}
+			command.Flags = test.flags
+			command.namespace = test.namespace
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdMultiKeyListenerUpdate_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdMultiKeyListenerUpdate{Flags: &common.CommandMultiKeyListenerUpdateFlags{}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.multiKeyListenerName = "my-mkl"
+	command.namespace = "test"
+	command.newSettings = v2alpha1.MultiKeyListenerSpec{
+		Host: "1.2.3.4",
+		Port: 8181,
+		Strategy: v2alpha1.MultiKeyListenerStrategy{
+			Closest: &v2alpha1.ClosestStrategySpec{RoutingKeys: []string{"east", "west"}},
+		},
+	}
+	command.multiKeyListenerHandler = fs.NewMultiKeyListenerHandler(command.namespace)
+	defer command.multiKeyListenerHandler.Delete("my-mkl")
+
+	command.InputToOptions()
+	assert.Assert(t, command.Run())
+
+	mkl, err := command.multiKeyListenerHandler.Get("my-mkl", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, mkl.Spec, command.newSettings)
+}
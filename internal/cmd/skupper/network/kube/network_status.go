@@ -0,0 +1,90 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdNetworkStatus struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandNetworkStatusFlags
+	namespace string
+	output    string
+}
+
+func NewCmdNetworkStatus() *CmdNetworkStatus {
+
+	return &CmdNetworkStatus{}
+}
+
+func (cmd *CmdNetworkStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdNetworkStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.NetworkFormats)
+
+	// Validate if Site CRD is installed
+	_, err := cmd.client.Sites(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("arguments are not allowed in this command"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdNetworkStatus) Run() error {
+	sites, err := cmd.client.Sites(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil || sites == nil || len(sites.Items) == 0 {
+		fmt.Println("There is no existing Skupper site resource")
+		return err
+	}
+
+	// the network is reported by the active site in the namespace
+	site := &sites.Items[0]
+	for i := range sites.Items {
+		if sites.Items[i].IsReady() {
+			site = &sites.Items[i]
+			break
+		}
+	}
+	if len(site.Status.Network) == 0 {
+		fmt.Printf("Network status is not yet available for site %q\n", site.Name)
+		return nil
+	}
+
+	return utils.RenderNetworkStatus(os.Stdout, cmd.output, utils.NewNetworkStatus(site.GetSiteId(), site.Status.Network))
+}
+
+func (cmd *CmdNetworkStatus) InputToOptions()  {}
+func (cmd *CmdNetworkStatus) WaitUntil() error { return nil }
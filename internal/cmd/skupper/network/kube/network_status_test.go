@@ -0,0 +1,154 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdNetworkStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandNetworkStatusFlags
+		expectedError string
+		skupperError  string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "arguments are specified",
+			args:          []string{"my-site"},
+			expectedError: "arguments are not allowed in this command",
+		},
+		{
+			name:          "output format is not valid",
+			flags:         common.CommandNetworkStatusFlags{Output: "svg"},
+			expectedError: "output type is not valid: value svg not allowed. It should be one of this options: [tree table dot mermaid json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			flags: common.CommandNetworkStatusFlags{Output: "mermaid"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdNetworkStatusWithMocks("test", nil, nil, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdNetworkStatus_Run(t *testing.T) {
+	type test struct {
+		name           string
+		output         string
+		skupperObjects []runtime.Object
+		skupperError   string
+		errorMessage   string
+	}
+
+	site := &v2alpha1.Site{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "west",
+			Namespace: "test",
+			UID:       "west-id",
+		},
+		Status: v2alpha1.SiteStatus{
+			Network: []v2alpha1.SiteRecord{
+				{
+					Id:   "west-id",
+					Name: "west",
+					Links: []v2alpha1.LinkRecord{
+						{Name: "to-east", RemoteSiteId: "east-id", Operational: false},
+					},
+					Services: []v2alpha1.ServiceRecord{
+						{RoutingKey: "backend", Listeners: []string{"backend"}},
+					},
+				},
+				{
+					Id:   "east-id",
+					Name: "east",
+				},
+			},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:           "runs ok",
+			skupperObjects: []runtime.Object{site},
+		},
+		{
+			name:           "runs ok with dot output",
+			output:         "dot",
+			skupperObjects: []runtime.Object{site},
+		},
+		{
+			name: "network status not yet available",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.Site{
+					ObjectMeta: v1.ObjectMeta{Name: "west", Namespace: "test"},
+				},
+			},
+		},
+		{
+			name: "no site",
+		},
+		{
+			name:         "returns error",
+			skupperError: "error listing sites",
+			errorMessage: "error listing sites",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdNetworkStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+		assert.Assert(t, err)
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && err.Error() == test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdNetworkStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdNetworkStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdNetworkStatus := &CmdNetworkStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+
+	return cmdNetworkStatus, nil
+}
@@ -0,0 +1,56 @@
+package network
+
+import (
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdNetwork() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Display information about the network of sites this site belongs to.",
+		Long: `A network is the set of sites connected to each other by links.
+The status of the network is reported by the current site.`,
+		Example: `skupper network status
+skupper network status --output mermaid`,
+	}
+
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdNetworkStatusFactory(platform))
+
+	return cmd
+}
+
+func CmdNetworkStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdNetworkStatus()
+	nonKubeCommand := nonkube.NewCmdNetworkStatus()
+
+	cmdNetworkStatusDesc := common.SkupperCmdDescription{
+		Use:   "status",
+		Short: "Display the status of the network",
+		Long: `Display the sites in the network together with their links and services.
+Routing keys that have listeners but no connectors, and links that are not
+operational, are reported after the network.`,
+		Example: `skupper network status
+skupper network status --output table
+skupper network status --output dot | dot -Tsvg > network.svg`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdNetworkStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandNetworkStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameNetworkStatusOutput, "o", "tree", common.FlagDescNetworkStatusOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
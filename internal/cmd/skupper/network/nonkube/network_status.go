@@ -0,0 +1,74 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdNetworkStatus struct {
+	siteHandler *fs.SiteHandler
+	CobraCmd    *cobra.Command
+	Flags       *common.CommandNetworkStatusFlags
+	namespace   string
+	output      string
+}
+
+func NewCmdNetworkStatus() *CmdNetworkStatus {
+	return &CmdNetworkStatus{}
+}
+
+func (cmd *CmdNetworkStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.siteHandler = fs.NewSiteHandler(cmd.namespace)
+}
+
+func (cmd *CmdNetworkStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	outputTypeValidator := validator.NewOptionValidator(common.NetworkFormats)
+
+	if len(args) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("arguments are not allowed in this command"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdNetworkStatus) Run() error {
+	// the network status is only recorded on the runtime site, once the
+	// site has been bootstrapped and the controller is running
+	sites, err := cmd.siteHandler.List(fs.GetOptions{RuntimeOnly: true})
+	if err != nil || len(sites) == 0 {
+		fmt.Println("There is no existing Skupper site resource")
+		return nil
+	}
+
+	site := sites[0]
+	if len(site.Status.Network) == 0 {
+		fmt.Printf("Network status is not yet available for site %q\n", site.Name)
+		return nil
+	}
+
+	return utils.RenderNetworkStatus(os.Stdout, cmd.output, utils.NewNetworkStatus(site.GetSiteId(), site.Status.Network))
+}
+
+func (cmd *CmdNetworkStatus) InputToOptions()  {}
+func (cmd *CmdNetworkStatus) WaitUntil() error { return nil }
@@ -0,0 +1,94 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdNetworkStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandNetworkStatusFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "arguments are specified",
+			args:          []string{"my-site"},
+			expectedError: "arguments are not allowed in this command",
+		},
+		{
+			name:          "bad output",
+			flags:         &common.CommandNetworkStatusFlags{Output: "svg"},
+			expectedError: "output type is not valid: value svg not allowed. It should be one of this options: [tree table dot mermaid json yaml]",
+		},
+		{
+			name:  "good flags",
+			flags: &common.CommandNetworkStatusFlags{Output: "table"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdNetworkStatus{Flags: test.flags}
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdNetworkStatus_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	path := filepath.Join(api.GetDataHome(), "/namespaces/test/", string(api.RuntimeSiteStatePath))
+
+	command := &CmdNetworkStatus{namespace: "test"}
+	command.siteHandler = fs.NewSiteHandler(command.namespace)
+
+	// no runtime site yet
+	assert.Assert(t, command.Run())
+
+	siteResource := v2alpha1.Site{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "Site",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-site",
+			Namespace: "test",
+			UID:       "my-site-id",
+		},
+		Status: v2alpha1.SiteStatus{
+			Network: []v2alpha1.SiteRecord{
+				{
+					Id:   "my-site-id",
+					Name: "my-site",
+					Services: []v2alpha1.ServiceRecord{
+						{RoutingKey: "backend", Listeners: []string{"backend"}},
+					},
+				},
+			},
+		},
+	}
+	content, err := command.siteHandler.EncodeToYaml(siteResource)
+	assert.Assert(t, err)
+	assert.Assert(t, command.siteHandler.WriteFile(path, "my-site.yaml", content, common.Sites))
+
+	for _, output := range []string{"tree", "table", "mermaid", "json"} {
+		command.output = output
+		assert.Assert(t, command.Run())
+	}
+}
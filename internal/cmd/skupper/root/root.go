@@ -7,6 +7,9 @@ import (
 	"github.com/skupperproject/skupper/internal/cmd/skupper/link"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/listener"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/manifest"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/multikeylistener"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/network"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/site"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/system"
 	"github.com/skupperproject/skupper/internal/cmd/skupper/token"
@@ -31,8 +34,11 @@ func NewSkupperRootCommand() *cobra.Command {
 	rootCmd.AddCommand(site.NewCmdSite())
 	rootCmd.AddCommand(token.NewCmdToken())
 	rootCmd.AddCommand(listener.NewCmdListener())
+	rootCmd.AddCommand(multikeylistener.NewCmdMultiKeyListener())
+	rootCmd.AddCommand(routeraccess.NewCmdRouterAccess())
 	rootCmd.AddCommand(link.NewCmdLink())
 	rootCmd.AddCommand(connector.NewCmdConnector())
+	rootCmd.AddCommand(network.NewCmdNetwork())
 	rootCmd.AddCommand(version.NewCmdVersion())
 	rootCmd.AddCommand(manifest.NewCmdManifest())
 	rootCmd.AddCommand(debug.NewCmdDebug())
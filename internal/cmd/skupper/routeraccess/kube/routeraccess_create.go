@@ -0,0 +1,240 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CmdRouterAccessCreate struct {
+	client                 skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd               *cobra.Command
+	Flags                  *common.CommandRouterAccessCreateFlags
+	namespace              string
+	name                   string
+	roles                  []v2alpha1.RouterAccessRole
+	accessType             string
+	tlsCredentials         string
+	generateTlsCredentials bool
+	issuer                 string
+	timeout                time.Duration
+	KubeClient             kubernetes.Interface
+	status                 string
+}
+
+func NewCmdRouterAccessCreate() *CmdRouterAccessCreate {
+
+	return &CmdRouterAccessCreate{}
+
+}
+
+func (cmd *CmdRouterAccessCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+	cmd.KubeClient = cli.Kube
+}
+
+func (cmd *CmdRouterAccessCreate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+	statusValidator := validator.NewOptionValidator(common.WaitStatusTypes)
+
+	// Check if RouterAccess CRD is installed
+	_, err := cmd.client.RouterAccesses(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("routeraccess name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("routeraccess name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("routeraccess name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate if there is already a routeraccess with this name in the namespace
+	if cmd.name != "" {
+		ra, err := cmd.client.RouterAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if ra != nil && !k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("There is already a routeraccess %s created for namespace %s", cmd.name, cmd.namespace))
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		if len(cmd.Flags.Roles) == 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("roles are not valid: at least one role must be specified"))
+		} else {
+			roles, err := utils.RouterAccessRoles(cmd.Flags.Roles)
+			if err != nil {
+				validationErrors = append(validationErrors, fmt.Errorf("roles are not valid: %s", err))
+			} else {
+				cmd.roles = roles
+			}
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.AccessType != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.AccessType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("access type is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		} else if !cmd.Flags.GenerateTlsCredentials {
+			// check that the secret exists
+			_, err := cmd.KubeClient.CoreV1().Secrets(cmd.namespace).Get(context.TODO(), cmd.Flags.TlsCredentials, metav1.GetOptions{})
+			if err != nil {
+				validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: secret does not exist"))
+			}
+		}
+	} else if cmd.Flags != nil && !cmd.Flags.GenerateTlsCredentials {
+		validationErrors = append(validationErrors, fmt.Errorf("tls-credentials must be specified when tls credentials are not generated"))
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Wait != "" {
+		ok, err := statusValidator.Evaluate(cmd.Flags.Wait)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("status is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessCreate) InputToOptions() {
+	// default tls credentials to name of routeraccess
+	if cmd.Flags.TlsCredentials == "" {
+		cmd.tlsCredentials = cmd.name
+	} else {
+		cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	}
+	cmd.accessType = cmd.Flags.AccessType
+	cmd.generateTlsCredentials = cmd.Flags.GenerateTlsCredentials
+	cmd.issuer = cmd.Flags.Issuer
+	cmd.timeout = cmd.Flags.Timeout
+	cmd.status = cmd.Flags.Wait
+}
+
+func (cmd *CmdRouterAccessCreate) Run() error {
+
+	resource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			AccessType:             cmd.accessType,
+			Roles:                  cmd.roles,
+			TlsCredentials:         cmd.tlsCredentials,
+			GenerateTlsCredentials: cmd.generateTlsCredentials,
+			Issuer:                 cmd.issuer,
+		},
+	}
+
+	_, err := cmd.client.RouterAccesses(cmd.namespace).Create(context.TODO(), &resource, metav1.CreateOptions{})
+	return err
+}
+
+func (cmd *CmdRouterAccessCreate) WaitUntil() error {
+
+	if cmd.status == "none" {
+		return nil
+	}
+
+	waitTime := int(cmd.timeout.Seconds())
+	var raCondition *metav1.Condition
+
+	err := utils.NewSpinnerWithTimeout("Waiting for create to complete...", waitTime, func() error {
+
+		resource, err := cmd.client.RouterAccesses(cmd.namespace).Get(context.TODO(), cmd.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		isConditionFound := false
+		isConditionTrue := false
+
+		switch cmd.status {
+		case "ready":
+			raCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_READY)
+		default:
+			raCondition = meta.FindStatusCondition(resource.Status.Conditions, v2alpha1.CONDITION_TYPE_CONFIGURED)
+		}
+
+		if raCondition != nil {
+			isConditionFound = true
+			isConditionTrue = raCondition.Status == metav1.ConditionTrue
+		}
+
+		if resource != nil && isConditionFound && isConditionTrue {
+			return nil
+		}
+
+		if resource != nil && isConditionFound && !isConditionTrue {
+			return fmt.Errorf("error in the condition")
+		}
+
+		return fmt.Errorf("error getting the resource")
+	})
+
+	if err != nil && raCondition == nil {
+		return fmt.Errorf("RouterAccess %q is not yet %s, check the status for more information\n", cmd.name, cmd.status)
+	} else if err != nil && raCondition.Status == metav1.ConditionFalse {
+		return fmt.Errorf("RouterAccess %q is not yet %s: %s\n", cmd.name, cmd.status, raCondition.Message)
+	}
+
+	fmt.Printf("RouterAccess %q is %s.\n", cmd.name, cmd.status)
+	return nil
+}
@@ -0,0 +1,342 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name                string
+		args                []string
+		flags               common.CommandRouterAccessCreateFlags
+		k8sObjects          []runtime.Object
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		expectedError       string
+	}
+
+	testTable := []test{
+		{
+			name:                "missing CRD",
+			flags:               common.CommandRouterAccessCreateFlags{},
+			skupperErrorMessage: utils.CrdErr,
+			expectedError:       utils.CrdHelpErr,
+		},
+		{
+			name:  "routeraccess is not created because there is already the same routeraccess in the namespace",
+			args:  []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge"}, GenerateTlsCredentials: true},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "There is already a routeraccess my-access created for namespace test",
+		},
+		{
+			name:          "routeraccess name is not specified",
+			args:          []string{},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge"}, GenerateTlsCredentials: true},
+			expectedError: "routeraccess name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge"}, GenerateTlsCredentials: true},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "routeraccess name is not valid",
+			args:          []string{"my access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge"}, GenerateTlsCredentials: true},
+			expectedError: "routeraccess name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "roles are not specified",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, GenerateTlsCredentials: true},
+			expectedError: "roles are not valid: at least one role must be specified",
+		},
+		{
+			name:          "role is not valid",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"normal"}, GenerateTlsCredentials: true},
+			expectedError: "roles are not valid: role \"normal\" is not valid: value normal not allowed. It should be one of this options: [inter-router edge]",
+		},
+		{
+			name:          "role port is not valid",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge:0"}, GenerateTlsCredentials: true},
+			expectedError: "roles are not valid: port for role \"edge\" is not valid: value 0 is not allowed",
+		},
+		{
+			name:          "tls-credentials are required when not generated",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge"}},
+			expectedError: "tls-credentials must be specified when tls credentials are not generated",
+		},
+		{
+			name:          "tls-credentials secret does not exist",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge"}, TlsCredentials: "not-there"},
+			expectedError: "tls-credentials is not valid: secret does not exist",
+		},
+		{
+			name:          "issuer is not valid",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge"}, GenerateTlsCredentials: true, Issuer: "not valid"},
+			expectedError: "issuer is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "timeout is not valid",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: 0 * time.Second, Roles: []string{"edge"}, GenerateTlsCredentials: true},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:          "wait status is not valid",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessCreateFlags{Timeout: time.Minute, Roles: []string{"edge"}, GenerateTlsCredentials: true, Wait: "created"},
+			expectedError: "status is not valid: value created not allowed. It should be one of this options: [ready configured none]",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessCreateFlags{
+				Roles:          []string{"inter-router:55671", "edge:45671"},
+				AccessType:     "loadbalancer",
+				TlsCredentials: "secretname",
+				Timeout:        1 * time.Minute,
+			},
+			k8sObjects: []runtime.Object{
+				&v12.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "secretname",
+						Namespace: "test",
+					},
+				},
+			},
+			expectedError: "",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessCreateWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperErrorMessage)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_InputToOptions(t *testing.T) {
+
+	type test struct {
+		name                   string
+		flags                  common.CommandRouterAccessCreateFlags
+		expectedTlsCredentials string
+		expectedAccessType     string
+		expectedTimeout        time.Duration
+		expectedStatus         string
+	}
+
+	testTable := []test{
+		{
+			name: "test1",
+			flags: common.CommandRouterAccessCreateFlags{
+				AccessType:     "route",
+				TlsCredentials: "secret",
+				Timeout:        20 * time.Second,
+				Wait:           "configured",
+			},
+			expectedTlsCredentials: "secret",
+			expectedAccessType:     "route",
+			expectedTimeout:        20 * time.Second,
+			expectedStatus:         "configured",
+		},
+		{
+			name: "test2",
+			flags: common.CommandRouterAccessCreateFlags{
+				Timeout: 30 * time.Second,
+				Wait:    "ready",
+			},
+			expectedTlsCredentials: "test2",
+			expectedTimeout:        30 * time.Second,
+			expectedStatus:         "ready",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, nil, "")
+			assert.Assert(t, err)
+
+			cmd.Flags = &test.flags
+			cmd.name = test.name
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.tlsCredentials == test.expectedTlsCredentials)
+			assert.Check(t, cmd.accessType == test.expectedAccessType)
+			assert.Check(t, cmd.timeout == test.expectedTimeout)
+			assert.Check(t, cmd.status == test.expectedStatus)
+		})
+	}
+}
+
+func TestCmdRouterAccessCreate_Run(t *testing.T) {
+	cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, nil, "")
+	assert.Assert(t, err)
+	cmd.name = "run-access"
+	cmd.roles = []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}}
+	cmd.tlsCredentials = "run-access"
+	cmd.generateTlsCredentials = true
+
+	assert.Assert(t, cmd.Run())
+
+	created, err := cmd.client.RouterAccesses("test").Get(context.TODO(), "run-access", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, created.Spec.Roles, cmd.roles)
+	assert.Equal(t, created.Spec.TlsCredentials, "run-access")
+	assert.Equal(t, created.Spec.GenerateTlsCredentials, true)
+}
+
+func TestCmdRouterAccessCreate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:        "routeraccess is not returned",
+			expectError: true,
+		},
+		{
+			name:   "routeraccess is not ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:   "routeraccess is ready",
+			status: "ready",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.RouterAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   "Ready",
+									Status: "True",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+		{
+			name:   "user waits for configured, but routeraccess had some errors while being configured",
+			status: "configured",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-access",
+						Namespace: "test",
+					},
+					Status: v2alpha1.RouterAccessStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Message: "Error",
+									Reason:  "Error",
+									Status:  "False",
+									Type:    "Configured",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessCreateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-access"
+		cmd.timeout = 1 * time.Second
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessCreateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessCreate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessCreate := &CmdRouterAccessCreate{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdRouterAccessCreate, nil
+}
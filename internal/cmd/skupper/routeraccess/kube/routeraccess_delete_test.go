@@ -0,0 +1,180 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandRouterAccessDeleteFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-access"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "routeraccess is not deleted because routeraccess does not exist in the namespace",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessDeleteFlags{Timeout: 1 * time.Minute},
+			expectedError: "routeraccess my-access does not exist in namespace test",
+		},
+		{
+			name:          "routeraccess name is not specified",
+			args:          []string{},
+			flags:         common.CommandRouterAccessDeleteFlags{Timeout: 1 * time.Minute},
+			expectedError: "routeraccess name must be specified",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			flags:         common.CommandRouterAccessDeleteFlags{Timeout: 1 * time.Minute},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:           "timeout is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessDeleteFlags{Timeout: 0 * time.Second},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:           "routeraccess is deleted",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessDeleteFlags{Timeout: 1 * time.Minute},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessDeleteWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessDelete_Run(t *testing.T) {
+	type test struct {
+		name                string
+		skupperObjects      []runtime.Object
+		skupperErrorMessage string
+		errorMessage        string
+	}
+
+	testTable := []test{
+		{
+			name:           "runs ok",
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+		{
+			name:                "run fails",
+			skupperErrorMessage: "error",
+			errorMessage:        "error",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessDeleteWithMocks("test", nil, test.skupperObjects, test.skupperErrorMessage)
+		assert.Assert(t, err)
+
+		cmd.name = "my-access"
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+func TestCmdRouterAccessDelete_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	testTable := []test{
+		{
+			name:           "error deleting routeraccess",
+			wait:           true,
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectError:    true,
+		},
+		{
+			name:        "routeraccess is deleted",
+			wait:        true,
+			expectError: false,
+		},
+		{
+			name:           "routeraccess is not deleted but user does not want to wait",
+			wait:           false,
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectError:    false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessDeleteWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-access"
+		cmd.Flags = &common.CommandRouterAccessDeleteFlags{Timeout: 1 * time.Second}
+		cmd.wait = test.wait
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessDeleteWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessDelete, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessDelete := &CmdRouterAccessDelete{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdRouterAccessDelete, nil
+}
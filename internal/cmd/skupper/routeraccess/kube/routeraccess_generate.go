@@ -0,0 +1,146 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdRouterAccessGenerate struct {
+	client                 skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd               *cobra.Command
+	Flags                  *common.CommandRouterAccessGenerateFlags
+	namespace              string
+	name                   string
+	roles                  []v2alpha1.RouterAccessRole
+	accessType             string
+	tlsCredentials         string
+	generateTlsCredentials bool
+	issuer                 string
+	output                 string
+}
+
+func NewCmdRouterAccessGenerate() *CmdRouterAccessGenerate {
+
+	return &CmdRouterAccessGenerate{}
+
+}
+
+func (cmd *CmdRouterAccessGenerate) NewClient(cobraCommand *cobra.Command, args []string) {
+
+	cmd.namespace = cobraCommand.Flag("namespace").Value.String()
+}
+
+func (cmd *CmdRouterAccessGenerate) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("routeraccess name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("routeraccess name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("routeraccess name is not valid: %s", err))
+		} else {
+			cmd.name = args[0]
+		}
+	}
+
+	// Validate flags
+	if cmd.Flags != nil {
+		if len(cmd.Flags.Roles) == 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("roles are not valid: at least one role must be specified"))
+		} else {
+			roles, err := utils.RouterAccessRoles(cmd.Flags.Roles)
+			if err != nil {
+				validationErrors = append(validationErrors, fmt.Errorf("roles are not valid: %s", err))
+			} else {
+				cmd.roles = roles
+			}
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.AccessType != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.AccessType)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("access type is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tls-credentials is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		}
+	}
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessGenerate) InputToOptions() {
+	// default tls credentials to name of routeraccess
+	if cmd.Flags.TlsCredentials == "" {
+		cmd.tlsCredentials = cmd.name
+	} else {
+		cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	}
+
+	cmd.accessType = cmd.Flags.AccessType
+	cmd.generateTlsCredentials = cmd.Flags.GenerateTlsCredentials
+	cmd.issuer = cmd.Flags.Issuer
+	cmd.output = cmd.Flags.Output
+}
+
+func (cmd *CmdRouterAccessGenerate) Run() error {
+
+	resource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.name,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			AccessType:             cmd.accessType,
+			Roles:                  cmd.roles,
+			TlsCredentials:         cmd.tlsCredentials,
+			GenerateTlsCredentials: cmd.generateTlsCredentials,
+			Issuer:                 cmd.issuer,
+		},
+	}
+
+	encodedOutput, err := utils.Encode(cmd.output, resource)
+	fmt.Println(encodedOutput)
+	return err
+}
+
+func (cmd *CmdRouterAccessGenerate) WaitUntil() error { return nil }
@@ -0,0 +1,111 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandRouterAccessGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "routeraccess name is not specified",
+			args:          []string{},
+			flags:         common.CommandRouterAccessGenerateFlags{Roles: []string{"edge"}},
+			expectedError: "routeraccess name must be configured",
+		},
+		{
+			name:          "roles are not specified",
+			args:          []string{"my-access"},
+			expectedError: "roles are not valid: at least one role must be specified",
+		},
+		{
+			name:          "role is not valid",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessGenerateFlags{Roles: []string{"edge:abcd"}},
+			expectedError: "roles are not valid: port for role \"edge\" is not valid: strconv.Atoi: parsing \"abcd\": invalid syntax",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessGenerateFlags{Roles: []string{"edge"}, Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessGenerateFlags{
+				Roles:                  []string{"inter-router", "edge:45671"},
+				AccessType:             "loadbalancer",
+				TlsCredentials:         "secretname",
+				GenerateTlsCredentials: true,
+				Issuer:                 "my-ca",
+				Output:                 "yaml",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command := &CmdRouterAccessGenerate{namespace: "test"}
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessGenerate_InputToOptions(t *testing.T) {
+	command := &CmdRouterAccessGenerate{name: "my-access"}
+	command.Flags = &common.CommandRouterAccessGenerateFlags{Output: "json", GenerateTlsCredentials: true}
+
+	command.InputToOptions()
+
+	assert.Equal(t, command.tlsCredentials, "my-access")
+	assert.Equal(t, command.generateTlsCredentials, true)
+	assert.Equal(t, command.output, "json")
+}
+
+func TestCmdRouterAccessGenerate_Run(t *testing.T) {
+	type test struct {
+		name   string
+		output string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok yaml",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok json",
+			output: "json",
+		},
+	}
+
+	for _, test := range testTable {
+		command := &CmdRouterAccessGenerate{
+			namespace:              "test",
+			name:                   "my-access",
+			roles:                  []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}},
+			tlsCredentials:         "my-access",
+			generateTlsCredentials: true,
+			output:                 test.output,
+		}
+
+		t.Run(test.name, func(t *testing.T) {
+			assert.Assert(t, command.Run())
+		})
+	}
+}
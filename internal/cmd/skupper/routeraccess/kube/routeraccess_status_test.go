@@ -0,0 +1,162 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdRouterAccessStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandRouterAccessStatusFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-access"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "routeraccess is not shown because routeraccess does not exist in the namespace",
+			args:          []string{"my-access"},
+			expectedError: "routeraccess my-access does not exist in namespace test",
+		},
+		{
+			name:          "routeraccess name is nil",
+			args:          []string{""},
+			expectedError: "routeraccess name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			expectedError: "",
+		},
+		{
+			name:           "bad output status",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessStatusFlags{Output: "not-supported"},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:           "good output status",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessStatusFlags{Output: "json"},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessStatus_Run(t *testing.T) {
+	type test struct {
+		name           string
+		raName         string
+		output         string
+		skupperObjects []runtime.Object
+		skupperError   string
+		errorMessage   string
+	}
+
+	resolved := existingRouterAccess()
+	resolved.Status = v2alpha1.RouterAccessStatus{
+		Status: v2alpha1.Status{StatusType: v2alpha1.StatusReady},
+		Endpoints: []v2alpha1.Endpoint{
+			{Name: "inter-router", Host: "10.0.0.1", Port: "55671"},
+			{Name: "edge", Host: "10.0.0.1", Port: "45671"},
+		},
+	}
+
+	testTable := []test{
+		{
+			name:         "run fails",
+			raName:       "my-access",
+			skupperError: "error getting the resource",
+			errorMessage: "error getting the resource",
+		},
+		{
+			name:           "runs ok, returns all routeraccesses",
+			skupperObjects: []runtime.Object{resolved},
+		},
+		{
+			name:           "runs ok, returns one routeraccess",
+			raName:         "my-access",
+			skupperObjects: []runtime.Object{resolved},
+		},
+		{
+			name:           "runs ok, returns all routeraccesses yaml",
+			output:         "yaml",
+			skupperObjects: []runtime.Object{resolved},
+		},
+		{
+			name:           "runs ok, returns one routeraccess json",
+			raName:         "my-access",
+			output:         "json",
+			skupperObjects: []runtime.Object{resolved},
+		},
+		{
+			name:   "returns no routeraccesses",
+			raName: "",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessStatusWithMocks("test", nil, test.skupperObjects, test.skupperError)
+		assert.Assert(t, err)
+
+		cmd.name = test.raName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessStatusWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessStatus, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessStatus := &CmdRouterAccessStatus{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+	return cmdRouterAccessStatus, nil
+}
@@ -0,0 +1,236 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	v12 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func existingRouterAccess() *v2alpha1.RouterAccess {
+	return &v2alpha1.RouterAccess{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-access",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles: []v2alpha1.RouterAccessRole{
+				{Name: "inter-router", Port: 55671},
+				{Name: "edge", Port: 45671},
+			},
+			TlsCredentials:         "my-access",
+			GenerateTlsCredentials: true,
+		},
+	}
+}
+
+func TestCmdRouterAccessUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandRouterAccessUpdateFlags
+		k8sObjects     []runtime.Object
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-access"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "routeraccess is not updated because routeraccess does not exist in the namespace",
+			args:          []string{"my-access"},
+			flags:         common.CommandRouterAccessUpdateFlags{Timeout: 1 * time.Minute},
+			expectedError: "routeraccess my-access must exist in namespace test to be updated",
+		},
+		{
+			name:          "routeraccess name is not specified",
+			args:          []string{},
+			flags:         common.CommandRouterAccessUpdateFlags{Timeout: 1 * time.Minute},
+			expectedError: "routeraccess name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			flags:         common.CommandRouterAccessUpdateFlags{Timeout: 1 * time.Minute},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:           "roles are not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: 1 * time.Minute, Roles: []string{"edge", "edge"}},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "roles are not valid: role \"edge\" is specified more than once",
+		},
+		{
+			name:           "access type is not valid",
+			args:           []string{"my-access"},
+			flags:          common.CommandRouterAccessUpdateFlags{Timeout: 1 * time.Minute, AccessType: "Not_Valid"},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			expectedError:  "access type is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name: "tls-credentials secret does not exist",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessUpdateFlags{
+				Timeout:        1 * time.Minute,
+				TlsCredentials: "not-there",
+			},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.RouterAccess{
+					ObjectMeta: v1.ObjectMeta{Name: "my-access", Namespace: "test"},
+					Spec:       v2alpha1.RouterAccessSpec{Roles: []v2alpha1.RouterAccessRole{{Name: "edge"}}},
+				},
+			},
+			expectedError: "tls-credentials is not valid: secret does not exist",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: common.CommandRouterAccessUpdateFlags{
+				Roles:          []string{"inter-router:9090"},
+				AccessType:     "route",
+				TlsCredentials: "secretname",
+				Issuer:         "my-ca",
+				Timeout:        1 * time.Minute,
+			},
+			skupperObjects: []runtime.Object{existingRouterAccess()},
+			k8sObjects: []runtime.Object{
+				&v12.Secret{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "secretname",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdRouterAccessUpdateWithMocks("test", test.k8sObjects, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessUpdate_Run(t *testing.T) {
+	cmd, err := newCmdRouterAccessUpdateWithMocks("test", nil, []runtime.Object{existingRouterAccess()}, "")
+	assert.Assert(t, err)
+	cmd.CobraCmd = &cobra.Command{Use: "test"}
+	cmd.Flags = &common.CommandRouterAccessUpdateFlags{
+		Roles:   []string{"edge:9091"},
+		Issuer:  "my-ca",
+		Timeout: 1 * time.Minute,
+	}
+
+	assert.Assert(t, cmd.ValidateInput([]string{"my-access"}))
+	assert.Assert(t, cmd.Run())
+
+	updated, err := cmd.client.RouterAccesses("test").Get(context.TODO(), "my-access", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, updated.Spec.Roles, []v2alpha1.RouterAccessRole{{Name: "edge", Port: 9091}})
+	assert.Equal(t, updated.Spec.Issuer, "my-ca")
+	assert.Equal(t, updated.Spec.TlsCredentials, "my-access")
+	assert.Equal(t, updated.Spec.GenerateTlsCredentials, true)
+}
+
+func TestCmdRouterAccessUpdate_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		status         string
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	configured := existingRouterAccess()
+	configured.Status.Conditions = []v1.Condition{
+		{
+			Type:   "Configured",
+			Status: "True",
+		},
+	}
+
+	testTable := []test{
+		{
+			name:        "routeraccess is not returned",
+			status:      "configured",
+			expectError: true,
+		},
+		{
+			name:           "routeraccess is configured",
+			status:         "configured",
+			skupperObjects: []runtime.Object{configured},
+			expectError:    false,
+		},
+		{
+			name:           "routeraccess is not ready",
+			status:         "ready",
+			skupperObjects: []runtime.Object{configured},
+			expectError:    true,
+		},
+		{
+			name:        "user does not wait",
+			status:      "none",
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdRouterAccessUpdateWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.name = "my-access"
+		cmd.Flags = &common.CommandRouterAccessUpdateFlags{Timeout: 1 * time.Second}
+		cmd.status = test.status
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdRouterAccessUpdateWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdRouterAccessUpdate, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdRouterAccessUpdate := &CmdRouterAccessUpdate{
+		client:     client.GetSkupperClient().SkupperV2alpha1(),
+		KubeClient: client.GetKubeClient(),
+		namespace:  namespace,
+	}
+	return cmdRouterAccessUpdate, nil
+}
@@ -0,0 +1,170 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdRouterAccessCreate struct {
+	routerAccessHandler     *fs.RouterAccessHandler
+	CobraCmd                *cobra.Command
+	Flags                   *common.CommandRouterAccessCreateFlags
+	namespace               string
+	routerAccessName        string
+	roles                   []v2alpha1.RouterAccessRole
+	tlsCredentials          string
+	issuer                  string
+	bindHost                string
+	subjectAlternativeNames []string
+}
+
+func NewCmdRouterAccessCreate() *CmdRouterAccessCreate {
+	return &CmdRouterAccessCreate{}
+}
+
+func (cmd *CmdRouterAccessCreate) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdRouterAccessCreate) ValidateInput(args []string) error {
+	var validationErrors []error
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameContext) != nil && cmd.CobraCmd.Flag(common.FlagNameContext).Value.String() != "" {
+		fmt.Println("Warning: --context flag is not supported on this platform")
+	}
+
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig) != nil && cmd.CobraCmd.Flag(common.FlagNameKubeconfig).Value.String() != "" {
+		fmt.Println("Warning: --kubeconfig flag is not supported on this platform")
+	}
+
+	resourceStringValidator := validator.NewResourceStringValidator()
+	namespaceStringValidator := validator.NamespaceStringValidator()
+
+	if cmd.namespace != "" {
+		ok, err := namespaceStringValidator.Evaluate(cmd.namespace)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("namespace is not valid: %s", err))
+		}
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("routeraccess name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("routeraccess name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("routeraccess name is not valid: %s", err))
+		} else {
+			cmd.routerAccessName = args[0]
+		}
+	}
+
+	// Validate flags
+	if len(cmd.Flags.Roles) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("roles are not valid: at least one role must be specified"))
+	} else {
+		roles, err := utils.RouterAccessRoles(cmd.Flags.Roles)
+		if err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("roles are not valid: %s", err))
+		} else {
+			cmd.roles = roles
+		}
+	}
+
+	if cmd.Flags.TlsCredentials != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.TlsCredentials)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("tlsCredentials value is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags.Issuer != "" {
+		ok, err := resourceStringValidator.Evaluate(cmd.Flags.Issuer)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("issuer value is not valid: %s", err))
+		}
+	}
+
+	if cmd.Flags.BindHost != "" && !isValidHost(cmd.Flags.BindHost) {
+		validationErrors = append(validationErrors, fmt.Errorf("bind host is not valid: a valid IP address or hostname is expected"))
+	}
+
+	for _, san := range cmd.Flags.SubjectAlternativeNames {
+		if !isValidHost(san) {
+			validationErrors = append(validationErrors, fmt.Errorf("subject alternative name %q is not valid: a valid IP address or hostname is expected", san))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessCreate) InputToOptions() {
+	if cmd.namespace == "" {
+		cmd.namespace = "default"
+	}
+
+	cmd.tlsCredentials = cmd.Flags.TlsCredentials
+	cmd.issuer = cmd.Flags.Issuer
+	cmd.bindHost = cmd.Flags.BindHost
+
+	// default to the names the host is known by, as link access does
+	if len(cmd.Flags.SubjectAlternativeNames) == 0 {
+		sans, err := utils.GetSansByDefault()
+		if err == nil {
+			cmd.subjectAlternativeNames = sans
+		}
+	} else {
+		cmd.subjectAlternativeNames = cmd.Flags.SubjectAlternativeNames
+	}
+}
+
+func (cmd *CmdRouterAccessCreate) Run() error {
+	routerAccessResource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.routerAccessName,
+			Namespace: cmd.namespace,
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles:                   cmd.roles,
+			TlsCredentials:          cmd.tlsCredentials,
+			Issuer:                  cmd.issuer,
+			BindHost:                cmd.bindHost,
+			SubjectAlternativeNames: cmd.subjectAlternativeNames,
+		},
+	}
+
+	err := cmd.routerAccessHandler.Add(routerAccessResource)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *CmdRouterAccessCreate) WaitUntil() error { return nil }
+
+func isValidHost(host string) bool {
+	hostStringValidator := validator.NewHostStringValidator()
+	ok, _ := hostStringValidator.Evaluate(host)
+	return ok || net.ParseIP(host) != nil
+}
@@ -0,0 +1,192 @@
+package nonkube
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNonKubeCmdRouterAccessCreate_ValidateInput(t *testing.T) {
+	type test struct {
+		name              string
+		namespace         string
+		args              []string
+		flags             *common.CommandRouterAccessCreateFlags
+		cobraGenericFlags map[string]string
+		expectedError     string
+	}
+
+	testTable := []test{
+		{
+			name:          "routeraccess name is not specified",
+			namespace:     "test",
+			args:          []string{},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}},
+			expectedError: "routeraccess name must be configured",
+		},
+		{
+			name:          "routeraccess name is not valid",
+			namespace:     "test",
+			args:          []string{"my new access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}},
+			expectedError: "routeraccess name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "roles are not specified",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{},
+			expectedError: "roles are not valid: at least one role must be specified",
+		},
+		{
+			name:          "role is specified more than once",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge:1234", "edge:5678"}},
+			expectedError: "roles are not valid: role \"edge\" is specified more than once",
+		},
+		{
+			name:          "bind host is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, BindHost: "not-valid$"},
+			expectedError: "bind host is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:          "subject alternative name is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, SubjectAlternativeNames: []string{"my-host", "not-valid$"}},
+			expectedError: "subject alternative name \"not-valid$\" is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:          "TlsCredentials key is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}, TlsCredentials: "not-valid$"},
+			expectedError: "tlsCredentials value is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:  "kubernetes flags are not valid on this platform",
+			args:  []string{"my-access"},
+			flags: &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}},
+			cobraGenericFlags: map[string]string{
+				common.FlagNameContext:    "test",
+				common.FlagNameKubeconfig: "test",
+			},
+		},
+		{
+			name:          "invalid namespace",
+			namespace:     "TestInvalid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessCreateFlags{Roles: []string{"edge"}},
+			expectedError: "namespace is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: &common.CommandRouterAccessCreateFlags{
+				Roles:                   []string{"inter-router:55671", "edge:45671"},
+				TlsCredentials:          "secretname",
+				Issuer:                  "my-ca",
+				BindHost:                "1.2.3.4",
+				SubjectAlternativeNames: []string{"my-host", "1.2.3.4"},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessCreate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.namespace = test.namespace
+
+			if len(test.cobraGenericFlags) > 0 {
+				for name, value := range test.cobraGenericFlags {
+					command.CobraCmd.Flags().String(name, value, "")
+				}
+			}
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestNonKubeCmdRouterAccessCreate_InputToOptions(t *testing.T) {
+	type test struct {
+		name                   string
+		namespace              string
+		flags                  common.CommandRouterAccessCreateFlags
+		expectedTlsCredentials string
+		expectedBindHost       string
+		expectedSans           []string
+		expectedNamespace      string
+	}
+
+	testTable := []test{
+		{
+			name:              "defaults",
+			expectedNamespace: "default",
+		},
+		{
+			name:      "all set",
+			namespace: "test",
+			flags: common.CommandRouterAccessCreateFlags{
+				BindHost:                "1.2.3.4",
+				TlsCredentials:          "secret",
+				SubjectAlternativeNames: []string{"my-host"},
+			},
+			expectedTlsCredentials: "secret",
+			expectedBindHost:       "1.2.3.4",
+			expectedSans:           []string{"my-host"},
+			expectedNamespace:      "test",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := CmdRouterAccessCreate{}
+			cmd.Flags = &test.flags
+			cmd.routerAccessName = "my-access"
+			cmd.namespace = test.namespace
+
+			cmd.InputToOptions()
+
+			assert.Check(t, cmd.tlsCredentials == test.expectedTlsCredentials)
+			assert.Check(t, cmd.bindHost == test.expectedBindHost)
+			assert.Check(t, cmd.namespace == test.expectedNamespace)
+			if test.expectedSans != nil {
+				assert.DeepEqual(t, cmd.subjectAlternativeNames, test.expectedSans)
+			}
+		})
+	}
+}
+
+func TestNonKubeCmdRouterAccessCreate_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdRouterAccessCreate{}
+	command.routerAccessName = "my-access"
+	command.namespace = "test"
+	command.roles = []v2alpha1.RouterAccessRole{
+		{Name: "inter-router", Port: 55671},
+		{Name: "edge", Port: 45671},
+	}
+	command.bindHost = "1.2.3.4"
+	command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+	defer command.routerAccessHandler.Delete("my-access")
+
+	assert.Assert(t, command.Run())
+
+	ra, err := command.routerAccessHandler.Get("my-access", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, ra.Spec.BindHost, "1.2.3.4")
+	assert.DeepEqual(t, ra.Spec.Roles, command.roles)
+}
@@ -0,0 +1,123 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdRouterAccessDelete_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		expectedError string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test/", string(api.InputSiteStatePath))
+
+	testTable := []test{
+		{
+			name:          "routeraccess is not deleted because it does not exist",
+			namespace:     "test",
+			args:          []string{"no-access"},
+			expectedError: "routeraccess no-access does not exist",
+		},
+		{
+			name:          "routeraccess name is not specified",
+			namespace:     "test",
+			args:          []string{},
+			expectedError: "routeraccess name must be specified",
+		},
+		{
+			name:          "more than one argument is specified",
+			namespace:     "test",
+			args:          []string{"my", "access"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "invalid namespace",
+			namespace:     "TestInvalid",
+			args:          []string{"my-access"},
+			expectedError: "namespace is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$\nrouteraccess my-access does not exist",
+		},
+		{
+			name:      "routeraccess exists",
+			namespace: "test",
+			args:      []string{"my-access"},
+		},
+	}
+
+	//Add a temp file so routeraccess exists for delete tests
+	raResource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-access",
+			Namespace: "test",
+		},
+	}
+
+	command := &CmdRouterAccessDelete{Flags: &common.CommandRouterAccessDeleteFlags{}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.namespace = "test"
+	command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+
+	defer command.routerAccessHandler.Delete("my-access")
+	content, err := command.routerAccessHandler.EncodeToYaml(raResource)
+	assert.Check(t, err == nil)
+	err = command.routerAccessHandler.WriteFile(path, "my-access.yaml", content, common.RouterAccesses)
+	assert.Check(t, err == nil)
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command.routerAccessName = ""
+			command.namespace = test.namespace
+			command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessDelete_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdRouterAccessDelete{}
+	command.namespace = "test"
+	command.routerAccessName = "my-access"
+	command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+
+	err := command.Run()
+	assert.Check(t, err != nil)
+
+	err = command.routerAccessHandler.Add(v2alpha1.RouterAccess{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-access",
+			Namespace: "test",
+		},
+	})
+	assert.Assert(t, err)
+	assert.Assert(t, command.Run())
+}
@@ -0,0 +1,109 @@
+package nonkube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+)
+
+func TestCmdRouterAccessGenerate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		flags         *common.CommandRouterAccessGenerateFlags
+		expectedError string
+	}
+
+	testTable := []test{
+		{
+			name:          "routeraccess name is not specified",
+			args:          []string{},
+			flags:         &common.CommandRouterAccessGenerateFlags{Roles: []string{"edge"}},
+			expectedError: "routeraccess name must be configured",
+		},
+		{
+			name:          "role is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessGenerateFlags{Roles: []string{"normal"}},
+			expectedError: "roles are not valid: role \"normal\" is not valid: value normal not allowed. It should be one of this options: [inter-router edge]",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessGenerateFlags{Roles: []string{"edge"}, Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:          "invalid namespace",
+			namespace:     "TestInvalid",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessGenerateFlags{Roles: []string{"edge"}},
+			expectedError: "namespace is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$",
+		},
+		{
+			name: "flags all valid",
+			args: []string{"my-access"},
+			flags: &common.CommandRouterAccessGenerateFlags{
+				Roles:    []string{"inter-router", "edge"},
+				BindHost: "1.2.3.4",
+				Output:   "yaml",
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdRouterAccessGenerate{Flags: test.flags}
+			command.CobraCmd = &cobra.Command{Use: "test"}
+			command.namespace = test.namespace
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessGenerate_Run(t *testing.T) {
+	type test struct {
+		name         string
+		output       string
+		errorMessage string
+	}
+
+	testTable := []test{
+		{
+			name:   "runs ok yaml",
+			output: "yaml",
+		},
+		{
+			name:   "runs ok json",
+			output: "json",
+		},
+		{
+			name:         "output is not supported",
+			output:       "bad-value",
+			errorMessage: "format bad-value not supported",
+		},
+	}
+
+	for _, test := range testTable {
+		command := &CmdRouterAccessGenerate{}
+		command.Flags = &common.CommandRouterAccessGenerateFlags{Output: test.output}
+		command.routerAccessName = "my-access"
+		command.roles = []v2alpha1.RouterAccessRole{{Name: "inter-router", Port: 55671}}
+		command.InputToOptions()
+
+		t.Run(test.name, func(t *testing.T) {
+			err := command.Run()
+			if test.errorMessage != "" {
+				assert.Error(t, err, test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
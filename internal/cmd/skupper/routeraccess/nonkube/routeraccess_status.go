@@ -0,0 +1,138 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type CmdRouterAccessStatus struct {
+	routerAccessHandler *fs.RouterAccessHandler
+	CobraCmd            *cobra.Command
+	Flags               *common.CommandRouterAccessStatusFlags
+	namespace           string
+	routerAccessName    string
+	output              string
+}
+
+func NewCmdRouterAccessStatus() *CmdRouterAccessStatus {
+	return &CmdRouterAccessStatus{}
+}
+
+func (cmd *CmdRouterAccessStatus) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+
+	cmd.routerAccessHandler = fs.NewRouterAccessHandler(cmd.namespace)
+}
+
+func (cmd *CmdRouterAccessStatus) ValidateInput(args []string) error {
+	var validationErrors []error
+	opts := fs.GetOptions{RuntimeFirst: true, LogWarning: false}
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate arguments name if specified
+	if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if len(args) == 1 {
+		if args[0] == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("routeraccess name must not be empty"))
+		} else {
+			ok, err := resourceStringValidator.Evaluate(args[0])
+			if !ok {
+				validationErrors = append(validationErrors, fmt.Errorf("routeraccess name is not valid: %s", err))
+			} else {
+				cmd.routerAccessName = args[0]
+			}
+		}
+	}
+	// Validate that there is a routeraccess with this name in the namespace
+	if cmd.routerAccessName != "" {
+		ra, err := cmd.routerAccessHandler.Get(cmd.routerAccessName, opts)
+		if ra == nil || err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("routeraccess %s does not exist in namespace %s", cmd.routerAccessName, cmd.namespace))
+		}
+	}
+
+	if cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdRouterAccessStatus) Run() error {
+	opts := fs.GetOptions{RuntimeFirst: true, LogWarning: true}
+	if cmd.routerAccessName == "" {
+		resources, err := cmd.routerAccessHandler.List()
+		if err != nil || resources == nil || len(resources) == 0 {
+			fmt.Println("No routeraccesses found")
+			return err
+		}
+		if cmd.output != "" {
+			for _, resource := range resources {
+				encodedOutput, err := utils.Encode(cmd.output, resource)
+				if err != nil {
+					return err
+				}
+				fmt.Println(encodedOutput)
+			}
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			_, _ = fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+				"NAME", "STATUS", "BIND-HOST", "ROLES", "ENDPOINTS", "MESSAGE"))
+			for _, resource := range resources {
+				fmt.Fprintln(tw, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+					resource.Name, resource.Status.StatusType, resource.Spec.BindHost,
+					utils.RouterAccessRolesString(resource.Spec.Roles),
+					utils.RouterAccessEndpoints(resource.Status.Endpoints), resource.Status.Message))
+			}
+			_ = tw.Flush()
+		}
+	} else {
+		resource, err := cmd.routerAccessHandler.Get(cmd.routerAccessName, opts)
+		if err != nil || resource == nil || k8serrs.IsNotFound(err) {
+			fmt.Println("No routeraccesses found")
+			return err
+		}
+		if cmd.output != "" {
+			encodedOutput, err := utils.Encode(cmd.output, resource)
+			if err != nil {
+				return err
+			}
+			fmt.Println(encodedOutput)
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 8, 8, 1, '\t', tabwriter.TabIndent)
+			fmt.Fprintln(tw, fmt.Sprintf("Name:\t%s\nStatus:\t%s\nBind Host:\t%s\nRoles:\t%s\nTLS Credentials:\t%s\nIssuer:\t%s\nSubject Alternative Names:\t%s\nMessage:\t%s",
+				resource.Name, resource.Status.StatusType, resource.Spec.BindHost,
+				utils.RouterAccessRolesString(resource.Spec.Roles), resource.Spec.TlsCredentials,
+				resource.Spec.Issuer, strings.Join(resource.Spec.SubjectAlternativeNames, ","), resource.Status.Message))
+			fmt.Fprintln(tw, "Endpoints:")
+			for _, endpoint := range resource.Status.Endpoints {
+				fmt.Fprintln(tw, fmt.Sprintf("\t%s\t%s:%s", endpoint.Name, endpoint.Host, endpoint.Port))
+			}
+			_ = tw.Flush()
+		}
+	}
+
+	return nil
+}
+
+func (cmd *CmdRouterAccessStatus) InputToOptions()  {}
+func (cmd *CmdRouterAccessStatus) WaitUntil() error { return nil }
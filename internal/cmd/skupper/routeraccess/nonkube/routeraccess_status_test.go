@@ -0,0 +1,212 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdRouterAccessStatus_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         *common.CommandRouterAccessStatusFlags
+		expectedError string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test/", string(api.RuntimeSiteStatePath))
+
+	testTable := []test{
+		{
+			name:          "routeraccess is not shown because routeraccess does not exist in the namespace",
+			args:          []string{"no-access"},
+			flags:         &common.CommandRouterAccessStatusFlags{},
+			expectedError: "routeraccess no-access does not exist in namespace test",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "access"},
+			flags:         &common.CommandRouterAccessStatusFlags{},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "no args",
+			flags:         &common.CommandRouterAccessStatusFlags{},
+			expectedError: "",
+		},
+		{
+			name:          "bad output status",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessStatusFlags{Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:          "good output status",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessStatusFlags{Output: "json"},
+			expectedError: "",
+		},
+	}
+
+	//Add a temp file so routeraccess exists for status tests
+	raResource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-access",
+			Namespace: "test",
+		},
+	}
+
+	command := &CmdRouterAccessStatus{}
+	command.namespace = "test"
+	command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+
+	defer command.routerAccessHandler.Delete("my-access")
+	content, err := command.routerAccessHandler.EncodeToYaml(raResource)
+	assert.Check(t, err == nil)
+	err = command.routerAccessHandler.WriteFile(path, "my-access.yaml", content, common.RouterAccesses)
+	assert.Check(t, err == nil)
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command.routerAccessName = ""
+			command.Flags = test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessStatus_Run(t *testing.T) {
+	type test struct {
+		name         string
+		raName       string
+		flags        common.CommandRouterAccessStatusFlags
+		errorMessage string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test/", string(api.RuntimeSiteStatePath))
+
+	testTable := []test{
+		{
+			name:         "run fails routeraccess doesn't exist",
+			raName:       "no-access",
+			errorMessage: "no such file or directory",
+		},
+		{
+			name:   "runs ok, returns 1 routeraccess",
+			raName: "my-access",
+		},
+		{
+			name:   "runs ok, returns 1 routeraccess yaml",
+			raName: "my-access",
+			flags:  common.CommandRouterAccessStatusFlags{Output: "yaml"},
+		},
+		{
+			name: "runs ok, returns all routeraccesses",
+		},
+		{
+			name:  "runs ok, returns all routeraccesses json",
+			flags: common.CommandRouterAccessStatusFlags{Output: "json"},
+		},
+		{
+			name:         "runs ok, returns all routeraccesses output bad",
+			flags:        common.CommandRouterAccessStatusFlags{Output: "bad-value"},
+			errorMessage: "format bad-value not supported",
+		},
+	}
+
+	// add two routeraccesses in runtime directory
+	raResources := []v2alpha1.RouterAccess{
+		{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "skupper.io/v2alpha1",
+				Kind:       "RouterAccess",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-access",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.RouterAccessSpec{
+				Roles: []v2alpha1.RouterAccessRole{
+					{Name: "inter-router", Port: 55671},
+					{Name: "edge", Port: 45671},
+				},
+				BindHost:                "1.2.3.4",
+				SubjectAlternativeNames: []string{"my-host", "1.2.3.4"},
+			},
+			Status: v2alpha1.RouterAccessStatus{
+				Endpoints: []v2alpha1.Endpoint{
+					{Name: "inter-router", Host: "1.2.3.4", Port: "55671"},
+					{Name: "edge", Host: "1.2.3.4", Port: "45671"},
+				},
+			},
+		},
+		{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "skupper.io/v2alpha1",
+				Kind:       "RouterAccess",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-access2",
+				Namespace: "test",
+			},
+			Spec: v2alpha1.RouterAccessSpec{
+				Roles: []v2alpha1.RouterAccessRole{
+					{Name: "edge", Port: 45671},
+				},
+			},
+		},
+	}
+
+	command := &CmdRouterAccessStatus{}
+	command.namespace = "test"
+	command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+
+	for _, resource := range raResources {
+		defer command.routerAccessHandler.Delete(resource.Name)
+		content, err := command.routerAccessHandler.EncodeToYaml(resource)
+		assert.Check(t, err == nil)
+		err = command.routerAccessHandler.WriteFile(path, resource.Name+".yaml", content, common.RouterAccesses)
+		assert.Check(t, err == nil)
+	}
+
+	for _, test := range testTable {
+		command.routerAccessName = test.raName
+		command.Flags = &test.flags
+		command.output = command.Flags.Output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := command.Run()
+			if err != nil {
+				assert.Check(t, strings.HasSuffix(err.Error(), test.errorMessage))
+			} else {
+				assert.Check(t, test.errorMessage == "")
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"github.com/skupperproject/skupper/pkg/nonkube/api"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdRouterAccessUpdate_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		namespace     string
+		args          []string
+		flags         *common.CommandRouterAccessUpdateFlags
+		expectedError string
+	}
+
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+	tmpDir := api.GetDataHome()
+	path := filepath.Join(tmpDir, "/namespaces/test/", string(api.InputSiteStatePath))
+
+	testTable := []test{
+		{
+			name:          "routeraccess is not updated because get routeraccess returned error",
+			namespace:     "test",
+			args:          []string{"no-access"},
+			flags:         &common.CommandRouterAccessUpdateFlags{},
+			expectedError: "routeraccess no-access must exist in namespace test to be updated",
+		},
+		{
+			name:          "routeraccess name is not specified",
+			namespace:     "test",
+			args:          []string{},
+			flags:         &common.CommandRouterAccessUpdateFlags{},
+			expectedError: "routeraccess name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			namespace:     "test",
+			args:          []string{"my", "access"},
+			flags:         &common.CommandRouterAccessUpdateFlags{},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "roles are not valid",
+			namespace:     "test",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessUpdateFlags{Roles: []string{"edge:abc"}},
+			expectedError: "roles are not valid: port for role \"edge\" is not valid: strconv.Atoi: parsing \"abc\": invalid syntax",
+		},
+		{
+			name:          "bind host is not valid",
+			namespace:     "test",
+			args:          []string{"my-access"},
+			flags:         &common.CommandRouterAccessUpdateFlags{BindHost: "not-valid$"},
+			expectedError: "bind host is not valid: a valid IP address or hostname is expected",
+		},
+		{
+			name:      "flags all valid",
+			namespace: "test",
+			args:      []string{"my-access"},
+			flags: &common.CommandRouterAccessUpdateFlags{
+				Roles:                   []string{"edge:1234"},
+				TlsCredentials:          "secretname",
+				BindHost:                "1.2.3.4",
+				SubjectAlternativeNames: []string{"my-host"},
+			},
+		},
+	}
+
+	// Add a temp file so routeraccess exists for update tests to pass
+	raResource := v2alpha1.RouterAccess{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "RouterAccess",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-access",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.RouterAccessSpec{
+			Roles: []v2alpha1.RouterAccessRole{
+				{Name: "inter-router", Port: 55671},
+				{Name: "edge", Port: 45671},
+			},
+		},
+	}
+
+	command := &CmdRouterAccessUpdate{Flags: &common.CommandRouterAccessUpdateFlags{}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.namespace = "test"
+	command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+
+	defer command.routerAccessHandler.Delete("my-access")
+	content, err := command.routerAccessHandler.EncodeToYaml(raResource)
+	assert.Check(t, err == nil)
+	err = command.routerAccessHandler.WriteFile(path, "my-access.yaml", content, common.RouterAccesses)
+	assert.Check(t, err == nil)
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command.routerAccessName = ""
+			command.newSettings = v2alpha1.RouterAccessSpec{}
+			command.Flags = test.flags
+			command.namespace = test.namespace
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdRouterAccessUpdate_Run(t *testing.T) {
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = t.TempDir()
+	} else {
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+	}
+
+	command := &CmdRouterAccessUpdate{Flags: &common.CommandRouterAccessUpdateFlags{}}
+	command.CobraCmd = &cobra.Command{Use: "test"}
+	command.routerAccessName = "my-access"
+	command.namespace = "test"
+	command.newSettings = v2alpha1.RouterAccessSpec{
+		Roles:                   []v2alpha1.RouterAccessRole{{Name: "edge", Port: 1234}},
+		BindHost:                "1.2.3.4",
+		SubjectAlternativeNames: []string{"my-host"},
+	}
+	command.routerAccessHandler = fs.NewRouterAccessHandler(command.namespace)
+	defer command.routerAccessHandler.Delete("my-access")
+
+	command.InputToOptions()
+	assert.Assert(t, command.Run())
+
+	ra, err := command.routerAccessHandler.Get("my-access", fs.GetOptions{})
+	assert.Assert(t, err)
+	assert.DeepEqual(t, ra.Spec, command.newSettings)
+}
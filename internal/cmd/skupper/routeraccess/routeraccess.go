@@ -0,0 +1,199 @@
+package routeraccess
+
+import (
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess/kube"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/routeraccess/nonkube"
+	"github.com/skupperproject/skupper/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdRouterAccess() *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   "routeraccess",
+		Short: "Configures secure access to the site router.",
+		Long: `A routeraccess defines the TLS credentials and router ports through which remote sites link to the local site.
+It is used to implement link access for sites.`,
+		Example: `skupper routeraccess create my-access --roles inter-router:55671,edge:45671
+skupper routeraccess status my-access`,
+	}
+
+	platform := common.Platform(config.GetPlatform())
+	cmd.AddCommand(CmdRouterAccessCreateFactory(platform))
+	cmd.AddCommand(CmdRouterAccessStatusFactory(platform))
+	cmd.AddCommand(CmdRouterAccessUpdateFactory(platform))
+	cmd.AddCommand(CmdRouterAccessDeleteFactory(platform))
+	cmd.AddCommand(CmdRouterAccessGenerateFactory(platform))
+
+	return cmd
+}
+
+func CmdRouterAccessCreateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessCreate()
+	nonKubeCommand := nonkube.NewCmdRouterAccessCreate()
+
+	cmdRouterAccessCreateDesc := common.SkupperCmdDescription{
+		Use:   "create <name>",
+		Short: "create a routeraccess",
+		Long: `Remote sites use the endpoints of the routeraccess to establish links to this site.
+	Each role is exposed on its own port, the default ports being 55671 for inter-router and 45671 for edge.`,
+		Example: `skupper routeraccess create my-access --roles inter-router,edge
+skupper routeraccess create my-access --roles inter-router:9090 --tls-credentials my-secret`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessCreateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessCreateFlags{}
+
+	cmd.Flags().StringSliceVar(&cmdFlags.Roles, common.FlagNameRoles, []string{"inter-router", "edge"}, common.FlagDescRoles)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+		cmd.Flags().BoolVar(&cmdFlags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, true, common.FlagDescGenerateTlsCredentials)
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+	}
+	if configuredPlatform != common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.BindHost, common.FlagNameBindHost, "", common.FlagDescBindHost)
+		cmd.Flags().StringSliceVar(&cmdFlags.SubjectAlternativeNames, common.FlagNameSubjectAlternativeNames, nil, common.FlagDescSubjectAlternativeNames)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessUpdateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessUpdate()
+	nonKubeCommand := nonkube.NewCmdRouterAccessUpdate()
+
+	cmdRouterAccessUpdateDesc := common.SkupperCmdDescription{
+		Use:   "update <name>",
+		Short: "update a routeraccess",
+		Long: `Remote sites use the endpoints of the routeraccess to establish links to this site.
+	The user can change the roles and their ports, the TLS credentials and how they are issued.`,
+		Example: "skupper routeraccess update my-access --roles inter-router:9090,edge:9091",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessUpdateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessUpdateFlags{}
+
+	cmd.Flags().StringSliceVar(&cmdFlags.Roles, common.FlagNameRoles, nil, common.FlagDescRoles)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+		cmd.Flags().BoolVar(&cmdFlags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, true, common.FlagDescGenerateTlsCredentials)
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().StringVar(&cmdFlags.Wait, common.FlagNameWait, "configured", common.FlagDescWait)
+	}
+	if configuredPlatform != common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.BindHost, common.FlagNameBindHost, "", common.FlagDescBindHost)
+		cmd.Flags().StringSliceVar(&cmdFlags.SubjectAlternativeNames, common.FlagNameSubjectAlternativeNames, nil, common.FlagDescSubjectAlternativeNames)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessStatusFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessStatus()
+	nonKubeCommand := nonkube.NewCmdRouterAccessStatus()
+
+	cmdRouterAccessStatusDesc := common.SkupperCmdDescription{
+		Use:     "status <name>",
+		Short:   "get status of routeraccesses",
+		Long:    "Display status of all routeraccesses or a specific routeraccess, including the endpoints provisioned for it",
+		Example: "skupper routeraccess status my-access",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessStatusDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessStatusFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessDeleteFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessDelete()
+	nonKubeCommand := nonkube.NewCmdRouterAccessDelete()
+
+	cmdRouterAccessDeleteDesc := common.SkupperCmdDescription{
+		Use:     "delete <name>",
+		Short:   "delete a routeraccess",
+		Long:    "Delete a routeraccess <name>",
+		Example: "skupper routeraccess delete my-access",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessDeleteDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessDeleteFlags{}
+
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+		cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescDeleteWait)
+	}
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdRouterAccessGenerateFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdRouterAccessGenerate()
+	nonKubeCommand := nonkube.NewCmdRouterAccessGenerate()
+
+	cmdRouterAccessGenerateDesc := common.SkupperCmdDescription{
+		Use:   "generate <name>",
+		Short: "generate a routeraccess resource and output it to a file or screen",
+		Long: `Remote sites use the endpoints of the routeraccess to establish links to this site.
+	generate a routeraccess to evaluate what will be created with routeraccess create command`,
+		Example: "skupper routeraccess generate my-access --roles inter-router,edge",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdRouterAccessGenerateDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandRouterAccessGenerateFlags{}
+
+	cmd.Flags().StringSliceVar(&cmdFlags.Roles, common.FlagNameRoles, []string{"inter-router", "edge"}, common.FlagDescRoles)
+	cmd.Flags().StringVar(&cmdFlags.TlsCredentials, common.FlagNameTlsCredentials, "", common.FlagDescTlsCredentials)
+	cmd.Flags().StringVar(&cmdFlags.Issuer, common.FlagNameIssuer, "", common.FlagDescIssuer)
+	if configuredPlatform == common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.AccessType, common.FlagNameAccessType, "", common.FlagDescAccessType)
+		cmd.Flags().BoolVar(&cmdFlags.GenerateTlsCredentials, common.FlagNameGenerateTlsCredentials, true, common.FlagDescGenerateTlsCredentials)
+	}
+	if configuredPlatform != common.PlatformKubernetes {
+		cmd.Flags().StringVar(&cmdFlags.BindHost, common.FlagNameBindHost, "", common.FlagDescBindHost)
+		cmd.Flags().StringSliceVar(&cmdFlags.SubjectAlternativeNames, common.FlagNameSubjectAlternativeNames, nil, common.FlagDescSubjectAlternativeNames)
+	}
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameOutput, "o", "yaml", common.FlagDescOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
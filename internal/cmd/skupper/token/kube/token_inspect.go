@@ -0,0 +1,132 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/kube/grants"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdTokenInspect struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandTokenInspectFlags
+	namespace string
+	fileName  string
+	grantName string
+	output    string
+}
+
+func NewCmdTokenInspect() *CmdTokenInspect {
+
+	return &CmdTokenInspect{}
+}
+
+func (cmd *CmdTokenInspect) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdTokenInspect) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Validate if AccessGrant CRD is installed
+	_, err := cmd.client.AccessGrants(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// The argument is either a token file or the name of an AccessGrant
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("token file or name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("token file or name must not be empty"))
+	} else if fileInfo, err := os.Stat(args[0]); err == nil && !fileInfo.IsDir() {
+		cmd.fileName = args[0]
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("token file %s does not exist and is not a valid token name: %s", args[0], err))
+		} else {
+			grant, err := cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), args[0], metav1.GetOptions{})
+			if grant == nil || k8serrs.IsNotFound(err) {
+				validationErrors = append(validationErrors, fmt.Errorf("token %s does not exist in namespace %s", args[0], cmd.namespace))
+			} else {
+				cmd.grantName = args[0]
+			}
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdTokenInspect) Run() error {
+	var details *utils.TokenDetails
+	var grant *v2alpha1.AccessGrant
+	if cmd.fileName != "" {
+		accessToken, err := utils.ReadAccessToken(cmd.fileName)
+		if err != nil {
+			return err
+		}
+		details, err = utils.NewTokenDetails(accessToken.Name, accessToken.Spec.Url, accessToken.Spec.Ca)
+		if err != nil {
+			return err
+		}
+		// the grant the token was issued from is only available on the issuing site
+		grant, _ = cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), accessToken.Name, metav1.GetOptions{})
+		if grant != nil && grant.Status.Url != accessToken.Spec.Url {
+			grant = nil
+		}
+	} else {
+		var err error
+		grant, err = cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), cmd.grantName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if grant.Status.Url == "" || grant.Status.Ca == "" {
+			return fmt.Errorf("token %s has not been issued yet, check the status for more information", cmd.grantName)
+		}
+		details, err = utils.NewTokenDetails(grant.Name, grant.Status.Url, grant.Status.Ca)
+		if err != nil {
+			return err
+		}
+	}
+	if grant != nil {
+		details.Expiry = grant.Status.ExpirationTime
+		details.Redemptions = fmt.Sprintf("%d/%d", grant.Status.Redemptions, grant.Spec.RedemptionsAllowed)
+		details.Revoked = grants.RevokedAt(grant)
+	}
+
+	return utils.PrintTokenDetails(details, cmd.output)
+}
+
+func (cmd *CmdTokenInspect) InputToOptions()  {}
+func (cmd *CmdTokenInspect) WaitUntil() error { return nil }
@@ -0,0 +1,220 @@
+package kube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/certs"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdTokenInspect_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandTokenInspectFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	fileName := filepath.Join(t.TempDir(), "token.yaml")
+	assert.Assert(t, os.WriteFile(fileName, []byte{}, 0644))
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-grant"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "token is not specified",
+			args:          []string{},
+			expectedError: "token file or name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "grant"},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "token is empty",
+			args:          []string{""},
+			expectedError: "token file or name must not be empty",
+		},
+		{
+			name:          "token is neither a file nor a valid name",
+			args:          []string{"/tmp/not/there.yaml"},
+			expectedError: "token file /tmp/not/there.yaml does not exist and is not a valid token name: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "grant does not exist",
+			args:          []string{"my-grant"},
+			expectedError: "token my-grant does not exist in namespace test",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{fileName},
+			flags:         common.CommandTokenInspectFlags{Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "token file is valid",
+			args:  []string{fileName},
+			flags: common.CommandTokenInspectFlags{Output: "yaml"},
+		},
+		{
+			name: "grant name is valid",
+			args: []string{"my-grant"},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-grant",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdTokenInspectWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdTokenInspect_Run(t *testing.T) {
+	type test struct {
+		name           string
+		fileName       string
+		grantName      string
+		output         string
+		skupperObjects []runtime.Object
+		errorMessage   string
+	}
+
+	ca, err := certs.GenerateSecret("my-ca", "my-ca", nil, 0, nil)
+	assert.Assert(t, err)
+	grant := &v2alpha1.AccessGrant{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "my-grant",
+			Namespace: "test",
+		},
+		Spec: v2alpha1.AccessGrantSpec{RedemptionsAllowed: 2},
+		Status: v2alpha1.AccessGrantStatus{
+			Url:            "https://10.0.0.1:9090/abc",
+			Ca:             string(ca.Data["tls.crt"]),
+			Code:           "supersecret",
+			ExpirationTime: "2124-01-01T00:00:00Z",
+		},
+	}
+	pending := &v2alpha1.AccessGrant{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "pending-grant",
+			Namespace: "test",
+		},
+	}
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token.yaml")
+	accessToken := v2alpha1.AccessToken{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AccessToken",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: "my-grant",
+		},
+		Spec: v2alpha1.AccessTokenSpec{
+			Url:  grant.Status.Url,
+			Ca:   grant.Status.Ca,
+			Code: grant.Status.Code,
+		},
+	}
+	encoded, err := utils.Encode("yaml", accessToken)
+	assert.Assert(t, err)
+	assert.Assert(t, os.WriteFile(tokenFile, []byte(encoded), 0644))
+
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	assert.Assert(t, os.WriteFile(invalidFile, []byte("kind: Link\n"), 0644))
+
+	testTable := []test{
+		{
+			name:           "token file issued from this site",
+			fileName:       tokenFile,
+			skupperObjects: []runtime.Object{grant},
+		},
+		{
+			name:     "token file issued from another site",
+			fileName: tokenFile,
+			output:   "json",
+		},
+		{
+			name:         "token file is not valid",
+			fileName:     invalidFile,
+			errorMessage: "token file does not contain an AccessToken",
+		},
+		{
+			name:           "grant name",
+			grantName:      "my-grant",
+			output:         "yaml",
+			skupperObjects: []runtime.Object{grant},
+		},
+		{
+			name:           "grant not yet issued",
+			grantName:      "pending-grant",
+			skupperObjects: []runtime.Object{pending},
+			errorMessage:   "token pending-grant has not been issued yet, check the status for more information",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdTokenInspectWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+		cmd.fileName = test.fileName
+		cmd.grantName = test.grantName
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && err.Error() == test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdTokenInspectWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdTokenInspect, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdTokenInspect := &CmdTokenInspect{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+
+	return cmdTokenInspect, nil
+}
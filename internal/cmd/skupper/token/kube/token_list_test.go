@@ -0,0 +1,148 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/grants"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdTokenList_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandTokenListFlags
+		expectedError string
+		skupperError  string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "arguments are specified",
+			args:          []string{"my-grant"},
+			expectedError: "arguments are not allowed in this command",
+		},
+		{
+			name:          "output format is not valid",
+			flags:         common.CommandTokenListFlags{Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			flags: common.CommandTokenListFlags{Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdTokenListWithMocks("test", nil, nil, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdTokenList_Run(t *testing.T) {
+	type test struct {
+		name           string
+		output         string
+		skupperObjects []runtime.Object
+		skupperError   string
+		errorMessage   string
+	}
+
+	testTable := []test{
+		{
+			name: "runs ok",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-grant",
+						Namespace: "test",
+					},
+					Spec: v2alpha1.AccessGrantSpec{RedemptionsAllowed: 2},
+					Status: v2alpha1.AccessGrantStatus{
+						Redemptions:    1,
+						ExpirationTime: "2124-01-01T00:00:00Z",
+					},
+				},
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:        "revoked-grant",
+						Namespace:   "test",
+						Annotations: map[string]string{grants.GrantRevokedAnnotation: "2024-01-01T00:00:00Z"},
+					},
+					Spec: v2alpha1.AccessGrantSpec{RedemptionsAllowed: 1},
+				},
+			},
+		},
+		{
+			name:   "runs ok with yaml output",
+			output: "yaml",
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "my-grant",
+						Namespace: "test",
+					},
+				},
+			},
+		},
+		{
+			name: "no grants found",
+		},
+		{
+			name:         "returns error",
+			skupperError: "error listing grants",
+			errorMessage: "error listing grants",
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdTokenListWithMocks("test", nil, test.skupperObjects, test.skupperError)
+		assert.Assert(t, err)
+		cmd.output = test.output
+
+		t.Run(test.name, func(t *testing.T) {
+			err := cmd.Run()
+			if test.errorMessage != "" {
+				assert.Check(t, err != nil && err.Error() == test.errorMessage)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdTokenListWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdTokenList, error) {
+
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdTokenList := &CmdTokenList{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+
+	return cmdTokenList, nil
+}
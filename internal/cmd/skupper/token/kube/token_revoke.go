@@ -0,0 +1,124 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/client"
+	"github.com/skupperproject/skupper/internal/kube/grants"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+	"github.com/spf13/cobra"
+	k8serrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CmdTokenRevoke struct {
+	client    skupperv2alpha1.SkupperV2alpha1Interface
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandTokenRevokeFlags
+	namespace string
+	grantName string
+	wait      bool
+}
+
+func NewCmdTokenRevoke() *CmdTokenRevoke {
+
+	return &CmdTokenRevoke{}
+}
+
+func (cmd *CmdTokenRevoke) NewClient(cobraCommand *cobra.Command, args []string) {
+	cli, err := client.NewClient(cobraCommand.Flag("namespace").Value.String(), cobraCommand.Flag("context").Value.String(), cobraCommand.Flag("kubeconfig").Value.String())
+	utils.HandleError(utils.GenericError, err)
+
+	cmd.client = cli.GetSkupperClient().SkupperV2alpha1()
+	cmd.namespace = cli.Namespace
+}
+
+func (cmd *CmdTokenRevoke) ValidateInput(args []string) error {
+	var validationErrors []error
+	resourceStringValidator := validator.NewResourceStringValidator()
+	timeoutValidator := validator.NewTimeoutInSecondsValidator()
+
+	// Validate if AccessGrant CRD is installed
+	_, err := cmd.client.AccessGrants(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		validationErrors = append(validationErrors, utils.HandleMissingCrds(err))
+		return errors.Join(validationErrors...)
+	}
+
+	// Validate arguments name
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("token name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("token name must not be empty"))
+	} else {
+		ok, err := resourceStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("token name is not valid: %s", err))
+		} else {
+			cmd.grantName = args[0]
+		}
+	}
+
+	// Validate that the grant exists and has not already been revoked
+	if cmd.grantName != "" {
+		grant, err := cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), cmd.grantName, metav1.GetOptions{})
+		if grant == nil || k8serrs.IsNotFound(err) {
+			validationErrors = append(validationErrors, fmt.Errorf("token %s does not exist in namespace %s", cmd.grantName, cmd.namespace))
+		} else if grants.IsRevoked(grant) {
+			validationErrors = append(validationErrors, fmt.Errorf("token %s was already revoked at %s", cmd.grantName, grants.RevokedAt(grant)))
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Timeout.String() != "" {
+		ok, err := timeoutValidator.Evaluate(cmd.Flags.Timeout)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("timeout is not valid: %s", err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdTokenRevoke) InputToOptions() {
+	cmd.wait = cmd.Flags.Wait
+}
+
+func (cmd *CmdTokenRevoke) Run() error {
+	_, err := grants.Revoke(cmd.client, cmd.namespace, cmd.grantName)
+	return err
+}
+
+func (cmd *CmdTokenRevoke) WaitUntil() error {
+
+	if cmd.wait {
+		waitTime := int(cmd.Flags.Timeout.Seconds())
+		err := utils.NewSpinnerWithTimeout("Waiting for revocation to complete...", waitTime, func() error {
+
+			grant, err := cmd.client.AccessGrants(cmd.namespace).Get(context.TODO(), cmd.grantName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			processed := meta.FindStatusCondition(grant.Status.Conditions, v2alpha1.CONDITION_TYPE_PROCESSED)
+			if grants.IsRevoked(grant) && processed != nil && processed.Status == metav1.ConditionFalse {
+				return nil
+			}
+			return fmt.Errorf("revocation not yet acknowledged")
+		})
+
+		if err != nil {
+			return fmt.Errorf("token %q revoked but not yet acknowledged by the grant server, check the status for more information\n", cmd.grantName)
+		}
+	}
+
+	fmt.Printf("Token %q revoked\n", cmd.grantName)
+	return nil
+}
@@ -0,0 +1,233 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/kube/grants"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCmdTokenRevoke_ValidateInput(t *testing.T) {
+	type test struct {
+		name           string
+		args           []string
+		flags          common.CommandTokenRevokeFlags
+		skupperObjects []runtime.Object
+		expectedError  string
+		skupperError   string
+	}
+
+	testTable := []test{
+		{
+			name:          "missing CRD",
+			args:          []string{"my-grant"},
+			skupperError:  utils.CrdErr,
+			expectedError: utils.CrdHelpErr,
+		},
+		{
+			name:          "token name is not specified",
+			args:          []string{},
+			flags:         common.CommandTokenRevokeFlags{Timeout: time.Minute},
+			expectedError: "token name must be configured",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{"my", "grant"},
+			flags:         common.CommandTokenRevokeFlags{Timeout: time.Minute},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "token name is not valid",
+			args:          []string{"my_grant"},
+			flags:         common.CommandTokenRevokeFlags{Timeout: time.Minute},
+			expectedError: "token name is not valid: value does not match this regular expression: ^[a-z0-9]([-a-z0-9]*[a-z0-9])*(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])*)*$",
+		},
+		{
+			name:          "grant does not exist",
+			args:          []string{"my-grant"},
+			flags:         common.CommandTokenRevokeFlags{Timeout: time.Minute},
+			expectedError: "token my-grant does not exist in namespace test",
+		},
+		{
+			name:  "grant already revoked",
+			args:  []string{"my-grant"},
+			flags: common.CommandTokenRevokeFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{
+						Name:        "my-grant",
+						Namespace:   "test",
+						Annotations: map[string]string{grants.GrantRevokedAnnotation: "2024-01-01T00:00:00Z"},
+					},
+				},
+			},
+			expectedError: "token my-grant was already revoked at 2024-01-01T00:00:00Z",
+		},
+		{
+			name:  "timeout is not valid",
+			args:  []string{"my-grant"},
+			flags: common.CommandTokenRevokeFlags{Timeout: 0},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{Name: "my-grant", Namespace: "test"},
+				},
+			},
+			expectedError: "timeout is not valid: duration must not be less than 10s; got 0s",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{"my-grant"},
+			flags: common.CommandTokenRevokeFlags{Timeout: time.Minute},
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: v1.ObjectMeta{Name: "my-grant", Namespace: "test"},
+				},
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+
+			command, err := newCmdTokenRevokeWithMocks("test", nil, test.skupperObjects, test.skupperError)
+			assert.Assert(t, err)
+
+			command.Flags = &test.flags
+
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdTokenRevoke_Run(t *testing.T) {
+	cmd, err := newCmdTokenRevokeWithMocks("test", nil, []runtime.Object{
+		&v2alpha1.AccessGrant{
+			ObjectMeta: v1.ObjectMeta{Name: "my-grant", Namespace: "test"},
+		},
+	}, "")
+	assert.Assert(t, err)
+	cmd.grantName = "my-grant"
+
+	assert.Assert(t, cmd.Run())
+
+	grant, err := cmd.client.AccessGrants("test").Get(context.TODO(), "my-grant", v1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Assert(t, grants.IsRevoked(grant))
+
+	cmd.grantName = "not-there"
+	assert.Assert(t, cmd.Run() != nil)
+}
+
+func TestCmdTokenRevoke_WaitUntil(t *testing.T) {
+	type test struct {
+		name           string
+		wait           bool
+		skupperObjects []runtime.Object
+		expectError    bool
+	}
+
+	revoked := v1.ObjectMeta{
+		Name:        "my-grant",
+		Namespace:   "test",
+		Annotations: map[string]string{grants.GrantRevokedAnnotation: "2024-01-01T00:00:00Z"},
+	}
+
+	testTable := []test{
+		{
+			name:        "grant is not returned",
+			wait:        true,
+			expectError: true,
+		},
+		{
+			name: "revocation is acknowledged",
+			wait: true,
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: revoked,
+					Status: v2alpha1.AccessGrantStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   v2alpha1.CONDITION_TYPE_PROCESSED,
+									Status: v1.ConditionFalse,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "revocation is not yet acknowledged",
+			wait: true,
+			skupperObjects: []runtime.Object{
+				&v2alpha1.AccessGrant{
+					ObjectMeta: revoked,
+					Status: v2alpha1.AccessGrantStatus{
+						Status: v2alpha1.Status{
+							Conditions: []v1.Condition{
+								{
+									Type:   v2alpha1.CONDITION_TYPE_PROCESSED,
+									Status: v1.ConditionTrue,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "user does not wait",
+			wait:        false,
+			expectError: false,
+		},
+	}
+
+	for _, test := range testTable {
+		cmd, err := newCmdTokenRevokeWithMocks("test", nil, test.skupperObjects, "")
+		assert.Assert(t, err)
+
+		cmd.grantName = "my-grant"
+		cmd.Flags = &common.CommandTokenRevokeFlags{Timeout: 1 * time.Second}
+		cmd.wait = test.wait
+
+		t.Run(test.name, func(t *testing.T) {
+
+			err := cmd.WaitUntil()
+			if test.expectError {
+				assert.Check(t, err != nil)
+			} else {
+				assert.Assert(t, err)
+			}
+		})
+	}
+}
+
+// --- helper methods
+
+func newCmdTokenRevokeWithMocks(namespace string, k8sObjects []runtime.Object, skupperObjects []runtime.Object, fakeSkupperError string) (*CmdTokenRevoke, error) {
+
+	// We make sure the interval is appropriate
+	utils.SetRetryProfile(utils.TestRetryProfile)
+	client, err := fakeclient.NewFakeClient(namespace, k8sObjects, skupperObjects, fakeSkupperError)
+	if err != nil {
+		return nil, err
+	}
+	cmdTokenRevoke := &CmdTokenRevoke{
+		client:    client.GetSkupperClient().SkupperV2alpha1(),
+		namespace: namespace,
+	}
+
+	return cmdTokenRevoke, nil
+}
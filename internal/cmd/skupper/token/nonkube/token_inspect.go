@@ -0,0 +1,81 @@
+package nonkube
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/internal/utils/validator"
+	"github.com/spf13/cobra"
+)
+
+type CmdTokenInspect struct {
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandTokenInspectFlags
+	Namespace string
+	fileName  string
+	output    string
+}
+
+func NewCmdTokenInspect() *CmdTokenInspect {
+	return &CmdTokenInspect{}
+}
+
+func (cmd *CmdTokenInspect) NewClient(cobraCommand *cobra.Command, args []string) {
+	if cmd.CobraCmd != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace) != nil && cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String() != "" {
+		cmd.Namespace = cmd.CobraCmd.Flag(common.FlagNameNamespace).Value.String()
+	}
+}
+
+func (cmd *CmdTokenInspect) ValidateInput(args []string) error {
+	var validationErrors []error
+	tokenStringValidator := validator.NewFilePathStringValidator()
+	outputTypeValidator := validator.NewOptionValidator(common.OutputTypes)
+
+	// Only token files can be inspected, as grants are not issued on this platform
+	if len(args) < 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("token file name must be configured"))
+	} else if len(args) > 1 {
+		validationErrors = append(validationErrors, fmt.Errorf("only one argument is allowed for this command"))
+	} else if args[0] == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("file name must not be empty"))
+	} else {
+		ok, err := tokenStringValidator.Evaluate(args[0])
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("token file name is not valid: %s", err))
+		} else if _, err := os.Stat(args[0]); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("cannot open token file: %s", err))
+		} else {
+			cmd.fileName = args[0]
+		}
+	}
+
+	if cmd.Flags != nil && cmd.Flags.Output != "" {
+		ok, err := outputTypeValidator.Evaluate(cmd.Flags.Output)
+		if !ok {
+			validationErrors = append(validationErrors, fmt.Errorf("output type is not valid: %s", err))
+		} else {
+			cmd.output = cmd.Flags.Output
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+func (cmd *CmdTokenInspect) InputToOptions() {}
+
+func (cmd *CmdTokenInspect) Run() error {
+	accessToken, err := utils.ReadAccessToken(cmd.fileName)
+	if err != nil {
+		return err
+	}
+	details, err := utils.NewTokenDetails(accessToken.Name, accessToken.Spec.Url, accessToken.Spec.Ca)
+	if err != nil {
+		return err
+	}
+	return utils.PrintTokenDetails(details, cmd.output)
+}
+
+func (cmd *CmdTokenInspect) WaitUntil() error { return nil }
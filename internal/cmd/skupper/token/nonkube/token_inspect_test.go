@@ -0,0 +1,102 @@
+package nonkube
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/certs"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/testutils"
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common/utils"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCmdTokenInspect_ValidateInput(t *testing.T) {
+	type test struct {
+		name          string
+		args          []string
+		flags         common.CommandTokenInspectFlags
+		expectedError string
+	}
+
+	// create temp token file for tests
+	fileName := "/tmp/token-inspect.yaml"
+	assert.Assert(t, os.WriteFile(fileName, []byte{}, 0644))
+
+	defer os.Remove(fileName)
+
+	testTable := []test{
+		{
+			name:          "file name is not specified",
+			args:          []string{},
+			expectedError: "token file name must be configured",
+		},
+		{
+			name:          "file name is empty",
+			args:          []string{""},
+			expectedError: "file name must not be empty",
+		},
+		{
+			name:          "more than one argument is specified",
+			args:          []string{fileName, fileName},
+			expectedError: "only one argument is allowed for this command",
+		},
+		{
+			name:          "file does not exist",
+			args:          []string{"/tmp/not-there/token.yaml"},
+			expectedError: "cannot open token file: stat /tmp/not-there/token.yaml: no such file or directory",
+		},
+		{
+			name:          "output format is not valid",
+			args:          []string{fileName},
+			flags:         common.CommandTokenInspectFlags{Output: "not-supported"},
+			expectedError: "output type is not valid: value not-supported not allowed. It should be one of this options: [json yaml]",
+		},
+		{
+			name:  "flags all valid",
+			args:  []string{fileName},
+			flags: common.CommandTokenInspectFlags{Output: "json"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CmdTokenInspect{Flags: &test.flags}
+			testutils.CheckValidateInput(t, command, test.expectedError, test.args)
+		})
+	}
+}
+
+func TestCmdTokenInspect_Run(t *testing.T) {
+	ca, err := certs.GenerateSecret("my-ca", "my-ca", nil, 0, nil)
+	assert.Assert(t, err)
+	accessToken := v2alpha1.AccessToken{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "skupper.io/v2alpha1",
+			Kind:       "AccessToken",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-grant",
+		},
+		Spec: v2alpha1.AccessTokenSpec{
+			Url:  "https://10.0.0.1:9090/abc",
+			Ca:   string(ca.Data["tls.crt"]),
+			Code: "supersecret",
+		},
+	}
+	encoded, err := utils.Encode("yaml", accessToken)
+	assert.Assert(t, err)
+	fileName := filepath.Join(t.TempDir(), "token.yaml")
+	assert.Assert(t, os.WriteFile(fileName, []byte(encoded), 0644))
+
+	for _, output := range []string{"", "yaml"} {
+		command := &CmdTokenInspect{fileName: fileName, output: output}
+		assert.Assert(t, command.Run())
+	}
+
+	command := &CmdTokenInspect{fileName: filepath.Join(t.TempDir(), "missing.yaml")}
+	assert.ErrorContains(t, command.Run(), "unable to read token file")
+}
@@ -0,0 +1,29 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdTokenList struct {
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandTokenListFlags
+	Namespace string
+}
+
+func NewCmdTokenList() *CmdTokenList {
+	return &CmdTokenList{}
+}
+
+func (cmd *CmdTokenList) NewClient(cobraCommand *cobra.Command, args []string) {
+	//TODO
+}
+
+func (cmd *CmdTokenList) ValidateInput(args []string) error { return nil }
+func (cmd *CmdTokenList) InputToOptions()                   {}
+func (cmd *CmdTokenList) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdTokenList) WaitUntil() error { return nil }
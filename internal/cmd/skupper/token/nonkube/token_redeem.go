@@ -102,7 +102,7 @@ func (cmd *CmdTokenRedeem) Run() error {
 
 	// redeem the access token and store the secret and links into the input resources path
 	// to redeem the token we use the namespace as a subject to allow redeeming tokens without an active site.
-	decoder, err := nonkubecommon.RedeemAccessToken(&accessToken, cmd.Namespace)
+	decoder, err := nonkubecommon.RedeemAccessToken(&accessToken, "", cmd.Namespace, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,29 @@
+package nonkube
+
+import (
+	"fmt"
+
+	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
+	"github.com/spf13/cobra"
+)
+
+type CmdTokenRevoke struct {
+	CobraCmd  *cobra.Command
+	Flags     *common.CommandTokenRevokeFlags
+	Namespace string
+}
+
+func NewCmdTokenRevoke() *CmdTokenRevoke {
+	return &CmdTokenRevoke{}
+}
+
+func (cmd *CmdTokenRevoke) NewClient(cobraCommand *cobra.Command, args []string) {
+	//TODO
+}
+
+func (cmd *CmdTokenRevoke) ValidateInput(args []string) error { return nil }
+func (cmd *CmdTokenRevoke) InputToOptions()                   {}
+func (cmd *CmdTokenRevoke) Run() error {
+	return fmt.Errorf("command not supported by the selected platform")
+}
+func (cmd *CmdTokenRevoke) WaitUntil() error { return nil }
@@ -20,12 +20,18 @@ func NewCmdToken() *cobra.Command {
 service network to connect to another.
 Issue the token on the site that was configured to allow incoming links.
 Redeem the token on the other site. `,
-		Example: `skupper token issue <name> ~/token.yaml`,
+		Example: `skupper token issue <name> ~/token.yaml
+skupper token list
+skupper token inspect ~/token.yaml
+skupper token revoke <name>`,
 	}
 
 	platform := common.Platform(config.GetPlatform())
 	cmd.AddCommand(CmdTokenIssueFactory(platform))
 	cmd.AddCommand(CmdTokenRedeemFactory(platform))
+	cmd.AddCommand(CmdTokenListFactory(platform))
+	cmd.AddCommand(CmdTokenInspectFactory(platform))
+	cmd.AddCommand(CmdTokenRevokeFactory(platform))
 
 	return cmd
 }
@@ -82,3 +88,83 @@ func CmdTokenRedeemFactory(configuredPlatform common.Platform) *cobra.Command {
 
 	return cmd
 }
+
+func CmdTokenListFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdTokenList()
+	nonKubeCommand := nonkube.NewCmdTokenList()
+
+	cmdTokenListDesc := common.SkupperCmdDescription{
+		Use:     "list",
+		Short:   "list the issued tokens",
+		Long:    "List the access grants issued from the current site, with their redemptions, expiration and revocation status.",
+		Example: "skupper token list",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdTokenListDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandTokenListFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameTokenOutput, "o", "", common.FlagDescTokenOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdTokenInspectFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdTokenInspect()
+	nonKubeCommand := nonkube.NewCmdTokenInspect()
+
+	cmdTokenInspectDesc := common.SkupperCmdDescription{
+		Use:   "inspect <fileName|name>",
+		Short: "inspect a token",
+		Long: `Show the URL, CA fingerprint and expiry of a token file, or of a token
+issued from the current site when given its name.`,
+		Example: `skupper token inspect ~/token1.yaml
+skupper token inspect my-token`,
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdTokenInspectDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandTokenInspectFlags{}
+
+	cmd.Flags().StringVarP(&cmdFlags.Output, common.FlagNameTokenOutput, "o", "", common.FlagDescTokenOutput)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
+
+func CmdTokenRevokeFactory(configuredPlatform common.Platform) *cobra.Command {
+	kubeCommand := kube.NewCmdTokenRevoke()
+	nonKubeCommand := nonkube.NewCmdTokenRevoke()
+
+	cmdTokenRevokeDesc := common.SkupperCmdDescription{
+		Use:   "revoke <name>",
+		Short: "revoke a token",
+		Long: `Revoke a token issued from the current site. The grant server refuses
+any further redemption of the token, while the grant is kept so that its
+redemption history can still be inspected.`,
+		Example: "skupper token revoke my-token",
+	}
+
+	cmd := common.ConfigureCobraCommand(configuredPlatform, cmdTokenRevokeDesc, kubeCommand, nonKubeCommand)
+
+	cmdFlags := common.CommandTokenRevokeFlags{}
+
+	cmd.Flags().DurationVar(&cmdFlags.Timeout, common.FlagNameTimeout, 60*time.Second, common.FlagDescTimeout)
+	cmd.Flags().BoolVar(&cmdFlags.Wait, common.FlagNameWait, true, common.FlagDescRevokeWait)
+
+	kubeCommand.CobraCmd = cmd
+	kubeCommand.Flags = &cmdFlags
+	nonKubeCommand.CobraCmd = cmd
+	nonKubeCommand.Flags = &cmdFlags
+
+	return cmd
+}
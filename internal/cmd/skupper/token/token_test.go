@@ -36,6 +36,28 @@ func TestCmdTokenFactory(t *testing.T) {
 			},
 			command: CmdTokenRedeemFactory(common.PlatformKubernetes),
 		},
+		{
+			name: "CmdTokenListFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTokenOutput: "",
+			},
+			command: CmdTokenListFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdTokenInspectFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTokenOutput: "",
+			},
+			command: CmdTokenInspectFactory(common.PlatformKubernetes),
+		},
+		{
+			name: "CmdTokenRevokeFactory",
+			expectedFlagsWithDefaultValue: map[string]interface{}{
+				common.FlagNameTimeout: "1m0s",
+				common.FlagNameWait:    "true",
+			},
+			command: CmdTokenRevokeFactory(common.PlatformKubernetes),
+		},
 	}
 
 	for _, test := range testTable {
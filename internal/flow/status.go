@@ -496,6 +496,7 @@ func asConnectorInfo(connector vanflow.ConnectorRecord) network.ConnectorInfo {
 	return network.ConnectorInfo{
 		DestHost: dref(connector.DestHost),
 		DestPort: dref(connector.DestPort),
+		Protocol: dref(connector.Protocol),
 		Address:  dref(connector.Address),
 		Process:  dref(connector.ProcessID),
 	}
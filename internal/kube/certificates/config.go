@@ -0,0 +1,75 @@
+package certificates
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	iflag "github.com/skupperproject/skupper/internal/flag"
+)
+
+const defaultValidity = time.Hour * 24 * 365 * 5
+
+// Config controls the lifetime of the certificates issued by the
+// CertificateManager. Certificates are checked whenever their resources
+// are resynced, and those within the renewal window of their expiry are
+// re-issued.
+type Config struct {
+	CertificateValidity time.Duration
+	CaValidity          time.Duration
+	RenewalWindow       time.Duration
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		CertificateValidity: defaultValidity,
+		CaValidity:          defaultValidity,
+		RenewalWindow:       time.Hour * 24 * 30,
+	}
+}
+
+func BoundConfig(flags *flag.FlagSet) (*Config, error) {
+	defaults := DefaultConfig()
+	c := &Config{}
+	var errors []string
+	if err := iflag.DurationVar(flags, &c.CertificateValidity, "certificate-validity", "SKUPPER_CERTIFICATE_VALIDITY", defaults.CertificateValidity, "How long certificates issued by a site CA are valid for."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.DurationVar(flags, &c.CaValidity, "ca-validity", "SKUPPER_CA_VALIDITY", defaults.CaValidity, "How long CA certificates are valid for. Renewed CA certificates retain their key, so existing certificates remain trusted."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.DurationVar(flags, &c.RenewalWindow, "certificate-renewal-window", "SKUPPER_CERTIFICATE_RENEWAL_WINDOW", defaults.RenewalWindow, "How long before expiry a certificate should be renewed."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if len(errors) > 0 {
+		return c, fmt.Errorf("Invalid environment variable(s): %s", strings.Join(errors, ", "))
+	}
+	return c, nil
+}
+
+func (c *Config) Verify() error {
+	if c.CertificateValidity <= 0 || c.CaValidity <= 0 {
+		return fmt.Errorf("Certificate validity must be positive.")
+	}
+	if c.RenewalWindow < 0 {
+		return fmt.Errorf("Certificate renewal window must not be negative.")
+	}
+	// a window as long as the validity would renew certificates as
+	// soon as they were issued
+	if c.RenewalWindow >= c.CertificateValidity || c.RenewalWindow >= c.CaValidity {
+		return fmt.Errorf("Certificate renewal window (%s) must be shorter than the certificate validity.", c.RenewalWindow)
+	}
+	return nil
+}
+
+func (c *Config) validity(signing bool) time.Duration {
+	if signing {
+		return c.CaValidity
+	}
+	return c.CertificateValidity
+}
+
+func (c *Config) isExpiring(expiration time.Time) bool {
+	return time.Until(expiration) <= c.RenewalWindow
+}
@@ -0,0 +1,29 @@
+package certificates
+
+import (
+	"time"
+)
+
+// MetricsProvider records the state of the certificates managed by the
+// CertificateManager.
+type MetricsProvider interface {
+	// Records the time at which a certificate expires and whether
+	// it is within the renewal window.
+	SetExpiration(namespace string, name string, expiration time.Time, expiring bool)
+	// Counts a certificate being re-issued for the given reason.
+	Renewed(namespace string, name string, reason string)
+	// Removes the metrics for a deleted certificate.
+	Deleted(namespace string, name string)
+}
+
+const (
+	RenewalReasonExpiring  = "expiring"
+	RenewalReasonCaRotated = "ca-rotated"
+)
+
+type noopMetricsProvider struct{}
+
+func (noopMetricsProvider) SetExpiration(namespace string, name string, expiration time.Time, expiring bool) {
+}
+func (noopMetricsProvider) Renewed(namespace string, name string, reason string) {}
+func (noopMetricsProvider) Deleted(namespace string, name string)                {}
@@ -4,6 +4,7 @@
 package certificates
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -41,25 +42,34 @@ type ControllerContext interface {
 type CertificateManager interface {
 	EnsureCA(namespace string, name string, subject string, refs []metav1.OwnerReference) error
 	Ensure(namespace string, name string, ca string, subject string, hosts []string, client bool, server bool, refs []metav1.OwnerReference) error
+	// Sets the key algorithm used for certificates in the namespace
+	// that do not specify one in their own settings.
+	SetDefaultKeyAlgorithm(namespace string, algorithm string)
 }
 
 type CertificateManagerImpl struct {
 	definitions        map[string]*skupperv2alpha1.Certificate
 	secrets            map[string]*corev1.Secret
+	keyAlgorithms      map[string]string
 	certificateWatcher *watchers.CertificateWatcher
 	secretWatcher      *watchers.SecretWatcher
 	processor          *watchers.EventProcessor
 	context            ControllerContext
+	config             *Config
+	metrics            MetricsProvider
 	logger             *slog.Logger
 }
 
 // Returns a correctly initialised CertificateManager.
 func NewCertificateManager(processor *watchers.EventProcessor) *CertificateManagerImpl {
 	return &CertificateManagerImpl{
-		definitions: map[string]*skupperv2alpha1.Certificate{},
-		secrets:     map[string]*corev1.Secret{},
-		processor:   processor,
-		logger:      slog.New(slog.Default().Handler()).With(slog.String("component", "kube.certificates.manager")),
+		definitions:   map[string]*skupperv2alpha1.Certificate{},
+		secrets:       map[string]*corev1.Secret{},
+		keyAlgorithms: map[string]string{},
+		processor:     processor,
+		config:        DefaultConfig(),
+		metrics:       noopMetricsProvider{},
+		logger:        slog.New(slog.Default().Handler()).With(slog.String("component", "kube.certificates.manager")),
 	}
 }
 
@@ -68,6 +78,40 @@ func (m *CertificateManagerImpl) SetControllerContext(context ControllerContext)
 	m.context = context
 }
 
+// Allows the validity and renewal window of issued certificates to be
+// configured.
+func (m *CertificateManagerImpl) SetConfig(config *Config) {
+	if config != nil {
+		m.config = config
+	}
+}
+
+// Allows metrics on managed certificates to be recorded.
+func (m *CertificateManagerImpl) SetMetricsProvider(provider MetricsProvider) {
+	if provider != nil {
+		m.metrics = provider
+	}
+}
+
+// The default key algorithm only applies to newly generated
+// certificates. Existing certificates are not regenerated when it
+// changes, as replacing the key of a CA would invalidate any links
+// using it.
+func (m *CertificateManagerImpl) SetDefaultKeyAlgorithm(namespace string, algorithm string) {
+	if algorithm == "" {
+		delete(m.keyAlgorithms, namespace)
+	} else {
+		m.keyAlgorithms[namespace] = algorithm
+	}
+}
+
+func (m *CertificateManagerImpl) keyAlgorithm(certificate *skupperv2alpha1.Certificate) string {
+	if algorithm := certs.KeyAlgorithmFromSettings(certificate.Spec.Settings); algorithm != "" {
+		return algorithm
+	}
+	return m.keyAlgorithms[certificate.Namespace]
+}
+
 // Causes the CertificateManager to start watching relevant resources.
 func (m *CertificateManagerImpl) Watch(watchNamespace string) {
 	m.certificateWatcher = m.processor.WatchCertificates(watchNamespace, watchers.FilterByNamespace(m.isControlled, m.checkCertificate))
@@ -165,6 +209,8 @@ func (m *CertificateManagerImpl) ensure(namespace string, name string, spec skup
 			spec.Subject = current.Spec.Subject
 		}
 		spec.Hosts = ownerMap.CombinedHosts()
+		// settings are not managed through Ensure
+		spec.Settings = current.Spec.Settings
 		if !cmp.Equal(spec, current.Spec, compareSpecUnordered...) {
 			current.Spec = spec
 			changed = true
@@ -266,11 +312,13 @@ func (m *CertificateManagerImpl) reconcileSecret(key string, certificate *skuppe
 	} else {
 		err = m.createSecret(key, certificate)
 	}
-	return m.updateStatus(certificate, err)
+	return m.updateStatus(certificate, m.secrets[key], err)
 }
 
 func (m *CertificateManagerImpl) certificateDeleted(key string) error {
 	delete(m.definitions, key)
+	namespace, name, _ := strings.Cut(key, "/")
+	m.metrics.Deleted(namespace, name)
 	if secret, ok := m.secrets[key]; ok {
 		err := m.processor.GetKubeClient().CoreV1().Secrets(secret.Namespace).Delete(context.Background(), secret.Name, metav1.DeleteOptions{})
 		if err != nil {
@@ -287,8 +335,16 @@ func (m *CertificateManagerImpl) secretDeleted(key string) error {
 	return nil
 }
 
-func (m *CertificateManagerImpl) updateStatus(certificate *skupperv2alpha1.Certificate, err error) error {
-	if certificate.SetReady(err) {
+func (m *CertificateManagerImpl) updateStatus(certificate *skupperv2alpha1.Certificate, secret *corev1.Secret, err error) error {
+	changed := certificate.SetReady(err)
+	if expiration, ok := secretExpiration(secret); ok {
+		expiring := m.config.isExpiring(expiration)
+		if certificate.SetExpiration(expiration, expiring) {
+			changed = true
+		}
+		m.metrics.SetExpiration(certificate.Namespace, certificate.Name, expiration, expiring)
+	}
+	if changed {
 		latest, err := m.processor.GetSkupperClient().SkupperV2alpha1().Certificates(certificate.Namespace).UpdateStatus(context.TODO(), certificate, metav1.UpdateOptions{})
 		if err != nil {
 			return err
@@ -304,7 +360,24 @@ func (m *CertificateManagerImpl) updateStatus(certificate *skupperv2alpha1.Certi
 func (m *CertificateManagerImpl) updateSecret(key string, certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) error {
 	changed := false
 	controlled := isSecretControlled(secret)
-	if !isSecretCorrect(certificate, secret) {
+	correct := isSecretCorrect(certificate, secret)
+	renewal := ""
+	if correct && controlled {
+		renewal = m.renewalReason(certificate, secret)
+	}
+	if renewal != "" {
+		renewed, err := m.renewSecret(certificate, secret)
+		if err != nil {
+			m.logger.Error("Error renewing Secret for Certificate",
+				slog.String("namespace", certificate.Namespace),
+				slog.String("name", secret.Name),
+				slog.String("reason", renewal),
+				slog.Any("error", err))
+			return err
+		}
+		changed = true
+		secret.Data = renewed.Data
+	} else if !correct {
 		if !controlled {
 			return errors.New("secret exists but is not controlled by skupper")
 		}
@@ -352,6 +425,15 @@ func (m *CertificateManagerImpl) updateSecret(key string, certificate *skupperv2
 		return err
 	}
 	m.secrets[key] = updated
+	if renewal != "" {
+		m.metrics.Renewed(certificate.Namespace, certificate.Name, renewal)
+		m.logger.Info("Renewed Secret for Certificate",
+			slog.String("namespace", secret.Namespace),
+			slog.String("name", secret.Name),
+			slog.String("key", key),
+			slog.String("reason", renewal))
+		return nil
+	}
 	m.logger.Info("Updated Secret for Certificate",
 		slog.String("namespace", secret.Namespace),
 		slog.String("name", secret.Name),
@@ -360,16 +442,41 @@ func (m *CertificateManagerImpl) updateSecret(key string, certificate *skupperv2
 	return nil
 }
 
+// Determines whether a secret that is otherwise correct for its
+// certificate needs to be re-issued, either because it is close to
+// expiry or because the CA that signed it has since been rotated.
+func (m *CertificateManagerImpl) renewalReason(certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) string {
+	if expiration, ok := secretExpiration(secret); ok && m.config.isExpiring(expiration) {
+		return RenewalReasonExpiring
+	}
+	if !certificate.Spec.Signing {
+		ca, ok := m.secrets[fmt.Sprintf("%s/%s", certificate.Namespace, certificate.Spec.Ca)]
+		if ok && !bytes.Equal(secret.Data["ca.crt"], ca.Data["tls.crt"]) {
+			return RenewalReasonCaRotated
+		}
+	}
+	return ""
+}
+
+// A CA is renewed with its existing key, so that certificates it has
+// already issued (including those held by other sites) remain
+// trusted. Any other certificate is re-issued by its CA.
+func (m *CertificateManagerImpl) renewSecret(certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) (*corev1.Secret, error) {
+	if certificate.Spec.Signing {
+		return certs.RenewCASecret(secret, m.config.validity(true))
+	}
+	return m.generateSecret(certificate)
+}
+
 func (m *CertificateManagerImpl) generateSecret(certificate *skupperv2alpha1.Certificate) (*corev1.Secret, error) {
 	var secret *corev1.Secret
 	var err error
 	if certificate.Spec.Signing {
-		secret, err = certs.GenerateSecret(certificate.Name, certificate.Spec.Subject, nil, 0, nil)
+		secret, err = certs.GenerateSecretWithKeyAlgorithm(certificate.Name, certificate.Spec.Subject, nil, m.config.validity(true), nil, m.keyAlgorithm(certificate))
 		if err != nil {
 			return secret, err
 		}
 	} else {
-		expiration := time.Hour * 24 * 365 * 5 // TODO: make this configurable (through controller setting or field on certificate?)
 		caKey := fmt.Sprintf("%s/%s", certificate.Namespace, certificate.Spec.Ca)
 		ca, ok := m.secrets[caKey]
 		if !ok {
@@ -377,7 +484,7 @@ func (m *CertificateManagerImpl) generateSecret(certificate *skupperv2alpha1.Cer
 			return nil, fmt.Errorf("CA %q not found", caKey)
 		}
 		// TODO: handle server and client roles properly
-		secret, err = certs.GenerateSecret(certificate.Name, certificate.Spec.Subject, certificate.Spec.Hosts, expiration, ca)
+		secret, err = certs.GenerateSecretWithKeyAlgorithm(certificate.Name, certificate.Spec.Subject, certificate.Spec.Hosts, m.config.validity(false), ca, m.keyAlgorithm(certificate))
 		if err != nil {
 			return nil, err
 		}
@@ -436,12 +543,36 @@ func (m *CertificateManagerImpl) checkSecret(key string, secret *corev1.Secret)
 	}
 	m.secrets[key] = secret
 	if definition, ok := m.definitions[key]; ok {
-		return m.reconcileSecret(key, definition, secret)
+		if err := m.reconcileSecret(key, definition, secret); err != nil {
+			return err
+		}
+		if definition.Spec.Signing {
+			return m.reconcileIssued(definition)
+		}
 	}
 
 	return nil
 }
 
+// Reconciles the secrets for all certificates issued by the supplied
+// CA, so that they are re-issued if the CA has been rotated.
+func (m *CertificateManagerImpl) reconcileIssued(ca *skupperv2alpha1.Certificate) error {
+	var errs []error
+	for key, definition := range m.definitions {
+		if definition.Namespace != ca.Namespace || definition.Spec.Signing || definition.Spec.Ca != ca.Name {
+			continue
+		}
+		secret, ok := m.secrets[key]
+		if !ok {
+			continue
+		}
+		if err := m.reconcileSecret(key, definition, secret); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func isSecretCorrect(certificate *skupperv2alpha1.Certificate, secret *corev1.Secret) bool {
 	data, ok := secret.Data["tls.crt"]
 	if !ok {
@@ -459,6 +590,11 @@ func isSecretCorrect(certificate *skupperv2alpha1.Certificate, secret *corev1.Se
 	if certificate.Spec.Subject != cert.Subject.CommonName {
 		return false
 	}
+	// only a key algorithm set explicitly on the certificate causes an
+	// existing key to be replaced
+	if algorithm := certs.KeyAlgorithmFromSettings(certificate.Spec.Settings); algorithm != "" && algorithm != certs.KeyAlgorithmOf(cert) {
+		return false
+	}
 	validFor := map[string]string{}
 	for _, host := range cert.DNSNames {
 		// Ignore empty DNSNames - GH-2277
@@ -481,6 +617,21 @@ func isSecretCorrect(certificate *skupperv2alpha1.Certificate, secret *corev1.Se
 	return true
 }
 
+func secretExpiration(secret *corev1.Secret) (time.Time, bool) {
+	if secret == nil {
+		return time.Time{}, false
+	}
+	data, ok := secret.Data["tls.crt"]
+	if !ok {
+		return time.Time{}, false
+	}
+	cert, err := certs.DecodeCertificate(data)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return cert.NotAfter, true
+}
+
 func isSecretControlled(secret *corev1.Secret) bool {
 	return hasControlledAnnotation(secret) || hasCertificateOwner(secret)
 }
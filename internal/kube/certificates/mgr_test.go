@@ -1,6 +1,7 @@
 package certificates
 
 import (
+	"bytes"
 	"context"
 	"strings"
 	"testing"
@@ -390,6 +391,268 @@ func TestCertificateManager(t *testing.T) {
 	}
 }
 
+func TestCertificateRenewal(t *testing.T) {
+	config := &Config{
+		CertificateValidity: time.Hour * 24,
+		CaValidity:          time.Hour * 48,
+		RenewalWindow:       time.Hour * 12,
+	}
+	oldCa := controlled(generateSecret(t, "my-ca", "test", "my-ca", nil, time.Hour*72, nil))
+	currentCa := controlled(generateSecret(t, "my-ca", "test", "my-ca", nil, time.Hour*72, nil))
+	expiringCa := controlled(generateSecret(t, "my-ca", "test", "my-ca", nil, time.Hour*6, nil))
+	testTable := []struct {
+		name             string
+		k8sObjects       []runtime.Object
+		expectedRenewals map[string]string
+		expectedExpiring map[string]bool
+		renewedCaKey     bool
+	}{
+		{
+			name: "certificate within renewal window is renewed",
+			k8sObjects: []runtime.Object{
+				currentCa,
+				controlled(generateSecret(t, "foo", "test", "my-subject", []string{"aaa"}, time.Hour*6, currentCa)),
+			},
+			expectedRenewals: map[string]string{
+				"test/foo": RenewalReasonExpiring,
+			},
+			expectedExpiring: map[string]bool{
+				"test/foo":   false,
+				"test/my-ca": false,
+			},
+		},
+		{
+			name: "certificate outside renewal window is not renewed",
+			k8sObjects: []runtime.Object{
+				currentCa,
+				controlled(generateSecret(t, "foo", "test", "my-subject", []string{"aaa"}, time.Hour*20, currentCa)),
+			},
+			expectedRenewals: map[string]string{},
+			expectedExpiring: map[string]bool{
+				"test/foo":   false,
+				"test/my-ca": false,
+			},
+		},
+		{
+			name: "uncontrolled certificate within renewal window is not renewed",
+			k8sObjects: []runtime.Object{
+				currentCa,
+				generateSecret(t, "foo", "test", "my-subject", []string{"aaa"}, time.Hour*6, currentCa),
+			},
+			expectedRenewals: map[string]string{},
+			expectedExpiring: map[string]bool{
+				"test/foo":   true,
+				"test/my-ca": false,
+			},
+		},
+		{
+			name: "certificates reissued when CA rotated",
+			k8sObjects: []runtime.Object{
+				currentCa,
+				controlled(generateSecret(t, "foo", "test", "my-subject", []string{"aaa"}, time.Hour*20, oldCa)),
+			},
+			expectedRenewals: map[string]string{
+				"test/foo": RenewalReasonCaRotated,
+			},
+			expectedExpiring: map[string]bool{
+				"test/foo":   false,
+				"test/my-ca": false,
+			},
+		},
+		{
+			name: "CA within renewal window is renewed with the same key",
+			k8sObjects: []runtime.Object{
+				expiringCa,
+				controlled(generateSecret(t, "foo", "test", "my-subject", []string{"aaa"}, time.Hour*20, expiringCa)),
+			},
+			expectedRenewals: map[string]string{
+				"test/my-ca": RenewalReasonExpiring,
+				"test/foo":   RenewalReasonCaRotated,
+			},
+			expectedExpiring: map[string]bool{
+				"test/foo":   false,
+				"test/my-ca": false,
+			},
+			renewedCaKey: true,
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			skupperObjects := []runtime.Object{
+				caCertificate("my-ca", "test", "my-ca", nil, nil),
+				certificate("foo", "test", "my-ca", "my-subject", []string{"aaa"}, false, true, nil, nil),
+			}
+			client, err := fakeclient.NewFakeClient("test", tt.k8sObjects, skupperObjects, "")
+			assert.Assert(t, err)
+			processor := watchers.NewEventProcessor("Controller", client)
+			metrics := &fakeMetrics{
+				renewals: map[string]string{},
+				expiring: map[string]bool{},
+			}
+			mgr := NewCertificateManager(processor)
+			mgr.SetConfig(config)
+			mgr.SetMetricsProvider(metrics)
+			mgr.Watch(metav1.NamespaceAll)
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			processor.StartWatchers(stopCh)
+			processor.WaitForCacheSync(stopCh)
+			mgr.Recover()
+			processor.TestProcessAll()
+
+			assert.DeepEqual(t, metrics.renewals, tt.expectedRenewals)
+			assert.DeepEqual(t, metrics.expiring, tt.expectedExpiring)
+
+			ca, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.Background(), "my-ca", metav1.GetOptions{})
+			assert.Assert(t, err)
+			if tt.renewedCaKey {
+				assert.DeepEqual(t, ca.Data["tls.key"], expiringCa.Data["tls.key"])
+				assert.Assert(t, !bytes.Equal(ca.Data["tls.crt"], expiringCa.Data["tls.crt"]))
+			}
+			foo, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.Background(), "foo", metav1.GetOptions{})
+			assert.Assert(t, err)
+			if _, ok := tt.expectedRenewals["test/foo"]; ok {
+				assert.DeepEqual(t, foo.Data["ca.crt"], ca.Data["tls.crt"])
+				expiration, ok := secretExpiration(foo)
+				assert.Assert(t, ok)
+				assert.Assert(t, time.Until(expiration) > time.Hour*23)
+			}
+
+			for key, expiring := range tt.expectedExpiring {
+				namespace, name, _ := strings.Cut(key, "/")
+				actual, err := client.GetSkupperClient().SkupperV2alpha1().Certificates(namespace).Get(context.Background(), name, metav1.GetOptions{})
+				assert.Assert(t, err)
+				assert.Assert(t, actual.Status.Expiration != "")
+				existing := meta.FindStatusCondition(actual.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_EXPIRING)
+				assert.Assert(t, existing != nil)
+				assert.Equal(t, existing.Status == metav1.ConditionTrue, expiring, key)
+			}
+		})
+	}
+}
+
+func TestCertificateKeyAlgorithm(t *testing.T) {
+	client, err := fakeclient.NewFakeClient("test", nil, nil, "")
+	assert.Assert(t, err)
+	processor := watchers.NewEventProcessor("Controller", client)
+	mgr := NewCertificateManager(processor)
+	mgr.Watch(metav1.NamespaceAll)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	processor.StartWatchers(stopCh)
+	processor.WaitForCacheSync(stopCh)
+	mgr.Recover()
+
+	keyAlgorithmOf := func(name string) string {
+		secret, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return ""
+		}
+		cert, err := certs.DecodeCertificate(secret.Data["tls.crt"])
+		if err != nil {
+			return ""
+		}
+		return certs.KeyAlgorithmOf(cert)
+	}
+	// events are delivered asynchronously by the informers, so keep
+	// processing them until the expected state is reached
+	waitFor := func(condition func() bool) {
+		t.Helper()
+		for i := 0; i < 100 && !condition(); i++ {
+			time.Sleep(10 * time.Millisecond)
+			processor.TestProcessAll()
+		}
+		assert.Assert(t, condition())
+	}
+	hasKeyAlgorithm := func(name string, algorithm string) func() bool {
+		return func() bool { return keyAlgorithmOf(name) == algorithm }
+	}
+
+	// the namespace default applies to certificates without their own setting
+	mgr.SetDefaultKeyAlgorithm("test", certs.KeyAlgorithmECDSAP256)
+	assert.Assert(t, mgr.EnsureCA("test", "my-ca", "my-ca", fixtureRefs))
+	waitFor(hasKeyAlgorithm("my-ca", certs.KeyAlgorithmECDSAP256))
+	assert.Assert(t, mgr.Ensure("test", "foo", "my-ca", "foo", []string{"aaa"}, false, true, fixtureRefs))
+	waitFor(hasKeyAlgorithm("foo", certs.KeyAlgorithmECDSAP256))
+
+	// changing the default does not regenerate existing certificates
+	mgr.SetDefaultKeyAlgorithm("test", certs.KeyAlgorithmRSA2048)
+	assert.Assert(t, mgr.Ensure("test", "foo", "my-ca", "foo", []string{"aaa"}, false, true, fixtureRefs))
+	time.Sleep(50 * time.Millisecond)
+	processor.TestProcessAll()
+	assert.Equal(t, keyAlgorithmOf("foo"), certs.KeyAlgorithmECDSAP256)
+
+	// setting the algorithm on the certificate itself does
+	foo, err := client.GetSkupperClient().SkupperV2alpha1().Certificates("test").Get(context.Background(), "foo", metav1.GetOptions{})
+	assert.Assert(t, err)
+	foo.Spec.Settings = map[string]string{certs.KeyAlgorithmSetting: certs.KeyAlgorithmEd25519}
+	_, err = client.GetSkupperClient().SkupperV2alpha1().Certificates("test").Update(context.Background(), foo, metav1.UpdateOptions{})
+	assert.Assert(t, err)
+	waitFor(hasKeyAlgorithm("foo", certs.KeyAlgorithmEd25519))
+	assert.Equal(t, keyAlgorithmOf("my-ca"), certs.KeyAlgorithmECDSAP256)
+
+	// and the setting is retained when the certificate is ensured again
+	assert.Assert(t, mgr.Ensure("test", "foo", "my-ca", "foo", []string{"aaa", "bbb"}, false, true, fixtureRefs))
+	waitFor(func() bool {
+		secret, err := client.GetKubeClient().CoreV1().Secrets("test").Get(context.Background(), "foo", metav1.GetOptions{})
+		return err == nil && secret.Annotations["internal.skupper.io/hosts"] == "aaa,bbb"
+	})
+	foo, err = client.GetSkupperClient().SkupperV2alpha1().Certificates("test").Get(context.Background(), "foo", metav1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Equal(t, foo.Spec.Settings[certs.KeyAlgorithmSetting], certs.KeyAlgorithmEd25519)
+	assert.Equal(t, keyAlgorithmOf("foo"), certs.KeyAlgorithmEd25519)
+
+	// an unsupported algorithm is reported in the certificate status
+	foo.Spec.Settings = map[string]string{certs.KeyAlgorithmSetting: "dsa"}
+	_, err = client.GetSkupperClient().SkupperV2alpha1().Certificates("test").Update(context.Background(), foo, metav1.UpdateOptions{})
+	assert.Assert(t, err)
+	waitFor(func() bool {
+		foo, err = client.GetSkupperClient().SkupperV2alpha1().Certificates("test").Get(context.Background(), "foo", metav1.GetOptions{})
+		return err == nil && meta.IsStatusConditionFalse(foo.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_READY)
+	})
+	ready := meta.FindStatusCondition(foo.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_READY)
+	assert.Assert(t, strings.Contains(ready.Message, "unsupported key algorithm"), ready.Message)
+}
+
+func TestConfigVerify(t *testing.T) {
+	assert.Assert(t, DefaultConfig().Verify())
+	assert.ErrorContains(t, (&Config{CertificateValidity: time.Hour, CaValidity: time.Hour * 2, RenewalWindow: time.Hour}).Verify(), "must be shorter than the certificate validity")
+	assert.ErrorContains(t, (&Config{CertificateValidity: time.Hour, CaValidity: time.Hour, RenewalWindow: -time.Hour}).Verify(), "must not be negative")
+	assert.ErrorContains(t, (&Config{CaValidity: time.Hour}).Verify(), "must be positive")
+}
+
+type fakeMetrics struct {
+	renewals map[string]string
+	expiring map[string]bool
+}
+
+func (m *fakeMetrics) SetExpiration(namespace string, name string, expiration time.Time, expiring bool) {
+	m.expiring[namespace+"/"+name] = expiring
+}
+
+func (m *fakeMetrics) Renewed(namespace string, name string, reason string) {
+	m.renewals[namespace+"/"+name] = reason
+}
+
+func (m *fakeMetrics) Deleted(namespace string, name string) {
+	delete(m.expiring, namespace+"/"+name)
+}
+
+func generateSecret(t *testing.T, name string, namespace string, subject string, hosts []string, expiration time.Duration, ca *corev1.Secret) *corev1.Secret {
+	t.Helper()
+	secret, err := certs.GenerateSecret(name, subject, hosts, expiration, ca)
+	assert.Assert(t, err)
+	secret.Namespace = namespace
+	return secret
+}
+
+func controlled(secret *corev1.Secret) *corev1.Secret {
+	secret.Annotations = map[string]string{
+		"internal.skupper.io/controlled": "true",
+	}
+	return secret
+}
+
 func secret(name string, namespace string, data map[string][]byte, labels map[string]string, annotations map[string]string) *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
@@ -51,10 +51,15 @@ func NewFakeClient(namespace string, k8sObjects []runtime.Object, skupperObjects
 	scheme := runtime.NewScheme()
 	appsv1.AddToScheme(scheme)
 	c.Dynamic = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
-		resource.ContourHttpProxyResource(): "HTTPProxyList",
-		resource.GatewayResource():          "GatewayList",
-		resource.TlsRouteResource():         "TLSRouteList",
-		resource.DeploymentResource():       "DeploymentList",
+		resource.ContourHttpProxyResource():        "HTTPProxyList",
+		resource.GatewayResource():                 "GatewayList",
+		resource.TlsRouteResource():                "TLSRouteList",
+		resource.TcpRouteResource():                "TCPRouteList",
+		resource.IstioGatewayResource():            "GatewayList",
+		resource.VirtualServiceResource():          "VirtualServiceList",
+		resource.DeploymentResource():              "DeploymentList",
+		resource.HorizontalPodAutoscalerResource(): "HorizontalPodAutoscalerList",
+		resource.VerticalPodAutoscalerResource():   "VerticalPodAutoscalerList",
 	}, dynamic...)
 	// prepopulated objects not working for some reason with dynamic client, so create them manually here for now:
 	for _, d := range dynamic {
@@ -81,9 +86,23 @@ func gvrFromGvk(gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool)
 		if gvk.Kind == "TLSRoute" {
 			return resource.TlsRouteResource(), true
 		}
+		if gvk.Kind == "TCPRoute" {
+			return resource.TcpRouteResource(), true
+		}
 		if gvk.Kind == "Gateway" {
 			return resource.GatewayResource(), true
 		}
+	case "networking.istio.io":
+		if gvk.Kind == "Gateway" {
+			return resource.IstioGatewayResource(), true
+		}
+		if gvk.Kind == "VirtualService" {
+			return resource.VirtualServiceResource(), true
+		}
+	case "autoscaling.k8s.io":
+		if gvk.Kind == "VerticalPodAutoscaler" {
+			return resource.VerticalPodAutoscalerResource(), true
+		}
 	}
 	return schema.GroupVersionResource{}, false
 }
@@ -113,6 +132,35 @@ func fakedApiResources() []*metav1.APIResourceList {
 					Version:      "v1alpha2",
 					Kind:         "TLSRoute",
 				},
+				{
+					Name:         "tcproutes",
+					SingularName: "tcproute",
+					Namespaced:   true,
+					Group:        "gateway.networking.k8s.io",
+					Version:      "v1alpha2",
+					Kind:         "TCPRoute",
+				},
+			},
+		},
+		{
+			GroupVersion: "networking.istio.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{
+					Name:         "gateways",
+					SingularName: "gateway",
+					Namespaced:   true,
+					Group:        "networking.istio.io",
+					Version:      "v1beta1",
+					Kind:         "Gateway",
+				},
+				{
+					Name:         "virtualservices",
+					SingularName: "virtualservice",
+					Namespaced:   true,
+					Group:        "networking.istio.io",
+					Version:      "v1beta1",
+					Kind:         "VirtualService",
+				},
 			},
 		},
 		{
@@ -128,6 +176,19 @@ func fakedApiResources() []*metav1.APIResourceList {
 				},
 			},
 		},
+		{
+			GroupVersion: "autoscaling.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{
+					Name:         "verticalpodautoscalers",
+					SingularName: "verticalpodautoscaler",
+					Namespaced:   true,
+					Group:        "autoscaling.k8s.io",
+					Version:      "v1",
+					Kind:         "VerticalPodAutoscaler",
+				},
+			},
+		},
 		{
 			GroupVersion: "skupper.io/v2alpha1",
 			APIResources: []metav1.APIResource{
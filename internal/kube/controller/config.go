@@ -6,15 +6,20 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	iflag "github.com/skupperproject/skupper/internal/flag"
+	"github.com/skupperproject/skupper/internal/kube/certificates"
 	"github.com/skupperproject/skupper/internal/kube/grants"
 	"github.com/skupperproject/skupper/internal/kube/metrics"
 	"github.com/skupperproject/skupper/internal/kube/securedaccess"
 )
 
 type Config struct {
-	GrantConfig            *grants.GrantConfig
-	SecuredAccessConfig    *securedaccess.Config
-	MetricsConfig          *metrics.Config
+	GrantConfig         *grants.GrantConfig
+	SecuredAccessConfig *securedaccess.Config
+	MetricsConfig       *metrics.Config
+	CertificateConfig   *certificates.Config
+	// CertificateMetrics is not bound to a flag; it is set when
+	// metrics are enabled.
+	CertificateMetrics     certificates.MetricsProvider
 	Namespace              string
 	Kubeconfig             string
 	WatchNamespace         string
@@ -46,10 +51,15 @@ func BoundConfig(flags *flag.FlagSet) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	certificateConfig, err := certificates.BoundConfig(flags)
+	if err != nil {
+		return nil, err
+	}
 	c := &Config{
 		GrantConfig:         grantConfig,
 		SecuredAccessConfig: securedAccessConfig,
 		MetricsConfig:       metricsConfig,
+		CertificateConfig:   certificateConfig,
 	}
 	iflag.StringVar(flags, &c.Namespace, "namespace", "NAMESPACE", "", "The Kubernetes namespace scope for the controller")
 	iflag.StringVar(flags, &c.Kubeconfig, "kubeconfig", "KUBECONFIG", "", "A path to the kubeconfig file to use")
@@ -10,8 +10,11 @@ import (
 	"regexp"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers/internalinterfaces"
 	"k8s.io/client-go/tools/cache"
 
@@ -21,6 +24,7 @@ import (
 	"github.com/skupperproject/skupper/internal/kube/securedaccess"
 	"github.com/skupperproject/skupper/internal/kube/site"
 	"github.com/skupperproject/skupper/internal/kube/site/labels"
+	"github.com/skupperproject/skupper/internal/kube/site/scheduling"
 	"github.com/skupperproject/skupper/internal/kube/site/sizing"
 	"github.com/skupperproject/skupper/internal/kube/watchers"
 	"github.com/skupperproject/skupper/internal/network"
@@ -30,32 +34,34 @@ import (
 )
 
 type Controller struct {
-	self                    skupperv2alpha1.Controller
-	deploymentName          string
-	deploymentUid           string
-	eventProcessor          *watchers.EventProcessor
-	stopCh                  <-chan struct{}
-	siteWatcher             *watchers.SiteWatcher
-	listenerWatcher         *watchers.ListenerWatcher
-	connectorWatcher        *watchers.ConnectorWatcher
-	multiKeyListenerWatcher *watchers.MultiKeyListenerWatcher
-	linkAccessWatcher       *watchers.RouterAccessWatcher
-	grantWatcher            *watchers.AccessGrantWatcher
-	serviceWatcher          *watchers.ServiceWatcher
-	sites                   map[string]*site.Site
-	startGrantServer        func()
-	accessMgr               *securedaccess.SecuredAccessManager
-	accessRecovery          *securedaccess.SecuredAccessResourceWatcher
-	certMgr                 *certificates.CertificateManagerImpl
-	siteSizing              *sizing.Registry
-	siteSizingWatcher       *watchers.ConfigMapWatcher
-	labelling               *labels.LabelsAndAnnotations
-	labellingWatcher        *watchers.ConfigMapWatcher
-	attachableConnectors    map[string]*skupperv2alpha1.AttachedConnector
-	disableSecContext       bool
-	log                     *slog.Logger
-	namespaces              *NamespaceConfig
-	observedServices        map[string]string
+	self                     skupperv2alpha1.Controller
+	deploymentName           string
+	deploymentUid            string
+	eventProcessor           *watchers.EventProcessor
+	stopCh                   <-chan struct{}
+	siteWatcher              *watchers.SiteWatcher
+	listenerWatcher          *watchers.ListenerWatcher
+	connectorWatcher         *watchers.ConnectorWatcher
+	multiKeyListenerWatcher  *watchers.MultiKeyListenerWatcher
+	linkAccessWatcher        *watchers.RouterAccessWatcher
+	grantWatcher             *watchers.AccessGrantWatcher
+	serviceWatcher           *watchers.ServiceWatcher
+	sites                    map[string]*site.Site
+	startGrantServer         func()
+	accessMgr                *securedaccess.SecuredAccessManager
+	accessRecovery           *securedaccess.SecuredAccessResourceWatcher
+	certMgr                  *certificates.CertificateManagerImpl
+	siteSizing               *sizing.Registry
+	siteSizingWatcher        *watchers.ConfigMapWatcher
+	schedulingProfiles       *scheduling.Registry
+	schedulingProfileWatcher *watchers.ConfigMapWatcher
+	labelling                *labels.LabelsAndAnnotations
+	labellingWatcher         *watchers.ConfigMapWatcher
+	attachableConnectors     map[string]*skupperv2alpha1.AttachedConnector
+	disableSecContext        bool
+	log                      *slog.Logger
+	namespaces               *NamespaceConfig
+	observedServices         map[string]string
 }
 
 func skupperRouterConfig() internalinterfaces.TweakListOptionsFunc {
@@ -87,6 +93,24 @@ func skupperSiteSizingConfig() internalinterfaces.TweakListOptionsFunc {
 	}
 }
 
+func routerAutoscalers() internalinterfaces.TweakListOptionsFunc {
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = "skupper.io/component=router,skupper.io/type=site"
+	}
+}
+
+func dynamicRouterAutoscalers() dynamicinformer.TweakListOptionsFunc {
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = "skupper.io/component=router,skupper.io/type=site"
+	}
+}
+
+func skupperSchedulingProfileConfig() internalinterfaces.TweakListOptionsFunc {
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = scheduling.SchedulingProfileLabel
+	}
+}
+
 func labelling() internalinterfaces.TweakListOptionsFunc {
 	return func(options *metav1.ListOptions) {
 		options.LabelSelector = "skupper.io/label-template"
@@ -98,6 +122,7 @@ func NewController(cli internalclient.Clients, config *Config, options ...watche
 		eventProcessor:       watchers.NewEventProcessor("Controller", cli, options...),
 		sites:                map[string]*site.Site{},
 		siteSizing:           sizing.NewRegistry(),
+		schedulingProfiles:   scheduling.NewRegistry(),
 		labelling:            labels.NewLabelsAndAnnotations(config.Namespace),
 		attachableConnectors: map[string]*skupperv2alpha1.AttachedConnector{},
 		log:                  slog.New(slog.Default().Handler()).With(slog.String("component", "kube.controller")),
@@ -143,12 +168,19 @@ func NewController(cli internalclient.Clients, config *Config, options ...watche
 	controller.eventProcessor.WatchConfigMaps(skupperRouterConfig(), config.WatchNamespace, filter(controller, controller.routerConfigUpdate))
 	controller.eventProcessor.WatchAccessTokens(config.WatchNamespace, filter(controller, controller.checkAccessToken))
 	controller.eventProcessor.WatchPods("skupper.io/component=router,skupper.io/type=site", config.WatchNamespace, filter(controller, controller.routerPodEvent))
+	controller.eventProcessor.WatchHorizontalPodAutoscalers(routerAutoscalers(), config.WatchNamespace, filter(controller, controller.horizontalAutoscalerEvent))
+	if controller.eventProcessor.HasVerticalPodAutoscaler() {
+		controller.eventProcessor.WatchVerticalPodAutoscalers(dynamicRouterAutoscalers(), config.WatchNamespace, filter(controller, controller.verticalAutoscalerEvent))
+	}
 	controller.siteSizingWatcher = controller.eventProcessor.WatchConfigMaps(skupperSiteSizingConfig(), config.Namespace, filter(controller, controller.siteSizing.Update))
+	controller.schedulingProfileWatcher = controller.eventProcessor.WatchConfigMaps(skupperSchedulingProfileConfig(), config.Namespace, filter(controller, controller.schedulingProfiles.Update))
 	controller.namespaces.watch(controller.eventProcessor, config.WatchNamespace)
 	controller.labellingWatcher = controller.eventProcessor.WatchConfigMaps(labelling(), config.WatchNamespace, controller.labelling.Update)
 
 	controller.certMgr = certificates.NewCertificateManager(controller.eventProcessor)
 	controller.certMgr.SetControllerContext(controller)
+	controller.certMgr.SetConfig(config.CertificateConfig)
+	controller.certMgr.SetMetricsProvider(config.CertificateMetrics)
 	controller.certMgr.Watch(config.WatchNamespace)
 
 	controller.accessMgr = securedaccess.NewSecuredAccessManager(controller.eventProcessor, controller.certMgr, config.SecuredAccessConfig, controller)
@@ -156,6 +188,7 @@ func NewController(cli internalclient.Clients, config *Config, options ...watche
 	controller.accessRecovery.WatchResources(controller.eventProcessor, config.WatchNamespace)
 	controller.accessRecovery.WatchSecuredAccesses(controller.eventProcessor, config.WatchNamespace, controller.checkSecuredAccess)
 	controller.accessRecovery.WatchGateway(controller.eventProcessor, config.Namespace)
+	controller.accessRecovery.WatchAccessTypes(controller.eventProcessor, config.Namespace)
 
 	controller.startGrantServer = grants.Initialise(controller.eventProcessor, config.Namespace, config.WatchNamespace, config.GrantConfig, controller.generateLinkConfig, controller.IsControlled)
 
@@ -231,6 +264,13 @@ func (c *Controller) init(stopCh <-chan struct{}) error {
 		)
 		c.siteSizing.Update(config.Namespace+"/"+config.Name, config)
 	}
+	for _, config := range c.schedulingProfileWatcher.List() {
+		c.log.Info("Recovering scheduling profile",
+			slog.String("namespace", config.Namespace),
+			slog.String("name", config.Name),
+		)
+		c.schedulingProfiles.Update(config.Namespace+"/"+config.Name, config)
+	}
 	for _, config := range c.labellingWatcher.List() {
 		c.log.Info("Recovering label and annotation configuration",
 			slog.String("namespace", config.Namespace),
@@ -361,7 +401,7 @@ func (c *Controller) getSite(namespace string) *site.Site {
 	if existing, ok := c.sites[namespace]; ok {
 		return existing
 	}
-	site := site.NewSite(namespace, c.eventProcessor, c.certMgr, c.accessMgr, c.siteSizing, c, c.disableSecContext)
+	site := site.NewSite(namespace, c.eventProcessor, c.certMgr, c.accessMgr, c.siteSizing, c.schedulingProfiles, c, c.disableSecContext)
 	c.sites[namespace] = site
 	return site
 }
@@ -483,6 +523,22 @@ func (c *Controller) routerPodEvent(key string, pod *corev1.Pod) error {
 	return c.getSite(namespace).RouterPodEvent(key, pod)
 }
 
+func (c *Controller) horizontalAutoscalerEvent(key string, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	return c.getSite(namespace).HorizontalAutoscalerEvent(key, hpa)
+}
+
+func (c *Controller) verticalAutoscalerEvent(key string, vpa *unstructured.Unstructured) error {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	return c.getSite(namespace).VerticalAutoscalerEvent(key, vpa)
+}
+
 func (c *Controller) generateLinkConfig(namespace string, name string, subject string, writer io.Writer) error {
 	site := c.getSite(namespace).GetSite()
 	if site == nil {
@@ -4,10 +4,15 @@ import (
 	"flag"
 	"fmt"
 	"strings"
+	"time"
 
 	iflag "github.com/skupperproject/skupper/internal/flag"
 )
 
+// The number of redemptions kept in the status of each AccessGrant
+// unless configured otherwise.
+const defaultHistoryLimit = 10
+
 type GrantConfig struct {
 	Enabled              bool
 	AutoConfigure        bool
@@ -16,6 +21,13 @@ type GrantConfig struct {
 	TlsCredentialsSecret string
 	Hostname             string
 	RedeemByKey          bool
+	MaxFailures          int
+	FailureWindow        time.Duration
+	LockoutDuration      time.Duration
+	HistoryLimit         int
+	// Metrics is not bound to a flag; it is set when metrics are
+	// enabled.
+	Metrics MetricsProvider
 }
 
 func BoundGrantConfig(flags *flag.FlagSet) (*GrantConfig, error) {
@@ -36,6 +48,18 @@ func BoundGrantConfig(flags *flag.FlagSet) (*GrantConfig, error) {
 	if err := iflag.BoolVar(flags, &c.RedeemByKey, "allow-redeem-by-key", "SKUPPER_ALLOW_REDEEM_BY_KEY", false, "Allow AccessGrant redemption using a predictable key."); err != nil {
 		errors = append(errors, err.Error())
 	}
+	if err := iflag.IntVar(flags, &c.MaxFailures, "grant-server-max-failures", "SKUPPER_GRANT_SERVER_MAX_FAILURES", 10, "The number of redemptions refused for an invalid code, from a single address or for a single grant, after which that address or grant is locked out (0 disables lockout)."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.DurationVar(flags, &c.FailureWindow, "grant-server-failure-window", "SKUPPER_GRANT_SERVER_FAILURE_WINDOW", time.Minute, "The period over which failed redemptions from a single address or for a single grant are counted."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.DurationVar(flags, &c.LockoutDuration, "grant-server-lockout", "SKUPPER_GRANT_SERVER_LOCKOUT", 15*time.Minute, "The period for which an address or grant is refused after too many failed redemptions."); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if err := iflag.IntVar(flags, &c.HistoryLimit, "grant-redemption-history", "SKUPPER_GRANT_REDEMPTION_HISTORY", defaultHistoryLimit, "The number of redemptions recorded in the status of each AccessGrant."); err != nil {
+		errors = append(errors, err.Error())
+	}
 	if len(errors) > 0 {
 		return c, fmt.Errorf("Invalid environment variable(s): %s", strings.Join(errors, ", "))
 	}
@@ -57,3 +81,30 @@ func (c *GrantConfig) scheme() string {
 func (c *GrantConfig) tlsEnabled() bool {
 	return c.TlsCredentialsSecret != ""
 }
+
+func (c *GrantConfig) Verify() error {
+	var errors []string
+	if c.MaxFailures < 0 {
+		errors = append(errors, "grant-server-max-failures must not be negative")
+	}
+	if c.MaxFailures > 0 && c.FailureWindow <= 0 {
+		errors = append(errors, "grant-server-failure-window must be positive")
+	}
+	if c.MaxFailures > 0 && c.LockoutDuration <= 0 {
+		errors = append(errors, "grant-server-lockout must be positive")
+	}
+	if c.HistoryLimit < 0 {
+		errors = append(errors, "grant-redemption-history must not be negative")
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("Invalid grant configuration: %s", strings.Join(errors, ", "))
+	}
+	return nil
+}
+
+func (c *GrantConfig) metrics() MetricsProvider {
+	if c.Metrics == nil {
+		return noopMetricsProvider{}
+	}
+	return c.Metrics
+}
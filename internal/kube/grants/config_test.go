@@ -4,6 +4,7 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 )
@@ -22,6 +23,10 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9090,
 				TlsCredentialsSecret: "skupper-grant-server",
 				Hostname:             os.Getenv("HOSTNAME"),
+				MaxFailures:          10,
+				FailureWindow:        time.Minute,
+				LockoutDuration:      15 * time.Minute,
+				HistoryLimit:         10,
 			},
 		},
 		{
@@ -41,6 +46,10 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				MaxFailures:          10,
+				FailureWindow:        time.Minute,
+				LockoutDuration:      15 * time.Minute,
+				HistoryLimit:         10,
 			},
 		},
 		{
@@ -68,6 +77,10 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9876,
 				TlsCredentialsSecret: "a-different-secret",
 				Hostname:             "a-different-host",
+				MaxFailures:          10,
+				FailureWindow:        time.Minute,
+				LockoutDuration:      15 * time.Minute,
+				HistoryLimit:         10,
 			},
 		},
 		{
@@ -87,6 +100,10 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				MaxFailures:          10,
+				FailureWindow:        time.Minute,
+				LockoutDuration:      15 * time.Minute,
+				HistoryLimit:         10,
 			},
 		},
 		{
@@ -111,6 +128,10 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				MaxFailures:          10,
+				FailureWindow:        time.Minute,
+				LockoutDuration:      15 * time.Minute,
+				HistoryLimit:         10,
 			},
 		},
 		{
@@ -135,6 +156,10 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 1234,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				MaxFailures:          10,
+				FailureWindow:        time.Minute,
+				LockoutDuration:      15 * time.Minute,
+				HistoryLimit:         10,
 			},
 		},
 		{
@@ -159,6 +184,50 @@ func Test_BoundGrantConfig(t *testing.T) {
 				Port:                 9090,
 				TlsCredentialsSecret: "my-secret",
 				Hostname:             "my-host",
+				MaxFailures:          10,
+				FailureWindow:        time.Minute,
+				LockoutDuration:      15 * time.Minute,
+				HistoryLimit:         10,
+			},
+		},
+		{
+			name: "redemption limits",
+			env: map[string]string{
+				"SKUPPER_GRANT_SERVER_MAX_FAILURES":   "3",
+				"SKUPPER_GRANT_SERVER_FAILURE_WINDOW": "30s",
+				"SKUPPER_GRANT_REDEMPTION_HISTORY":    "5",
+			},
+			args: []string{
+				"--grant-server-lockout=1h",
+			},
+			expectedValue: &GrantConfig{
+				Port:                 9090,
+				TlsCredentialsSecret: "skupper-grant-server",
+				Hostname:             os.Getenv("HOSTNAME"),
+				MaxFailures:          3,
+				FailureWindow:        30 * time.Second,
+				LockoutDuration:      time.Hour,
+				HistoryLimit:         5,
+			},
+		},
+		{
+			name: "invalid env var for lockout",
+			env: map[string]string{
+				"SKUPPER_GRANT_SERVER_LOCKOUT": "forever",
+			},
+			expectedErrors: []string{
+				"Invalid environment variable(s)",
+				"SKUPPER_GRANT_SERVER_LOCKOUT",
+				"forever",
+			},
+			expectedValue: &GrantConfig{
+				Port:                 9090,
+				TlsCredentialsSecret: "skupper-grant-server",
+				Hostname:             os.Getenv("HOSTNAME"),
+				MaxFailures:          10,
+				FailureWindow:        time.Minute,
+				LockoutDuration:      15 * time.Minute,
+				HistoryLimit:         10,
 			},
 		},
 	}
@@ -214,3 +283,65 @@ func Test_GrantConfigMethods(t *testing.T) {
 		})
 	}
 }
+
+func Test_GrantConfigVerify(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *GrantConfig
+		expectedError string
+	}{
+		{
+			name: "valid",
+			input: &GrantConfig{
+				MaxFailures:     10,
+				FailureWindow:   time.Minute,
+				LockoutDuration: time.Minute,
+				HistoryLimit:    10,
+			},
+		},
+		{
+			name:  "lockout disabled",
+			input: &GrantConfig{},
+		},
+		{
+			name: "negative max failures",
+			input: &GrantConfig{
+				MaxFailures: -1,
+			},
+			expectedError: "grant-server-max-failures must not be negative",
+		},
+		{
+			name: "no failure window",
+			input: &GrantConfig{
+				MaxFailures:     5,
+				LockoutDuration: time.Minute,
+			},
+			expectedError: "grant-server-failure-window must be positive",
+		},
+		{
+			name: "no lockout",
+			input: &GrantConfig{
+				MaxFailures:   5,
+				FailureWindow: time.Minute,
+			},
+			expectedError: "grant-server-lockout must be positive",
+		},
+		{
+			name: "negative history",
+			input: &GrantConfig{
+				HistoryLimit: -1,
+			},
+			expectedError: "grant-redemption-history must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Verify()
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
@@ -17,7 +17,11 @@ func enabled(controller *watchers.EventProcessor, currentNamespace string, watch
 		grants: newGrants(controller, generator, config.scheme(), config.BaseUrl),
 		logger: slog.New(slog.Default().Handler()).With(slog.String("component", "kube.grants.enabled")),
 	}
+	gc.grants.historyLimit = config.HistoryLimit
+	gc.grants.metrics = config.metrics()
 	gc.server = newServer(config.addr(), config.tlsEnabled(), gc.grants)
+	gc.server.limiter = newRateLimiter(config.MaxFailures, config.FailureWindow, config.LockoutDuration)
+	gc.server.metrics = config.metrics()
 
 	gc.grantWatcher = controller.WatchAccessGrants(watchNamespace, watchers.FilterByNamespace(filter, gc.grants.checkGrant))
 	gc.secretWatcher = controller.WatchSecrets(watchers.ByName(config.TlsCredentialsSecret), watchNamespace, watchers.FilterByNamespace(filter, gc.tlsCredentialsUpdated))
@@ -0,0 +1,69 @@
+package grants
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+const (
+	EventReasonRedeemed           = "AccessGrantRedeemed"
+	EventReasonRedemptionRejected = "AccessGrantRedemptionRejected"
+	eventSourceComponent          = "skupper-controller"
+)
+
+// newEventRecorder returns a recorder that writes events through a
+// broadcaster. The broadcaster aggregates similar events and limits the
+// rate at which events are created for any one grant, so that repeated
+// refused redemptions cannot flood the namespace with events.
+func newEventRecorder(clients internalclient.Clients) record.EventRecorder {
+	if clients == nil || clients.GetKubeClient() == nil {
+		return nil
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: clients.GetKubeClient().CoreV1().Events(""),
+	})
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: eventSourceComponent})
+}
+
+func grantReference(grant *skupperv2alpha1.AccessGrant) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: "skupper.io/v2alpha1",
+		Kind:       "AccessGrant",
+		Namespace:  grant.Namespace,
+		Name:       grant.Name,
+		UID:        grant.UID,
+	}
+}
+
+// recordEvent records an event for the grant. Events are written
+// asynchronously and do not affect the outcome of the redemption.
+func (g *Grants) recordEvent(grant *skupperv2alpha1.AccessGrant, eventType string, reason string, message string) {
+	if g.recorder == nil {
+		return
+	}
+	g.recorder.Event(grantReference(grant), eventType, reason, message)
+}
+
+// redeemer describes the redeeming site for events, using the site name
+// and the subject (the UID of the site) it supplied.
+func redeemer(redemption skupperv2alpha1.AccessGrantRedemption) string {
+	site := "unnamed site"
+	if redemption.Site != "" {
+		site = fmt.Sprintf("site %s", redemption.Site)
+		if redemption.Namespace != "" {
+			site = fmt.Sprintf("site %s/%s", redemption.Namespace, redemption.Site)
+		}
+	}
+	if redemption.Subject != "" {
+		return fmt.Sprintf("%s (subject %s)", site, redemption.Subject)
+	}
+	return site
+}
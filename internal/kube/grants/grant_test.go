@@ -15,6 +15,7 @@ import (
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/skupperproject/skupper/internal/kube/client/fake"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
@@ -222,6 +223,23 @@ func Test_ServeHttp(t *testing.T) {
 			ExpirationTime: time.Date(2124, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
 		},
 	}
+	revoked := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "revoked",
+			Namespace: "test",
+			UID:       "5d1f64a8-1f3c-4a7e-9a1b-2f0e6f1c9e42",
+			Annotations: map[string]string{
+				GrantRevokedAnnotation: time.Date(2024, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			},
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 1,
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Code:           "supersecret",
+			ExpirationTime: time.Date(2124, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		},
+	}
 
 	skupperObjects := []runtime.Object{
 		good,
@@ -229,6 +247,7 @@ func Test_ServeHttp(t *testing.T) {
 		used,
 		badExpiration,
 		deleted,
+		revoked,
 	}
 
 	var tests = []struct {
@@ -266,6 +285,13 @@ func Test_ServeHttp(t *testing.T) {
 			body:         bytes.NewBufferString(used.Status.Code),
 			expectedCode: http.StatusNotFound,
 		},
+		{
+			name:         "revoked grant",
+			method:       http.MethodPost,
+			path:         "/" + string(revoked.ObjectMeta.UID),
+			body:         bytes.NewBufferString(revoked.Status.Code),
+			expectedCode: http.StatusNotFound,
+		},
 		{
 			name:         "wrong code",
 			method:       http.MethodPost,
@@ -321,7 +347,7 @@ func Test_ServeHttp(t *testing.T) {
 				generator = dummyGenerator
 			}
 			registry := newGrants(client, generator, "https", "")
-			for _, grant := range []*v2alpha1.AccessGrant{good, expired, used, badExpiration, deleted} {
+			for _, grant := range []*v2alpha1.AccessGrant{good, expired, used, badExpiration, deleted, revoked} {
 				err = registry.checkGrant(grant.Namespace+"/"+grant.Name, grant)
 				if err != nil {
 					t.Error(err)
@@ -416,6 +442,80 @@ func Test_ServeHttpUIDAndKey(t *testing.T) {
 	}
 }
 
+func Test_ServeHttpRedemptionHistory(t *testing.T) {
+	grant := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "history",
+			Namespace: "test",
+			UID:       "7a3e2f4c-58b1-4b8e-9d0f-3c6a1e2b4d5f",
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 5,
+		},
+		Status: v2alpha1.AccessGrantStatus{
+			Code:           "supersecret",
+			ExpirationTime: time.Date(2124, time.January, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		},
+	}
+	client, err := fake.NewFakeClient("test", nil, []runtime.Object{grant}, "")
+	assert.NilError(t, err)
+	metrics := newTestGrantMetrics()
+	registry := newGrants(client, dummyGenerator, "https", "")
+	recorder := record.NewFakeRecorder(10)
+	registry.historyLimit = 2
+	registry.metrics = metrics
+	registry.recorder = recorder
+	assert.NilError(t, registry.checkGrant(grant.Namespace+"/"+grant.Name, grant))
+
+	redeem := func(code string, address string, site string, subject string) int {
+		req := httptest.NewRequest(http.MethodPost, "/"+string(grant.ObjectMeta.UID), bytes.NewBufferString(code))
+		req.RemoteAddr = address
+		req.Header.Set("name", "my-token")
+		if site != "" {
+			req.Header.Set("site-name", site)
+			req.Header.Set("site-namespace", "partner")
+		}
+		if subject != "" {
+			req.Header.Set("subject", subject)
+		}
+		res := httptest.NewRecorder()
+		registry.ServeHTTP(res, req)
+		return res.Code
+	}
+	assert.Equal(t, redeem("supersecret", "10.0.0.1:5000", "", ""), http.StatusOK)
+	assert.Equal(t, redeem("opensesame", "10.0.0.9:5000", "intruder", ""), http.StatusForbidden)
+	assert.Equal(t, redeem("supersecret", "10.0.0.2:5000", "west", "west-uid"), http.StatusOK)
+	assert.Equal(t, redeem("supersecret", "10.0.0.3:5000", "east", "east-uid"), http.StatusOK)
+
+	updated, err := client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "history", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, updated.Status.Redemptions, 3)
+	assert.Equal(t, len(updated.Status.RedemptionHistory), 2)
+	assert.Equal(t, updated.Status.RedemptionHistory[0].Site, "west")
+	assert.Equal(t, updated.Status.RedemptionHistory[0].Namespace, "partner")
+	assert.Equal(t, updated.Status.RedemptionHistory[0].Subject, "west-uid")
+	assert.Equal(t, updated.Status.RedemptionHistory[0].Address, "10.0.0.2")
+	assert.Equal(t, updated.Status.RedemptionHistory[1].Site, "east")
+	assert.Equal(t, updated.Status.RedemptionHistory[1].Subject, "east-uid")
+	assert.Equal(t, updated.Status.RedemptionHistory[1].Address, "10.0.0.3")
+	_, err = time.Parse(time.RFC3339, updated.Status.RedemptionHistory[1].Time)
+	assert.NilError(t, err)
+
+	events := []string{}
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.DeepEqual(t, events, []string{
+		"Normal AccessGrantRedeemed Redeemed by unnamed site (subject my-token) from 10.0.0.1",
+		"Warning AccessGrantRedemptionRejected Redemption by site partner/intruder from 10.0.0.9 rejected: invalid-code",
+		"Normal AccessGrantRedeemed Redeemed by site partner/west (subject west-uid) from 10.0.0.2",
+		"Normal AccessGrantRedeemed Redeemed by site partner/east (subject east-uid) from 10.0.0.3",
+	})
+
+	assert.Equal(t, metrics.redeemed, 3)
+	assert.Equal(t, metrics.rejected[RejectionReasonInvalidCode], 1)
+}
+
 type CheckGrantTestInvocation struct {
 	key           string
 	grant         *v2alpha1.AccessGrant
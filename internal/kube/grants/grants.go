@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubetypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	"github.com/skupperproject/skupper/internal/utils"
@@ -21,27 +24,33 @@ import (
 type GrantResponse func(namespace string, name string, subject string, writer io.Writer) error
 
 type Grants struct {
-	clients    internalclient.Clients
-	generator  GrantResponse
-	url        string
-	ca         string
-	scheme     string
-	grants     map[kubetypes.UID]*skupperv2alpha1.AccessGrant
-	grantIndex map[string]kubetypes.UID
-	keyRedeem  bool
-	lock       sync.Mutex
-	logger     *slog.Logger
+	clients      internalclient.Clients
+	generator    GrantResponse
+	url          string
+	ca           string
+	scheme       string
+	grants       map[kubetypes.UID]*skupperv2alpha1.AccessGrant
+	grantIndex   map[string]kubetypes.UID
+	keyRedeem    bool
+	historyLimit int
+	metrics      MetricsProvider
+	recorder     record.EventRecorder
+	lock         sync.Mutex
+	logger       *slog.Logger
 }
 
 func newGrants(clients internalclient.Clients, generator GrantResponse, scheme string, url string) *Grants {
 	return &Grants{
-		clients:    clients,
-		generator:  generator,
-		scheme:     scheme,
-		url:        url,
-		grants:     map[kubetypes.UID]*skupperv2alpha1.AccessGrant{},
-		grantIndex: map[string]kubetypes.UID{},
-		logger:     slog.New(slog.Default().Handler()).With(slog.String("component", "kube.grants")),
+		clients:      clients,
+		generator:    generator,
+		scheme:       scheme,
+		url:          url,
+		grants:       map[kubetypes.UID]*skupperv2alpha1.AccessGrant{},
+		grantIndex:   map[string]kubetypes.UID{},
+		historyLimit: defaultHistoryLimit,
+		metrics:      noopMetricsProvider{},
+		recorder:     newEventRecorder(clients),
+		logger:       slog.New(slog.Default().Handler()).With(slog.String("component", "kube.grants")),
 	}
 }
 
@@ -208,6 +217,10 @@ func (g *Grants) checkGrant(key string, grant *skupperv2alpha1.AccessGrant) erro
 		}
 	}
 
+	if IsRevoked(grant) {
+		status = append(status, fmt.Sprintf("AccessGrant revoked at %s", RevokedAt(grant)))
+	}
+
 	if g.checkUrl(key, grant) {
 		changed = true
 	}
@@ -274,10 +287,11 @@ func (g *Grants) updateGrantStatus(grant *skupperv2alpha1.AccessGrant) error {
 	return nil
 }
 
-func (g *Grants) checkAndUpdateAccessToken(key string, data []byte) (*skupperv2alpha1.AccessGrant, *HttpError) {
-	g.logger.Info("Checking access token", slog.String("key", key))
+func (g *Grants) checkAndUpdateAccessToken(key string, data []byte, name string, redemption skupperv2alpha1.AccessGrantRedemption) (*skupperv2alpha1.AccessGrant, *HttpError) {
+	g.logger.Info("Checking access token", slog.String("key", key), slog.String("address", redemption.Address))
 	grant := g.get(key)
 	if grant == nil {
+		g.metrics.Rejected("", RejectionReasonNotFound)
 		return nil, httpError("No such claim", http.StatusNotFound)
 	}
 
@@ -287,31 +301,63 @@ func (g *Grants) checkAndUpdateAccessToken(key string, data []byte) (*skupperv2a
 			slog.String("namespace", grant.Namespace),
 			slog.String("name", grant.Name),
 			slog.Any("error", err))
+		g.rejected(grant, redemption, RejectionReasonError)
 		return nil, httpError("Corrupted claim", http.StatusInternalServerError)
 	}
 	if expiration.Before(time.Now()) {
 		g.logger.Info("AccessGrant expired", slog.String("namespace", grant.Namespace), slog.String("name", grant.Name))
+		g.rejected(grant, redemption, RejectionReasonExpired)
 		return nil, httpError("No such claim", http.StatusNotFound)
 	}
+	if IsRevoked(grant) {
+		g.logger.Info("AccessGrant revoked", slog.String("namespace", grant.Namespace), slog.String("name", grant.Name))
+		g.rejected(grant, redemption, RejectionReasonRevoked)
+		return nil, httpError("No such access granted", http.StatusNotFound)
+	}
 	if grant.Spec.RedemptionsAllowed <= grant.Status.Redemptions {
 		g.logger.Info("AccessGrant already redeemed", slog.String("namespace", grant.Namespace), slog.String("name", grant.Name))
+		g.rejected(grant, redemption, RejectionReasonRedeemed)
 		return nil, httpError("No such access granted", http.StatusNotFound)
 	}
 	if grant.Status.Code != string(data) {
+		g.logger.Info("Invalid code for AccessGrant",
+			slog.String("namespace", grant.Namespace),
+			slog.String("name", grant.Name),
+			slog.String("address", redemption.Address))
+		g.rejected(grant, redemption, RejectionReasonInvalidCode)
 		return nil, httpError("Redemption of access token refused", http.StatusForbidden)
 	}
+	if name == "" {
+		name = grant.Name
+	}
+	if redemption.Subject == "" {
+		redemption.Subject = name
+	}
+	redemption.Time = time.Now().UTC().Format(time.RFC3339)
 	grant.Status.Redemptions += 1
+	grant.RecordRedemption(redemption, g.historyLimit)
 	err = g.updateGrantStatus(grant)
 	if err != nil {
 		g.logger.Error("Error updating access grant",
 			slog.String("namespace", grant.Namespace),
 			slog.String("name", grant.Name),
 			slog.Any("error", err))
+		g.metrics.Rejected(grant.Namespace, RejectionReasonError)
 		return nil, httpError("Internal error", http.StatusServiceUnavailable)
 	}
+	g.metrics.Redeemed(grant.Namespace)
+	g.recordEvent(grant, corev1.EventTypeNormal, EventReasonRedeemed,
+		fmt.Sprintf("Redeemed by %s from %s", redeemer(redemption), redemption.Address))
 	return grant, nil
 }
 
+// rejected records a refused redemption of a token for a known grant.
+func (g *Grants) rejected(grant *skupperv2alpha1.AccessGrant, redemption skupperv2alpha1.AccessGrantRedemption, reason string) {
+	g.metrics.Rejected(grant.Namespace, reason)
+	g.recordEvent(grant, corev1.EventTypeWarning, EventReasonRedemptionRejected,
+		fmt.Sprintf("Redemption by %s from %s rejected: %s", redeemer(redemption), redemption.Address, reason))
+}
+
 func (g *Grants) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		g.logger.Error("Bad method for path", slog.String("method", r.Method), slog.String("path", r.URL.Path))
@@ -331,7 +377,12 @@ func (g *Grants) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	grant, e := g.checkAndUpdateAccessToken(key, body)
+	grant, e := g.checkAndUpdateAccessToken(key, body, r.Header.Get("name"), skupperv2alpha1.AccessGrantRedemption{
+		Subject:   r.Header.Get("subject"),
+		Site:      r.Header.Get("site-name"),
+		Namespace: r.Header.Get("site-namespace"),
+		Address:   sourceAddress(r),
+	})
 	if e != nil {
 		e.write(w)
 		return
@@ -359,6 +410,16 @@ func (g *Grants) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	g.logger.Info("Redemption of access token succeeded", slog.String("namespace", grant.Namespace), slog.String("name", grant.Name))
 }
 
+// sourceAddress returns the host from which a request was received.
+// Forwarding headers are deliberately ignored as they can be set by the
+// client.
+func sourceAddress(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
 func (g *Grants) keyFromUrl(url string) (string, bool) {
 	if g.keyRedeem && len(url) > 0 && strings.Contains(url[1:], "/") {
 		return url[1:], true
@@ -0,0 +1,31 @@
+package grants
+
+// MetricsProvider records the outcome of requests to redeem access
+// tokens made to the grant server.
+type MetricsProvider interface {
+	// Counts a successful redemption of a token for a grant in the
+	// given namespace.
+	Redeemed(namespace string)
+	// Counts a rejected redemption for the given reason. The
+	// namespace is empty if no matching grant was found.
+	Rejected(namespace string, reason string)
+	// Counts a source address being locked out after repeated
+	// failures.
+	LockedOut()
+}
+
+const (
+	RejectionReasonNotFound    = "not-found"
+	RejectionReasonExpired     = "expired"
+	RejectionReasonRevoked     = "revoked"
+	RejectionReasonRedeemed    = "redeemed"
+	RejectionReasonInvalidCode = "invalid-code"
+	RejectionReasonLockedOut   = "locked-out"
+	RejectionReasonError       = "error"
+)
+
+type noopMetricsProvider struct{}
+
+func (noopMetricsProvider) Redeemed(namespace string)                {}
+func (noopMetricsProvider) Rejected(namespace string, reason string) {}
+func (noopMetricsProvider) LockedOut()                               {}
@@ -0,0 +1,103 @@
+package grants
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter tracks failed redemptions by source, where a source is
+// either the address a request came from or the grant it was for. Once
+// a source has failed maxFailures times within the failure window, it is
+// locked out for the lockout period.
+type rateLimiter struct {
+	maxFailures int
+	window      time.Duration
+	lockout     time.Duration
+	sources     map[string]*sourceFailures
+	lock        sync.Mutex
+	now         func() time.Time
+}
+
+type sourceFailures struct {
+	count       int
+	since       time.Time
+	lockedUntil time.Time
+}
+
+func newRateLimiter(maxFailures int, window time.Duration, lockout time.Duration) *rateLimiter {
+	if maxFailures <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		maxFailures: maxFailures,
+		window:      window,
+		lockout:     lockout,
+		sources:     map[string]*sourceFailures{},
+		now:         time.Now,
+	}
+}
+
+// locked returns the time remaining until all of the sources may try
+// again, or zero if none of them is locked out.
+func (l *rateLimiter) locked(sources ...string) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var longest time.Duration
+	for _, source := range sources {
+		if state, ok := l.sources[source]; ok {
+			if remaining := state.lockedUntil.Sub(l.now()); remaining > longest {
+				longest = remaining
+			}
+		}
+	}
+	return longest
+}
+
+// failed records a failure for each of the sources and returns true if
+// that caused any of them to be locked out.
+func (l *rateLimiter) failed(sources ...string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	l.prune(now)
+	lockedOut := false
+	for _, source := range sources {
+		if l.fail(source, now) {
+			lockedOut = true
+		}
+	}
+	return lockedOut
+}
+
+func (l *rateLimiter) fail(source string, now time.Time) bool {
+	state, ok := l.sources[source]
+	if !ok || now.Sub(state.since) > l.window {
+		state = &sourceFailures{since: now}
+		l.sources[source] = state
+	}
+	state.count++
+	if state.count < l.maxFailures {
+		return false
+	}
+	state.count = 0
+	state.since = now
+	state.lockedUntil = now.Add(l.lockout)
+	return true
+}
+
+// succeeded clears any failures recorded for the sources.
+func (l *rateLimiter) succeeded(sources ...string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, source := range sources {
+		delete(l.sources, source)
+	}
+}
+
+func (l *rateLimiter) prune(now time.Time) {
+	for source, state := range l.sources {
+		if now.Sub(state.since) > l.window && !state.lockedUntil.After(now) {
+			delete(l.sources, source)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package grants
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func Test_rateLimiter(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(3, time.Minute, 10*time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	assert.Equal(t, limiter.failed("10.0.0.1"), false)
+	assert.Equal(t, limiter.failed("10.0.0.1"), false)
+	assert.Equal(t, limiter.locked("10.0.0.1"), time.Duration(0))
+
+	// failures outside the window are forgotten
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, limiter.failed("10.0.0.1"), false)
+	assert.Equal(t, limiter.failed("10.0.0.1"), false)
+	assert.Equal(t, limiter.failed("10.0.0.1"), true)
+	assert.Equal(t, limiter.locked("10.0.0.1"), 10*time.Minute)
+
+	// other sources are unaffected
+	assert.Equal(t, limiter.locked("10.0.0.2"), time.Duration(0))
+
+	now = now.Add(9 * time.Minute)
+	assert.Equal(t, limiter.locked("10.0.0.1"), time.Minute)
+	now = now.Add(time.Minute)
+	assert.Equal(t, limiter.locked("10.0.0.1"), time.Duration(0))
+
+	// success clears previous failures
+	assert.Equal(t, limiter.failed("10.0.0.2"), false)
+	assert.Equal(t, limiter.failed("10.0.0.2"), false)
+	limiter.succeeded("10.0.0.2")
+	assert.Equal(t, limiter.failed("10.0.0.2"), false)
+	assert.Equal(t, limiter.locked("10.0.0.2"), time.Duration(0))
+
+	// any of several sources can cause a lockout
+	assert.Equal(t, limiter.failed("10.0.0.4", "grant:/a"), false)
+	assert.Equal(t, limiter.failed("10.0.0.5", "grant:/a"), false)
+	assert.Equal(t, limiter.failed("10.0.0.6", "grant:/a"), true)
+	assert.Equal(t, limiter.locked("10.0.0.7", "grant:/a"), 10*time.Minute)
+	assert.Equal(t, limiter.locked("10.0.0.6", "grant:/b"), time.Duration(0))
+	limiter.succeeded("10.0.0.4", "grant:/a")
+	assert.Equal(t, limiter.locked("grant:/a"), time.Duration(0))
+
+	// stale entries are pruned
+	now = now.Add(time.Hour)
+	limiter.failed("10.0.0.3")
+	assert.Equal(t, len(limiter.sources), 1)
+}
+
+func Test_rateLimiterDisabled(t *testing.T) {
+	assert.Assert(t, newRateLimiter(0, time.Minute, time.Minute) == nil)
+}
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/yaml"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	internalsite "github.com/skupperproject/skupper/internal/site"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
@@ -30,8 +31,18 @@ func RedeemAccessToken(token *skupperv2alpha1.AccessToken, site *skupperv2alpha1
 	transport := &http.Transport{
 		TLSClientConfig: tlsConfig(token),
 	}
+	proxy, err := proxyURL(token, site, clients)
+	if err != nil {
+		return updateAccessTokenStatus(token, err, clients)
+	}
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
 	body, err := postTokenRequest(token, site, transport)
 	if err != nil {
+		if proxy != nil {
+			err = internalsite.ProxyError(proxy, err)
+		}
 		return updateAccessTokenStatus(token, err, clients)
 	}
 	slog.Info("HTTP Post was successful, decoding response body",
@@ -41,6 +52,27 @@ func RedeemAccessToken(token *skupperv2alpha1.AccessToken, site *skupperv2alpha1
 	return handleTokenResponse(body, token, site, clients)
 }
 
+// proxyConfiguration returns the name of the secret describing the
+// proxy through which the token should be redeemed, if any.
+func proxyConfiguration(token *skupperv2alpha1.AccessToken, site *skupperv2alpha1.Site) string {
+	if name := token.Spec.GetProxyConfiguration(); name != "" {
+		return name
+	}
+	return site.Spec.GetProxyConfiguration()
+}
+
+func proxyURL(token *skupperv2alpha1.AccessToken, site *skupperv2alpha1.Site, clients internalclient.Clients) (*url.URL, error) {
+	name := proxyConfiguration(token, site)
+	if name == "" {
+		return nil, nil
+	}
+	secret, err := clients.GetKubeClient().CoreV1().Secrets(token.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Controller could not read proxy configuration %q: %s", name, err)
+	}
+	return internalsite.ProxyURL(secret)
+}
+
 func tlsConfig(token *skupperv2alpha1.AccessToken) *tls.Config {
 	caPool := x509.NewCertPool()
 	caPool.AppendCertsFromPEM([]byte(token.Spec.Ca))
@@ -71,9 +103,11 @@ func postTokenRequest(token *skupperv2alpha1.AccessToken, site *skupperv2alpha1.
 	}
 	request.Header.Add("name", token.Name)
 	request.Header.Add("subject", string(site.ObjectMeta.UID))
+	request.Header.Add("site-name", site.Name)
+	request.Header.Add("site-namespace", site.Namespace)
 	response, err := client.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("Controller got error: %s", err)
+		return nil, fmt.Errorf("Controller got error: %w", err)
 	}
 	if response.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(response.Body)
@@ -108,6 +142,13 @@ func handleTokenResponse(body io.Reader, token *skupperv2alpha1.AccessToken, sit
 		if token.Spec.LinkCost > 0 {
 			link.Spec.Cost = token.Spec.LinkCost
 		}
+		// links reach the remote site through the same proxy used to redeem the token
+		if proxy := proxyConfiguration(token, site); proxy != "" && link.Spec.GetProxyConfiguration() == "" {
+			if link.Spec.Settings == nil {
+				link.Spec.Settings = map[string]string{}
+			}
+			link.Spec.Settings["proxy-configuration"] = proxy
+		}
 		if _, err := clients.GetSkupperClient().SkupperV2alpha1().Links(token.ObjectMeta.Namespace).Create(context.TODO(), &link, metav1.CreateOptions{}); err != nil {
 			return updateAccessTokenStatus(token, fmt.Errorf("Controller could not create received link: %s", err), clients)
 		}
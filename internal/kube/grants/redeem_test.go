@@ -3,14 +3,21 @@ package grants
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 
 	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -522,6 +529,197 @@ func Test_RedeemAccessToken(t *testing.T) {
 	}
 }
 
+// testProxy is a minimal HTTP CONNECT proxy, optionally requiring
+// basic authentication.
+type testProxy struct {
+	server   *httptest.Server
+	username string
+	password string
+	lock     sync.Mutex
+	connects int
+}
+
+func newTestProxy(username string, password string) *testProxy {
+	p := &testProxy{
+		username: username,
+		password: password,
+	}
+	p.server = httptest.NewServer(p)
+	return p
+}
+
+func (p *testProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodConnect {
+		http.Error(w, "Only CONNECT is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if p.username != "" {
+		expected := "Basic " + base64.StdEncoding.EncodeToString([]byte(p.username+":"+p.password))
+		if r.Header.Get("Proxy-Authorization") != expected {
+			http.Error(w, "Proxy authentication required", http.StatusProxyAuthRequired)
+			return
+		}
+	}
+	target, err := net.Dial("tcp", r.Host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		target.Close()
+		return
+	}
+	p.lock.Lock()
+	p.connects++
+	p.lock.Unlock()
+	conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+	go func() {
+		defer target.Close()
+		io.Copy(target, conn)
+	}()
+	go func() {
+		defer conn.Close()
+		io.Copy(conn, target)
+	}()
+}
+
+func (p *testProxy) secret(name string, password string) *corev1.Secret {
+	u, _ := url.Parse(p.server.URL)
+	host, port, _ := net.SplitHostPort(u.Host)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			"host": []byte(host),
+			"port": []byte(port),
+		},
+	}
+	if p.username != "" {
+		secret.Data["username"] = []byte(p.username)
+		secret.Data["password"] = []byte(password)
+	}
+	return secret
+}
+
+func (p *testProxy) connectCount() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.connects
+}
+
+func Test_RedeemAccessTokenThroughProxy(t *testing.T) {
+	proxy := newTestProxy("proxy-user", "proxy-password")
+	defer proxy.server.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedProxy := &testProxy{server: closed}
+	closed.Close()
+
+	var tests = []struct {
+		name             string
+		tokenSetting     string
+		siteSetting      string
+		secrets          []runtime.Object
+		expectedStatus   string
+		expectRedeemed   bool
+		expectedConnects int
+	}{
+		{
+			name:             "token proxy with authentication",
+			tokenSetting:     "my-proxy",
+			secrets:          []runtime.Object{proxy.secret("my-proxy", "proxy-password")},
+			expectedStatus:   "OK",
+			expectRedeemed:   true,
+			expectedConnects: 1,
+		},
+		{
+			name:             "site proxy",
+			siteSetting:      "site-proxy",
+			secrets:          []runtime.Object{proxy.secret("site-proxy", "proxy-password")},
+			expectedStatus:   "OK",
+			expectRedeemed:   true,
+			expectedConnects: 1,
+		},
+		{
+			name:           "bad proxy credentials",
+			tokenSetting:   "my-proxy",
+			secrets:        []runtime.Object{proxy.secret("my-proxy", "wrong")},
+			expectedStatus: "Proxy Authentication Required",
+		},
+		{
+			name:           "proxy unreachable",
+			tokenSetting:   "dead-proxy",
+			secrets:        []runtime.Object{closedProxy.secret("dead-proxy", "")},
+			expectedStatus: "could not connect to proxy",
+		},
+		{
+			name:           "proxy configuration missing",
+			tokenSetting:   "no-such-proxy",
+			expectedStatus: "Controller could not read proxy configuration \"no-such-proxy\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := proxy.connectCount()
+			siteCa, err := tf.secret("skupper-site-ca", "test", "Test Site CA", nil)
+			assert.NilError(t, err)
+			mySite := tf.site("my-site", "test")
+			if tt.siteSetting != "" {
+				mySite.Spec.Settings = map[string]string{"proxy-configuration": tt.siteSetting}
+			}
+			client, err := fake.NewFakeClient("test", append([]runtime.Object{siteCa}, tt.secrets...), []runtime.Object{mySite, tf.grant("my-grant", "test", "")}, "")
+			assert.NilError(t, err)
+			site, err := client.GetSkupperClient().SkupperV2alpha1().Sites("test").Get(context.TODO(), "my-site", metav1.GetOptions{})
+			assert.NilError(t, err)
+			site.Status.DefaultIssuer = "skupper-site-ca"
+			site.Status.Endpoints = []v2alpha1.Endpoint{
+				{
+					Name: "inter-router",
+					Host: "my-link-host",
+					Port: "1111",
+				},
+			}
+			site, err = client.GetSkupperClient().SkupperV2alpha1().Sites("test").UpdateStatus(context.TODO(), site, metav1.UpdateOptions{})
+			assert.NilError(t, err)
+
+			grants := newGrants(client, generator(site, client), "https", "")
+			server := newServer(":0", true, grants)
+			assert.NilError(t, server.listen())
+			grants.setUrl(fmt.Sprintf("localhost:%d", server.port()))
+			myCreds, err := tf.secret("my-creds", "test", "grant server", []string{"localhost"})
+			assert.NilError(t, err)
+			go server.serve()
+			defer server.stop()
+			assert.NilError(t, server.setCertificateFromSecret(myCreds))
+			grants.setCA(string(myCreds.Data["ca.crt"]))
+			grant, err := client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.NilError(t, grants.checkGrant(grant.Namespace+"/"+grant.Name, grant))
+
+			token := tf.token("my-token", "test", grant.Status.Url, grant.Status.Code, grant.Status.Ca)
+			if tt.tokenSetting != "" {
+				token.Spec.Settings = map[string]string{"proxy-configuration": tt.tokenSetting}
+			}
+			token, err = client.GetSkupperClient().SkupperV2alpha1().AccessTokens("test").Create(context.TODO(), token, metav1.CreateOptions{})
+			assert.NilError(t, err)
+			assert.NilError(t, RedeemAccessToken(token, site, client))
+
+			token, err = client.GetSkupperClient().SkupperV2alpha1().AccessTokens("test").Get(context.TODO(), "my-token", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(token.Status.Message, tt.expectedStatus), token.Status.Message)
+			assert.Equal(t, meta.IsStatusConditionTrue(token.Status.Conditions, v2alpha1.CONDITION_TYPE_REDEEMED), tt.expectRedeemed)
+			assert.Equal(t, proxy.connectCount()-before, tt.expectedConnects)
+			if tt.expectRedeemed {
+				link, err := client.GetSkupperClient().SkupperV2alpha1().Links("test").Get(context.TODO(), "my-token", metav1.GetOptions{})
+				assert.NilError(t, err)
+				assert.Equal(t, link.Spec.GetProxyConfiguration(), tt.tokenSetting+tt.siteSetting)
+			}
+		})
+	}
+}
+
 type TestTokenGenerator struct {
 	site    *v2alpha1.Site
 	clients internalclient.Clients
@@ -0,0 +1,62 @@
+package grants
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	skupperclient "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1"
+)
+
+// GrantRevokedAnnotation marks an AccessGrant as revoked. Its value
+// records the time at which the grant was revoked. The grant server
+// refuses to redeem the code of a revoked grant, regardless of any
+// remaining redemptions or expiration time.
+const GrantRevokedAnnotation = "skupper.io/revoked"
+
+func IsRevoked(grant *skupperv2alpha1.AccessGrant) bool {
+	if grant == nil || grant.ObjectMeta.Annotations == nil {
+		return false
+	}
+	_, ok := grant.ObjectMeta.Annotations[GrantRevokedAnnotation]
+	return ok
+}
+
+func RevokedAt(grant *skupperv2alpha1.AccessGrant) string {
+	if !IsRevoked(grant) {
+		return ""
+	}
+	return grant.ObjectMeta.Annotations[GrantRevokedAnnotation]
+}
+
+// Revoke marks the named AccessGrant as revoked. The grant itself is
+// retained so that its status and redemption history can still be
+// inspected. Any redemption in flight against a stale copy of the grant
+// will fail to update its status, as the revocation changes the
+// resource version.
+func Revoke(client skupperclient.AccessGrantsGetter, namespace string, name string) (*skupperv2alpha1.AccessGrant, error) {
+	var revoked *skupperv2alpha1.AccessGrant
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		grant, err := client.AccessGrants(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if IsRevoked(grant) {
+			revoked = grant
+			return nil
+		}
+		if grant.ObjectMeta.Annotations == nil {
+			grant.ObjectMeta.Annotations = map[string]string{}
+		}
+		grant.ObjectMeta.Annotations[GrantRevokedAnnotation] = time.Now().Format(time.RFC3339)
+		revoked, err = client.AccessGrants(namespace).Update(context.TODO(), grant, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
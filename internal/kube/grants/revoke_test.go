@@ -0,0 +1,97 @@
+package grants
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+func Test_Revoke(t *testing.T) {
+	tests := []struct {
+		name          string
+		grant         *v2alpha1.AccessGrant
+		expectedError string
+		revokedAt     string
+	}{
+		{
+			name: "grant is revoked",
+			grant: &v2alpha1.AccessGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-grant",
+					Namespace: "test",
+				},
+			},
+		},
+		{
+			name: "grant already revoked",
+			grant: &v2alpha1.AccessGrant{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-grant",
+					Namespace: "test",
+					Annotations: map[string]string{
+						GrantRevokedAnnotation: "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+			revokedAt: "2024-01-01T00:00:00Z",
+		},
+		{
+			name:          "grant does not exist",
+			expectedError: "accessgrants.skupper.io \"my-grant\" not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var skupperObjects []runtime.Object
+			if tt.grant != nil {
+				skupperObjects = append(skupperObjects, tt.grant)
+			}
+			client, err := fake.NewFakeClient("test", nil, skupperObjects, "")
+			assert.Assert(t, err)
+			grants := client.GetSkupperClient().SkupperV2alpha1()
+			revoked, err := Revoke(grants, "test", "my-grant")
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.Assert(t, err)
+			assert.Assert(t, IsRevoked(revoked))
+			if tt.revokedAt != "" {
+				assert.Equal(t, RevokedAt(revoked), tt.revokedAt)
+			}
+			latest, err := grants.AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+			assert.Assert(t, err)
+			assert.Assert(t, IsRevoked(latest))
+		})
+	}
+}
+
+func Test_checkGrantRevoked(t *testing.T) {
+	grant := &v2alpha1.AccessGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-grant",
+			Namespace: "test",
+			UID:       "1b1c3a64-5a4e-4b0c-8b5f-63f0c8e7d9aa",
+			Annotations: map[string]string{
+				GrantRevokedAnnotation: "2024-01-01T00:00:00Z",
+			},
+		},
+		Spec: v2alpha1.AccessGrantSpec{
+			RedemptionsAllowed: 1,
+		},
+	}
+	client, err := fake.NewFakeClient("test", nil, []runtime.Object{grant}, "")
+	assert.Assert(t, err)
+	registry := newGrants(client, dummyGenerator, "https", "")
+	assert.Assert(t, registry.checkGrant("test/my-grant", grant))
+	latest, err := client.GetSkupperClient().SkupperV2alpha1().AccessGrants("test").Get(context.TODO(), "my-grant", metav1.GetOptions{})
+	assert.Assert(t, err)
+	assert.Assert(t, !latest.IsReady())
+	assert.Equal(t, latest.Status.Message, "AccessGrant revoked at 2024-01-01T00:00:00Z")
+}
@@ -4,8 +4,10 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log/slog"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -20,21 +22,74 @@ type Server struct {
 	cert       *tls.Certificate
 	server     *http.Server
 	listener   net.Listener
+	handler    http.Handler
+	limiter    *rateLimiter
+	metrics    MetricsProvider
 	logger     *slog.Logger
 }
 
 func newServer(addr string, tlsEnabled bool, handler http.Handler) *Server {
-	return &Server{
-		server: &http.Server{
-			Addr:         addr,
-			Handler:      handler,
-			ReadTimeout:  60 * time.Second,
-			WriteTimeout: 60 * time.Second,
-			TLSConfig:    tlscfg.Modern(),
-		},
+	s := &Server{
 		tlsEnabled: tlsEnabled,
+		handler:    handler,
+		metrics:    noopMetricsProvider{},
 		logger:     slog.New(slog.Default().Handler()).With(slog.String("component", "kube.grants.server")),
 	}
+	s.server = &http.Server{
+		Addr:         addr,
+		Handler:      s,
+		ReadTimeout:  60 * time.Second,
+		WriteTimeout: 60 * time.Second,
+		TLSConfig:    tlscfg.Modern(),
+	}
+	return s
+}
+
+// ServeHTTP refuses requests from addresses, or for grants, that have
+// been locked out after repeated failed redemptions, and otherwise
+// passes the request on to the handler, recording whether it failed.
+// Failures are counted for the grant as well as the address, so that
+// guessing the code of a grant from many addresses is also limited.
+// Only redemptions refused for an invalid code count as failures;
+// unknown, expired or used up grants do not, so that retries of a stale
+// token cannot lock out redemptions of valid grants.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.limiter == nil {
+		s.handler.ServeHTTP(w, r)
+		return
+	}
+	source := sourceAddress(r)
+	grant := "grant:" + r.URL.Path
+	if remaining := s.limiter.locked(source, grant); remaining > 0 {
+		s.metrics.Rejected("", RejectionReasonLockedOut)
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(remaining.Seconds()))))
+		http.Error(w, "Too many failed requests", http.StatusTooManyRequests)
+		return
+	}
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.handler.ServeHTTP(recorder, r)
+	switch recorder.status {
+	case http.StatusOK:
+		s.limiter.succeeded(source, grant)
+	case http.StatusForbidden:
+		if s.limiter.failed(source, grant) {
+			s.logger.Warn("Locking out after repeated failed redemptions",
+				slog.String("address", source),
+				slog.String("path", r.URL.Path),
+				slog.Duration("duration", s.limiter.lockout))
+			s.metrics.LockedOut()
+		}
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
 }
 
 func (s *Server) start() {
@@ -4,7 +4,9 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -84,3 +86,97 @@ func Test_handlesPortBeforeListen(t *testing.T) {
 type TestHandler struct{}
 
 func (h *TestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+type StatusHandler struct {
+	codes map[string]int
+}
+
+func (h *StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if code, ok := h.codes[r.URL.Path]; ok && code != http.StatusOK {
+		http.Error(w, http.StatusText(code), code)
+	}
+}
+
+type testGrantMetrics struct {
+	redeemed  int
+	rejected  map[string]int
+	lockedOut int
+}
+
+func newTestGrantMetrics() *testGrantMetrics {
+	return &testGrantMetrics{rejected: map[string]int{}}
+}
+
+func (m *testGrantMetrics) Redeemed(namespace string) {
+	m.redeemed++
+}
+
+func (m *testGrantMetrics) Rejected(namespace string, reason string) {
+	m.rejected[reason]++
+}
+
+func (m *testGrantMetrics) LockedOut() {
+	m.lockedOut++
+}
+
+func Test_serverLockout(t *testing.T) {
+	handler := &StatusHandler{
+		codes: map[string]int{
+			"/good":      http.StatusOK,
+			"/forbidden": http.StatusForbidden,
+			"/denied":    http.StatusForbidden,
+			"/missing":   http.StatusNotFound,
+			"/broken":    http.StatusInternalServerError,
+		},
+	}
+	metrics := newTestGrantMetrics()
+	server := newServer(":0", true, handler)
+	server.limiter = newRateLimiter(3, time.Minute, time.Minute)
+	server.metrics = metrics
+
+	request := func(path string, address string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = address
+		res := httptest.NewRecorder()
+		server.ServeHTTP(res, req)
+		return res
+	}
+
+	assert.Equal(t, request("/broken", "10.0.0.1:1000").Code, http.StatusInternalServerError)
+	assert.Equal(t, request("/denied", "10.0.0.1:1001").Code, http.StatusForbidden)
+	assert.Equal(t, request("/missing", "10.0.0.1:1002").Code, http.StatusNotFound)
+	assert.Equal(t, request("/good", "10.0.0.1:1003").Code, http.StatusOK)
+	assert.Equal(t, request("/forbidden", "10.0.0.1:1004").Code, http.StatusForbidden)
+	assert.Equal(t, request("/forbidden", "10.0.0.1:1005").Code, http.StatusForbidden)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, request("/missing", "10.0.0.1:1006").Code, http.StatusNotFound)
+	}
+	assert.Equal(t, metrics.lockedOut, 0)
+	assert.Equal(t, request("/forbidden", "10.0.0.1:1006").Code, http.StatusForbidden)
+	assert.Equal(t, metrics.lockedOut, 1)
+
+	res := request("/good", "10.0.0.1:1007")
+	assert.Equal(t, res.Code, http.StatusTooManyRequests)
+	assert.Equal(t, res.Header().Get("Retry-After"), "60")
+	assert.Equal(t, metrics.rejected[RejectionReasonLockedOut], 1)
+
+	assert.Equal(t, request("/good", "10.0.0.2:1000").Code, http.StatusOK)
+
+	// failures for a single grant are counted across addresses
+	assert.Equal(t, request("/denied", "10.0.1.1:1000").Code, http.StatusForbidden)
+	assert.Equal(t, request("/denied", "10.0.1.2:1000").Code, http.StatusForbidden)
+	assert.Equal(t, metrics.lockedOut, 2)
+	res = request("/denied", "10.0.1.3:1000")
+	assert.Equal(t, res.Code, http.StatusTooManyRequests)
+	assert.Equal(t, request("/good", "10.0.1.3:1000").Code, http.StatusOK)
+}
+
+func Test_serverWithoutLimiter(t *testing.T) {
+	server := newServer(":0", true, &StatusHandler{codes: map[string]int{"/forbidden": http.StatusForbidden}})
+	for i := 0; i < 20; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/forbidden", nil)
+		res := httptest.NewRecorder()
+		server.ServeHTTP(res, req)
+		assert.Equal(t, res.Code, http.StatusForbidden)
+	}
+}
@@ -35,14 +35,18 @@ type TokenGenerator struct {
 	ca        *corev1.Secret
 	endpoints [][]skupperv2alpha1.Endpoint
 	hosts     []string
-	logger    *slog.Logger
+	// the key algorithm configured for the site, used for the
+	// certificates of the tokens generated
+	keyAlgorithm string
+	logger       *slog.Logger
 }
 
 func NewTokenGenerator(site *skupperv2alpha1.Site, clients internalclient.Clients) (*TokenGenerator, error) {
 	generator := &TokenGenerator{
-		namespace: site.Namespace,
-		clients:   clients,
-		logger:    slog.New(slog.Default().Handler()).With(slog.String("component", "kube.grants.tokenGenerator")),
+		namespace:    site.Namespace,
+		clients:      clients,
+		keyAlgorithm: certs.KeyAlgorithmFromSettings(site.Spec.Settings),
+		logger:       slog.New(slog.Default().Handler()).With(slog.String("component", "kube.grants.tokenGenerator")),
 	}
 	if err := generator.loadCA(site.DefaultIssuer()); err != nil {
 		generator.logger.Error("Error retrieving default issuer for site",
@@ -95,7 +99,7 @@ func (g *TokenGenerator) setValidHostsFromSite(site *skupperv2alpha1.Site) error
 }
 
 func (g *TokenGenerator) NewCertToken(name string, subject string) (Token, error) {
-	cert, err := certs.GenerateSecret(name, subject, g.hosts, 0, g.ca)
+	cert, err := certs.GenerateSecretWithKeyAlgorithm(name, subject, g.hosts, 0, g.ca, g.keyAlgorithm)
 	if err != nil {
 		return nil, err
 	}
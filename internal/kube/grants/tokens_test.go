@@ -2,9 +2,12 @@ package grants
 
 import (
 	"bytes"
+	"crypto/x509"
+	"encoding/pem"
 	"io"
 	"testing"
 
+	"github.com/skupperproject/skupper/internal/certs"
 	"github.com/skupperproject/skupper/internal/kube/client/fake"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"gotest.tools/v3/assert"
@@ -139,3 +142,41 @@ func Test_NewTokenGenerator(t *testing.T) {
 		assert.Assert(t, generator == nil)
 	})
 }
+
+func Test_NewCertTokenKeyAlgorithm(t *testing.T) {
+	caSecret, err := tf.secret("skupper-site-ca", "test", "site-ca", nil)
+	assert.Assert(t, err)
+	client, err := fake.NewFakeClient("test", []runtime.Object{caSecret}, nil, "")
+	assert.Assert(t, err)
+	var tests = []struct {
+		name     string
+		settings map[string]string
+		expected string
+	}{
+		{
+			name:     "default",
+			expected: certs.KeyAlgorithmRSA2048,
+		},
+		{
+			name:     "site setting",
+			settings: map[string]string{certs.KeyAlgorithmSetting: certs.KeyAlgorithmECDSAP256},
+			expected: certs.KeyAlgorithmECDSAP256,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := tf.site("my-site", "test")
+			site.Spec.Settings = tt.settings
+			site.Status.Endpoints = []v2alpha1.Endpoint{{Name: "inter-router", Host: "my-host", Port: "55671"}}
+			generator, err := NewTokenGenerator(site, client)
+			assert.Assert(t, err)
+			token, err := generator.NewCertToken("my-link", "my-subject")
+			assert.Assert(t, err)
+			block, _ := pem.Decode(token.(*CertToken).tlsCredentials.Data["tls.crt"])
+			assert.Assert(t, block != nil)
+			cert, err := x509.ParseCertificate(block.Bytes)
+			assert.Assert(t, err)
+			assert.Equal(t, certs.KeyAlgorithmOf(cert), tt.expected)
+		})
+	}
+}
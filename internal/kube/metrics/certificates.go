@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/internal/kube/certificates"
+)
+
+func MustRegisterCertificateMetrics(registry *prometheus.Registry) certificates.MetricsProvider {
+	provider := certificateMetrics{
+		expiration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "skupper",
+			Subsystem: "certificate",
+			Name:      "expiration_timestamp_seconds",
+			Help:      "Time at which the certificate expires, in seconds since the epoch.",
+		}, []string{"namespace", "name"}),
+		expiring: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "skupper",
+			Subsystem: "certificate",
+			Name:      "expiring",
+			Help:      "Set to 1 when the certificate is within its renewal window.",
+		}, []string{"namespace", "name"}),
+		renewals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "skupper",
+			Subsystem: "certificate",
+			Name:      "renewals_total",
+			Help:      "Total number of certificates re-issued by reason.",
+		}, []string{"namespace", "name", "reason"}),
+	}
+	registry.MustRegister(provider.expiration, provider.expiring, provider.renewals)
+	return provider
+}
+
+type certificateMetrics struct {
+	expiration *prometheus.GaugeVec
+	expiring   *prometheus.GaugeVec
+	renewals   *prometheus.CounterVec
+}
+
+func (p certificateMetrics) SetExpiration(namespace string, name string, expiration time.Time, expiring bool) {
+	p.expiration.WithLabelValues(namespace, name).Set(float64(expiration.Unix()))
+	if expiring {
+		p.expiring.WithLabelValues(namespace, name).Set(1)
+	} else {
+		p.expiring.WithLabelValues(namespace, name).Set(0)
+	}
+}
+
+func (p certificateMetrics) Renewed(namespace string, name string, reason string) {
+	p.renewals.WithLabelValues(namespace, name, reason).Inc()
+}
+
+func (p certificateMetrics) Deleted(namespace string, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	p.expiration.Delete(labels)
+	p.expiring.Delete(labels)
+	p.renewals.DeletePartialMatch(labels)
+}
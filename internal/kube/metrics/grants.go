@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/skupperproject/skupper/internal/kube/grants"
+)
+
+func MustRegisterGrantMetrics(registry *prometheus.Registry) grants.MetricsProvider {
+	provider := grantMetrics{
+		redemptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "skupper",
+			Subsystem: "grant",
+			Name:      "redemptions_total",
+			Help:      "Total number of access tokens successfully redeemed.",
+		}, []string{"namespace"}),
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "skupper",
+			Subsystem: "grant",
+			Name:      "rejected_redemptions_total",
+			Help:      "Total number of access token redemptions refused by reason.",
+		}, []string{"namespace", "reason"}),
+		lockouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "skupper",
+			Subsystem: "grant",
+			Name:      "server_lockouts_total",
+			Help:      "Total number of times an address was locked out after repeated failed redemptions.",
+		}),
+	}
+	registry.MustRegister(provider.redemptions, provider.rejections, provider.lockouts)
+	return provider
+}
+
+type grantMetrics struct {
+	redemptions *prometheus.CounterVec
+	rejections  *prometheus.CounterVec
+	lockouts    prometheus.Counter
+}
+
+func (p grantMetrics) Redeemed(namespace string) {
+	p.redemptions.WithLabelValues(namespace).Inc()
+}
+
+func (p grantMetrics) Rejected(namespace string, reason string) {
+	p.rejections.WithLabelValues(namespace, reason).Inc()
+}
+
+func (p grantMetrics) LockedOut() {
+	p.lockouts.Inc()
+}
@@ -1,6 +1,9 @@
 package resource
 
 import (
+	"fmt"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 )
@@ -29,6 +32,46 @@ func TlsRouteResource() schema.GroupVersionResource {
 	}
 }
 
+func TcpRouteResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1alpha2",
+		Resource: "tcproutes",
+	}
+}
+
+func IstioGatewayResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "networking.istio.io",
+		Version:  "v1beta1",
+		Resource: "gateways",
+	}
+}
+
+func VirtualServiceResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "networking.istio.io",
+		Version:  "v1beta1",
+		Resource: "virtualservices",
+	}
+}
+
+func HorizontalPodAutoscalerResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "autoscaling",
+		Version:  "v2",
+		Resource: "horizontalpodautoscalers",
+	}
+}
+
+func VerticalPodAutoscalerResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "autoscaling.k8s.io",
+		Version:  "v1",
+		Resource: "verticalpodautoscalers",
+	}
+}
+
 func MultiKeyListenerResource() schema.GroupVersionResource {
 	return schema.GroupVersionResource{
 		Group:    "skupper.io",
@@ -57,3 +100,18 @@ func IsResourceAvailable(client discovery.DiscoveryInterface, resource schema.Gr
 	}
 	return false
 }
+
+// ResourceForKind returns the resource through which objects of the
+// given kind are served, and whether that resource is namespaced.
+func ResourceForKind(client discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool, error) {
+	resources, err := client.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		return schema.GroupVersionResource{}, false, err
+	}
+	for _, available := range resources.APIResources {
+		if available.Kind == gvk.Kind && !strings.Contains(available.Name, "/") {
+			return gvk.GroupVersion().WithResource(available.Name), available.Namespaced, nil
+		}
+	}
+	return schema.GroupVersionResource{}, false, fmt.Errorf("No resource found for kind %s in %s", gvk.Kind, gvk.GroupVersion())
+}
@@ -36,7 +36,8 @@ func (t Template) getYaml() ([]byte, error) {
 
 var decoder = yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
 
-func (t Template) Apply(client dynamic.Interface, ctx context.Context, namespace string) (*unstructured.Unstructured, error) {
+// Object renders the template and decodes the result.
+func (t Template) Object() (*unstructured.Unstructured, error) {
 	raw, err := t.getYaml()
 	if err != nil {
 		return nil, err
@@ -46,11 +47,24 @@ func (t Template) Apply(client dynamic.Interface, ctx context.Context, namespace
 	if err != nil {
 		return nil, err
 	}
+	return obj, nil
+}
+
+func (t Template) Apply(client dynamic.Interface, ctx context.Context, namespace string) (*unstructured.Unstructured, error) {
+	obj, err := t.Object()
+	if err != nil {
+		return nil, err
+	}
+	return Apply(client, ctx, namespace, t.Resource, obj)
+}
+
+// Apply creates or updates the object through server side apply.
+func Apply(client dynamic.Interface, ctx context.Context, namespace string, resource schema.GroupVersionResource, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	data, err := json.Marshal(obj)
 	if err != nil {
 		return nil, err
 	}
-	return client.Resource(t.Resource).Namespace(namespace).Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+	return client.Resource(resource).Namespace(namespace).Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
 		FieldManager: "skupper-controller",
 	})
 }
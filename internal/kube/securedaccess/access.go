@@ -9,9 +9,12 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
 
 	routev1 "github.com/openshift/api/route/v1"
 
@@ -41,13 +44,20 @@ type SecuredAccessManager struct {
 	ingresses          map[string]*networkingv1.Ingress
 	httpProxies        map[string]*unstructured.Unstructured
 	tlsRoutes          map[string]*unstructured.Unstructured
+	tcpRoutes          map[string]*unstructured.Unstructured
+	virtualServices    map[string]*unstructured.Unstructured
+	customAccessTypes  map[string]string //ConfigMap key -> access type name
 	clients            internalclient.Clients
 	certMgr            certificates.CertificateManager
 	enabledAccessTypes map[string]AccessType
 	defaultAccessType  string
 	gatewayInit        func() error
-	context            ControllerContext
-	logger             *slog.Logger
+	istioGatewayInit   func() error
+	// called with the resource of each object applied by a custom
+	// access type, so that changes to it can be watched
+	watchCustomResource func(schema.GroupVersionResource)
+	context             ControllerContext
+	logger              *slog.Logger
 }
 
 func NewSecuredAccessManager(clients internalclient.Clients, certMgr certificates.CertificateManager, config *Config, context ControllerContext) *SecuredAccessManager {
@@ -58,6 +68,9 @@ func NewSecuredAccessManager(clients internalclient.Clients, certMgr certificate
 		ingresses:          map[string]*networkingv1.Ingress{},
 		httpProxies:        map[string]*unstructured.Unstructured{},
 		tlsRoutes:          map[string]*unstructured.Unstructured{},
+		tcpRoutes:          map[string]*unstructured.Unstructured{},
+		virtualServices:    map[string]*unstructured.Unstructured{},
+		customAccessTypes:  map[string]string{},
 		clients:            clients,
 		certMgr:            certMgr,
 		enabledAccessTypes: map[string]AccessType{},
@@ -84,10 +97,22 @@ func NewSecuredAccessManager(clients internalclient.Clients, certMgr certificate
 				mgr.enabledAccessTypes[accessType] = at
 				mgr.gatewayInit = init
 			}
+		} else if accessType == ACCESS_TYPE_GATEWAY_TCP {
+			mgr.enabledAccessTypes[accessType] = newGatewayTcpAccess(mgr, config.GatewayClass)
+		} else if accessType == ACCESS_TYPE_ISTIO {
+			at, init, err := newIstioAccess(mgr, config.IstioSelector, config.IstioDomain, config.IstioPort, context)
+			if err != nil {
+				mgr.logger.Error("Failed to create istio gateway, istio access type will not be enabled", slog.Any("error", err))
+			} else {
+				mgr.enabledAccessTypes[accessType] = at
+				mgr.istioGatewayInit = init
+			}
 		} else if accessType == ACCESS_TYPE_NODEPORT {
 			mgr.enabledAccessTypes[accessType] = newNodeportAccess(mgr, config.ClusterHost)
 		} else if accessType == ACCESS_TYPE_LOCAL {
 			mgr.enabledAccessTypes[accessType] = newLocalAccess(mgr)
+		} else {
+			mgr.enabledAccessTypes[accessType] = newCustomAccess(mgr, accessType, config.ClusterHost)
 		}
 	}
 
@@ -242,7 +267,9 @@ func (m *SecuredAccessManager) reconcile(sa *skupperv2alpha1.SecuredAccess) erro
 		return nil
 	}
 	updated := false
-	endpoints, resourceErr := m.accessType(sa).RealiseAndResolve(sa, svc)
+	accessType := m.accessType(sa)
+	releaseErr := m.releaseCustomAccess(sa, accessType)
+	endpoints, resourceErr := accessType.RealiseAndResolve(sa, svc)
 
 	if sa.SetResolved(endpoints) {
 		if len(endpoints) > 0 {
@@ -253,7 +280,7 @@ func (m *SecuredAccessManager) reconcile(sa *skupperv2alpha1.SecuredAccess) erro
 
 	certErr := m.checkCertificate(sa)
 
-	if sa.SetConfigured(errors.Join(resourceErr, certErr)) {
+	if sa.SetConfigured(errors.Join(resourceErr, releaseErr, certErr)) {
 		updated = true
 	}
 
@@ -262,6 +289,19 @@ func (m *SecuredAccessManager) reconcile(sa *skupperv2alpha1.SecuredAccess) erro
 	}
 	return m.updateStatus(sa)
 }
+
+// releaseCustomAccess deletes the objects applied for a SecuredAccess
+// by any custom access type other than the one it now uses.
+func (m *SecuredAccessManager) releaseCustomAccess(sa *skupperv2alpha1.SecuredAccess, current AccessType) error {
+	var errs []error
+	for _, at := range m.enabledAccessTypes {
+		if custom, ok := at.(*CustomAccessType); ok && at != current {
+			errs = append(errs, custom.prune(sa, nil))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (m *SecuredAccessManager) updateStatus(sa *skupperv2alpha1.SecuredAccess) error {
 	latest, err := m.clients.GetSkupperClient().SkupperV2alpha1().SecuredAccesses(sa.Namespace).UpdateStatus(context.TODO(), sa, metav1.UpdateOptions{})
 	if err != nil {
@@ -366,6 +406,11 @@ func (m *SecuredAccessManager) SecuredAccessDeleted(key string) error {
 		//deleted also
 		delete(m.definitions, key)
 	}
+	for _, at := range m.enabledAccessTypes {
+		if custom, ok := at.(*CustomAccessType); ok {
+			delete(custom.rendered, key)
+		}
+	}
 	return nil
 }
 
@@ -384,6 +429,16 @@ func (m *SecuredAccessManager) RecoverTlsRoute(o *unstructured.Unstructured) {
 	m.tlsRoutes[key] = o
 }
 
+func (m *SecuredAccessManager) RecoverTcpRoute(o *unstructured.Unstructured) {
+	key := fmt.Sprintf("%s/%s", o.GetNamespace(), o.GetName())
+	m.tcpRoutes[key] = o
+}
+
+func (m *SecuredAccessManager) RecoverVirtualService(o *unstructured.Unstructured) {
+	key := fmt.Sprintf("%s/%s", o.GetNamespace(), o.GetName())
+	m.virtualServices[key] = o
+}
+
 func (m *SecuredAccessManager) RecoverIngress(ingress *networkingv1.Ingress) {
 	key := fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
 	m.ingresses[key] = ingress
@@ -394,6 +449,61 @@ func (m *SecuredAccessManager) RecoverService(svc *corev1.Service) {
 	m.services[key] = svc
 }
 
+// RecoverAccessTypeDefinition records the definition of a custom access
+// type without reconciling the SecuredAccess instances that use it.
+func (m *SecuredAccessManager) RecoverAccessTypeDefinition(key string, cm *corev1.ConfigMap) {
+	m.updateAccessTypeDefinition(key, cm)
+}
+
+// CheckAccessTypeDefinition handles changes to ConfigMaps defining
+// custom access types, reconciling any SecuredAccess instances that
+// use the access type.
+func (m *SecuredAccessManager) CheckAccessTypeDefinition(key string, cm *corev1.ConfigMap) error {
+	var errs []error
+	for _, name := range m.updateAccessTypeDefinition(key, cm) {
+		for _, sa := range m.definitions {
+			if m.actualAccessType(sa) == name {
+				errs = append(errs, m.reconcile(sa))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// updateAccessTypeDefinition returns the names of the enabled access
+// types whose definition was changed.
+func (m *SecuredAccessManager) updateAccessTypeDefinition(key string, cm *corev1.ConfigMap) []string {
+	var changed []string
+	if previous, ok := m.customAccessTypes[key]; ok {
+		delete(m.customAccessTypes, key)
+		if at, ok := m.enabledAccessTypes[previous].(*CustomAccessType); ok {
+			at.setDefinition(nil, nil)
+			changed = append(changed, previous)
+		}
+	}
+	name, ok := accessTypeName(cm)
+	if !ok {
+		return changed
+	}
+	m.customAccessTypes[key] = name
+	// validated whether or not the access type is enabled, so that a
+	// mistake is reported when the ConfigMap is written
+	definition, err := NewAccessTypeDefinition(cm)
+	if err != nil {
+		m.logger.Error("Invalid access type definition in ConfigMap", slog.String("configmap", key), slog.String("accessType", name), slog.Any("error", err))
+	}
+	at, ok := m.enabledAccessTypes[name].(*CustomAccessType)
+	if !ok {
+		m.logger.Info("Ignoring definition of access type that is not enabled", slog.String("configmap", key), slog.String("accessType", name))
+		return changed
+	}
+	at.setDefinition(definition, err)
+	if len(changed) == 0 || changed[0] != name {
+		changed = append(changed, name)
+	}
+	return changed
+}
+
 func (m *SecuredAccessManager) getDefinitionForPortQualifiedResourceKey(qualifiedKey string, expectedAccessType string) *skupperv2alpha1.SecuredAccess {
 	for _, p := range possibleKeyPortNamePairs(qualifiedKey) {
 		key, portName := p.get()
@@ -462,6 +572,123 @@ func (m *SecuredAccessManager) CheckTlsRoute(key string, o *unstructured.Unstruc
 	return m.reconcile(sa)
 }
 
+func (m *SecuredAccessManager) CheckTcpRoute(key string, o *unstructured.Unstructured) error {
+	sa := m.getDefinitionForPortQualifiedResourceKey(key, ACCESS_TYPE_GATEWAY_TCP)
+	if o == nil {
+		delete(m.tcpRoutes, key)
+		if sa == nil {
+			return nil
+		}
+	} else {
+		m.tcpRoutes[key] = o
+		if sa == nil {
+			m.logger.Info("Deleting redundant TCPRoute", slog.String("namespace", o.GetNamespace()), slog.String("name", o.GetName()))
+			return m.clients.GetDynamicClient().Resource(resource.TcpRouteResource()).Namespace(o.GetNamespace()).Delete(context.Background(), o.GetName(), metav1.DeleteOptions{})
+		}
+	}
+	return m.reconcile(sa)
+}
+
+func (m *SecuredAccessManager) CheckVirtualService(key string, o *unstructured.Unstructured) error {
+	sa := m.getDefinitionForPortQualifiedResourceKey(key, ACCESS_TYPE_ISTIO)
+	if o == nil {
+		delete(m.virtualServices, key)
+		if sa == nil {
+			return nil
+		}
+	} else {
+		m.virtualServices[key] = o
+		if sa == nil {
+			m.logger.Info("Deleting redundant VirtualService", slog.String("namespace", o.GetNamespace()), slog.String("name", o.GetName()))
+			return m.clients.GetDynamicClient().Resource(resource.VirtualServiceResource()).Namespace(o.GetNamespace()).Delete(context.Background(), o.GetName(), metav1.DeleteOptions{})
+		}
+	}
+	return m.reconcile(sa)
+}
+
+// CheckTcpGateway handles changes to the per SecuredAccess Gateways
+// created for the gateway-tcp access type. The addresses assigned to
+// the Gateway determine the endpoints of the SecuredAccess.
+func (m *SecuredAccessManager) CheckTcpGateway(key string, o *unstructured.Unstructured) error {
+	sa, ok := m.definitions[key]
+	if ok && m.actualAccessType(sa) != ACCESS_TYPE_GATEWAY_TCP {
+		ok = false
+	}
+	if o == nil {
+		if !ok {
+			return nil
+		}
+	} else if !ok {
+		if !canDelete(&metav1.ObjectMeta{Labels: o.GetLabels(), Annotations: o.GetAnnotations()}) {
+			return nil
+		}
+		m.logger.Info("Deleting redundant Gateway", slog.String("namespace", o.GetNamespace()), slog.String("name", o.GetName()))
+		return m.clients.GetDynamicClient().Resource(resource.GatewayResource()).Namespace(o.GetNamespace()).Delete(context.Background(), o.GetName(), metav1.DeleteOptions{})
+	}
+	return m.reconcile(sa)
+}
+
+// CheckCustomResource handles changes to objects applied by custom
+// access types. The SecuredAccess the object was applied for is
+// reconciled, so that endpoints taken from the status of the object
+// are resolved as soon as they are set and deleted objects are
+// recreated. An object owned by a SecuredAccess for which it is no
+// longer rendered is deleted.
+func (m *SecuredAccessManager) CheckCustomResource(gvr schema.GroupVersionResource, key string, o *unstructured.Unstructured) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	rendered := renderedObject{resource: gvr, namespace: namespace, name: name}
+	for _, at := range m.enabledAccessTypes {
+		custom, ok := at.(*CustomAccessType)
+		if !ok {
+			continue
+		}
+		if saKey, ok := custom.renderedBy(rendered); ok {
+			if sa, ok := m.definitions[saKey]; ok {
+				return m.reconcile(sa)
+			}
+		}
+	}
+	if o == nil || !canDelete(&metav1.ObjectMeta{Labels: o.GetLabels(), Annotations: o.GetAnnotations()}) {
+		return nil
+	}
+	sa := m.securedAccessOwner(o)
+	if sa == nil {
+		// objects of a deleted SecuredAccess are garbage collected
+		// through their owner references
+		return nil
+	}
+	if custom, ok := m.accessType(sa).(*CustomAccessType); ok {
+		if _, ok := custom.rendered[sa.Key()]; !ok {
+			// not yet realised, so what is rendered is not known
+			return nil
+		}
+	}
+	m.logger.Info("Deleting redundant object for custom access type",
+		slog.String("resource", gvr.Resource),
+		slog.String("namespace", namespace),
+		slog.String("name", name))
+	err = m.clients.GetDynamicClient().Resource(gvr).Namespace(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (m *SecuredAccessManager) securedAccessOwner(o *unstructured.Unstructured) *skupperv2alpha1.SecuredAccess {
+	for _, ref := range o.GetOwnerReferences() {
+		if ref.Kind != "SecuredAccess" {
+			continue
+		}
+		if sa, ok := m.definitions[o.GetNamespace()+"/"+ref.Name]; ok && sa.UID == ref.UID {
+			return sa
+		}
+	}
+	return nil
+}
+
 func isIngressBackedAccessType(accessType string) bool {
 	return accessType == ACCESS_TYPE_INGRESS_NGINX || accessType == ACCESS_TYPE_INGRESS
 }
@@ -494,6 +721,13 @@ func (m *SecuredAccessManager) CheckGateway(key string, o *unstructured.Unstruct
 	return m.gatewayInit()
 }
 
+func (m *SecuredAccessManager) CheckIstioGateway(key string, o *unstructured.Unstructured) error {
+	if m.istioGatewayInit == nil {
+		return nil
+	}
+	return m.istioGatewayInit()
+}
+
 func (m *SecuredAccessManager) CheckService(key string, svc *corev1.Service) error {
 	if svc == nil {
 		delete(m.services, key)
@@ -1879,6 +1879,293 @@ func TestSecuredAccessGeneral(t *testing.T) {
 			},
 			expectedStatus: "Gateway base domain not yet resolved",
 		},
+		{
+			name: "istio",
+			config: Config{
+				EnabledAccessTypes: []string{
+					ACCESS_TYPE_ISTIO,
+				},
+				IstioSelector: "istio=ingressgateway",
+				IstioDomain:   "mesh.example.com",
+				IstioPort:     443,
+			},
+			ssaRecorder: newServerSideApplyRecorder(),
+			expectedSSA: map[string]*unstructured.Unstructured{
+				"test/skupper": istioGateway("skupper", "test"),
+				"test/mysvc-a": virtualService("mysvc-a", "test"),
+				"test/mysvc-b": virtualService("mysvc-b", "test"),
+			},
+			definition: &skupperv2alpha1.SecuredAccess{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "skupper.io/v2alpha1",
+					Kind:       "SecuredAccess",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mysvc",
+					Namespace: "test",
+				},
+				Spec: skupperv2alpha1.SecuredAccessSpec{
+					AccessType: ACCESS_TYPE_ISTIO,
+					Selector: map[string]string{
+						"app": "foo",
+					},
+					Ports: []skupperv2alpha1.SecuredAccessPort{
+						{
+							Name:       "a",
+							Port:       8080,
+							TargetPort: 8081,
+							Protocol:   "TCP",
+						},
+						{
+							Name:       "b",
+							Port:       9090,
+							TargetPort: 9191,
+							Protocol:   "TCP",
+						},
+					},
+					Certificate: "my-cert",
+					Issuer:      "skupper-site-ca",
+				},
+			},
+			expectedServices: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "mysvc",
+						Namespace: "test",
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{
+							"app": "foo",
+						},
+						Ports: []corev1.ServicePort{
+							{
+								Name:       "a",
+								Port:       8080,
+								TargetPort: intstr.IntOrString{IntVal: int32(8081)},
+								Protocol:   corev1.Protocol("TCP"),
+							},
+							{
+								Name:       "b",
+								Port:       9090,
+								TargetPort: intstr.IntOrString{IntVal: int32(9191)},
+								Protocol:   corev1.Protocol("TCP"),
+							},
+						},
+					},
+				},
+			},
+			expectedCertificates: []MockCertificate{
+				{
+					namespace: "test",
+					name:      "my-cert",
+					ca:        "skupper-site-ca",
+					subject:   "mysvc",
+					hosts:     []string{"mysvc", "mysvc.test", "mysvc-a.test.mesh.example.com", "mysvc-b.test.mesh.example.com"},
+					client:    false,
+					server:    true,
+					refs:      nil,
+				},
+			},
+			expectedStatus: "OK",
+			expectedEndpoints: []skupperv2alpha1.Endpoint{
+				{
+					Name: "a",
+					Port: "443",
+					Host: "mysvc-a.test.mesh.example.com",
+				},
+				{
+					Name: "b",
+					Port: "443",
+					Host: "mysvc-b.test.mesh.example.com",
+				},
+			},
+		},
+		{
+			name: "gateway-tcp",
+			config: Config{
+				EnabledAccessTypes: []string{
+					ACCESS_TYPE_GATEWAY_TCP,
+				},
+				GatewayClass: "xyz",
+			},
+			ssaRecorder: newServerSideApplyRecorder().setGatewayHostname("test/mysvc", "tcp.mygateway.net"),
+			expectedSSA: map[string]*unstructured.Unstructured{
+				"test/mysvc":   tcpGateway("mysvc", "test"),
+				"test/mysvc-a": tcproute("mysvc-a", "test"),
+				"test/mysvc-b": tcproute("mysvc-b", "test"),
+			},
+			definition: &skupperv2alpha1.SecuredAccess{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "skupper.io/v2alpha1",
+					Kind:       "SecuredAccess",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mysvc",
+					Namespace: "test",
+				},
+				Spec: skupperv2alpha1.SecuredAccessSpec{
+					AccessType: ACCESS_TYPE_GATEWAY_TCP,
+					Selector: map[string]string{
+						"app": "foo",
+					},
+					Ports: []skupperv2alpha1.SecuredAccessPort{
+						{
+							Name:       "a",
+							Port:       8080,
+							TargetPort: 8081,
+							Protocol:   "TCP",
+						},
+						{
+							Name:       "b",
+							Port:       9090,
+							TargetPort: 9191,
+							Protocol:   "TCP",
+						},
+					},
+					Certificate: "my-cert",
+					Issuer:      "skupper-site-ca",
+				},
+			},
+			expectedServices: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "mysvc",
+						Namespace: "test",
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{
+							"app": "foo",
+						},
+						Ports: []corev1.ServicePort{
+							{
+								Name:       "a",
+								Port:       8080,
+								TargetPort: intstr.IntOrString{IntVal: int32(8081)},
+								Protocol:   corev1.Protocol("TCP"),
+							},
+							{
+								Name:       "b",
+								Port:       9090,
+								TargetPort: intstr.IntOrString{IntVal: int32(9191)},
+								Protocol:   corev1.Protocol("TCP"),
+							},
+						},
+					},
+				},
+			},
+			expectedCertificates: []MockCertificate{
+				{
+					namespace: "test",
+					name:      "my-cert",
+					ca:        "skupper-site-ca",
+					subject:   "mysvc",
+					hosts:     []string{"mysvc", "mysvc.test", "tcp.mygateway.net"},
+					client:    false,
+					server:    true,
+					refs:      nil,
+				},
+			},
+			expectedStatus: "OK",
+			expectedEndpoints: []skupperv2alpha1.Endpoint{
+				{
+					Name: "a",
+					Port: "8080",
+					Host: "tcp.mygateway.net",
+				},
+				{
+					Name: "b",
+					Port: "9090",
+					Host: "tcp.mygateway.net",
+				},
+			},
+		},
+		{
+			name: "unresolved gateway-tcp",
+			config: Config{
+				EnabledAccessTypes: []string{
+					ACCESS_TYPE_GATEWAY_TCP,
+				},
+				GatewayClass: "xyz",
+			},
+			ssaRecorder: newServerSideApplyRecorder(),
+			expectedSSA: map[string]*unstructured.Unstructured{
+				"test/mysvc":   tcpGateway("mysvc", "test"),
+				"test/mysvc-a": tcproute("mysvc-a", "test"),
+				"test/mysvc-b": tcproute("mysvc-b", "test"),
+			},
+			definition: &skupperv2alpha1.SecuredAccess{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "skupper.io/v2alpha1",
+					Kind:       "SecuredAccess",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mysvc",
+					Namespace: "test",
+				},
+				Spec: skupperv2alpha1.SecuredAccessSpec{
+					AccessType: ACCESS_TYPE_GATEWAY_TCP,
+					Selector: map[string]string{
+						"app": "foo",
+					},
+					Ports: []skupperv2alpha1.SecuredAccessPort{
+						{
+							Name:       "a",
+							Port:       8080,
+							TargetPort: 8081,
+							Protocol:   "TCP",
+						},
+						{
+							Name:       "b",
+							Port:       9090,
+							TargetPort: 9191,
+							Protocol:   "TCP",
+						},
+					},
+					Certificate: "my-cert",
+					Issuer:      "skupper-site-ca",
+				},
+			},
+			expectedServices: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "mysvc",
+						Namespace: "test",
+					},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{
+							"app": "foo",
+						},
+						Ports: []corev1.ServicePort{
+							{
+								Name:       "a",
+								Port:       8080,
+								TargetPort: intstr.IntOrString{IntVal: int32(8081)},
+								Protocol:   corev1.Protocol("TCP"),
+							},
+							{
+								Name:       "b",
+								Port:       9090,
+								TargetPort: intstr.IntOrString{IntVal: int32(9191)},
+								Protocol:   corev1.Protocol("TCP"),
+							},
+						},
+					},
+				},
+			},
+			expectedCertificates: []MockCertificate{
+				{
+					namespace: "test",
+					name:      "my-cert",
+					ca:        "skupper-site-ca",
+					subject:   "mysvc",
+					hosts:     []string{"mysvc", "mysvc.test"},
+					client:    false,
+					server:    true,
+					refs:      nil,
+				},
+			},
+			expectedStatus: "Gateway address not yet resolved",
+		},
 	}
 	for _, tt := range testTable {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2005,8 +2292,13 @@ func TestSecuredAccessGeneral(t *testing.T) {
 						assert.Equal(t, desired.GetName(), actual.GetName())
 						assert.Equal(t, desired.GetNamespace(), actual.GetNamespace())
 						assert.Equal(t, desired.GroupVersionKind(), actual.GroupVersionKind())
-						if actual.GroupVersionKind().Kind == "TLSRoute" {
+						switch actual.GroupVersionKind().Kind {
+						case "TLSRoute":
 							m.CheckTlsRoute(actual.GetNamespace()+"/"+actual.GetName(), actual)
+						case "TCPRoute":
+							m.CheckTcpRoute(actual.GetNamespace()+"/"+actual.GetName(), actual)
+						case "VirtualService":
+							m.CheckVirtualService(actual.GetNamespace()+"/"+actual.GetName(), actual)
 						}
 					}
 				}
@@ -3157,6 +3449,9 @@ func (m *MockCertificateManager) Ensure(namespace string, name string, ca string
 	return m.popError()
 }
 
+func (m *MockCertificateManager) SetDefaultKeyAlgorithm(namespace string, algorithm string) {
+}
+
 func gateway(name string, namespace string) *unstructured.Unstructured {
 	obj := &unstructured.Unstructured{}
 	obj.SetGroupVersionKind(schema.GroupVersionKind{
@@ -3187,6 +3482,53 @@ func tlsroute(name string, namespace string) *unstructured.Unstructured {
 	return obj
 }
 
+func tcpGateway(name string, namespace string) *unstructured.Unstructured {
+	obj := gateway(name, namespace)
+	obj.SetLabels(map[string]string{
+		"internal.skupper.io/secured-access": "true",
+	})
+	obj.SetAnnotations(map[string]string{
+		"internal.skupper.io/controlled": "true",
+	})
+	return obj
+}
+
+func tcproute(name string, namespace string) *unstructured.Unstructured {
+	obj := tlsroute(name, namespace)
+	obj.SetKind("TCPRoute")
+	return obj
+}
+
+func istioGateway(name string, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "networking.istio.io",
+		Version: "v1beta1",
+		Kind:    "Gateway",
+	})
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
+func virtualService(name string, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "networking.istio.io",
+		Version: "v1beta1",
+		Kind:    "VirtualService",
+	})
+	obj.SetLabels(map[string]string{
+		"internal.skupper.io/secured-access": "true",
+	})
+	obj.SetAnnotations(map[string]string{
+		"internal.skupper.io/controlled": "true",
+	})
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
 type ServerSideApplyRecorder struct {
 	objects   map[string]*unstructured.Unstructured
 	modifiers map[string]func(*unstructured.Unstructured)
@@ -3597,6 +3939,142 @@ func TestRecreateOnDelete(t *testing.T) {
 				"test/mysvc-b": tlsroute("mysvc-b", "test"),
 			},
 		},
+		{
+			name: "tcp route",
+			config: Config{
+				EnabledAccessTypes: []string{"gateway-tcp"},
+				GatewayClass:       "contour",
+			},
+			k8sObjects: []runtime.Object{
+				service("mysvc", "test", selector(), "", servicePorts()),
+				tcproute("mysvc-a", "test"),
+				tcproute("mysvc-b", "test"),
+			},
+			deletions: []Delete{
+				func(clients internalclient.Clients, manager *SecuredAccessManager) error {
+					if err := manager.CheckTcpRoute("test/mysvc-a", nil); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			skupperObjects: []runtime.Object{
+				securedAccess("mysvc", "test", selector(), securedAccessPorts()),
+			},
+			expectedServices: []*corev1.Service{
+				service("mysvc", "test", selector(), "", servicePorts()),
+			},
+			expectedSSA: map[string]*unstructured.Unstructured{
+				"test/mysvc":   tcpGateway("mysvc", "test"),
+				"test/mysvc-a": tcproute("mysvc-a", "test"),
+				"test/mysvc-b": tcproute("mysvc-b", "test"),
+			},
+		},
+		{
+			name: "redundant tcp route",
+			config: Config{
+				EnabledAccessTypes: []string{"gateway-tcp"},
+				GatewayClass:       "contour",
+			},
+			k8sObjects: []runtime.Object{
+				service("mysvc", "test", selector(), "", servicePorts()),
+				tcproute("mysvc-a", "test"),
+				tcproute("mysvc-b", "test"),
+				tcproute("mysvc-c", "test"),
+			},
+			deletions: []Delete{
+				func(clients internalclient.Clients, manager *SecuredAccessManager) error {
+					if err := manager.CheckTcpRoute("test/mysvc-c", tcproute("mysvc-c", "test")); err != nil {
+						return err
+					}
+					if err := manager.CheckTcpRoute("test/mysvc-c", nil); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			skupperObjects: []runtime.Object{
+				securedAccess("mysvc", "test", selector(), securedAccessPorts()),
+			},
+			expectedServices: []*corev1.Service{
+				service("mysvc", "test", selector(), "", servicePorts()),
+			},
+			expectedSSA: map[string]*unstructured.Unstructured{
+				"test/mysvc":   tcpGateway("mysvc", "test"),
+				"test/mysvc-a": tcproute("mysvc-a", "test"),
+				"test/mysvc-b": tcproute("mysvc-b", "test"),
+			},
+		},
+		{
+			name: "virtual service",
+			config: Config{
+				EnabledAccessTypes: []string{"istio"},
+				IstioSelector:      "istio=ingressgateway",
+				IstioDomain:        "mesh.example.com",
+				IstioPort:          443,
+			},
+			k8sObjects: []runtime.Object{
+				service("mysvc", "test", selector(), "", servicePorts()),
+				virtualService("mysvc-a", "test"),
+				virtualService("mysvc-b", "test"),
+			},
+			deletions: []Delete{
+				func(clients internalclient.Clients, manager *SecuredAccessManager) error {
+					if err := manager.CheckVirtualService("test/mysvc-a", nil); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			skupperObjects: []runtime.Object{
+				securedAccess("mysvc", "test", selector(), securedAccessPorts()),
+			},
+			expectedServices: []*corev1.Service{
+				service("mysvc", "test", selector(), "", servicePorts()),
+			},
+			expectedSSA: map[string]*unstructured.Unstructured{
+				"test/skupper": istioGateway("skupper", "test"),
+				"test/mysvc-a": virtualService("mysvc-a", "test"),
+				"test/mysvc-b": virtualService("mysvc-b", "test"),
+			},
+		},
+		{
+			name: "redundant virtual service",
+			config: Config{
+				EnabledAccessTypes: []string{"istio"},
+				IstioSelector:      "istio=ingressgateway",
+				IstioDomain:        "mesh.example.com",
+				IstioPort:          443,
+			},
+			k8sObjects: []runtime.Object{
+				service("mysvc", "test", selector(), "", servicePorts()),
+				virtualService("mysvc-a", "test"),
+				virtualService("mysvc-b", "test"),
+				virtualService("mysvc-c", "test"),
+			},
+			deletions: []Delete{
+				func(clients internalclient.Clients, manager *SecuredAccessManager) error {
+					if err := manager.CheckVirtualService("test/mysvc-c", virtualService("mysvc-c", "test")); err != nil {
+						return err
+					}
+					if err := manager.CheckVirtualService("test/mysvc-c", nil); err != nil {
+						return err
+					}
+					return nil
+				},
+			},
+			skupperObjects: []runtime.Object{
+				securedAccess("mysvc", "test", selector(), securedAccessPorts()),
+			},
+			expectedServices: []*corev1.Service{
+				service("mysvc", "test", selector(), "", servicePorts()),
+			},
+			expectedSSA: map[string]*unstructured.Unstructured{
+				"test/skupper": istioGateway("skupper", "test"),
+				"test/mysvc-a": virtualService("mysvc-a", "test"),
+				"test/mysvc-b": virtualService("mysvc-b", "test"),
+			},
+		},
 	}
 	for _, tt := range testTable {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4,6 +4,9 @@ import (
 	"flag"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+
 	iflag "github.com/skupperproject/skupper/internal/flag"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
@@ -17,8 +20,32 @@ const ACCESS_TYPE_INGRESS = "ingress"
 const SettingIngressClassName = "ingressClassName"
 const ACCESS_TYPE_CONTOUR_HTTP_PROXY = "contour-http-proxy"
 const ACCESS_TYPE_GATEWAY = "gateway"
+const ACCESS_TYPE_GATEWAY_TCP = "gateway-tcp"
+const ACCESS_TYPE_ISTIO = "istio"
 const ACCESS_TYPE_LOCAL = "local"
 
+var builtinAccessTypes = []string{
+	ACCESS_TYPE_LOADBALANCER,
+	ACCESS_TYPE_ROUTE,
+	ACCESS_TYPE_NODEPORT,
+	ACCESS_TYPE_INGRESS_NGINX,
+	ACCESS_TYPE_INGRESS,
+	ACCESS_TYPE_CONTOUR_HTTP_PROXY,
+	ACCESS_TYPE_GATEWAY,
+	ACCESS_TYPE_GATEWAY_TCP,
+	ACCESS_TYPE_ISTIO,
+	ACCESS_TYPE_LOCAL,
+}
+
+func isBuiltinAccessType(accessType string) bool {
+	for _, a := range builtinAccessTypes {
+		if a == accessType {
+			return true
+		}
+	}
+	return false
+}
+
 type Config struct {
 	EnabledAccessTypes []string
 	DefaultAccessType  string
@@ -29,6 +56,9 @@ type Config struct {
 	GatewayPort        int
 	GatewayClass       string
 	GatewayDomain      string
+	IstioSelector      string
+	IstioDomain        string
+	IstioPort          int
 }
 
 func (c *Config) isEnabled(accessType string) bool {
@@ -45,6 +75,16 @@ func (c *Config) Verify() error {
 	if c.DefaultAccessType != "" && !c.isEnabled(c.DefaultAccessType) {
 		return fmt.Errorf("Default access type %q is not in enabled list.", c.DefaultAccessType)
 	}
+	// any access type that is not built in is defined by a ConfigMap
+	// labelled with its name, so the name must be a valid label value
+	for _, accessType := range c.EnabledAccessTypes {
+		if isBuiltinAccessType(accessType) {
+			continue
+		}
+		if accessType == "" || len(validation.IsValidLabelValue(accessType)) > 0 {
+			return fmt.Errorf("Access type %q is neither built in nor a valid name for a custom access type.", accessType)
+		}
+	}
 	// if nodeport is in enabled list, check that clusterhost is set
 	if c.isEnabled("nodeport") && c.ClusterHost == "" {
 		return fmt.Errorf("Cluster host must be set to enable nodeport access type.")
@@ -53,6 +93,18 @@ func (c *Config) Verify() error {
 	if c.isEnabled("gateway") && c.GatewayClass == "" {
 		return fmt.Errorf("Gateway class must be set to enable gateway access type.")
 	}
+	if c.isEnabled(ACCESS_TYPE_GATEWAY_TCP) && c.GatewayClass == "" {
+		return fmt.Errorf("Gateway class must be set to enable gateway-tcp access type.")
+	}
+	// if istio is in enabled list, check that the domain is set and the selector is valid
+	if c.isEnabled(ACCESS_TYPE_ISTIO) {
+		if c.IstioDomain == "" {
+			return fmt.Errorf("Istio domain must be set to enable istio access type.")
+		}
+		if _, err := labels.ConvertSelectorToLabelsMap(c.IstioSelector); err != nil || c.IstioSelector == "" {
+			return fmt.Errorf("Istio gateway selector %q is not valid.", c.IstioSelector)
+		}
+	}
 	return nil
 }
 
@@ -73,15 +125,18 @@ func (c *Config) getDefaultAccessType(clients internalclient.Clients) string {
 
 func BoundConfig(flags *flag.FlagSet) (*Config, error) {
 	c := &Config{}
-	iflag.MultiStringVar(flags, &c.EnabledAccessTypes, "enabled-access-types", "SKUPPER_ENABLED_ACCESS_TYPES", defaultEnabledAccessTypes(), "The access types which should be enabled for sites to choose from.")
+	iflag.MultiStringVar(flags, &c.EnabledAccessTypes, "enabled-access-types", "SKUPPER_ENABLED_ACCESS_TYPES", defaultEnabledAccessTypes(), "The access types which should be enabled for sites to choose from. Any access type that is not built in must be defined by a ConfigMap in the controller's namespace labelled "+AccessTypeLabel+"=<name>.")
 	iflag.StringVar(flags, &c.DefaultAccessType, "default-access-type", "SKUPPER_DEFAULT_ACCESS_TYPE", "", "The default access type.")
 	iflag.StringVar(flags, &c.ClusterHost, "cluster-host", "SKUPPER_CLUSTER_HOST", "", "The hostname or IP address through which the cluster can be reached. Required for configuring nodeport as an access type.")
 	iflag.StringVar(flags, &c.IngressDomain, "ingress-domain", "SKUPPER_INGRESS_DOMAIN", "", "The domain to use in constructing the fully qualified hostname for Ingress resources, through which the ingress controller can be reached. Used for ingress and ingress-nginx access types.")
 	iflag.StringVar(flags, &c.IngressClassName, "ingress-class-name", "SKUPPER_INGRESS_CLASS_NAME", "", "Optional ingressClassName for Skupper-managed Ingress resources. Per-resource override: RouterAccess spec.settings."+SettingIngressClassName+". For ingress-nginx, defaults to \"nginx\" if unset.")
 	iflag.StringVar(flags, &c.HttpProxyDomain, "http-proxy-domain", "SKUPPER_HTTP_PROXY_DOMAIN", "", "The domain to use in constructing the fully qualified hostname for contour HttpProxy resources, through which the contour controller can be reached. Only used when selecting contour-http-proxy as an access type.")
 	iflag.StringVar(flags, &c.GatewayDomain, "gateway-domain", "SKUPPER_GATEWAY_DOMAIN", "", "The domain to use in constructing the fully qualified hostname for TLSRoutes resources. Only used when selecting gateway as an access type.")
-	iflag.StringVar(flags, &c.GatewayClass, "gateway-class", "SKUPPER_GATEWAY_CLASS", "", "The class of Gateway to use. This is required to enable gateway as an access type.")
+	iflag.StringVar(flags, &c.GatewayClass, "gateway-class", "SKUPPER_GATEWAY_CLASS", "", "The class of Gateway to use. This is required to enable gateway or gateway-tcp as an access type.")
 	iflag.IntVar(flags, &c.GatewayPort, "gateway-port", "SKUPPER_GATEWAY_PORT", 8443, "The port the Gateway should be configured to listen on. This is only used if gateway is enabled as an access type.")
+	iflag.StringVar(flags, &c.IstioSelector, "istio-gateway-selector", "SKUPPER_ISTIO_GATEWAY_SELECTOR", "istio=ingressgateway", "The labels selecting the Istio ingress gateway pods. Only used when selecting istio as an access type.")
+	iflag.StringVar(flags, &c.IstioDomain, "istio-gateway-domain", "SKUPPER_ISTIO_GATEWAY_DOMAIN", "", "The domain to use in constructing the fully qualified hostname for VirtualService resources, through which the Istio ingress gateway can be reached. This is required to enable istio as an access type.")
+	iflag.IntVar(flags, &c.IstioPort, "istio-gateway-port", "SKUPPER_ISTIO_GATEWAY_PORT", 443, "The port the Istio ingress gateway should be configured to listen on. This is only used if istio is enabled as an access type.")
 	return c, nil
 }
 
@@ -23,7 +23,9 @@ func Test_BoundConfig(t *testing.T) {
 					"loadbalancer",
 					"route",
 				},
-				GatewayPort: 8443,
+				GatewayPort:   8443,
+				IstioSelector: "istio=ingressgateway",
+				IstioPort:     443,
 			},
 		},
 		{
@@ -47,6 +49,8 @@ func Test_BoundConfig(t *testing.T) {
 				IngressClassName:  "public-ingress",
 				HttpProxyDomain:   "gateway.contour.com",
 				GatewayPort:       8443,
+				IstioSelector:     "istio=ingressgateway",
+				IstioPort:         443,
 			},
 		},
 		{
@@ -70,6 +74,26 @@ func Test_BoundConfig(t *testing.T) {
 				IngressClassName:  "my-class",
 				HttpProxyDomain:   "bif.baf.bof.com",
 				GatewayPort:       8443,
+				IstioSelector:     "istio=ingressgateway",
+				IstioPort:         443,
+			},
+		},
+		{
+			name: "istio",
+			args: []string{
+				"--enabled-access-types=istio",
+				"--istio-gateway-selector=app=istio-ingress",
+				"--istio-gateway-domain=mesh.example.com",
+				"--istio-gateway-port=8443",
+			},
+			expectedValue: &Config{
+				EnabledAccessTypes: []string{
+					"istio",
+				},
+				GatewayPort:   8443,
+				IstioSelector: "app=istio-ingress",
+				IstioDomain:   "mesh.example.com",
+				IstioPort:     8443,
 			},
 		},
 	}
@@ -154,6 +178,66 @@ func Test_Verify(t *testing.T) {
 			},
 			expectedError: "Gateway class must be set to enable gateway access type.",
 		},
+		{
+			name: "gateway-tcp class not configured",
+			config: &Config{
+				EnabledAccessTypes: []string{
+					"gateway-tcp",
+				},
+			},
+			expectedError: "Gateway class must be set to enable gateway-tcp access type.",
+		},
+		{
+			name: "istio domain not configured",
+			config: &Config{
+				EnabledAccessTypes: []string{
+					"istio",
+				},
+				IstioSelector: "istio=ingressgateway",
+			},
+			expectedError: "Istio domain must be set to enable istio access type.",
+		},
+		{
+			name: "istio selector not valid",
+			config: &Config{
+				EnabledAccessTypes: []string{
+					"istio",
+				},
+				IstioSelector: "istio",
+				IstioDomain:   "mesh.example.com",
+			},
+			expectedError: "Istio gateway selector \"istio\" is not valid.",
+		},
+		{
+			name: "custom access type",
+			config: &Config{
+				EnabledAccessTypes: []string{
+					"loadbalancer",
+					"my-proxy",
+				},
+				DefaultAccessType: "my-proxy",
+			},
+		},
+		{
+			name: "invalid custom access type name",
+			config: &Config{
+				EnabledAccessTypes: []string{
+					"loadbalancer",
+					"my proxy!",
+				},
+			},
+			expectedError: "Access type \"my proxy!\" is neither built in nor a valid name for a custom access type.",
+		},
+		{
+			name: "istio is configured",
+			config: &Config{
+				EnabledAccessTypes: []string{
+					"istio",
+				},
+				IstioSelector: "istio=ingressgateway",
+				IstioDomain:   "mesh.example.com",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,368 @@
+package securedaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/skupperproject/skupper/internal/kube/resource"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// AccessTypeLabel identifies a ConfigMap in the controller's namespace
+// that defines a custom access type. The value of the label is the
+// name of the access type, which must also be included in the enabled
+// access types for the definition to be used.
+const AccessTypeLabel = "skupper.io/access-type"
+
+const (
+	// Every key ending in this suffix holds an object template. The
+	// templates are rendered once for each port of a SecuredAccess.
+	accessTypeTemplateSuffix = ".yaml"
+	// The template whose rendered object the endpoint JSONPaths are
+	// evaluated against. Only required if there is more than one
+	// template.
+	accessTypeEndpointTemplate = "endpoint-template"
+	// JSONPath to the host of the endpoint for a port. If not set the
+	// generated hostname for the port is used or, if no domain is
+	// set, the cluster host.
+	accessTypeEndpointHost = "endpoint-host"
+	// JSONPath to the port of the endpoint for a port. If not set the
+	// port of the SecuredAccess is used.
+	accessTypeEndpointPort = "endpoint-port"
+	// The domain used to generate hostnames for each port.
+	accessTypeDomain = "domain"
+)
+
+type CustomAccessPort struct {
+	Name       string
+	Port       int
+	TargetPort int
+	Protocol   string
+	Host       string
+}
+
+// CustomAccessParameters are the values available to the templates of
+// a custom access type.
+type CustomAccessParameters struct {
+	Name        string
+	Namespace   string
+	Port        CustomAccessPort
+	Ports       []CustomAccessPort
+	Hosts       []string
+	ClusterHost string
+	Domain      string
+}
+
+type namedTemplate struct {
+	name string
+	text string
+}
+
+// AccessTypeDefinition is the parsed content of a ConfigMap defining a
+// custom access type.
+type AccessTypeDefinition struct {
+	Name             string
+	templates        []namedTemplate
+	endpointTemplate string
+	host             *jsonpath.JSONPath
+	port             *jsonpath.JSONPath
+	domain           string
+}
+
+func accessTypeName(cm *corev1.ConfigMap) (string, bool) {
+	if cm == nil || cm.ObjectMeta.Labels == nil {
+		return "", false
+	}
+	name, ok := cm.ObjectMeta.Labels[AccessTypeLabel]
+	return name, ok && name != ""
+}
+
+func parseJsonPath(name string, path string) (*jsonpath.JSONPath, error) {
+	if path == "" {
+		return nil, nil
+	}
+	parsed := jsonpath.New(name)
+	if err := parsed.Parse(path); err != nil {
+		return nil, fmt.Errorf("Invalid %s %q: %s", name, path, err)
+	}
+	return parsed, nil
+}
+
+// NewAccessTypeDefinition parses and validates the definition of a
+// custom access type held in a ConfigMap.
+func NewAccessTypeDefinition(cm *corev1.ConfigMap) (*AccessTypeDefinition, error) {
+	name, ok := accessTypeName(cm)
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s/%s has no %s label", cm.Namespace, cm.Name, AccessTypeLabel)
+	}
+	if isBuiltinAccessType(name) {
+		return nil, fmt.Errorf("Access type %q is built in and cannot be redefined", name)
+	}
+	def := &AccessTypeDefinition{
+		Name:             name,
+		endpointTemplate: cm.Data[accessTypeEndpointTemplate],
+		domain:           cm.Data[accessTypeDomain],
+	}
+	var errs []error
+	var keys []string
+	for key := range cm.Data {
+		if strings.HasSuffix(key, accessTypeTemplateSuffix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, err := template.New(key).Parse(cm.Data[key]); err != nil {
+			errs = append(errs, fmt.Errorf("Invalid template %q: %s", key, err))
+			continue
+		}
+		def.templates = append(def.templates, namedTemplate{name: key, text: cm.Data[key]})
+	}
+	if len(keys) == 0 {
+		errs = append(errs, fmt.Errorf("No templates defined, expected at least one key ending in %q", accessTypeTemplateSuffix))
+	}
+	if def.endpointTemplate == "" && len(keys) == 1 {
+		def.endpointTemplate = keys[0]
+	} else if def.endpointTemplate != "" && !slices.Contains(keys, def.endpointTemplate) {
+		errs = append(errs, fmt.Errorf("Endpoint template %q is not defined", def.endpointTemplate))
+	}
+	var err error
+	if def.host, err = parseJsonPath(accessTypeEndpointHost, cm.Data[accessTypeEndpointHost]); err != nil {
+		errs = append(errs, err)
+	}
+	if def.port, err = parseJsonPath(accessTypeEndpointPort, cm.Data[accessTypeEndpointPort]); err != nil {
+		errs = append(errs, err)
+	}
+	if (def.host != nil || def.port != nil) && def.endpointTemplate == "" {
+		errs = append(errs, fmt.Errorf("%s must be set when there is more than one template", accessTypeEndpointTemplate))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return def, nil
+}
+
+func (d *AccessTypeDefinition) parameters(access *skupperv2alpha1.SecuredAccess, clusterHost string) CustomAccessParameters {
+	params := CustomAccessParameters{
+		Name:        access.Name,
+		Namespace:   access.Namespace,
+		ClusterHost: clusterHost,
+		Domain:      d.domain,
+	}
+	for _, port := range access.Spec.Ports {
+		p := CustomAccessPort{
+			Name:       port.Name,
+			Port:       port.Port,
+			TargetPort: port.TargetPort,
+			Protocol:   port.Protocol,
+		}
+		if d.domain != "" {
+			p.Host = fmt.Sprintf("%s-%s.%s.%s", access.Name, port.Name, access.Namespace, d.domain)
+			params.Hosts = append(params.Hosts, p.Host)
+		}
+		params.Ports = append(params.Ports, p)
+	}
+	return params
+}
+
+func (d *AccessTypeDefinition) render(t namedTemplate, params CustomAccessParameters) (*unstructured.Unstructured, error) {
+	return resource.Template{
+		Name:       t.name,
+		Template:   t.text,
+		Parameters: params,
+	}.Object()
+}
+
+func evaluate(path *jsonpath.JSONPath, obj *unstructured.Unstructured) string {
+	if path == nil || obj == nil {
+		return ""
+	}
+	results, err := path.FindResults(obj.UnstructuredContent())
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return ""
+	}
+	value := results[0][0].Interface()
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// renderedObject identifies an object applied for a SecuredAccess.
+type renderedObject struct {
+	resource  schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+// CustomAccessType realises SecuredAccess instances by applying the
+// objects rendered from the templates of an AccessTypeDefinition.
+type CustomAccessType struct {
+	manager     *SecuredAccessManager
+	name        string
+	clusterHost string
+	definition  *AccessTypeDefinition
+	err         error
+	// the objects last applied for each SecuredAccess, keyed by
+	// the key of the SecuredAccess
+	rendered map[string]map[renderedObject]bool
+	logger   *slog.Logger
+}
+
+func newCustomAccess(manager *SecuredAccessManager, name string, clusterHost string) *CustomAccessType {
+	return &CustomAccessType{
+		manager:     manager,
+		name:        name,
+		clusterHost: clusterHost,
+		rendered:    map[string]map[renderedObject]bool{},
+		logger:      slog.New(slog.Default().Handler()).With(slog.String("component", "kube.securedaccess.customAccessType"), slog.String("accessType", name)),
+	}
+}
+
+func (o *CustomAccessType) setDefinition(definition *AccessTypeDefinition, err error) {
+	o.definition = definition
+	o.err = err
+}
+
+func (o *CustomAccessType) apply(access *skupperv2alpha1.SecuredAccess, obj *unstructured.Unstructured) (schema.GroupVersionResource, *unstructured.Unstructured, error) {
+	gvr, namespaced, err := resource.ResourceForKind(o.manager.clients.GetDiscoveryClient(), obj.GroupVersionKind())
+	if err != nil {
+		return gvr, nil, err
+	}
+	if !namespaced {
+		return gvr, nil, fmt.Errorf("Cannot create %s %s: only namespaced resources are supported", obj.GetKind(), obj.GetName())
+	}
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["internal.skupper.io/secured-access"] = "true"
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations["internal.skupper.io/controlled"] = "true"
+	if o.manager.context != nil {
+		o.manager.context.SetLabels(access.Namespace, obj.GetName(), obj.GetKind(), labels)
+		o.manager.context.SetAnnotations(access.Namespace, obj.GetName(), obj.GetKind(), annotations)
+	}
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	obj.SetNamespace(access.Namespace)
+	obj.SetOwnerReferences(ownerReferences(access))
+	applied, err := resource.Apply(o.manager.clients.GetDynamicClient(), context.Background(), access.Namespace, gvr, obj)
+	return gvr, applied, err
+}
+
+// prune deletes the objects previously applied for a SecuredAccess
+// that are not in the current set, e.g. those for a port that has
+// been removed. A nil set deletes all of them.
+func (o *CustomAccessType) prune(access *skupperv2alpha1.SecuredAccess, current map[renderedObject]bool) error {
+	var errs []error
+	for obj := range o.rendered[access.Key()] {
+		if current[obj] {
+			continue
+		}
+		o.logger.Info("Deleting object no longer rendered for SecuredAccess",
+			slog.String("key", access.Key()),
+			slog.String("resource", obj.resource.Resource),
+			slog.String("name", obj.name))
+		err := o.manager.clients.GetDynamicClient().Resource(obj.resource).Namespace(obj.namespace).Delete(context.Background(), obj.name, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	if current == nil {
+		delete(o.rendered, access.Key())
+	} else {
+		o.rendered[access.Key()] = current
+	}
+	return errors.Join(errs...)
+}
+
+// renderedBy returns the key of the SecuredAccess for which the
+// object was last applied.
+func (o *CustomAccessType) renderedBy(obj renderedObject) (string, bool) {
+	for key, objects := range o.rendered {
+		if objects[obj] {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func (o *CustomAccessType) RealiseAndResolve(access *skupperv2alpha1.SecuredAccess, svc *corev1.Service) ([]skupperv2alpha1.Endpoint, error) {
+	if o.err != nil {
+		return nil, fmt.Errorf("Invalid definition for access type %q: %s", o.name, o.err)
+	}
+	if o.definition == nil {
+		return nil, fmt.Errorf("No definition found for access type %q", o.name)
+	}
+	def := o.definition
+	params := def.parameters(access, o.clusterHost)
+	var endpoints []skupperv2alpha1.Endpoint
+	var unresolved []string
+	current := map[renderedObject]bool{}
+	for _, port := range params.Ports {
+		params.Port = port
+		var endpointObj *unstructured.Unstructured
+		for _, t := range def.templates {
+			obj, err := def.render(t, params)
+			if err != nil {
+				return nil, fmt.Errorf("Error rendering template %q: %s", t.name, err)
+			}
+			gvr, applied, err := o.apply(access, obj)
+			if err != nil {
+				return nil, err
+			}
+			current[renderedObject{resource: gvr, namespace: access.Namespace, name: obj.GetName()}] = true
+			if o.manager.watchCustomResource != nil {
+				o.manager.watchCustomResource(gvr)
+			}
+			if t.name == def.endpointTemplate {
+				endpointObj = applied
+			}
+		}
+		host := port.Host
+		if host == "" {
+			host = o.clusterHost
+		}
+		if def.host != nil {
+			host = evaluate(def.host, endpointObj)
+		}
+		portNumber := strconv.Itoa(port.Port)
+		if def.port != nil {
+			portNumber = evaluate(def.port, endpointObj)
+		}
+		if host == "" || portNumber == "" {
+			unresolved = append(unresolved, port.Name)
+			continue
+		}
+		endpoints = append(endpoints, skupperv2alpha1.Endpoint{
+			Name: port.Name,
+			Host: host,
+			Port: portNumber,
+		})
+	}
+	if err := o.prune(access, current); err != nil {
+		return endpoints, err
+	}
+	if len(unresolved) > 0 {
+		return endpoints, fmt.Errorf("Endpoints not yet resolved for port(s) %s", strings.Join(unresolved, ", "))
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,388 @@
+package securedaccess
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+const httpProxyAccessTemplate = `apiVersion: projectcontour.io/v1
+kind: HTTPProxy
+metadata:
+  name: {{ .Name }}-{{ .Port.Name }}
+spec:
+  virtualhost:
+    fqdn: {{ .Port.Host }}
+    tls:
+      passthrough: true
+  tcpproxy:
+    services:
+    - name: {{ .Name }}
+      port: {{ .Port.Port }}
+`
+
+func accessTypeConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels: map[string]string{
+				AccessTypeLabel: name,
+			},
+		},
+		Data: data,
+	}
+}
+
+func Test_NewAccessTypeDefinition(t *testing.T) {
+	tests := []struct {
+		name             string
+		configMap        *corev1.ConfigMap
+		expectedTemplate string
+		expectedErrors   []string
+	}{
+		{
+			name: "single template",
+			configMap: accessTypeConfigMap("my-proxy", map[string]string{
+				"proxy.yaml":    httpProxyAccessTemplate,
+				"endpoint-host": "{.spec.virtualhost.fqdn}",
+				"domain":        "example.com",
+			}),
+			expectedTemplate: "proxy.yaml",
+		},
+		{
+			name: "multiple templates",
+			configMap: accessTypeConfigMap("my-proxy", map[string]string{
+				"a.yaml":            httpProxyAccessTemplate,
+				"b.yaml":            httpProxyAccessTemplate,
+				"endpoint-template": "b.yaml",
+				"endpoint-port":     "{.status.port}",
+			}),
+			expectedTemplate: "b.yaml",
+		},
+		{
+			name: "no label",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-proxy",
+					Namespace: "test",
+				},
+			},
+			expectedErrors: []string{"ConfigMap test/my-proxy has no skupper.io/access-type label"},
+		},
+		{
+			name: "built in",
+			configMap: accessTypeConfigMap("route", map[string]string{
+				"route.yaml": httpProxyAccessTemplate,
+			}),
+			expectedErrors: []string{"Access type \"route\" is built in and cannot be redefined"},
+		},
+		{
+			name:           "no templates",
+			configMap:      accessTypeConfigMap("my-proxy", map[string]string{"domain": "example.com"}),
+			expectedErrors: []string{"No templates defined"},
+		},
+		{
+			name: "invalid template and paths",
+			configMap: accessTypeConfigMap("my-proxy", map[string]string{
+				"proxy.yaml":    "name: {{ .Name ",
+				"endpoint-host": "{.spec.virtualhost.fqdn",
+				"endpoint-port": "{.spec[}",
+			}),
+			expectedErrors: []string{
+				"Invalid template \"proxy.yaml\"",
+				"Invalid endpoint-host \"{.spec.virtualhost.fqdn\"",
+				"Invalid endpoint-port \"{.spec[}\"",
+			},
+		},
+		{
+			name: "endpoint template required",
+			configMap: accessTypeConfigMap("my-proxy", map[string]string{
+				"a.yaml":        httpProxyAccessTemplate,
+				"b.yaml":        httpProxyAccessTemplate,
+				"endpoint-host": "{.spec.virtualhost.fqdn}",
+			}),
+			expectedErrors: []string{"endpoint-template must be set when there is more than one template"},
+		},
+		{
+			name: "endpoint template not defined",
+			configMap: accessTypeConfigMap("my-proxy", map[string]string{
+				"a.yaml":            httpProxyAccessTemplate,
+				"endpoint-template": "c.yaml",
+			}),
+			expectedErrors: []string{"Endpoint template \"c.yaml\" is not defined"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := NewAccessTypeDefinition(tt.configMap)
+			if len(tt.expectedErrors) > 0 {
+				for _, text := range tt.expectedErrors {
+					assert.ErrorContains(t, err, text)
+				}
+				return
+			}
+			assert.Assert(t, err)
+			assert.Equal(t, def.Name, tt.configMap.Labels[AccessTypeLabel])
+			assert.Equal(t, def.endpointTemplate, tt.expectedTemplate)
+		})
+	}
+}
+
+func TestCustomAccessType(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            Config
+		configMaps        []*corev1.ConfigMap
+		deleted           []string
+		ssaRecorder       *ServerSideApplyRecorder
+		expectedSSA       []string
+		expectedStatus    string
+		expectedEndpoints []skupperv2alpha1.Endpoint
+	}{
+		{
+			name: "host from object",
+			config: Config{
+				EnabledAccessTypes: []string{"my-proxy"},
+			},
+			configMaps: []*corev1.ConfigMap{
+				accessTypeConfigMap("my-proxy", map[string]string{
+					"proxy.yaml":    httpProxyAccessTemplate,
+					"endpoint-host": "{.spec.virtualhost.fqdn}",
+					"domain":        "example.com",
+				}),
+			},
+			ssaRecorder:    newServerSideApplyRecorder(),
+			expectedSSA:    []string{"test/mysvc-a", "test/mysvc-b"},
+			expectedStatus: "OK",
+			expectedEndpoints: []skupperv2alpha1.Endpoint{
+				endpoint("a", "8080", "mysvc-a.test.example.com"),
+				endpoint("b", "9090", "mysvc-b.test.example.com"),
+			},
+		},
+		{
+			name: "port from object",
+			config: Config{
+				EnabledAccessTypes: []string{"my-proxy"},
+				ClusterHost:        "my.cluster.com",
+			},
+			configMaps: []*corev1.ConfigMap{
+				accessTypeConfigMap("my-proxy", map[string]string{
+					"proxy.yaml":    httpProxyAccessTemplate,
+					"endpoint-port": "{.spec.tcpproxy.services[0].port}",
+				}),
+			},
+			ssaRecorder:    newServerSideApplyRecorder(),
+			expectedSSA:    []string{"test/mysvc-a", "test/mysvc-b"},
+			expectedStatus: "OK",
+			expectedEndpoints: []skupperv2alpha1.Endpoint{
+				endpoint("a", "8080", "my.cluster.com"),
+				endpoint("b", "9090", "my.cluster.com"),
+			},
+		},
+		{
+			name: "endpoint not resolved",
+			config: Config{
+				EnabledAccessTypes: []string{"my-proxy"},
+			},
+			configMaps: []*corev1.ConfigMap{
+				accessTypeConfigMap("my-proxy", map[string]string{
+					"proxy.yaml":    httpProxyAccessTemplate,
+					"endpoint-host": "{.status.loadBalancer.ingress[0].hostname}",
+				}),
+			},
+			ssaRecorder:    newServerSideApplyRecorder(),
+			expectedSSA:    []string{"test/mysvc-a", "test/mysvc-b"},
+			expectedStatus: "Endpoints not yet resolved for port(s) a, b",
+		},
+		{
+			name: "no definition",
+			config: Config{
+				EnabledAccessTypes: []string{"my-proxy"},
+			},
+			ssaRecorder:    newServerSideApplyRecorder(),
+			expectedStatus: "No definition found for access type \"my-proxy\"",
+		},
+		{
+			name: "definition deleted",
+			config: Config{
+				EnabledAccessTypes: []string{"my-proxy"},
+			},
+			configMaps: []*corev1.ConfigMap{
+				accessTypeConfigMap("my-proxy", map[string]string{
+					"proxy.yaml": httpProxyAccessTemplate,
+					"domain":     "example.com",
+				}),
+			},
+			deleted:        []string{"test/my-proxy"},
+			ssaRecorder:    newServerSideApplyRecorder(),
+			expectedSSA:    []string{"test/mysvc-a", "test/mysvc-b"},
+			expectedStatus: "No definition found for access type \"my-proxy\"",
+		},
+		{
+			name: "invalid definition",
+			config: Config{
+				EnabledAccessTypes: []string{"my-proxy"},
+			},
+			configMaps: []*corev1.ConfigMap{
+				accessTypeConfigMap("my-proxy", map[string]string{
+					"domain": "example.com",
+				}),
+			},
+			ssaRecorder:    newServerSideApplyRecorder(),
+			expectedStatus: "Invalid definition for access type \"my-proxy\": No templates defined, expected at least one key ending in \".yaml\"",
+		},
+		{
+			name: "definition not enabled",
+			config: Config{
+				EnabledAccessTypes: []string{"other"},
+			},
+			configMaps: []*corev1.ConfigMap{
+				accessTypeConfigMap("my-proxy", map[string]string{
+					"proxy.yaml": httpProxyAccessTemplate,
+				}),
+			},
+			ssaRecorder:    newServerSideApplyRecorder(),
+			expectedStatus: "No definition found for access type \"other\"",
+		},
+		{
+			name: "apply fails",
+			config: Config{
+				EnabledAccessTypes: []string{"my-proxy"},
+			},
+			configMaps: []*corev1.ConfigMap{
+				accessTypeConfigMap("my-proxy", map[string]string{
+					"proxy.yaml": httpProxyAccessTemplate,
+					"domain":     "example.com",
+				}),
+			},
+			ssaRecorder:    newServerSideApplyRecorder().setError("test/mysvc-a", "apply blocked"),
+			expectedStatus: "apply blocked",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := fakeclient.NewFakeClient("test", nil, []runtime.Object{
+				securedAccess("mysvc", "test", selector(), securedAccessPorts()),
+			}, "")
+			assert.Assert(t, err)
+			assert.Assert(t, tt.ssaRecorder.enable(client.GetDynamicClient()))
+			m := NewSecuredAccessManager(client, newMockCertificateManager(), &tt.config, &FakeControllerContext{namespace: "test"})
+			sa, err := client.GetSkupperClient().SkupperV2alpha1().SecuredAccesses("test").Get(context.Background(), "mysvc", metav1.GetOptions{})
+			assert.Assert(t, err)
+			assert.Assert(t, m.SecuredAccessChanged(sa.Namespace+"/"+sa.Name, sa))
+			for _, cm := range tt.configMaps {
+				m.CheckAccessTypeDefinition(cm.Namespace+"/"+cm.Name, cm)
+			}
+			for _, key := range tt.deleted {
+				m.CheckAccessTypeDefinition(key, nil)
+			}
+			assert.Equal(t, len(tt.expectedSSA), len(tt.ssaRecorder.objects))
+			for _, key := range tt.expectedSSA {
+				obj, ok := tt.ssaRecorder.objects[key]
+				assert.Assert(t, ok, "No ssa object found for "+key)
+				assert.Equal(t, obj.GetLabels()["internal.skupper.io/secured-access"], "true")
+				assert.Equal(t, len(obj.GetOwnerReferences()), 1)
+				assert.Equal(t, obj.GetOwnerReferences()[0].Name, "mysvc")
+			}
+			actual, err := client.GetSkupperClient().SkupperV2alpha1().SecuredAccesses("test").Get(context.Background(), "mysvc", metav1.GetOptions{})
+			assert.Assert(t, err)
+			assert.Equal(t, actual.Status.Message, tt.expectedStatus)
+			assert.Equal(t, len(actual.Status.Endpoints), len(tt.expectedEndpoints))
+			for _, endpoint := range tt.expectedEndpoints {
+				assert.Assert(t, cmp.Contains(actual.Status.Endpoints, endpoint))
+			}
+		})
+	}
+}
+
+func TestCustomAccessTypeRenderedObjects(t *testing.T) {
+	client, err := fakeclient.NewFakeClient("test", nil, []runtime.Object{
+		securedAccess("mysvc", "test", selector(), securedAccessPorts()),
+	}, "")
+	assert.Assert(t, err)
+	recorder := newServerSideApplyRecorder()
+	assert.Assert(t, recorder.enable(client.GetDynamicClient()))
+	m := NewSecuredAccessManager(client, newMockCertificateManager(), &Config{
+		EnabledAccessTypes: []string{"my-proxy", ACCESS_TYPE_LOCAL},
+	}, &FakeControllerContext{namespace: "test"})
+	watched := map[schema.GroupVersionResource]bool{}
+	m.watchCustomResource = func(gvr schema.GroupVersionResource) {
+		watched[gvr] = true
+	}
+	cm := accessTypeConfigMap("my-proxy", map[string]string{
+		"proxy.yaml":    httpProxyAccessTemplate,
+		"endpoint-host": "{.status.loadBalancer.ingress[0].hostname}",
+	})
+	m.RecoverAccessTypeDefinition(cm.Namespace+"/"+cm.Name, cm)
+	gvr := schema.GroupVersionResource{Group: "projectcontour.io", Version: "v1", Resource: "httpproxies"}
+
+	getSecuredAccess := func() *skupperv2alpha1.SecuredAccess {
+		sa, err := client.GetSkupperClient().SkupperV2alpha1().SecuredAccesses("test").Get(context.Background(), "mysvc", metav1.GetOptions{})
+		assert.Assert(t, err)
+		return sa
+	}
+	deleted := func() []string {
+		var names []string
+		for _, action := range client.GetDynamicClient().(*fakedynamic.FakeDynamicClient).Actions() {
+			if action.GetVerb() == "delete" && action.GetResource() == gvr {
+				names = append(names, action.(k8stesting.DeleteAction).GetName())
+			}
+		}
+		return names
+	}
+
+	sa := getSecuredAccess()
+	assert.Assert(t, m.SecuredAccessChanged(sa.Key(), sa))
+	assert.Equal(t, getSecuredAccess().Status.Message, "Endpoints not yet resolved for port(s) a, b")
+	assert.DeepEqual(t, watched, map[schema.GroupVersionResource]bool{gvr: true})
+
+	// the load balancer hostname is set in the status of the objects
+	for _, name := range []string{"mysvc-a", "mysvc-b"} {
+		recorder.modifiers["test/"+name] = func(obj *unstructured.Unstructured) {
+			unstructured.SetNestedSlice(obj.UnstructuredContent(), []interface{}{
+				map[string]interface{}{"hostname": name + ".lb.example.com"},
+			}, "status", "loadBalancer", "ingress")
+		}
+	}
+	assert.Assert(t, m.CheckCustomResource(gvr, "test/mysvc-a", recorder.objects["test/mysvc-a"]))
+	sa = getSecuredAccess()
+	assert.Equal(t, sa.Status.Message, "OK")
+	assert.DeepEqual(t, sa.Status.Endpoints, []skupperv2alpha1.Endpoint{
+		endpoint("a", "8080", "mysvc-a.lb.example.com"),
+		endpoint("b", "9090", "mysvc-b.lb.example.com"),
+	})
+	assert.Equal(t, len(deleted()), 0)
+
+	// objects for removed ports are deleted
+	sa.Spec.Ports = sa.Spec.Ports[:1]
+	assert.Assert(t, m.SecuredAccessChanged(sa.Key(), sa))
+	assert.DeepEqual(t, deleted(), []string{"mysvc-b"})
+
+	// objects owned by the SecuredAccess but not rendered are deleted
+	stray := recorder.objects["test/mysvc-b"]
+	assert.Assert(t, m.CheckCustomResource(gvr, "test/mysvc-b", stray))
+	assert.DeepEqual(t, deleted(), []string{"mysvc-b", "mysvc-b"})
+	unowned := stray.DeepCopy()
+	unowned.SetOwnerReferences(nil)
+	assert.Assert(t, m.CheckCustomResource(gvr, "test/mysvc-b", unowned))
+	assert.Equal(t, len(deleted()), 2)
+
+	// objects are deleted when the access type changes
+	sa = getSecuredAccess()
+	sa.Spec.AccessType = ACCESS_TYPE_LOCAL
+	assert.Assert(t, m.SecuredAccessChanged(sa.Key(), sa))
+	assert.DeepEqual(t, deleted(), []string{"mysvc-b", "mysvc-b", "mysvc-a"})
+}
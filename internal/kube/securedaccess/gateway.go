@@ -163,22 +163,31 @@ func (o *GatewayAccessType) RealiseAndResolve(access *skupperv2alpha1.SecuredAcc
 }
 
 func getBaseDomain(obj *unstructured.Unstructured) string {
+	if hostname := getAddress(obj, "Hostname"); hostname != "" {
+		return hostname
+	}
+	if ip := getAddress(obj, "IPAddress"); ip != "" {
+		return ip + ".nip.io"
+	}
+	return ""
+}
+
+func getGatewayAddress(obj *unstructured.Unstructured) string {
+	if hostname := getAddress(obj, "Hostname"); hostname != "" {
+		return hostname
+	}
+	return getAddress(obj, "IPAddress")
+}
+
+func getAddress(obj *unstructured.Unstructured, addressType string) string {
 	addresses, _, _ := unstructured.NestedSlice(obj.UnstructuredContent(), "status", "addresses")
 	for _, a := range addresses {
 		if address, ok := a.(map[string]interface{}); ok {
 			value, _, _ := unstructured.NestedString(address, "value")
-			if addressType, _, _ := unstructured.NestedString(address, "type"); addressType == "Hostname" && value != "" {
+			if t, _, _ := unstructured.NestedString(address, "type"); t == addressType && value != "" {
 				return value
 			}
 		}
 	}
-	for _, a := range addresses {
-		if address, ok := a.(map[string]interface{}); ok {
-			value, _, _ := unstructured.NestedString(address, "value")
-			if addressType, _, _ := unstructured.NestedString(address, "type"); addressType == "IPAddress" && value != "" {
-				return value + ".nip.io"
-			}
-		}
-	}
 	return ""
 }
@@ -0,0 +1,139 @@
+package securedaccess
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/skupperproject/skupper/internal/kube/resource"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+//go:embed tcp-gateway.yaml
+var tcpGatewayTemplate string
+
+type TcpGatewayListener struct {
+	Name string
+	Port int
+}
+
+type TcpGatewayParameters struct {
+	Name        string
+	Class       string
+	OwnerUID    string
+	Listeners   []TcpGatewayListener
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+//go:embed tcp-route.yaml
+var tcpRouteTemplate string
+
+type TcpRouteParameters struct {
+	Name        string
+	GatewayName string
+	SectionName string
+	OwnerUID    string
+	ServiceName string
+	ServicePort int
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// GatewayTcpAccessType exposes SecuredAccess ports through Gateway API
+// implementations that support TCPRoute but not TLS passthrough. As
+// there is no SNI based routing, each SecuredAccess gets its own
+// Gateway with a listener per port, and a TCPRoute attached to each
+// listener.
+type GatewayTcpAccessType struct {
+	manager *SecuredAccessManager
+	class   string
+	logger  *slog.Logger
+}
+
+func newGatewayTcpAccess(manager *SecuredAccessManager, class string) AccessType {
+	return &GatewayTcpAccessType{
+		manager: manager,
+		class:   class,
+		logger:  slog.New(slog.Default().Handler()).With(slog.String("component", "kube.securedaccess.gatewayTcpAccessType")),
+	}
+}
+
+func (o *GatewayTcpAccessType) labelsAndAnnotations(namespace string, name string, kind string) (map[string]string, map[string]string) {
+	if o.manager.context == nil {
+		return nil, nil
+	}
+	labels := map[string]string{}
+	annotations := map[string]string{}
+	o.manager.context.SetLabels(namespace, name, kind, labels)
+	o.manager.context.SetAnnotations(namespace, name, kind, annotations)
+	return labels, annotations
+}
+
+func (o *GatewayTcpAccessType) RealiseAndResolve(access *skupperv2alpha1.SecuredAccess, svc *corev1.Service) ([]skupperv2alpha1.Endpoint, error) {
+	var listeners []TcpGatewayListener
+	for _, port := range access.Spec.Ports {
+		listeners = append(listeners, TcpGatewayListener{
+			Name: port.Name,
+			Port: port.Port,
+		})
+	}
+	labels, annotations := o.labelsAndAnnotations(access.Namespace, access.Name, "Gateway")
+	template := resource.Template{
+		Name:     "tcpgateway",
+		Template: tcpGatewayTemplate,
+		Parameters: TcpGatewayParameters{
+			Name:        access.Name,
+			Class:       o.class,
+			OwnerUID:    string(access.ObjectMeta.UID),
+			Listeners:   listeners,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Resource: resource.GatewayResource(),
+	}
+	gateway, err := template.Apply(o.manager.clients.GetDynamicClient(), context.Background(), access.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, port := range access.Spec.Ports {
+		name := fmt.Sprintf("%s-%s", access.Name, port.Name)
+		labels, annotations := o.labelsAndAnnotations(access.Namespace, name, "TCPRoute")
+		template := resource.Template{
+			Name:     "tcproute",
+			Template: tcpRouteTemplate,
+			Parameters: TcpRouteParameters{
+				Name:        name,
+				GatewayName: access.Name,
+				SectionName: port.Name,
+				OwnerUID:    string(access.ObjectMeta.UID),
+				ServiceName: access.Name,
+				ServicePort: port.Port,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Resource: resource.TcpRouteResource(),
+		}
+		if _, err := template.Apply(o.manager.clients.GetDynamicClient(), context.Background(), access.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	address := getGatewayAddress(gateway)
+	if address == "" {
+		return nil, errors.New("Gateway address not yet resolved")
+	}
+	var endpoints []skupperv2alpha1.Endpoint
+	for _, port := range access.Spec.Ports {
+		endpoints = append(endpoints, skupperv2alpha1.Endpoint{
+			Name: port.Name,
+			Host: address,
+			Port: strconv.Itoa(port.Port),
+		})
+	}
+	return endpoints, nil
+}
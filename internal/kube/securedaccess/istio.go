@@ -0,0 +1,158 @@
+package securedaccess
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/skupperproject/skupper/internal/kube/resource"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+//go:embed istio-gateway.yaml
+var istioGatewayTemplate string
+
+type IstioGatewayParameters struct {
+	Name        string
+	Selector    map[string]string
+	Domain      string
+	Port        int
+	OwnerName   string
+	OwnerUID    string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+//go:embed virtual-service.yaml
+var virtualServiceTemplate string
+
+type VirtualServiceParameters struct {
+	Name             string
+	GatewayName      string
+	GatewayNamespace string
+	GatewayPort      int
+	OwnerUID         string
+	Hostname         string
+	ServiceName      string
+	ServiceNamespace string
+	ServicePort      int
+	Labels           map[string]string
+	Annotations      map[string]string
+}
+
+// IstioAccessType exposes SecuredAccess ports through an Istio
+// ingress gateway. A single Gateway configured for TLS passthrough is
+// created in the controller's namespace, owned by the controller's
+// Deployment, and a VirtualService routing on SNI is created for each
+// port.
+type IstioAccessType struct {
+	manager          *SecuredAccessManager
+	selector         map[string]string
+	domain           string
+	port             int
+	gatewayNamespace string
+	controllerName   string
+	controllerUID    string
+	logger           *slog.Logger
+}
+
+func newIstioAccess(manager *SecuredAccessManager, selector string, domain string, port int, context ControllerContext) (AccessType, func() error, error) {
+	parsed, err := labels.ConvertSelectorToLabelsMap(selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	at := &IstioAccessType{
+		manager:  manager,
+		selector: parsed,
+		domain:   domain,
+		port:     port,
+		logger:   slog.New(slog.Default().Handler()).With(slog.String("component", "kube.securedaccess.istioAccessType")),
+	}
+	if context != nil {
+		at.gatewayNamespace = context.Namespace()
+		at.controllerName = context.Name()
+		at.controllerUID = context.UID()
+	}
+	if err := at.init(); err != nil {
+		return nil, nil, err
+	}
+	return at, at.init, nil
+}
+
+// init applies the Gateway. It is also called whenever the Gateway
+// changes or is deleted, to restore it.
+func (o *IstioAccessType) init() error {
+	var labels map[string]string
+	var annotations map[string]string
+	if o.manager.context != nil {
+		labels = map[string]string{}
+		annotations = map[string]string{}
+		o.manager.context.SetLabels(o.gatewayNamespace, "skupper", "Gateway", labels)
+		o.manager.context.SetAnnotations(o.gatewayNamespace, "skupper", "Gateway", annotations)
+	}
+	template := resource.Template{
+		Name:     "istiogateway",
+		Template: istioGatewayTemplate,
+		Parameters: IstioGatewayParameters{
+			Name:        "skupper",
+			Selector:    o.selector,
+			Domain:      o.domain,
+			Port:        o.port,
+			OwnerName:   o.controllerName,
+			OwnerUID:    o.controllerUID,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Resource: resource.IstioGatewayResource(),
+	}
+	_, err := template.Apply(o.manager.clients.GetDynamicClient(), context.Background(), o.gatewayNamespace)
+	return err
+}
+
+func (o *IstioAccessType) RealiseAndResolve(access *skupperv2alpha1.SecuredAccess, svc *corev1.Service) ([]skupperv2alpha1.Endpoint, error) {
+	var endpoints []skupperv2alpha1.Endpoint
+	for _, port := range access.Spec.Ports {
+		name := fmt.Sprintf("%s-%s", access.Name, port.Name)
+		hostname := fmt.Sprintf("%s.%s.%s", name, access.Namespace, o.domain)
+		var labels map[string]string
+		var annotations map[string]string
+		if o.manager.context != nil {
+			labels = map[string]string{}
+			annotations = map[string]string{}
+			o.manager.context.SetLabels(access.Namespace, name, "VirtualService", labels)
+			o.manager.context.SetAnnotations(access.Namespace, name, "VirtualService", annotations)
+		}
+		template := resource.Template{
+			Name:     "virtualservice",
+			Template: virtualServiceTemplate,
+			Parameters: VirtualServiceParameters{
+				Name:             name,
+				GatewayName:      "skupper",
+				GatewayNamespace: o.gatewayNamespace,
+				GatewayPort:      o.port,
+				OwnerUID:         string(access.ObjectMeta.UID),
+				Hostname:         hostname,
+				ServiceName:      access.Name,
+				ServiceNamespace: access.Namespace,
+				ServicePort:      port.Port,
+				Labels:           labels,
+				Annotations:      annotations,
+			},
+			Resource: resource.VirtualServiceResource(),
+		}
+		if _, err := template.Apply(o.manager.clients.GetDynamicClient(), context.Background(), access.Namespace); err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, skupperv2alpha1.Endpoint{
+			Name: port.Name,
+			Host: hostname,
+			Port: strconv.Itoa(o.port),
+		})
+	}
+	return endpoints, nil
+}
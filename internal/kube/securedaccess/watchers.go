@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers/internalinterfaces"
 
@@ -14,18 +16,27 @@ import (
 )
 
 type SecuredAccessResourceWatcher struct {
-	accessMgr            *SecuredAccessManager
-	serviceWatcher       *watchers.ServiceWatcher
-	routeWatcher         *watchers.RouteWatcher
-	ingressWatcher       *watchers.IngressWatcher
-	httpProxyWatcher     *watchers.DynamicWatcher
-	tlsRouteWatcher      *watchers.DynamicWatcher
-	securedAccessWatcher *watchers.SecuredAccessWatcher
+	accessMgr             *SecuredAccessManager
+	serviceWatcher        *watchers.ServiceWatcher
+	routeWatcher          *watchers.RouteWatcher
+	ingressWatcher        *watchers.IngressWatcher
+	httpProxyWatcher      *watchers.DynamicWatcher
+	tlsRouteWatcher       *watchers.DynamicWatcher
+	tcpRouteWatcher       *watchers.DynamicWatcher
+	virtualServiceWatcher *watchers.DynamicWatcher
+	accessTypeWatcher     *watchers.ConfigMapWatcher
+	securedAccessWatcher  *watchers.SecuredAccessWatcher
+	customWatchers        map[schema.GroupVersionResource]*watchers.DynamicWatcher
+	processor             *watchers.EventProcessor
+	namespace             string
+	stopCh                chan struct{}
 }
 
 func NewSecuredAccessResourceWatcher(accessMgr *SecuredAccessManager) *SecuredAccessResourceWatcher {
 	return &SecuredAccessResourceWatcher{
-		accessMgr: accessMgr,
+		accessMgr:      accessMgr,
+		customWatchers: map[schema.GroupVersionResource]*watchers.DynamicWatcher{},
+		stopCh:         make(chan struct{}),
 	}
 }
 
@@ -35,10 +46,41 @@ func (m *SecuredAccessResourceWatcher) WatchResources(processor *watchers.EventP
 	m.routeWatcher = processor.WatchRoutes(routeSecuredAccess(), namespace, watchers.FilterByNamespace(m.isControlledResource, m.accessMgr.CheckRoute))
 	m.httpProxyWatcher = processor.WatchContourHttpProxies(dynamicSecuredAccess(), namespace, watchers.FilterByNamespace(m.isControlledResource, m.accessMgr.CheckHttpProxy))
 	m.tlsRouteWatcher = processor.WatchTlsRoutes(dynamicSecuredAccess(), namespace, watchers.FilterByNamespace(m.isControlledResource, m.accessMgr.CheckTlsRoute))
+	m.tcpRouteWatcher = processor.WatchTcpRoutes(dynamicSecuredAccess(), namespace, watchers.FilterByNamespace(m.isControlledResource, m.accessMgr.CheckTcpRoute))
+	m.virtualServiceWatcher = processor.WatchVirtualServices(dynamicSecuredAccess(), namespace, watchers.FilterByNamespace(m.isControlledResource, m.accessMgr.CheckVirtualService))
+	processor.WatchGateways(dynamicSecuredAccess(), namespace, watchers.FilterByNamespace(m.isControlledResource, m.accessMgr.CheckTcpGateway))
+	m.processor = processor
+	m.namespace = namespace
+	m.accessMgr.watchCustomResource = m.watchCustomResource
+}
+
+// watchCustomResource starts watching the objects of a resource
+// applied by custom access types, the first time an object of that
+// resource is applied. As the kinds are only known once rendered, the
+// watchers are started when added rather than with the others.
+func (m *SecuredAccessResourceWatcher) watchCustomResource(gvr schema.GroupVersionResource) {
+	if _, ok := m.customWatchers[gvr]; ok {
+		return
+	}
+	handler := func(key string, o *unstructured.Unstructured) error {
+		return m.accessMgr.CheckCustomResource(gvr, key, o)
+	}
+	watcher := m.processor.WatchDynamic(gvr, dynamicSecuredAccess(), m.namespace, watchers.FilterByNamespace(m.isControlledResource, handler))
+	watcher.Start(m.stopCh)
+	m.customWatchers[gvr] = watcher
 }
 
 func (m *SecuredAccessResourceWatcher) WatchGateway(processor *watchers.EventProcessor, namespace string) {
 	processor.WatchGateways(dynamicByName("skupper"), namespace, watchers.FilterByNamespace(m.isControlledResource, m.accessMgr.CheckGateway))
+	if m.accessMgr.istioGatewayInit != nil {
+		processor.WatchIstioGateways(dynamicByName("skupper"), namespace, watchers.FilterByNamespace(m.isControlledResource, m.accessMgr.CheckIstioGateway))
+	}
+}
+
+// WatchAccessTypes watches for ConfigMaps defining custom access types
+// in the given namespace, which should be that of the controller.
+func (m *SecuredAccessResourceWatcher) WatchAccessTypes(processor *watchers.EventProcessor, namespace string) {
+	m.accessTypeWatcher = processor.WatchConfigMaps(accessTypeDefinitions(), namespace, m.accessMgr.CheckAccessTypeDefinition)
 }
 
 func (m *SecuredAccessResourceWatcher) WatchSecuredAccesses(processor *watchers.EventProcessor, namespace string, handler watchers.SecuredAccessHandler) {
@@ -97,6 +139,27 @@ func (m *SecuredAccessResourceWatcher) Recover() {
 			m.accessMgr.RecoverTlsRoute(route)
 		}
 	}
+	if m.tcpRouteWatcher != nil {
+		for _, route := range m.tcpRouteWatcher.List() {
+			if !m.isControlledResource(route.GetNamespace()) {
+				continue
+			}
+			m.accessMgr.RecoverTcpRoute(route)
+		}
+	}
+	if m.virtualServiceWatcher != nil {
+		for _, vs := range m.virtualServiceWatcher.List() {
+			if !m.isControlledResource(vs.GetNamespace()) {
+				continue
+			}
+			m.accessMgr.RecoverVirtualService(vs)
+		}
+	}
+	if m.accessTypeWatcher != nil {
+		for _, cm := range m.accessTypeWatcher.List() {
+			m.accessMgr.RecoverAccessTypeDefinition(cm.Namespace+"/"+cm.Name, cm)
+		}
+	}
 	//once all resources are recovered, can process definitions
 	for _, sa := range m.securedAccessWatcher.List() {
 		if !m.isControlledResource(sa.Namespace) {
@@ -119,6 +182,12 @@ func coreSecuredAccess() internalinterfaces.TweakListOptionsFunc {
 	}
 }
 
+func accessTypeDefinitions() internalinterfaces.TweakListOptionsFunc {
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = AccessTypeLabel
+	}
+}
+
 func routeSecuredAccess() routev1interfaces.TweakListOptionsFunc {
 	return func(options *metav1.ListOptions) {
 		options.LabelSelector = "internal.skupper.io/secured-access"
@@ -10,6 +10,7 @@ import (
 	fakeroute "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1/fake"
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
+	"github.com/skupperproject/skupper/internal/kube/resource"
 	"github.com/skupperproject/skupper/internal/kube/watchers"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	fakev2alpha1 "github.com/skupperproject/skupper/pkg/generated/client/clientset/versioned/typed/skupper/v2alpha1/fake"
@@ -17,6 +18,7 @@ import (
 	"gotest.tools/v3/assert/cmp"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -1232,6 +1234,44 @@ func TestGateway(t *testing.T) {
 	}
 }
 
+func TestIstioGateway(t *testing.T) {
+	client, err := fakeclient.NewFakeClient("test", nil, nil, "")
+	assert.Assert(t, err)
+	recorder := newServerSideApplyRecorder()
+	assert.Assert(t, recorder.enable(client.GetDynamicClient()))
+	config := Config{
+		EnabledAccessTypes: []string{"istio"},
+		IstioSelector:      "istio=ingressgateway",
+		IstioDomain:        "example.com",
+		IstioPort:          443,
+	}
+	controllerContext := &FakeControllerContext{
+		namespace:   "test",
+		name:        "skupper-controller",
+		uid:         "00000000-0000-0000-0000-000000000001",
+		labels:      map[string]string{"foo": "bar"},
+		annotations: map[string]string{"a": "b"},
+	}
+	m := NewSecuredAccessManager(client, newMockCertificateManager(), &config, controllerContext)
+	gateway, ok := recorder.objects["test/skupper"]
+	assert.Assert(t, ok)
+	assert.Equal(t, gateway.GetLabels()["foo"], "bar")
+	assert.Equal(t, gateway.GetLabels()["internal.skupper.io/istio-gateway"], "true")
+	assert.Equal(t, gateway.GetAnnotations()["a"], "b")
+	assert.Equal(t, gateway.GetAnnotations()["internal.skupper.io/controlled"], "true")
+	refs := gateway.GetOwnerReferences()
+	assert.Equal(t, len(refs), 1)
+	assert.Equal(t, refs[0].Kind, "Deployment")
+	assert.Equal(t, refs[0].Name, "skupper-controller")
+	assert.Equal(t, string(refs[0].UID), "00000000-0000-0000-0000-000000000001")
+
+	// a deleted gateway is recreated
+	delete(recorder.objects, "test/skupper")
+	assert.Assert(t, m.CheckIstioGateway("test/skupper", nil))
+	_, ok = recorder.objects["test/skupper"]
+	assert.Assert(t, ok)
+}
+
 type FakeClient interface {
 	PrependReactor(verb, resource string, reaction k8stesting.ReactionFunc)
 }
@@ -1285,3 +1325,92 @@ func DynamicClientError(verb string, resource string, err string) ClientError {
 		return f
 	})
 }
+
+func TestGatewayTcp(t *testing.T) {
+	testTable := []struct {
+		name           string
+		k8sObjects     []runtime.Object
+		ssaRecorder    *ServerSideApplyRecorder
+		gatewayUpdates []GatewayUpdate
+		expectedStatus skupperv2alpha1.SecuredAccessStatus
+	}{
+		{
+			name:           "gateway address not resolved",
+			ssaRecorder:    newServerSideApplyRecorder(),
+			expectedStatus: statusOnly("Gateway address not yet resolved"),
+		},
+		{
+			name:           "gateway status has ip",
+			ssaRecorder:    newServerSideApplyRecorder().setGatewayIP("test/mysvc", "10.20.20.10"),
+			expectedStatus: statusOnly("OK", endpoint("a", "8080", "10.20.20.10"), endpoint("b", "9090", "10.20.20.10")),
+		},
+		{
+			name:        "gateway address resolved later",
+			ssaRecorder: newServerSideApplyRecorder(),
+			gatewayUpdates: []GatewayUpdate{
+				{
+					gateway:  tcpGateway("mysvc", "test"),
+					hostname: "tcp.mygateway.net",
+				},
+			},
+			expectedStatus: statusOnly("OK", endpoint("a", "8080", "tcp.mygateway.net"), endpoint("b", "9090", "tcp.mygateway.net")),
+		},
+		{
+			name: "redundant gateway",
+			k8sObjects: []runtime.Object{
+				tcpGateway("other", "test"),
+			},
+			ssaRecorder: newServerSideApplyRecorder().setGatewayIP("test/mysvc", "10.20.20.10"),
+			gatewayUpdates: []GatewayUpdate{
+				{
+					gateway: tcpGateway("other", "test"),
+				},
+			},
+			expectedStatus: statusOnly("OK", endpoint("a", "8080", "10.20.20.10"), endpoint("b", "9090", "10.20.20.10")),
+		},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			skupperObjects := []runtime.Object{
+				securedAccess("mysvc", "test", selector(), securedAccessPorts()),
+			}
+			client, err := fakeclient.NewFakeClient("test", tt.k8sObjects, skupperObjects, "")
+			if err != nil {
+				assert.Assert(t, err)
+			}
+			assert.Assert(t, tt.ssaRecorder.enable(client.GetDynamicClient()))
+			config := Config{
+				EnabledAccessTypes: []string{"gateway-tcp"},
+				GatewayClass:       "contour",
+			}
+			m := NewSecuredAccessManager(client, newMockCertificateManager(), &config, &FakeControllerContext{namespace: "test"})
+			w := NewSecuredAccessResourceWatcher(m)
+			controller := watchers.NewEventProcessor("Controller", client)
+			w.WatchResources(controller, metav1.NamespaceAll)
+			w.WatchSecuredAccesses(controller, metav1.NamespaceAll, func(string, *skupperv2alpha1.SecuredAccess) error { return nil })
+			stopCh := make(chan struct{})
+			controller.StartWatchers(stopCh)
+			controller.WaitForCacheSync(stopCh)
+			w.Recover()
+			for _, update := range tt.gatewayUpdates {
+				key := update.gateway.GetNamespace() + "/" + update.gateway.GetName()
+				if update.hostname != "" {
+					tt.ssaRecorder.setGatewayHostname(key, update.hostname)
+				}
+				assert.Assert(t, m.CheckTcpGateway(key, update.gateway))
+			}
+			for _, obj := range tt.k8sObjects {
+				o := obj.(*unstructured.Unstructured)
+				_, err := client.GetDynamicClient().Resource(resource.GatewayResource()).Namespace(o.GetNamespace()).Get(context.Background(), o.GetName(), metav1.GetOptions{})
+				assert.Assert(t, k8serrors.IsNotFound(err), "redundant gateway not deleted")
+			}
+			actual, err := client.GetSkupperClient().SkupperV2alpha1().SecuredAccesses("test").Get(context.Background(), "mysvc", metav1.GetOptions{})
+			assert.Assert(t, err)
+			assert.Equal(t, tt.expectedStatus.Message, actual.Status.Message)
+			assert.Equal(t, len(tt.expectedStatus.Endpoints), len(actual.Status.Endpoints))
+			for _, endpoint := range tt.expectedStatus.Endpoints {
+				assert.Assert(t, cmp.Contains(actual.Status.Endpoints, endpoint))
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package site
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"slices"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/skupperproject/skupper/internal/kube/site/resources"
+	"github.com/skupperproject/skupper/internal/kube/site/sizing"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+// allowedAutoscaling returns the autoscaling requested by the sizing
+// for the site, or no autoscaling if the request is refused. The
+// refusal is reported through the Autoscaling condition of the site.
+//
+// Horizontal autoscaling is refused for interior sites: each replica of
+// the router deployment is a separate router and the replicas are not
+// linked to each other, so links to the site and clients of its
+// services would be spread over disconnected routers.
+func (s *Site) allowedAutoscaling(autoscaling sizing.Autoscaling) sizing.Autoscaling {
+	s.autoscalingRefused = nil
+	if autoscaling.Horizontal() && !s.isEdge() {
+		s.logger.Error("Horizontal autoscaling is only supported for edge sites, not autoscaling router",
+			slog.String("namespace", s.namespace),
+			slog.String("site", s.name),
+		)
+		s.autoscalingRefused = stderrors.New("Horizontal autoscaling is only supported for edge sites")
+		return sizing.Autoscaling{}
+	}
+	return autoscaling
+}
+
+// checkAutoscalers ensures that each router deployment has the
+// autoscaler allowed for the site, and that any autoscaler no longer
+// required is removed.
+func (s *Site) checkAutoscalers(ctx context.Context, autoscaling sizing.Autoscaling) error {
+	var errs []error
+	groups := s.groups()
+	for _, group := range groups {
+		if err := resources.ApplyAutoscaler(s.clients, ctx, s.site, group, autoscaling, s.labelling); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for name := range s.horizontalAutoscalers {
+		if autoscaling.Horizontal() && slices.Contains(groups, name) {
+			continue
+		}
+		if err := resources.DeleteHorizontalAutoscaler(s.clients, ctx, s.namespace, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for name := range s.verticalAutoscalers {
+		if autoscaling.Vertical() && slices.Contains(groups, name) {
+			continue
+		}
+		if err := resources.DeleteVerticalAutoscaler(s.clients, ctx, s.namespace, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := s.updateAutoscalingStatus(); err != nil {
+		errs = append(errs, err)
+	}
+	return stderrors.Join(errs...)
+}
+
+func (s *Site) HorizontalAutoscalerEvent(key string, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	if hpa == nil {
+		delete(s.horizontalAutoscalers, name)
+	} else {
+		s.horizontalAutoscalers[name] = hpa
+	}
+	return s.updateAutoscalingStatus()
+}
+
+func (s *Site) VerticalAutoscalerEvent(key string, vpa *unstructured.Unstructured) error {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	if vpa == nil {
+		delete(s.verticalAutoscalers, name)
+	} else {
+		s.verticalAutoscalers[name] = vpa
+	}
+	return s.updateAutoscalingStatus()
+}
+
+func (s *Site) updateAutoscalingStatus() error {
+	if s.site == nil {
+		return nil
+	}
+	changed := s.site.SetAutoscaling(s.autoscalingStatus())
+	if s.site.SetAutoscalingRefused(s.autoscalingRefused) {
+		changed = true
+	}
+	if changed {
+		return s.updateSiteStatus()
+	}
+	return nil
+}
+
+func (s *Site) autoscalingStatus() []skupperv2alpha1.AutoscalingStatus {
+	var statuses []skupperv2alpha1.AutoscalingStatus
+	for _, group := range s.groups() {
+		if hpa, ok := s.horizontalAutoscalers[group]; ok {
+			statuses = append(statuses, horizontalAutoscalingStatus(group, hpa))
+		} else if vpa, ok := s.verticalAutoscalers[group]; ok {
+			statuses = append(statuses, verticalAutoscalingStatus(group, vpa, s.logger))
+		}
+	}
+	return statuses
+}
+
+func horizontalAutoscalingStatus(group string, hpa *autoscalingv2.HorizontalPodAutoscaler) skupperv2alpha1.AutoscalingStatus {
+	status := skupperv2alpha1.AutoscalingStatus{
+		Group:           group,
+		Mode:            sizing.AutoscalingHorizontal,
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+	}
+	if hpa.Spec.MinReplicas != nil {
+		status.MinReplicas = *hpa.Spec.MinReplicas
+	}
+	return status
+}
+
+func verticalAutoscalingStatus(group string, vpa *unstructured.Unstructured, logger *slog.Logger) skupperv2alpha1.AutoscalingStatus {
+	status := skupperv2alpha1.AutoscalingStatus{
+		Group: group,
+		Mode:  sizing.AutoscalingVertical,
+	}
+	recommendations, _, err := unstructured.NestedSlice(vpa.UnstructuredContent(), "status", "recommendation", "containerRecommendations")
+	if err != nil {
+		logger.Error("Could not read recommendations from VerticalPodAutoscaler",
+			slog.String("namespace", vpa.GetNamespace()),
+			slog.String("name", vpa.GetName()),
+			slog.Any("error", err))
+		return status
+	}
+	for _, item := range recommendations {
+		recommendation, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		container, _, _ := unstructured.NestedString(recommendation, "containerName")
+		cpu, _, _ := unstructured.NestedString(recommendation, "target", "cpu")
+		memory, _, _ := unstructured.NestedString(recommendation, "target", "memory")
+		status.Recommendations = append(status.Recommendations, skupperv2alpha1.ContainerRecommendation{
+			Container: container,
+			Cpu:       cpu,
+			Memory:    memory,
+		})
+	}
+	return status
+}
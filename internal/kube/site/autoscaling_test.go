@@ -0,0 +1,278 @@
+package site
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/skupperproject/skupper/internal/kube/site/sizing"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+func horizontalAutoscaler(name string, min int32, max int32, current int32, desired int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			MinReplicas: &min,
+			MaxReplicas: max,
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: current,
+			DesiredReplicas: desired,
+		},
+	}
+}
+
+func verticalAutoscaler(name string, recommendations ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "autoscaling.k8s.io/v1",
+			"kind":       "VerticalPodAutoscaler",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "test",
+			},
+		},
+	}
+	if len(recommendations) > 0 {
+		unstructured.SetNestedSlice(obj.Object, recommendations, "status", "recommendation", "containerRecommendations")
+	}
+	return obj
+}
+
+func recommendation(container string, cpu string, memory string) interface{} {
+	return map[string]interface{}{
+		"containerName": container,
+		"target": map[string]interface{}{
+			"cpu":    cpu,
+			"memory": memory,
+		},
+	}
+}
+
+// the fake dynamic client does not support server side apply, so
+// apply patches are accepted without being recorded
+func acceptServerSideApply(client dynamic.Interface) {
+	if fc, ok := client.(*fakedynamic.FakeDynamicClient); ok {
+		fc.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.(k8stesting.PatchAction).GetPatchType() != types.ApplyPatchType {
+				return false, nil, nil
+			}
+			return true, &unstructured.Unstructured{}, nil
+		})
+	}
+}
+
+func TestAutoscalingStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		ha         bool
+		horizontal []*autoscalingv2.HorizontalPodAutoscaler
+		vertical   []*unstructured.Unstructured
+		deleted    []string
+		expected   []skupperv2alpha1.AutoscalingStatus
+	}{
+		{
+			name: "no autoscalers",
+		},
+		{
+			name:       "horizontal",
+			horizontal: []*autoscalingv2.HorizontalPodAutoscaler{horizontalAutoscaler("skupper-router", 1, 10, 3, 4)},
+			expected: []skupperv2alpha1.AutoscalingStatus{
+				{
+					Group:           "skupper-router",
+					Mode:            sizing.AutoscalingHorizontal,
+					MinReplicas:     1,
+					MaxReplicas:     10,
+					CurrentReplicas: 3,
+					DesiredReplicas: 4,
+				},
+			},
+		},
+		{
+			name: "horizontal with HA",
+			ha:   true,
+			horizontal: []*autoscalingv2.HorizontalPodAutoscaler{
+				horizontalAutoscaler("skupper-router-2", 2, 5, 2, 2),
+				horizontalAutoscaler("skupper-router", 2, 5, 3, 3),
+			},
+			expected: []skupperv2alpha1.AutoscalingStatus{
+				{
+					Group:           "skupper-router",
+					Mode:            sizing.AutoscalingHorizontal,
+					MinReplicas:     2,
+					MaxReplicas:     5,
+					CurrentReplicas: 3,
+					DesiredReplicas: 3,
+				},
+				{
+					Group:           "skupper-router-2",
+					Mode:            sizing.AutoscalingHorizontal,
+					MinReplicas:     2,
+					MaxReplicas:     5,
+					CurrentReplicas: 2,
+					DesiredReplicas: 2,
+				},
+			},
+		},
+		{
+			name:       "autoscaler for unused group ignored",
+			horizontal: []*autoscalingv2.HorizontalPodAutoscaler{horizontalAutoscaler("skupper-router-2", 2, 5, 2, 2)},
+		},
+		{
+			name: "vertical",
+			vertical: []*unstructured.Unstructured{
+				verticalAutoscaler("skupper-router", recommendation("router", "250m", "256Mi"), recommendation("kube-adaptor", "50m", "64Mi")),
+			},
+			expected: []skupperv2alpha1.AutoscalingStatus{
+				{
+					Group: "skupper-router",
+					Mode:  sizing.AutoscalingVertical,
+					Recommendations: []skupperv2alpha1.ContainerRecommendation{
+						{
+							Container: "router",
+							Cpu:       "250m",
+							Memory:    "256Mi",
+						},
+						{
+							Container: "kube-adaptor",
+							Cpu:       "50m",
+							Memory:    "64Mi",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "vertical without recommendation",
+			vertical: []*unstructured.Unstructured{verticalAutoscaler("skupper-router")},
+			expected: []skupperv2alpha1.AutoscalingStatus{
+				{
+					Group: "skupper-router",
+					Mode:  sizing.AutoscalingVertical,
+				},
+			},
+		},
+		{
+			name:       "deleted",
+			horizontal: []*autoscalingv2.HorizontalPodAutoscaler{horizontalAutoscaler("skupper-router", 1, 10, 3, 4)},
+			deleted:    []string{"test/skupper-router"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSiteMocks("test", nil, nil, "", false)
+			assert.Assert(t, err)
+			s.site.Spec.HA = tt.ha
+			for _, hpa := range tt.horizontal {
+				assert.Assert(t, s.HorizontalAutoscalerEvent(hpa.Namespace+"/"+hpa.Name, hpa))
+			}
+			for _, vpa := range tt.vertical {
+				assert.Assert(t, s.VerticalAutoscalerEvent(vpa.GetNamespace()+"/"+vpa.GetName(), vpa))
+			}
+			for _, key := range tt.deleted {
+				assert.Assert(t, s.HorizontalAutoscalerEvent(key, nil))
+			}
+			actual, err := s.clients.GetSkupperClient().SkupperV2alpha1().Sites("test").Get(context.Background(), "site1", metav1.GetOptions{})
+			assert.Assert(t, err)
+			assert.DeepEqual(t, actual.Status.Autoscaling, tt.expected)
+		})
+	}
+}
+
+func TestCheckAutoscalers(t *testing.T) {
+	tests := []struct {
+		name               string
+		edge               bool
+		autoscaling        sizing.Autoscaling
+		existing           []runtime.Object
+		expectedHorizontal []string
+		expectedRefusal    string
+	}{
+		{
+			name: "horizontal autoscaler retained",
+			edge: true,
+			autoscaling: sizing.Autoscaling{
+				Mode:                 sizing.AutoscalingHorizontal,
+				MinReplicas:          1,
+				MaxReplicas:          4,
+				TargetCpuUtilization: 70,
+			},
+			existing:           []runtime.Object{horizontalAutoscaler("skupper-router", 1, 4, 1, 1)},
+			expectedHorizontal: []string{"skupper-router"},
+		},
+		{
+			name:     "horizontal autoscaler removed",
+			existing: []runtime.Object{horizontalAutoscaler("skupper-router", 1, 4, 1, 1)},
+		},
+		{
+			name: "horizontal autoscaler refused for interior site",
+			autoscaling: sizing.Autoscaling{
+				Mode:                 sizing.AutoscalingHorizontal,
+				MinReplicas:          1,
+				MaxReplicas:          4,
+				TargetCpuUtilization: 70,
+			},
+			existing:        []runtime.Object{horizontalAutoscaler("skupper-router", 1, 4, 1, 1)},
+			expectedRefusal: "Horizontal autoscaling is only supported for edge sites",
+		},
+		{
+			name: "horizontal autoscaler for unused group removed",
+			edge: true,
+			autoscaling: sizing.Autoscaling{
+				Mode:                 sizing.AutoscalingHorizontal,
+				MinReplicas:          1,
+				MaxReplicas:          4,
+				TargetCpuUtilization: 70,
+			},
+			existing: []runtime.Object{
+				horizontalAutoscaler("skupper-router", 1, 4, 1, 1),
+				horizontalAutoscaler("skupper-router-2", 1, 4, 1, 1),
+			},
+			expectedHorizontal: []string{"skupper-router"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSiteMocks("test", tt.existing, nil, "", false)
+			assert.Assert(t, err)
+			s.site.Spec.Edge = tt.edge
+			for _, obj := range tt.existing {
+				hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+				assert.Assert(t, s.HorizontalAutoscalerEvent(hpa.Namespace+"/"+hpa.Name, hpa))
+			}
+			acceptServerSideApply(s.clients.GetDynamicClient())
+			assert.Assert(t, s.checkAutoscalers(context.Background(), s.allowedAutoscaling(tt.autoscaling)))
+			remaining, err := s.clients.GetKubeClient().AutoscalingV2().HorizontalPodAutoscalers("test").List(context.Background(), metav1.ListOptions{})
+			assert.Assert(t, err)
+			var names []string
+			for _, hpa := range remaining.Items {
+				names = append(names, hpa.Name)
+			}
+			assert.DeepEqual(t, names, tt.expectedHorizontal)
+			site, err := s.clients.GetSkupperClient().SkupperV2alpha1().Sites("test").Get(context.Background(), "site1", metav1.GetOptions{})
+			assert.Assert(t, err)
+			condition := meta.FindStatusCondition(site.Status.Conditions, skupperv2alpha1.CONDITION_TYPE_AUTOSCALING)
+			if tt.expectedRefusal == "" {
+				assert.Assert(t, condition == nil)
+			} else {
+				assert.Assert(t, condition != nil)
+				assert.Equal(t, condition.Status, metav1.ConditionFalse)
+				assert.Equal(t, condition.Message, tt.expectedRefusal)
+			}
+		})
+	}
+}
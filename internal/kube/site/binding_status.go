@@ -6,19 +6,28 @@ import (
 	"strings"
 
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
+	internalnetwork "github.com/skupperproject/skupper/internal/network"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
 type BindingStatus struct {
+	siteId     string
+	network    []skupperv2alpha1.SiteRecord
 	connectors map[string][]string
 	listeners  map[string][]string
 	client     internalclient.Clients
 	errors     []string
 	logger     *slog.Logger
+	// closestChanged is set when the order of reachable routing keys
+	// for a MultiKeyListener with the closest strategy has changed,
+	// which requires the router config to be updated
+	closestChanged bool
 }
 
-func newBindingStatus(client internalclient.Clients, network []skupperv2alpha1.SiteRecord) *BindingStatus {
+func newBindingStatus(client internalclient.Clients, siteId string, network []skupperv2alpha1.SiteRecord) *BindingStatus {
 	s := &BindingStatus{
+		siteId:     siteId,
+		network:    network,
 		client:     client,
 		connectors: map[string][]string{},
 		listeners:  map[string][]string{},
@@ -33,23 +42,36 @@ func newBindingStatus(client internalclient.Clients, network []skupperv2alpha1.S
 func (s *BindingStatus) populate(network []skupperv2alpha1.SiteRecord) {
 	for _, site := range network {
 		for _, svc := range site.Services {
-			connectors := s.connectors[svc.RoutingKey]
+			key := internalnetwork.ServiceKey(svc.RoutingKey, svc.Protocol)
+			connectors := s.connectors[key]
 			for _, connector := range svc.Connectors {
 				connectors = append(connectors, connector)
 			}
-			s.connectors[svc.RoutingKey] = connectors
+			s.connectors[key] = connectors
 
-			listeners := s.listeners[svc.RoutingKey]
+			listeners := s.listeners[key]
 			for _, listener := range svc.Listeners {
 				listeners = append(listeners, listener)
 			}
-			s.listeners[svc.RoutingKey] = listeners
+			s.listeners[key] = listeners
 		}
 	}
 }
 
+// matchingListeners returns the listeners in the network for the
+// routing key that carry the same protocol as bindingType.
+func (s *BindingStatus) matchingListeners(routingKey string, bindingType string) []string {
+	return s.listeners[internalnetwork.ServiceKey(routingKey, internalnetwork.BindingProtocol(bindingType))]
+}
+
+// matchingConnectors returns the connectors in the network for the
+// routing key that carry the same protocol as bindingType.
+func (s *BindingStatus) matchingConnectors(routingKey string, bindingType string) []string {
+	return s.connectors[internalnetwork.ServiceKey(routingKey, internalnetwork.BindingProtocol(bindingType))]
+}
+
 func (s *BindingStatus) updateMatchingListenerCount(connector *skupperv2alpha1.Connector) *skupperv2alpha1.Connector {
-	if connector.SetHasMatchingListener(len(s.listeners[connector.Spec.RoutingKey]) > 0) {
+	if connector.SetHasMatchingListener(len(s.matchingListeners(connector.Spec.RoutingKey, connector.Spec.Type)) > 0) {
 		updated, err := updateConnectorStatus(s.client, connector)
 		if err != nil {
 			s.logger.Error("Failed to update status for connector",
@@ -64,7 +86,7 @@ func (s *BindingStatus) updateMatchingListenerCount(connector *skupperv2alpha1.C
 }
 
 func (s *BindingStatus) updateMatchingConnectorCount(listener *skupperv2alpha1.Listener) *skupperv2alpha1.Listener {
-	if listener.SetHasMatchingConnector(len(s.connectors[listener.Spec.RoutingKey]) > 0) {
+	if listener.SetHasMatchingConnector(len(s.matchingConnectors(listener.Spec.RoutingKey, listener.Spec.Type)) > 0) {
 		updated, err := updateListenerStatus(s.client, listener)
 		if err != nil {
 			s.logger.Error("Failed to update status for listener",
@@ -80,18 +102,27 @@ func (s *BindingStatus) updateMatchingConnectorCount(listener *skupperv2alpha1.L
 
 func (s *BindingStatus) updateMatchingListenerCountForAttachedConnector(connector *AttachedConnector) {
 	if connector.binding != nil {
-		connector.setMatchingListenerCount(len(s.listeners[connector.binding.Spec.RoutingKey]))
+		bindingType := ""
+		if definition := connector.activeDefinition(); definition != nil {
+			bindingType = definition.Spec.Type
+		}
+		connector.setMatchingListenerCount(len(s.matchingListeners(connector.binding.Spec.RoutingKey, bindingType)))
 	}
 }
 
 func (s *BindingStatus) updateMultiKeyListenerDestination(mkl *skupperv2alpha1.MultiKeyListener) *skupperv2alpha1.MultiKeyListener {
 	routingKeys := mkl.GetRoutingKeys()
 
-	// Find which routing keys have matching connectors, preserving priority order
 	var reachable []string
-	for _, key := range routingKeys {
-		if len(s.connectors[key]) > 0 {
-			reachable = append(reachable, key)
+	if mkl.Spec.Strategy.Closest != nil {
+		// Order the routing keys with matching connectors by link cost from this site
+		reachable = internalnetwork.OrderRoutingKeysByCost(s.siteId, s.network, routingKeys)
+	} else {
+		// Find which routing keys have matching connectors, preserving priority order
+		for _, key := range routingKeys {
+			if len(s.connectors[key]) > 0 {
+				reachable = append(reachable, key)
+			}
 		}
 	}
 
@@ -99,6 +130,9 @@ func (s *BindingStatus) updateMultiKeyListenerDestination(mkl *skupperv2alpha1.M
 	changed := mkl.SetHasDestination(hasDestination)
 	if mkl.SetRoutingKeysReachable(reachable) {
 		changed = true
+		if mkl.Spec.Strategy.Closest != nil {
+			s.closestChanged = true
+		}
 	}
 
 	if changed {
@@ -293,6 +293,10 @@ func TestBindingAdaptor_updateBridgeConfigForConnector(t *testing.T) {
 							SiteId: "00000000-0000-0000-0000-000000000001",
 						},
 					},
+					UdpListeners:      qdr.UdpEndpointMap{},
+					UdpConnectors:     qdr.UdpEndpointMap{},
+					HttpListeners:     qdr.HttpEndpointMap{},
+					HttpConnectors:    qdr.HttpEndpointMap{},
 					ListenerAddresses: qdr.ListenerAddressMap{},
 				},
 			},
@@ -336,6 +340,10 @@ func TestBindingAdaptor_updateBridgeConfigForConnector(t *testing.T) {
 							ProcessID: "30af5279-be83-41e4-86fe-cc45396786f4",
 						},
 					},
+					UdpListeners:      qdr.UdpEndpointMap{},
+					UdpConnectors:     qdr.UdpEndpointMap{},
+					HttpListeners:     qdr.HttpEndpointMap{},
+					HttpConnectors:    qdr.HttpEndpointMap{},
 					ListenerAddresses: qdr.ListenerAddressMap{},
 				},
 			},
@@ -364,6 +372,10 @@ func TestBindingAdaptor_updateBridgeConfigForConnector(t *testing.T) {
 				config: qdr.BridgeConfig{
 					TcpListeners:      map[string]qdr.TcpEndpoint{},
 					TcpConnectors:     map[string]qdr.TcpEndpoint{},
+					UdpListeners:      qdr.UdpEndpointMap{},
+					UdpConnectors:     qdr.UdpEndpointMap{},
+					HttpListeners:     qdr.HttpEndpointMap{},
+					HttpConnectors:    qdr.HttpEndpointMap{},
 					ListenerAddresses: qdr.ListenerAddressMap{},
 				},
 			},
@@ -390,6 +402,10 @@ func TestBindingAdaptor_updateBridgeConfigForConnector(t *testing.T) {
 				config: qdr.BridgeConfig{
 					TcpListeners:      map[string]qdr.TcpEndpoint{},
 					TcpConnectors:     map[string]qdr.TcpEndpoint{},
+					UdpListeners:      qdr.UdpEndpointMap{},
+					UdpConnectors:     qdr.UdpEndpointMap{},
+					HttpListeners:     qdr.HttpEndpointMap{},
+					HttpConnectors:    qdr.HttpEndpointMap{},
 					ListenerAddresses: qdr.ListenerAddressMap{},
 				},
 			},
@@ -605,6 +621,10 @@ func TestBindingAdaptor_updateBridgeConfigForListener(t *testing.T) {
 						},
 					},
 					TcpConnectors:     map[string]qdr.TcpEndpoint{},
+					UdpListeners:      qdr.UdpEndpointMap{},
+					UdpConnectors:     qdr.UdpEndpointMap{},
+					HttpListeners:     qdr.HttpEndpointMap{},
+					HttpConnectors:    qdr.HttpEndpointMap{},
 					ListenerAddresses: qdr.ListenerAddressMap{},
 				},
 			},
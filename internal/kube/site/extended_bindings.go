@@ -112,10 +112,11 @@ func (a *ExtendedBindings) ListenerUpdated(listener *skupperv2alpha1.Listener) {
 		return
 	}
 	port := Port{
-		Name:       listener.Name,
-		Port:       listener.Spec.Port,
-		TargetPort: allocatedRouterPort,
-		Protocol:   listener.Protocol(),
+		Name:        listener.Name,
+		Port:        listener.Spec.Port,
+		TargetPort:  allocatedRouterPort,
+		Protocol:    listener.Protocol(),
+		AppProtocol: listener.AppProtocol(),
 	}
 	if exposed := a.exposed.Expose(listener.Spec.Host, port); exposed != nil {
 		if err := a.context.Expose(exposed); err != nil {
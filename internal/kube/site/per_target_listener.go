@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/skupperproject/skupper/internal/qdr"
+	"github.com/skupperproject/skupper/internal/site"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
 
@@ -74,10 +75,11 @@ func (p *PerTargetListener) address(target string) string {
 func (p *PerTargetListener) expose(mapping *qdr.PortMapping, exposedPorts ExposedPorts, context BindingContext) error {
 	for target, allocatedRouterPort := range p.targets {
 		port := Port{
-			Name:       p.definition.Name,
-			Port:       p.definition.Spec.Port,
-			TargetPort: allocatedRouterPort,
-			Protocol:   p.definition.Protocol(),
+			Name:        p.definition.Name,
+			Port:        p.definition.Spec.Port,
+			TargetPort:  allocatedRouterPort,
+			Protocol:    p.definition.Protocol(),
+			AppProtocol: p.definition.AppProtocol(),
 		}
 		if ports := exposedPorts.Expose(target, port); ports != nil {
 			if err := context.Expose(ports); err != nil {
@@ -138,6 +140,26 @@ func (p *PerTargetListener) updateBridgeConfig(siteId string, config *qdr.Bridge
 			}) {
 				updated = true
 			}
+		} else if p.definition.Spec.Type == "udp" {
+			if config.AddUdpListener(qdr.UdpEndpoint{
+				Name:    qdr.TcpListenerNamePrefix + p.definition.Name + "@" + target,
+				SiteId:  siteId,
+				Port:    strconv.Itoa(port),
+				Address: p.address(target),
+			}) {
+				updated = true
+			}
+		} else if protocolVersion := site.HttpProtocolVersion(p.definition.Spec.Type); protocolVersion != "" {
+			if config.AddHttpListener(qdr.HttpEndpoint{
+				Name:            qdr.TcpListenerNamePrefix + p.definition.Name + "@" + target,
+				SiteId:          siteId,
+				Port:            strconv.Itoa(port),
+				Address:         p.address(target),
+				ProtocolVersion: protocolVersion,
+				SslProfile:      p.definition.Spec.TlsCredentials,
+			}) {
+				updated = true
+			}
 		}
 	}
 	return updated
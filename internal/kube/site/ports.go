@@ -6,10 +6,11 @@ import (
 )
 
 type Port struct {
-	Name       string
-	Port       int
-	TargetPort int
-	Protocol   corev1.Protocol
+	Name        string
+	Port        int
+	TargetPort  int
+	Protocol    corev1.Protocol
+	AppProtocol string
 }
 
 type ExposedPortSet struct {
@@ -78,16 +79,37 @@ func (p ExposedPorts) Unexpose(host string, portname string) *ExposedPortSet {
 func toServicePorts(desired map[string]Port) map[string]corev1.ServicePort {
 	results := map[string]corev1.ServicePort{}
 	for name, details := range desired {
-		results[name] = corev1.ServicePort{
+		port := corev1.ServicePort{
 			Name:       name,
 			Port:       int32(details.Port),
 			TargetPort: intstr.IntOrString{IntVal: int32(details.TargetPort)},
 			Protocol:   details.Protocol,
 		}
+		if details.AppProtocol != "" {
+			appProtocol := details.AppProtocol
+			port.AppProtocol = &appProtocol
+		}
+		results[name] = port
 	}
 	return results
 }
 
+func equivalentServicePorts(a corev1.ServicePort, b corev1.ServicePort) bool {
+	if appProtocol(a) != appProtocol(b) {
+		return false
+	}
+	a.AppProtocol = nil
+	b.AppProtocol = nil
+	return a == b
+}
+
+func appProtocol(port corev1.ServicePort) string {
+	if port.AppProtocol == nil {
+		return ""
+	}
+	return *port.AppProtocol
+}
+
 func updatePorts(spec *corev1.ServiceSpec, desired map[string]Port) bool {
 	expected := toServicePorts(desired)
 	changed := false
@@ -96,7 +118,7 @@ func updatePorts(spec *corev1.ServiceSpec, desired map[string]Port) bool {
 		if port, ok := expected[actual.Name]; ok {
 			ports = append(ports, port)
 			delete(expected, actual.Name)
-			if actual != port {
+			if !equivalentServicePorts(actual, port) {
 				changed = true
 			}
 		} else {
@@ -4,9 +4,12 @@ import (
 	"context"
 	"crypto/sha256"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
@@ -15,6 +18,7 @@ import (
 	"github.com/skupperproject/skupper/internal/images"
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	"github.com/skupperproject/skupper/internal/kube/resource"
+	"github.com/skupperproject/skupper/internal/kube/site/scheduling"
 	"github.com/skupperproject/skupper/internal/kube/site/sizing"
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
@@ -25,18 +29,29 @@ var routerDeploymentTemplate string
 //go:embed skupper-router-local-service.yaml
 var routerLocalServiceTemplate string
 
+//go:embed skupper-router-hpa.yaml
+var routerHorizontalAutoscalerTemplate string
+
+//go:embed skupper-router-vpa.yaml
+var routerVerticalAutoscalerTemplate string
+
+//go:embed skupper-router-pdb.yaml
+var routerDisruptionBudgetTemplate string
+
+const routerDisruptionBudgetName = "skupper-router"
+
 type Labelling interface {
 	SetLabels(namespace string, name string, kind string, labels map[string]string) bool
 	SetAnnotations(namespace string, name string, kind string, annotations map[string]string) bool
 	SetObjectMetadata(namespace string, name string, kind string, meta *metav1.ObjectMeta) bool
 }
 
-func resourceTemplates(site *skupperv2alpha1.Site, group string, size sizing.Sizing, labelling Labelling, disableSecCtx bool) []resource.Template {
+func resourceTemplates(site *skupperv2alpha1.Site, group string, size sizing.Sizing, profile scheduling.Profile, labelling Labelling, disableSecCtx bool) []resource.Template {
 	templates := []resource.Template{
 		{
 			Name:       "deployment",
 			Template:   routerDeploymentTemplate,
-			Parameters: getCoreParams(site, group, size, disableSecCtx).setLabelsAndAnnotations(labelling, site.Namespace, "skupper-router", "Deployment"),
+			Parameters: getCoreParams(site, group, size, profile, disableSecCtx).setLabelsAndAnnotations(labelling, site.Namespace, "skupper-router", "Deployment"),
 			Resource: schema.GroupVersionResource{
 				Group:    "apps",
 				Version:  "v1",
@@ -46,7 +61,7 @@ func resourceTemplates(site *skupperv2alpha1.Site, group string, size sizing.Siz
 		{
 			Name:       "localService",
 			Template:   routerLocalServiceTemplate,
-			Parameters: getCoreParams(site, group, size, disableSecCtx).setLabelsAndAnnotations(labelling, site.Namespace, "skupper-router-local", "Service"),
+			Parameters: getCoreParams(site, group, size, profile, disableSecCtx).setLabelsAndAnnotations(labelling, site.Namespace, "skupper-router-local", "Service"),
 			Resource: schema.GroupVersionResource{
 				Group:    "",
 				Version:  "v1",
@@ -67,6 +82,7 @@ type CoreParams struct {
 	RouterImage        skuppertypes.ImageDetails
 	AdaptorImage       skuppertypes.ImageDetails
 	Sizing             sizing.Sizing
+	Scheduling         SchedulingParams
 	Labels             map[string]string
 	Annotations        map[string]string
 	EnableAntiAffinity bool
@@ -126,6 +142,70 @@ func needsBlockQuote(s string) bool {
 	return false
 }
 
+// SchedulingParams holds the JSON encoded scheduling constraints from a
+// scheduling profile, ready for inclusion in the deployment template.
+type SchedulingParams struct {
+	NodeSelector              string
+	Tolerations               string
+	Affinity                  string
+	TopologySpreadConstraints string
+	PriorityClassName         string
+}
+
+func getSchedulingParams(profile scheduling.Profile, enableAntiAffinity bool) SchedulingParams {
+	params := SchedulingParams{
+		PriorityClassName: profile.PriorityClassName,
+	}
+	if len(profile.NodeSelector) > 0 {
+		params.NodeSelector = asJson(profile.NodeSelector)
+	}
+	if len(profile.Tolerations) > 0 {
+		params.Tolerations = asJson(profile.Tolerations)
+	}
+	if len(profile.TopologySpreadConstraints) > 0 {
+		params.TopologySpreadConstraints = asJson(profile.TopologySpreadConstraints)
+	}
+	if profile.Affinity != nil {
+		affinity := profile.Affinity.DeepCopy()
+		// the profile's own pod anti-affinity, if any, takes
+		// precedence over the default for HA sites
+		if enableAntiAffinity && affinity.PodAntiAffinity == nil {
+			affinity.PodAntiAffinity = defaultPodAntiAffinity()
+		}
+		params.Affinity = asJson(affinity)
+	}
+	return params
+}
+
+func defaultPodAntiAffinity() *corev1.PodAntiAffinity {
+	return &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+			{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"application":     "skupper-router",
+							"skupper.io/type": "site",
+						},
+					},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			},
+		},
+	}
+}
+
+// JSON is valid YAML, so the encoded value can be inlined in a
+// template regardless of indentation.
+func asJson(in interface{}) string {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 type Resources struct {
 	Requests map[string]string
 	Limits   map[string]string
@@ -155,7 +235,7 @@ func configDigest(config *skupperv2alpha1.SiteSpec) string {
 	return ""
 }
 
-func getCoreParams(site *skupperv2alpha1.Site, group string, size sizing.Sizing, disableSecCtx bool) *CoreParams {
+func getCoreParams(site *skupperv2alpha1.Site, group string, size sizing.Sizing, profile scheduling.Profile, disableSecCtx bool) *CoreParams {
 	return &CoreParams{
 		SiteId:             site.GetSiteId(),
 		SiteName:           site.Name,
@@ -166,14 +246,15 @@ func getCoreParams(site *skupperv2alpha1.Site, group string, size sizing.Sizing,
 		RouterImage:        images.GetRouterImageDetails(),
 		AdaptorImage:       images.GetKubeAdaptorImageDetails(),
 		Sizing:             size,
+		Scheduling:         getSchedulingParams(profile, enableAntiAffinity(site)),
 		Labels:             map[string]string{},
 		EnableAntiAffinity: enableAntiAffinity(site),
 		DisableSecCtx:      disableSecCtx,
 	}
 }
 
-func Apply(clients internalclient.Clients, ctx context.Context, site *skupperv2alpha1.Site, group string, size sizing.Sizing, labelling Labelling, disableSecCtx bool) error {
-	for _, t := range resourceTemplates(site, group, size, labelling, disableSecCtx) {
+func Apply(clients internalclient.Clients, ctx context.Context, site *skupperv2alpha1.Site, group string, size sizing.Sizing, profile scheduling.Profile, labelling Labelling, disableSecCtx bool) error {
+	for _, t := range resourceTemplates(site, group, size, profile, labelling, disableSecCtx) {
 		_, err := t.Apply(clients.GetDynamicClient(), ctx, site.Namespace)
 		if err != nil {
 			return err
@@ -182,6 +263,152 @@ func Apply(clients internalclient.Clients, ctx context.Context, site *skupperv2a
 	return nil
 }
 
+type AutoscalerParams struct {
+	Group       string
+	SiteId      string
+	SiteName    string
+	Autoscaling sizing.Autoscaling
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+func autoscalerTemplate(site *skupperv2alpha1.Site, group string, autoscaling sizing.Autoscaling, labelling Labelling) (resource.Template, bool) {
+	params := &AutoscalerParams{
+		Group:       group,
+		SiteId:      site.GetSiteId(),
+		SiteName:    site.Name,
+		Autoscaling: autoscaling,
+	}
+	var t resource.Template
+	var kind string
+	if autoscaling.Horizontal() {
+		kind = "HorizontalPodAutoscaler"
+		t = resource.Template{
+			Name:     "horizontalAutoscaler",
+			Template: routerHorizontalAutoscalerTemplate,
+			Resource: resource.HorizontalPodAutoscalerResource(),
+		}
+	} else if autoscaling.Vertical() {
+		kind = "VerticalPodAutoscaler"
+		t = resource.Template{
+			Name:     "verticalAutoscaler",
+			Template: routerVerticalAutoscalerTemplate,
+			Resource: resource.VerticalPodAutoscalerResource(),
+		}
+	} else {
+		return t, false
+	}
+	if labelling != nil {
+		params.Labels = map[string]string{}
+		params.Annotations = map[string]string{}
+		labelling.SetObjectMetadata(site.Namespace, group, kind, &metav1.ObjectMeta{
+			Labels:      params.Labels,
+			Annotations: params.Annotations,
+		})
+		quoteValues(params.Labels)
+		quoteValues(params.Annotations)
+	}
+	t.Parameters = params
+	return t, true
+}
+
+// ApplyAutoscaler creates or updates the autoscaler for the router
+// deployment of the given group, if the sizing enables autoscaling.
+func ApplyAutoscaler(clients internalclient.Clients, ctx context.Context, site *skupperv2alpha1.Site, group string, autoscaling sizing.Autoscaling, labelling Labelling) error {
+	t, ok := autoscalerTemplate(site, group, autoscaling, labelling)
+	if !ok {
+		return nil
+	}
+	if autoscaling.Vertical() && !resource.IsResourceAvailable(clients.GetDiscoveryClient(), resource.VerticalPodAutoscalerResource()) {
+		return fmt.Errorf("Cannot enable vertical autoscaling, VerticalPodAutoscaler resource is not installed")
+	}
+	_, err := t.Apply(clients.GetDynamicClient(), ctx, site.Namespace)
+	return err
+}
+
+// DeleteHorizontalAutoscaler removes the HorizontalPodAutoscaler for
+// the router deployment of the given group, if it exists.
+func DeleteHorizontalAutoscaler(clients internalclient.Clients, ctx context.Context, namespace string, group string) error {
+	err := clients.GetKubeClient().AutoscalingV2().HorizontalPodAutoscalers(namespace).Delete(ctx, group, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// DeleteVerticalAutoscaler removes the VerticalPodAutoscaler for the
+// router deployment of the given group, if it exists.
+func DeleteVerticalAutoscaler(clients internalclient.Clients, ctx context.Context, namespace string, group string) error {
+	err := clients.GetDynamicClient().Resource(resource.VerticalPodAutoscalerResource()).Namespace(namespace).Delete(ctx, group, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+type DisruptionBudgetParams struct {
+	Name           string
+	SiteId         string
+	SiteName       string
+	MinAvailable   string
+	MaxUnavailable string
+	Labels         map[string]string
+	Annotations    map[string]string
+}
+
+func disruptionBudgetTemplate(site *skupperv2alpha1.Site, budget scheduling.DisruptionBudget, labelling Labelling) resource.Template {
+	params := &DisruptionBudgetParams{
+		Name:     routerDisruptionBudgetName,
+		SiteId:   site.GetSiteId(),
+		SiteName: site.Name,
+	}
+	if budget.MaxUnavailable != nil {
+		params.MaxUnavailable = asJson(budget.MaxUnavailable)
+	} else if budget.MinAvailable != nil {
+		params.MinAvailable = asJson(budget.MinAvailable)
+	} else {
+		params.MinAvailable = "1"
+	}
+	if labelling != nil {
+		params.Labels = map[string]string{}
+		params.Annotations = map[string]string{}
+		labelling.SetObjectMetadata(site.Namespace, params.Name, "PodDisruptionBudget", &metav1.ObjectMeta{
+			Labels:      params.Labels,
+			Annotations: params.Annotations,
+		})
+		quoteValues(params.Labels)
+		quoteValues(params.Annotations)
+	}
+	return resource.Template{
+		Name:       "disruptionBudget",
+		Template:   routerDisruptionBudgetTemplate,
+		Parameters: params,
+		Resource: schema.GroupVersionResource{
+			Group:    "policy",
+			Version:  "v1",
+			Resource: "poddisruptionbudgets",
+		},
+	}
+}
+
+// ApplyDisruptionBudget ensures that an HA site has a
+// PodDisruptionBudget covering its routers, so that voluntary
+// disruptions do not take down both at once.
+func ApplyDisruptionBudget(clients internalclient.Clients, ctx context.Context, site *skupperv2alpha1.Site, budget scheduling.DisruptionBudget, labelling Labelling) error {
+	_, err := disruptionBudgetTemplate(site, budget, labelling).Apply(clients.GetDynamicClient(), ctx, site.Namespace)
+	return err
+}
+
+// DeleteDisruptionBudget removes the PodDisruptionBudget created by
+// ApplyDisruptionBudget, if it exists.
+func DeleteDisruptionBudget(clients internalclient.Clients, ctx context.Context, namespace string) error {
+	err := clients.GetKubeClient().PolicyV1().PodDisruptionBudgets(namespace).Delete(ctx, routerDisruptionBudgetName, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func enableAntiAffinity(site *skupperv2alpha1.Site) bool {
 	return site.Spec.HA && !getValueAsBool(site.Spec.Settings, "disable-anti-affinity")
 }
@@ -0,0 +1,331 @@
+package resources
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/skupperproject/skupper/internal/kube/site/scheduling"
+	"github.com/skupperproject/skupper/internal/kube/site/sizing"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+func testSite(ha bool, settings map[string]string) *skupperv2alpha1.Site {
+	return &skupperv2alpha1.Site{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mysite",
+			Namespace: "test",
+			UID:       "00000000-0000-0000-0000-000000000001",
+		},
+		Spec: skupperv2alpha1.SiteSpec{
+			HA:       ha,
+			Settings: settings,
+		},
+	}
+}
+
+func renderDeployment(t *testing.T, site *skupperv2alpha1.Site, profile scheduling.Profile) *appsv1.Deployment {
+	t.Helper()
+	templates := resourceTemplates(site, "skupper-router", sizing.Sizing{}, profile, nil, false)
+	obj, err := templates[0].Object()
+	assert.Assert(t, err)
+	deployment := &appsv1.Deployment{}
+	assert.Assert(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), deployment))
+	return deployment
+}
+
+func TestDeploymentScheduling(t *testing.T) {
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      "node-role",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"gateway"},
+						},
+					},
+				},
+			},
+		},
+	}
+	customAntiAffinity := &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+			{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"skupper.io/component": "router"},
+				},
+				TopologyKey: "topology.kubernetes.io/zone",
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		site     *skupperv2alpha1.Site
+		profile  scheduling.Profile
+		expected corev1.PodSpec
+	}{
+		{
+			name: "no profile",
+			site: testSite(false, nil),
+		},
+		{
+			name: "no profile with HA",
+			site: testSite(true, nil),
+			expected: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: defaultPodAntiAffinity(),
+				},
+			},
+		},
+		{
+			name: "all constraints",
+			site: testSite(false, nil),
+			profile: scheduling.Profile{
+				NodeSelector: map[string]string{"node-role": "gateway"},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "gateway",
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+				},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: nodeAffinity,
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+					},
+				},
+				PriorityClassName: "high",
+			},
+			expected: corev1.PodSpec{
+				NodeSelector: map[string]string{"node-role": "gateway"},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "gateway",
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+				},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: nodeAffinity,
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+					},
+				},
+				PriorityClassName: "high",
+			},
+		},
+		{
+			name: "profile affinity merged with default anti-affinity",
+			site: testSite(true, nil),
+			profile: scheduling.Profile{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: nodeAffinity,
+				},
+			},
+			expected: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity:    nodeAffinity,
+					PodAntiAffinity: defaultPodAntiAffinity(),
+				},
+			},
+		},
+		{
+			name: "profile anti-affinity takes precedence",
+			site: testSite(true, nil),
+			profile: scheduling.Profile{
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: customAntiAffinity,
+				},
+			},
+			expected: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: customAntiAffinity,
+				},
+			},
+		},
+		{
+			name: "profile affinity with anti-affinity disabled",
+			site: testSite(true, map[string]string{"disable-anti-affinity": "true"}),
+			profile: scheduling.Profile{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: nodeAffinity,
+				},
+			},
+			expected: corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: nodeAffinity,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := renderDeployment(t, tt.site, tt.profile).Spec.Template.Spec
+			assert.DeepEqual(t, spec.NodeSelector, tt.expected.NodeSelector)
+			assert.DeepEqual(t, spec.Tolerations, tt.expected.Tolerations)
+			assert.DeepEqual(t, spec.Affinity, tt.expected.Affinity)
+			assert.DeepEqual(t, spec.TopologySpreadConstraints, tt.expected.TopologySpreadConstraints)
+			assert.Equal(t, spec.PriorityClassName, tt.expected.PriorityClassName)
+		})
+	}
+}
+
+func TestDisruptionBudget(t *testing.T) {
+	one := intstr.FromInt32(1)
+	half := intstr.FromString("50%")
+	tests := []struct {
+		name                   string
+		budget                 scheduling.DisruptionBudget
+		expectedMinAvailable   *intstr.IntOrString
+		expectedMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:                 "default",
+			expectedMinAvailable: &one,
+		},
+		{
+			name: "min available",
+			budget: scheduling.DisruptionBudget{
+				MinAvailable: &half,
+			},
+			expectedMinAvailable: &half,
+		},
+		{
+			name: "max unavailable",
+			budget: scheduling.DisruptionBudget{
+				MaxUnavailable: &one,
+			},
+			expectedMaxUnavailable: &one,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := testSite(true, nil)
+			obj, err := disruptionBudgetTemplate(site, tt.budget, nil).Object()
+			assert.Assert(t, err)
+			pdb := &policyv1.PodDisruptionBudget{}
+			assert.Assert(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), pdb))
+			assert.Equal(t, pdb.Name, "skupper-router")
+			assert.Equal(t, len(pdb.OwnerReferences), 1)
+			assert.Equal(t, pdb.OwnerReferences[0].Name, site.Name)
+			assert.DeepEqual(t, pdb.Spec.MinAvailable, tt.expectedMinAvailable)
+			assert.DeepEqual(t, pdb.Spec.MaxUnavailable, tt.expectedMaxUnavailable)
+			assert.DeepEqual(t, pdb.Spec.Selector.MatchLabels, map[string]string{
+				"application":          "skupper-router",
+				"skupper.io/component": "router",
+				"skupper.io/type":      "site",
+			})
+		})
+	}
+}
+
+func TestAutoscaler(t *testing.T) {
+	tests := []struct {
+		name             string
+		autoscaling      sizing.Autoscaling
+		expectedKind     string
+		expectedReplicas bool
+		expectedMetrics  []string
+	}{
+		{
+			name:             "none",
+			expectedReplicas: true,
+		},
+		{
+			name: "horizontal",
+			autoscaling: sizing.Autoscaling{
+				Mode:                    sizing.AutoscalingHorizontal,
+				MinReplicas:             2,
+				MaxReplicas:             10,
+				TargetCpuUtilization:    70,
+				TargetMemoryUtilization: 80,
+			},
+			expectedKind:    "HorizontalPodAutoscaler",
+			expectedMetrics: []string{"cpu", "memory"},
+		},
+		{
+			name: "horizontal cpu only",
+			autoscaling: sizing.Autoscaling{
+				Mode:                 sizing.AutoscalingHorizontal,
+				MinReplicas:          1,
+				MaxReplicas:          3,
+				TargetCpuUtilization: 50,
+			},
+			expectedKind:    "HorizontalPodAutoscaler",
+			expectedMetrics: []string{"cpu"},
+		},
+		{
+			name: "vertical",
+			autoscaling: sizing.Autoscaling{
+				Mode: sizing.AutoscalingVertical,
+			},
+			expectedKind:     "VerticalPodAutoscaler",
+			expectedReplicas: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := testSite(false, nil)
+			templates := resourceTemplates(site, "skupper-router", sizing.Sizing{Autoscaling: tt.autoscaling}, scheduling.Profile{}, nil, false)
+			deployment, err := templates[0].Object()
+			assert.Assert(t, err)
+			_, found, err := unstructured.NestedInt64(deployment.UnstructuredContent(), "spec", "replicas")
+			assert.Assert(t, err)
+			assert.Equal(t, found, tt.expectedReplicas)
+
+			template, ok := autoscalerTemplate(site, "skupper-router", tt.autoscaling, nil)
+			assert.Equal(t, ok, tt.expectedKind != "")
+			if !ok {
+				return
+			}
+			obj, err := template.Object()
+			assert.Assert(t, err)
+			assert.Equal(t, obj.GetKind(), tt.expectedKind)
+			assert.Equal(t, obj.GetName(), "skupper-router")
+			assert.Equal(t, obj.GetOwnerReferences()[0].Name, site.Name)
+			if tt.expectedKind == "VerticalPodAutoscaler" {
+				mode, _, _ := unstructured.NestedString(obj.UnstructuredContent(), "spec", "updatePolicy", "updateMode")
+				assert.Equal(t, mode, "Off")
+				return
+			}
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			assert.Assert(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), hpa))
+			assert.Equal(t, hpa.Spec.ScaleTargetRef.Name, "skupper-router")
+			assert.Equal(t, hpa.Spec.ScaleTargetRef.Kind, "Deployment")
+			assert.Equal(t, *hpa.Spec.MinReplicas, tt.autoscaling.MinReplicas)
+			assert.Equal(t, hpa.Spec.MaxReplicas, tt.autoscaling.MaxReplicas)
+			var metrics []string
+			for _, metric := range hpa.Spec.Metrics {
+				metrics = append(metrics, string(metric.Resource.Name))
+				switch metric.Resource.Name {
+				case corev1.ResourceCPU:
+					assert.Equal(t, *metric.Resource.Target.AverageUtilization, tt.autoscaling.TargetCpuUtilization)
+				case corev1.ResourceMemory:
+					assert.Equal(t, *metric.Resource.Target.AverageUtilization, tt.autoscaling.TargetMemoryUtilization)
+				}
+			}
+			assert.DeepEqual(t, metrics, tt.expectedMetrics)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package scheduling
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+const (
+	SchedulingProfileLabel             = "skupper.io/scheduling-profile"
+	DefaultSchedulingProfileAnnotation = "skupper.io/default-scheduling-profile"
+)
+
+type Registry struct {
+	profiles       map[string]*corev1.ConfigMap //keyed on profile name
+	names          map[string]string            //ConfigMap key -> profile name
+	defaultProfile string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		profiles: map[string]*corev1.ConfigMap{},
+		names:    map[string]string{},
+	}
+}
+
+func (r *Registry) Update(key string, cm *corev1.ConfigMap) error {
+	if name, ok := getProfileName(cm); ok {
+		if existing, ok := r.names[key]; ok {
+			delete(r.profiles, existing)
+		}
+		r.names[key] = name
+		r.profiles[name] = cm
+		if isDefault(cm) {
+			r.defaultProfile = name
+		}
+	} else {
+		if name, ok := r.names[key]; ok {
+			delete(r.names, key)
+			delete(r.profiles, name)
+		}
+	}
+	return nil
+}
+
+func getProfileName(cm *corev1.ConfigMap) (string, bool) {
+	if cm == nil || cm.ObjectMeta.Labels == nil {
+		return "", false
+	}
+	name, ok := cm.ObjectMeta.Labels[SchedulingProfileLabel]
+	return name, ok
+}
+
+func isDefault(cm *corev1.ConfigMap) bool {
+	if cm == nil || cm.ObjectMeta.Annotations == nil {
+		return false
+	}
+	_, ok := cm.ObjectMeta.Annotations[DefaultSchedulingProfileAnnotation]
+	return ok
+}
+
+// GetProfile returns the scheduling profile named by the
+// scheduling-profile setting of the site, or the default profile if
+// that is not set or does not match any known profile.
+func (r *Registry) GetProfile(site *skupperv2alpha1.Site) (Profile, error) {
+	if config := r.getProfileConfiguration(desiredProfile(site)); config != nil {
+		return parse(config)
+	}
+	return Profile{}, nil
+}
+
+func desiredProfile(site *skupperv2alpha1.Site) string {
+	if site.Spec.Settings == nil {
+		return ""
+	}
+	return site.Spec.Settings["scheduling-profile"]
+}
+
+func (r *Registry) getProfileConfiguration(name string) *corev1.ConfigMap {
+	if conf, ok := r.profiles[name]; ok {
+		return conf
+	}
+	return r.profiles[r.defaultProfile]
+}
+
+// Profile holds the scheduling constraints applied to router pods.
+type Profile struct {
+	NodeSelector              map[string]string
+	Tolerations               []corev1.Toleration
+	Affinity                  *corev1.Affinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+	PriorityClassName         string
+	DisruptionBudget          DisruptionBudget
+}
+
+// DisruptionBudget configures the PodDisruptionBudget generated for
+// the routers of an HA site. At most one of MinAvailable and
+// MaxUnavailable may be set. If Disabled, no PodDisruptionBudget is
+// generated.
+type DisruptionBudget struct {
+	MinAvailable   *intstr.IntOrString
+	MaxUnavailable *intstr.IntOrString
+	Disabled       bool
+}
+
+func parse(cm *corev1.ConfigMap) (Profile, error) {
+	var errs []error
+	profile := Profile{}
+	for key, value := range cm.Data {
+		var err error
+		switch key {
+		case "node-selector":
+			err = yaml.Unmarshal([]byte(value), &profile.NodeSelector)
+		case "tolerations":
+			err = yaml.Unmarshal([]byte(value), &profile.Tolerations)
+		case "affinity":
+			err = yaml.Unmarshal([]byte(value), &profile.Affinity)
+		case "topology-spread-constraints":
+			err = yaml.Unmarshal([]byte(value), &profile.TopologySpreadConstraints)
+		case "priority-class-name":
+			if msgs := validation.IsDNS1123Subdomain(value); len(msgs) > 0 {
+				err = errors.New(msgs[0])
+			} else {
+				profile.PriorityClassName = value
+			}
+		case "pdb-min-available":
+			profile.DisruptionBudget.MinAvailable, err = parseIntOrPercent(value)
+		case "pdb-max-unavailable":
+			profile.DisruptionBudget.MaxUnavailable, err = parseIntOrPercent(value)
+		case "pdb-disabled":
+			profile.DisruptionBudget.Disabled, err = strconv.ParseBool(value)
+		default:
+			errs = append(errs, fmt.Errorf("Ignoring key %s in %s/%s", key, cm.Namespace, cm.Name))
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Bad value for %s in %s/%s: %s", key, cm.Namespace, cm.Name, err))
+		}
+	}
+	if profile.DisruptionBudget.MinAvailable != nil && profile.DisruptionBudget.MaxUnavailable != nil {
+		errs = append(errs, fmt.Errorf("Only one of pdb-min-available and pdb-max-unavailable can be set in %s/%s, using pdb-min-available", cm.Namespace, cm.Name))
+		profile.DisruptionBudget.MaxUnavailable = nil
+	}
+	return profile, errors.Join(errs...)
+}
+
+func parseIntOrPercent(value string) (*intstr.IntOrString, error) {
+	parsed := intstr.Parse(value)
+	if _, err := intstr.GetScaledValueFromIntOrPercent(&parsed, 100, true); err != nil {
+		return nil, err
+	}
+	if parsed.Type == intstr.Int && parsed.IntVal < 0 {
+		return nil, fmt.Errorf("%s is negative", value)
+	}
+	return &parsed, nil
+}
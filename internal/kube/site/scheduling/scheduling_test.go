@@ -0,0 +1,413 @@
+package scheduling
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"gotest.tools/v3/assert"
+
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+)
+
+const gatewayTolerations = `
+- key: dedicated
+  operator: Equal
+  value: gateway
+  effect: NoSchedule
+`
+
+const gatewayAffinity = `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"node-role","operator":"In","values":["gateway"]}]}]}}}`
+
+const zoneSpread = `
+- maxSkew: 1
+  topologyKey: topology.kubernetes.io/zone
+  whenUnsatisfiable: ScheduleAnyway
+  labelSelector:
+    matchLabels:
+      skupper.io/component: router
+`
+
+func TestScheduling(t *testing.T) {
+	type Update struct {
+		key    string
+		config *corev1.ConfigMap
+	}
+	type Expectation struct {
+		site    *skupperv2alpha1.Site
+		profile Profile
+		err     string
+	}
+	tests := []struct {
+		name         string
+		config       []Update
+		expectations []Expectation
+	}{
+		{
+			name: "no profile supplied",
+			expectations: []Expectation{
+				{
+					site: f.site(""),
+				},
+			},
+		},
+		{
+			name: "simple match",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("gateway", false).entry("node-selector", "node-role: gateway").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("gateway"),
+					profile: Profile{
+						NodeSelector: map[string]string{"node-role": "gateway"},
+					},
+				},
+				{
+					site: f.site(""),
+				},
+			},
+		},
+		{
+			name: "all values",
+			config: []Update{
+				{
+					key: "foo/bar",
+					config: f.config("gateway", false).entry(
+						"node-selector", `{"node-role": "gateway"}`,
+					).entry(
+						"tolerations", gatewayTolerations,
+					).entry(
+						"affinity", gatewayAffinity,
+					).entry(
+						"topology-spread-constraints", zoneSpread,
+					).entry(
+						"priority-class-name", "system-cluster-critical",
+					).entry(
+						"pdb-max-unavailable", "50%",
+					).configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("gateway"),
+					profile: Profile{
+						NodeSelector: map[string]string{"node-role": "gateway"},
+						Tolerations: []corev1.Toleration{
+							{
+								Key:      "dedicated",
+								Operator: corev1.TolerationOpEqual,
+								Value:    "gateway",
+								Effect:   corev1.TaintEffectNoSchedule,
+							},
+						},
+						Affinity: &corev1.Affinity{
+							NodeAffinity: &corev1.NodeAffinity{
+								RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+									NodeSelectorTerms: []corev1.NodeSelectorTerm{
+										{
+											MatchExpressions: []corev1.NodeSelectorRequirement{
+												{
+													Key:      "node-role",
+													Operator: corev1.NodeSelectorOpIn,
+													Values:   []string{"gateway"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+							{
+								MaxSkew:           1,
+								TopologyKey:       "topology.kubernetes.io/zone",
+								WhenUnsatisfiable: corev1.ScheduleAnyway,
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"skupper.io/component": "router"},
+								},
+							},
+						},
+						PriorityClassName: "system-cluster-critical",
+						DisruptionBudget: DisruptionBudget{
+							MaxUnavailable: intOrString("50%"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "default profile",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("gateway", true).entry("pdb-min-available", "1").configmap("bar", "foo"),
+				},
+				{
+					key:    "foo/baz",
+					config: f.config("other", false).entry("priority-class-name", "high").configmap("baz", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site(""),
+					profile: Profile{
+						DisruptionBudget: DisruptionBudget{
+							MinAvailable: intOrString("1"),
+						},
+					},
+				},
+				{
+					site: f.site("unknown"),
+					profile: Profile{
+						DisruptionBudget: DisruptionBudget{
+							MinAvailable: intOrString("1"),
+						},
+					},
+				},
+				{
+					site: f.site("other"),
+					profile: Profile{
+						PriorityClassName: "high",
+					},
+				},
+			},
+		},
+		{
+			name: "bad values",
+			config: []Update{
+				{
+					key: "foo/bar",
+					config: f.config("gateway", false).entry(
+						"node-selector", "node-role: gateway",
+					).entry(
+						"tolerations", "not a list",
+					).entry(
+						"priority-class-name", "Not_Valid",
+					).entry(
+						"pdb-min-available", "some",
+					).configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("gateway"),
+					profile: Profile{
+						NodeSelector: map[string]string{"node-role": "gateway"},
+					},
+					err: "Bad value for tolerations in foo/bar",
+				},
+				{
+					site: f.site("gateway"),
+					profile: Profile{
+						NodeSelector: map[string]string{"node-role": "gateway"},
+					},
+					err: "Bad value for priority-class-name in foo/bar",
+				},
+				{
+					site: f.site("gateway"),
+					profile: Profile{
+						NodeSelector: map[string]string{"node-role": "gateway"},
+					},
+					err: "Bad value for pdb-min-available in foo/bar",
+				},
+			},
+		},
+		{
+			name: "conflicting disruption budget",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("gateway", false).entry("pdb-min-available", "1").entry("pdb-max-unavailable", "1").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("gateway"),
+					profile: Profile{
+						DisruptionBudget: DisruptionBudget{
+							MinAvailable: intOrString("1"),
+						},
+					},
+					err: "Only one of pdb-min-available and pdb-max-unavailable can be set in foo/bar",
+				},
+			},
+		},
+		{
+			name: "disruption budget disabled",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("gateway", false).entry("pdb-disabled", "true").configmap("bar", "foo"),
+				},
+				{
+					key:    "foo/baz",
+					config: f.config("other", false).entry("pdb-disabled", "maybe").configmap("baz", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("gateway"),
+					profile: Profile{
+						DisruptionBudget: DisruptionBudget{
+							Disabled: true,
+						},
+					},
+				},
+				{
+					site: f.site("other"),
+					err:  "Bad value for pdb-disabled in foo/baz",
+				},
+			},
+		},
+		{
+			name: "unknown key",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("gateway", false).entry("priority-class-name", "high").entry("flibbertygibbet", "500M").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("gateway"),
+					profile: Profile{
+						PriorityClassName: "high",
+					},
+					err: "Ignoring key flibbertygibbet in foo/bar",
+				},
+			},
+		},
+		{
+			name: "config deleted",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("gateway", true).entry("priority-class-name", "high").configmap("bar", "foo"),
+				},
+				{
+					key: "foo/bar",
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("gateway"),
+				},
+			},
+		},
+		{
+			name: "label removed",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("gateway", false).entry("priority-class-name", "high").configmap("bar", "foo"),
+				},
+				{
+					key:    "foo/bar",
+					config: f.config("", false).entry("priority-class-name", "high").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("gateway"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			for _, update := range tt.config {
+				registry.Update(update.key, update.config)
+			}
+			for _, expectation := range tt.expectations {
+				actual, err := registry.GetProfile(expectation.site)
+				if expectation.err != "" {
+					assert.ErrorContains(t, err, expectation.err)
+				} else {
+					assert.Assert(t, err)
+				}
+				assert.DeepEqual(t, expectation.profile, actual)
+			}
+		})
+	}
+}
+
+func intOrString(value string) *intstr.IntOrString {
+	parsed := intstr.Parse(value)
+	return &parsed
+}
+
+type factory struct{}
+
+func (*factory) site(profile string) *skupperv2alpha1.Site {
+	if profile == "" {
+		return &skupperv2alpha1.Site{}
+	}
+	return &skupperv2alpha1.Site{
+		Spec: skupperv2alpha1.SiteSpec{
+			Settings: map[string]string{
+				"scheduling-profile": profile,
+			},
+		},
+	}
+}
+
+type ConfigBuilder struct {
+	labels      map[string]string
+	annotations map[string]string
+	data        map[string]string
+}
+
+func (*factory) config(profile string, isDefault bool) *ConfigBuilder {
+	config := &ConfigBuilder{
+		data: map[string]string{},
+	}
+	if profile != "" {
+		config.label(SchedulingProfileLabel, profile)
+	}
+	if isDefault {
+		config.annotation(DefaultSchedulingProfileAnnotation, "")
+	}
+	return config
+}
+
+func (c *ConfigBuilder) entry(key string, value string) *ConfigBuilder {
+	c.data[key] = value
+	return c
+}
+
+func (c *ConfigBuilder) label(key string, value string) *ConfigBuilder {
+	if c.labels == nil {
+		c.labels = map[string]string{}
+	}
+	c.labels[key] = value
+	return c
+}
+
+func (c *ConfigBuilder) annotation(key string, value string) *ConfigBuilder {
+	if c.annotations == nil {
+		c.annotations = map[string]string{}
+	}
+	c.annotations[key] = value
+	return c
+}
+
+func (c *ConfigBuilder) configmap(name string, namespace string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      c.labels,
+			Annotations: c.annotations,
+		},
+		Data: c.data,
+	}
+}
+
+var f factory
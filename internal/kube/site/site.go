@@ -11,19 +11,23 @@ import (
 
 	internalnetwork "github.com/skupperproject/skupper/internal/network"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/skupperproject/skupper/api/types"
+	"github.com/skupperproject/skupper/internal/certs"
 	"github.com/skupperproject/skupper/internal/kube/certificates"
 	internalclient "github.com/skupperproject/skupper/internal/kube/client"
 	kubeqdr "github.com/skupperproject/skupper/internal/kube/qdr"
 	"github.com/skupperproject/skupper/internal/kube/secrets"
 	"github.com/skupperproject/skupper/internal/kube/site/resources"
+	"github.com/skupperproject/skupper/internal/kube/site/scheduling"
 	"github.com/skupperproject/skupper/internal/kube/site/sizing"
 	"github.com/skupperproject/skupper/internal/kube/watchers"
 	"github.com/skupperproject/skupper/internal/qdr"
@@ -46,41 +50,48 @@ type Labelling interface {
 }
 
 type Site struct {
-	initialised   bool
-	site          *skupperv2alpha1.Site
-	name          string
-	namespace     string
-	clients       *watchers.EventProcessor
-	bindings      *ExtendedBindings
-	links         map[string]*site.Link
-	errors        map[string]string
-	linkAccess    site.RouterAccessMap
-	certs         certificates.CertificateManager
-	access        SecuredAccessFactory
-	accessMapping securedAccessMap
-	sizes         *sizing.Registry
-	routerPods    map[string]*corev1.Pod
-	logger        *slog.Logger
-	currentGroups []string
-	labelling     Labelling
-	profiles      *secrets.ProfilesWatcher
-	disableSecCtx bool
-	leadListeners map[string]string
-}
-
-func NewSite(namespace string, eventProcessor *watchers.EventProcessor, certs certificates.CertificateManager, access SecuredAccessFactory, sizes *sizing.Registry, labelling Labelling, disableSecCtx bool) *Site {
+	initialised           bool
+	site                  *skupperv2alpha1.Site
+	name                  string
+	namespace             string
+	clients               *watchers.EventProcessor
+	bindings              *ExtendedBindings
+	links                 map[string]*site.Link
+	errors                map[string]string
+	linkAccess            site.RouterAccessMap
+	certs                 certificates.CertificateManager
+	access                SecuredAccessFactory
+	accessMapping         securedAccessMap
+	sizes                 *sizing.Registry
+	scheduling            *scheduling.Registry
+	routerPods            map[string]*corev1.Pod
+	horizontalAutoscalers map[string]*autoscalingv2.HorizontalPodAutoscaler
+	verticalAutoscalers   map[string]*unstructured.Unstructured
+	autoscalingRefused    error
+	logger                *slog.Logger
+	currentGroups         []string
+	labelling             Labelling
+	profiles              *secrets.ProfilesWatcher
+	disableSecCtx         bool
+	leadListeners         map[string]string
+}
+
+func NewSite(namespace string, eventProcessor *watchers.EventProcessor, certs certificates.CertificateManager, access SecuredAccessFactory, sizes *sizing.Registry, profiles *scheduling.Registry, labelling Labelling, disableSecCtx bool) *Site {
 	logger := slog.New(slog.Default().Handler())
 	site := &Site{
-		bindings:      NewExtendedBindings(eventProcessor, SSL_PROFILE_PATH),
-		namespace:     namespace,
-		clients:       eventProcessor,
-		links:         map[string]*site.Link{},
-		linkAccess:    site.RouterAccessMap{},
-		certs:         certs,
-		access:        access,
-		accessMapping: make(securedAccessMap),
-		sizes:         sizes,
-		routerPods:    map[string]*corev1.Pod{},
+		bindings:              NewExtendedBindings(eventProcessor, SSL_PROFILE_PATH),
+		namespace:             namespace,
+		clients:               eventProcessor,
+		links:                 map[string]*site.Link{},
+		linkAccess:            site.RouterAccessMap{},
+		certs:                 certs,
+		access:                access,
+		accessMapping:         make(securedAccessMap),
+		sizes:                 sizes,
+		scheduling:            profiles,
+		routerPods:            map[string]*corev1.Pod{},
+		horizontalAutoscalers: map[string]*autoscalingv2.HorizontalPodAutoscaler{},
+		verticalAutoscalers:   map[string]*unstructured.Unstructured{},
 		logger: logger.With(
 			slog.String("component", "kube.site.site"),
 		),
@@ -119,6 +130,9 @@ func (s *Site) verifySiteSpec(site *skupperv2alpha1.Site) error {
 	if site.Spec.Edge && site.Spec.HA {
 		return fmt.Errorf("Edge sites cannot have HA enabled")
 	}
+	if err := certs.ValidateKeyAlgorithm(certs.KeyAlgorithmFromSettings(site.Spec.Settings)); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -265,6 +279,7 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 		}
 	}
 	// CAs for local and site access
+	s.certs.SetDefaultKeyAlgorithm(s.namespace, certs.KeyAlgorithmFromSettings(s.site.Spec.Settings))
 	if err := s.certs.EnsureCA(s.namespace, "skupper-site-ca", fmt.Sprintf("%s site CA", s.name), s.ownerReferences()); err != nil {
 		return err
 	}
@@ -294,11 +309,34 @@ func (s *Site) reconcile(siteDef *skupperv2alpha1.Site, inRecovery bool) error {
 			slog.Any("sizing", size),
 		)
 	}
+	profile, err := s.scheduling.GetProfile(s.site)
+	if err != nil {
+		s.logger.Info("Did not retrieve scheduling profile for site",
+			slog.String("namespace", s.site.Namespace),
+			slog.String("name", s.site.Name),
+			slog.String("reason", err.Error()),
+		)
+	}
+	// the deployments must be rendered without any autoscaling that is
+	// refused, so that they keep their replicas
+	size.Autoscaling = s.allowedAutoscaling(size.Autoscaling)
 	for _, group := range s.groups() {
-		if err := resources.Apply(s.clients, ctxt, s.site, group, size, s.labelling, s.disableSecCtx); err != nil {
+		if err := resources.Apply(s.clients, ctxt, s.site, group, size, profile, s.labelling, s.disableSecCtx); err != nil {
 			return err
 		}
 	}
+	if err := s.checkAutoscalers(ctxt, size.Autoscaling); err != nil {
+		return err
+	}
+	// checked on every reconcile, so that a budget left by an earlier
+	// HA setting or scheduling profile does not block node drains
+	if s.site.Spec.HA && !profile.DisruptionBudget.Disabled {
+		if err := resources.ApplyDisruptionBudget(s.clients, ctxt, s.site, profile.DisruptionBudget, s.labelling); err != nil {
+			return err
+		}
+	} else if err := resources.DeleteDisruptionBudget(s.clients, ctxt, s.namespace); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1554,9 +1592,14 @@ func (s *Site) NetworkStatusUpdated(network []skupperv2alpha1.SiteRecord) error
 		}
 	}
 
-	bindingStatus := newBindingStatus(s.clients, network)
+	bindingStatus := newBindingStatus(s.clients, s.site.GetSiteId(), network)
 	s.bindings.Map(bindingStatus.updateMatchingListenerCount, bindingStatus.updateMatchingConnectorCount)
 	s.bindings.MapOverMultiKeyListeners(bindingStatus.updateMultiKeyListenerDestination)
+	if bindingStatus.closestChanged {
+		if err := s.updateRouterConfig(s.bindings); err != nil {
+			return err
+		}
+	}
 	s.logger.Debug("Updating matching listeners for attached connectors")
 	s.bindings.MapOverAttachedConnectors(bindingStatus.updateMatchingListenerCountForAttachedConnector)
 	return bindingStatus.error()
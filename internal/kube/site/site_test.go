@@ -9,6 +9,7 @@ import (
 	"github.com/skupperproject/skupper/internal/kube/certificates"
 	fakeclient "github.com/skupperproject/skupper/internal/kube/client/fake"
 	"github.com/skupperproject/skupper/internal/kube/securedaccess"
+	"github.com/skupperproject/skupper/internal/kube/site/scheduling"
 	"github.com/skupperproject/skupper/internal/kube/site/sizing"
 	"github.com/skupperproject/skupper/internal/kube/watchers"
 	"github.com/skupperproject/skupper/internal/qdr"
@@ -17,11 +18,15 @@ import (
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"gotest.tools/v3/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
 )
 
 func TestSite_Recover(t *testing.T) {
@@ -1123,6 +1128,15 @@ func TestSite_verifySiteSpec(t *testing.T) {
 			spec:    skupperv2alpha1.SiteSpec{Edge: true, HA: true},
 			wantErr: "Edge sites cannot have HA enabled",
 		},
+		{
+			name: "supported key algorithm",
+			spec: skupperv2alpha1.SiteSpec{Settings: map[string]string{"keyAlgorithm": "ecdsa-p256"}},
+		},
+		{
+			name:    "unsupported key algorithm",
+			spec:    skupperv2alpha1.SiteSpec{Settings: map[string]string{"keyAlgorithm": "dsa"}},
+			wantErr: "unsupported key algorithm \"dsa\", must be one of rsa2048, rsa4096, ecdsa-p256, ecdsa-p384, ed25519",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1536,16 +1550,19 @@ func newSiteMocks(namespace string, k8sObjects []runtime.Object, skupperObjects
 
 	controller := watchers.NewEventProcessor("test", client)
 	newSite := &Site{
-		clients:       controller,
-		bindings:      NewExtendedBindings(controller, ""),
-		links:         make(map[string]*site1.Link),
-		errors:        make(map[string]string),
-		linkAccess:    make(map[string]*skupperv2alpha1.RouterAccess),
-		certs:         certificates.NewCertificateManager(controller),
-		access:        securedaccess.NewSecuredAccessManager(client, nil, &securedaccess.Config{DefaultAccessType: "loadbalancer"}, nil),
-		accessMapping: make(securedAccessMap),
-		routerPods:    make(map[string]*corev1.Pod),
-		sizes:         sizing.NewRegistry(),
+		clients:               controller,
+		bindings:              NewExtendedBindings(controller, ""),
+		links:                 make(map[string]*site1.Link),
+		errors:                make(map[string]string),
+		linkAccess:            make(map[string]*skupperv2alpha1.RouterAccess),
+		certs:                 certificates.NewCertificateManager(controller),
+		access:                securedaccess.NewSecuredAccessManager(client, nil, &securedaccess.Config{DefaultAccessType: "loadbalancer"}, nil),
+		accessMapping:         make(securedAccessMap),
+		routerPods:            make(map[string]*corev1.Pod),
+		horizontalAutoscalers: make(map[string]*autoscalingv2.HorizontalPodAutoscaler),
+		verticalAutoscalers:   make(map[string]*unstructured.Unstructured),
+		sizes:                 sizing.NewRegistry(),
+		scheduling:            scheduling.NewRegistry(),
 		logger: slog.New(slog.Default().Handler()).With(
 			slog.String("component", "kube.site.site"),
 		),
@@ -1767,3 +1784,90 @@ func Test_updateAccessTokensForDeletedSite(t *testing.T) {
 		})
 	}
 }
+
+func TestDisruptionBudget(t *testing.T) {
+	profile := func(name string, key string, value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels:    map[string]string{scheduling.SchedulingProfileLabel: name},
+			},
+			Data: map[string]string{key: value},
+		}
+	}
+	tests := []struct {
+		name            string
+		ha              bool
+		profile         string
+		switchToProfile string
+		expectApplied   bool
+	}{
+		{
+			name:          "ha site",
+			ha:            true,
+			expectApplied: true,
+		},
+		{
+			name:    "ha site with profile without budget",
+			ha:      true,
+			profile: "no-budget",
+		},
+		{
+			name: "site not ha",
+		},
+		{
+			name:            "ha site switched to profile without budget",
+			ha:              true,
+			profile:         "budget",
+			switchToProfile: "no-budget",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a budget left from an earlier configuration
+			existing := &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "skupper-router",
+					Namespace: "test",
+				},
+			}
+			s, err := newSiteMocks("test", []runtime.Object{existing}, nil, "", false)
+			assert.Assert(t, err)
+			dynamicClient := s.clients.GetDynamicClient().(*fakedynamic.FakeDynamicClient)
+			acceptServerSideApply(dynamicClient)
+			s.scheduling.Update("test/budget", profile("budget", "pdb-min-available", "2"))
+			s.scheduling.Update("test/no-budget", profile("no-budget", "pdb-disabled", "true"))
+			s.site.Spec.HA = tt.ha
+			if tt.profile != "" {
+				s.site.Spec.Settings = map[string]string{"scheduling-profile": tt.profile}
+			}
+			assert.Assert(t, s.StartRecovery(s.site))
+			if tt.switchToProfile != "" {
+				assert.Assert(t, appliedDisruptionBudget(dynamicClient))
+				_, err = s.clients.GetKubeClient().PolicyV1().PodDisruptionBudgets("test").Get(context.Background(), "skupper-router", metav1.GetOptions{})
+				assert.Assert(t, err)
+				dynamicClient.ClearActions()
+				site := s.site.DeepCopy()
+				site.Spec.Settings = map[string]string{"scheduling-profile": tt.switchToProfile}
+				assert.Assert(t, s.Reconcile(site))
+			}
+			assert.Equal(t, appliedDisruptionBudget(dynamicClient), tt.expectApplied)
+			_, err = s.clients.GetKubeClient().PolicyV1().PodDisruptionBudgets("test").Get(context.Background(), "skupper-router", metav1.GetOptions{})
+			if tt.expectApplied {
+				assert.Assert(t, err)
+			} else {
+				assert.Assert(t, errors.IsNotFound(err), "budget not deleted: %v", err)
+			}
+		})
+	}
+}
+
+func appliedDisruptionBudget(client *fakedynamic.FakeDynamicClient) bool {
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "patch" && action.GetResource().Resource == "poddisruptionbudgets" {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package sizing
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -85,8 +86,107 @@ func (r *Registry) getSizeConfiguration(name string) *corev1.ConfigMap {
 }
 
 type Sizing struct {
-	Router  ContainerResources
-	Adaptor ContainerResources
+	Router      ContainerResources
+	Adaptor     ContainerResources
+	Autoscaling Autoscaling
+}
+
+const (
+	AutoscalingHorizontal = "horizontal"
+	AutoscalingVertical   = "vertical"
+)
+
+// Autoscaling describes how the router deployments for a size are
+// scaled. If Mode is horizontal, a HorizontalPodAutoscaler is created
+// for each router deployment of an edge site; the replicas of a router
+// deployment are not linked to each other, so interior sites are not
+// scaled horizontally. If it is vertical, a
+// VerticalPodAutoscaler is created which only provides recommendations
+// and does not change the pods.
+type Autoscaling struct {
+	Mode                    string
+	MinReplicas             int32
+	MaxReplicas             int32
+	TargetCpuUtilization    int32
+	TargetMemoryUtilization int32
+}
+
+func (a Autoscaling) Horizontal() bool {
+	return a.Mode == AutoscalingHorizontal
+}
+
+func (a Autoscaling) Vertical() bool {
+	return a.Mode == AutoscalingVertical
+}
+
+func (a *Autoscaling) setMode(value string) error {
+	switch value {
+	case AutoscalingHorizontal, AutoscalingVertical:
+		a.Mode = value
+	case "", "none":
+		a.Mode = ""
+	default:
+		return fmt.Errorf("expected %s, %s or none", AutoscalingHorizontal, AutoscalingVertical)
+	}
+	return nil
+}
+
+func isAutoscalingKey(key string) bool {
+	switch key {
+	case "autoscaling", "min-replicas", "max-replicas", "target-cpu-utilization", "target-memory-utilization":
+		return true
+	}
+	return false
+}
+
+func (a *Autoscaling) set(key string, value string) error {
+	var err error
+	switch key {
+	case "autoscaling":
+		err = a.setMode(value)
+	case "min-replicas":
+		a.MinReplicas, err = parsePositiveInt(value)
+	case "max-replicas":
+		a.MaxReplicas, err = parsePositiveInt(value)
+	case "target-cpu-utilization":
+		a.TargetCpuUtilization, err = parsePositiveInt(value)
+	case "target-memory-utilization":
+		a.TargetMemoryUtilization, err = parsePositiveInt(value)
+	}
+	return err
+}
+
+func parsePositiveInt(value string) (int32, error) {
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if i < 1 {
+		return 0, fmt.Errorf("%s is not a positive integer", value)
+	}
+	return int32(i), nil
+}
+
+func (a *Autoscaling) verify(cm *corev1.ConfigMap) error {
+	if !a.Horizontal() {
+		return nil
+	}
+	var errs []error
+	if a.MinReplicas == 0 {
+		a.MinReplicas = 1
+	}
+	if a.MaxReplicas == 0 {
+		errs = append(errs, fmt.Errorf("max-replicas is required for horizontal autoscaling in %s/%s", cm.Namespace, cm.Name))
+	} else if a.MaxReplicas < a.MinReplicas {
+		errs = append(errs, fmt.Errorf("max-replicas cannot be less than min-replicas in %s/%s", cm.Namespace, cm.Name))
+	}
+	if a.TargetCpuUtilization == 0 && a.TargetMemoryUtilization == 0 {
+		errs = append(errs, fmt.Errorf("target-cpu-utilization or target-memory-utilization is required for horizontal autoscaling in %s/%s", cm.Namespace, cm.Name))
+	}
+	if len(errs) > 0 {
+		a.Mode = ""
+	}
+	return errors.Join(errs...)
 }
 
 func parse(cm *corev1.ConfigMap) (Sizing, error) {
@@ -102,6 +202,12 @@ func parse(cm *corev1.ConfigMap) (Sizing, error) {
 		},
 	}
 	for key, value := range cm.Data {
+		if isAutoscalingKey(key) {
+			if err := sizing.Autoscaling.set(key, value); err != nil {
+				errs = append(errs, fmt.Errorf("Bad value for %s in %s/%s: %s", key, cm.Namespace, cm.Name, err))
+			}
+			continue
+		}
 		if err := verify(value); err != nil {
 			errs = append(errs, fmt.Errorf("Bad value for %s in %s/%s: %s", key, cm.Namespace, cm.Name, err))
 			continue
@@ -127,6 +233,9 @@ func parse(cm *corev1.ConfigMap) (Sizing, error) {
 			errs = append(errs, fmt.Errorf("Ignoring key %s in %s/%s", key, cm.Namespace, cm.Name))
 		}
 	}
+	if err := sizing.Autoscaling.verify(cm); err != nil {
+		errs = append(errs, err)
+	}
 	return sizing, errors.Join(errs...)
 }
 
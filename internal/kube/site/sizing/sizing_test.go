@@ -147,6 +147,158 @@ func TestSizing(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "horizontal autoscaling",
+			config: []Update{
+				{
+					key: "foo/bar",
+					config: f.config("mysize", false).entry(
+						"router-cpu-request", "0.5",
+					).entry(
+						"autoscaling", "horizontal",
+					).entry(
+						"min-replicas", "2",
+					).entry(
+						"max-replicas", "10",
+					).entry(
+						"target-cpu-utilization", "70",
+					).entry(
+						"target-memory-utilization", "80",
+					).configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("mysize"),
+					sizing: f.sizing().routerRequest("cpu", "0.5").autoscaling(Autoscaling{
+						Mode:                    AutoscalingHorizontal,
+						MinReplicas:             2,
+						MaxReplicas:             10,
+						TargetCpuUtilization:    70,
+						TargetMemoryUtilization: 80,
+					}).sizing,
+				},
+			},
+		},
+		{
+			name: "horizontal autoscaling defaults min replicas",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("mysize", false).entry("autoscaling", "horizontal").entry("max-replicas", "4").entry("target-cpu-utilization", "70").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("mysize"),
+					sizing: f.sizing().autoscaling(Autoscaling{
+						Mode:                 AutoscalingHorizontal,
+						MinReplicas:          1,
+						MaxReplicas:          4,
+						TargetCpuUtilization: 70,
+					}).sizing,
+				},
+			},
+		},
+		{
+			name: "vertical autoscaling",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("mysize", false).entry("autoscaling", "vertical").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("mysize"),
+					sizing: f.sizing().autoscaling(Autoscaling{
+						Mode: AutoscalingVertical,
+					}).sizing,
+				},
+			},
+		},
+		{
+			name: "bad autoscaling mode",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("mysize", false).entry("autoscaling", "diagonal").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site:   f.site("mysize"),
+					sizing: f.sizing().sizing,
+					err:    "Bad value for autoscaling in foo/bar: expected horizontal, vertical or none",
+				},
+			},
+		},
+		{
+			name: "bad replicas",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("mysize", false).entry("autoscaling", "horizontal").entry("min-replicas", "0").entry("max-replicas", "4").entry("target-cpu-utilization", "70").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("mysize"),
+					sizing: f.sizing().autoscaling(Autoscaling{
+						Mode:                 AutoscalingHorizontal,
+						MinReplicas:          1,
+						MaxReplicas:          4,
+						TargetCpuUtilization: 70,
+					}).sizing,
+					err: "Bad value for min-replicas in foo/bar: 0 is not a positive integer",
+				},
+			},
+		},
+		{
+			name: "incomplete horizontal autoscaling",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("mysize", false).entry("autoscaling", "horizontal").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("mysize"),
+					sizing: f.sizing().autoscaling(Autoscaling{
+						MinReplicas: 1,
+					}).sizing,
+					err: "max-replicas is required for horizontal autoscaling in foo/bar",
+				},
+				{
+					site: f.site("mysize"),
+					sizing: f.sizing().autoscaling(Autoscaling{
+						MinReplicas: 1,
+					}).sizing,
+					err: "target-cpu-utilization or target-memory-utilization is required for horizontal autoscaling in foo/bar",
+				},
+			},
+		},
+		{
+			name: "max replicas less than min replicas",
+			config: []Update{
+				{
+					key:    "foo/bar",
+					config: f.config("mysize", false).entry("autoscaling", "horizontal").entry("min-replicas", "5").entry("max-replicas", "4").entry("target-cpu-utilization", "70").configmap("bar", "foo"),
+				},
+			},
+			expectations: []Expectation{
+				{
+					site: f.site("mysize"),
+					sizing: f.sizing().autoscaling(Autoscaling{
+						MinReplicas:          5,
+						MaxReplicas:          4,
+						TargetCpuUtilization: 70,
+					}).sizing,
+					err: "max-replicas cannot be less than min-replicas in foo/bar",
+				},
+			},
+		},
 		{
 			name: "config changed",
 			config: []Update{
@@ -329,4 +481,9 @@ func (s *SizingBuilder) adaptorLimit(key string, value string) *SizingBuilder {
 	return s
 }
 
+func (s *SizingBuilder) autoscaling(autoscaling Autoscaling) *SizingBuilder {
+	s.sizing.Autoscaling = autoscaling
+	return s
+}
+
 var f factory
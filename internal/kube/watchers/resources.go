@@ -6,6 +6,7 @@ import (
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -33,6 +34,10 @@ type (
 	ServiceHandler   = Handler[*corev1.Service]
 	ServiceWatcher   = ResourceWatcher[*corev1.Service]
 
+	// autoscaling/v2
+	HorizontalPodAutoscalerHandler = Handler[*autoscalingv2.HorizontalPodAutoscaler]
+	HorizontalPodAutoscalerWatcher = ResourceWatcher[*autoscalingv2.HorizontalPodAutoscaler]
+
 	// networking/v1
 	IngressHandler = Handler[*networkingv1.Ingress]
 	IngressWatcher = ResourceWatcher[*networkingv1.Ingress]
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
+	autoscalingv2informer "k8s.io/client-go/informers/autoscaling/v2"
 	corev1informer "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/informers/internalinterfaces"
 	networkingv1informer "k8s.io/client-go/informers/networking/v1"
@@ -154,6 +156,22 @@ func (c *EventProcessor) HasTlsRoute() bool {
 	return resource.IsResourceAvailable(c.discoveryClient, resource.TlsRouteResource())
 }
 
+func (c *EventProcessor) HasTcpRoute() bool {
+	return resource.IsResourceAvailable(c.discoveryClient, resource.TcpRouteResource())
+}
+
+func (c *EventProcessor) HasIstioGateway() bool {
+	return resource.IsResourceAvailable(c.discoveryClient, resource.IstioGatewayResource())
+}
+
+func (c *EventProcessor) HasVirtualService() bool {
+	return resource.IsResourceAvailable(c.discoveryClient, resource.VirtualServiceResource())
+}
+
+func (c *EventProcessor) HasVerticalPodAutoscaler() bool {
+	return resource.IsResourceAvailable(c.discoveryClient, resource.VerticalPodAutoscalerResource())
+}
+
 func (c *EventProcessor) HasMultiKeyListener() bool {
 	return resource.IsResourceAvailable(c.discoveryClient, resource.MultiKeyListenerResource())
 }
@@ -397,6 +415,38 @@ func (c *EventProcessor) WatchTlsRoutes(options dynamicinformer.TweakListOptions
 	return c.WatchDynamic(resource.TlsRouteResource(), options, namespace, handler)
 }
 
+func (c *EventProcessor) WatchTcpRoutes(options dynamicinformer.TweakListOptionsFunc, namespace string, handler DynamicHandler) *DynamicWatcher {
+	if !c.HasTcpRoute() {
+		c.logger.Error("Cannot watch TCPRoutes; resource not installed")
+		return nil
+	}
+	return c.WatchDynamic(resource.TcpRouteResource(), options, namespace, handler)
+}
+
+func (c *EventProcessor) WatchIstioGateways(options dynamicinformer.TweakListOptionsFunc, namespace string, handler DynamicHandler) *DynamicWatcher {
+	if !c.HasIstioGateway() {
+		c.logger.Error("Cannot watch Istio Gateways; resource not installed")
+		return nil
+	}
+	return c.WatchDynamic(resource.IstioGatewayResource(), options, namespace, handler)
+}
+
+func (c *EventProcessor) WatchVirtualServices(options dynamicinformer.TweakListOptionsFunc, namespace string, handler DynamicHandler) *DynamicWatcher {
+	if !c.HasVirtualService() {
+		c.logger.Error("Cannot watch VirtualServices; resource not installed")
+		return nil
+	}
+	return c.WatchDynamic(resource.VirtualServiceResource(), options, namespace, handler)
+}
+
+func (c *EventProcessor) WatchVerticalPodAutoscalers(options dynamicinformer.TweakListOptionsFunc, namespace string, handler DynamicHandler) *DynamicWatcher {
+	if !c.HasVerticalPodAutoscaler() {
+		c.logger.Error("Cannot watch VerticalPodAutoscalers; resource not installed")
+		return nil
+	}
+	return c.WatchDynamic(resource.VerticalPodAutoscalerResource(), options, namespace, handler)
+}
+
 func (c *EventProcessor) WatchDynamic(resource schema.GroupVersionResource, options dynamicinformer.TweakListOptionsFunc, namespace string, handler DynamicHandler) *DynamicWatcher {
 	informer := dynamicinformer.NewFilteredDynamicInformer(
 		c.dynamicClient,
@@ -436,6 +486,16 @@ func (c *EventProcessor) WatchIngresses(options internalinterfaces.TweakListOpti
 	return addEventProcessorWatcher(c, handler, networkingv1.SchemeGroupVersion, informer)
 }
 
+func (c *EventProcessor) WatchHorizontalPodAutoscalers(options internalinterfaces.TweakListOptionsFunc, namespace string, handler HorizontalPodAutoscalerHandler) *HorizontalPodAutoscalerWatcher {
+	informer := autoscalingv2informer.NewFilteredHorizontalPodAutoscalerInformer(
+		c.client,
+		namespace,
+		c.resync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		options)
+	return addEventProcessorWatcher(c, handler, autoscalingv2.SchemeGroupVersion, informer)
+}
+
 func (c *EventProcessor) WatchRoutes(options routev1interfaces.TweakListOptionsFunc, namespace string, handler RouteHandler) *RouteWatcher {
 	if c.routeClient == nil {
 		return nil
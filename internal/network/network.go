@@ -1,8 +1,11 @@
 package network
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
 	"strings"
 
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
@@ -214,31 +217,36 @@ func ExtractSiteRecords(status NetworkStatusInfo) []v2alpha1.SiteRecord {
 						RemoteSiteId:   site,
 						RemoteSiteName: siteNames[site],
 						Operational:    strings.EqualFold(link.Status, "up"),
+						Cost:           link.LinkCost,
 					})
 				}
 			}
 			for _, connector := range router.Connectors {
 				if connector.Address != "" && connector.DestHost != "" {
-					address := connector.Address
-					service, ok := services[address]
+					protocol := BindingProtocol(connector.Protocol)
+					key := ServiceKey(connector.Address, protocol)
+					service, ok := services[key]
 					if !ok {
 						service = &v2alpha1.ServiceRecord{
-							RoutingKey: address,
+							RoutingKey: connector.Address,
+							Protocol:   protocol,
 						}
-						services[address] = service
+						services[key] = service
 					}
 					service.Connectors = append(service.Connectors, connector.DestHost)
 				}
 			}
 			for _, listener := range router.Listeners {
 				if listener.Address != "" && listener.Name != "" {
-					address := listener.Address
-					service, ok := services[address]
+					protocol := BindingProtocol(listener.Protocol)
+					key := ServiceKey(listener.Address, protocol)
+					service, ok := services[key]
 					if !ok {
 						service = &v2alpha1.ServiceRecord{
-							RoutingKey: address,
+							RoutingKey: listener.Address,
+							Protocol:   protocol,
 						}
-						services[address] = service
+						services[key] = service
 					}
 					service.Listeners = append(service.Listeners, listener.Name)
 				}
@@ -252,6 +260,32 @@ func ExtractSiteRecords(status NetworkStatusInfo) []v2alpha1.SiteRecord {
 	return records
 }
 
+// BindingProtocol normalises a listener or connector type, or the
+// protocol reported by the router, to the protocol family that must match
+// for a listener and connector to be paired. TCP is the default and is
+// returned as the empty string so that the site records only distinguish
+// services that use a different router adaptor.
+func BindingProtocol(protocol string) string {
+	switch strings.ToLower(protocol) {
+	case "udp":
+		return "udp"
+	case "http", "http1":
+		return "http"
+	case "http2":
+		return "http2"
+	}
+	return ""
+}
+
+// ServiceKey returns the key under which services for the address and
+// normalised protocol are recorded.
+func ServiceKey(address string, protocol string) string {
+	if protocol == "" {
+		return address
+	}
+	return protocol + ":" + address
+}
+
 func GetLinkRecordsForSite(siteId string, network []v2alpha1.SiteRecord) []v2alpha1.LinkRecord {
 	for _, siteRecord := range network {
 		if siteRecord.Id == siteId {
@@ -261,6 +295,30 @@ func GetLinkRecordsForSite(siteId string, network []v2alpha1.SiteRecord) []v2alp
 	return nil
 }
 
+// HasMatchingPairForProtocol is like HasMatchingPair but only counts
+// listeners and connectors for the address that use the same protocol,
+// so that a TCP listener is not paired with a UDP connector.
+func HasMatchingPairForProtocol(networkStatus NetworkStatusInfo, address string, protocol string) bool {
+	protocol = BindingProtocol(protocol)
+	listeners := false
+	connectors := false
+	for _, site := range networkStatus.SiteStatus {
+		for _, router := range site.RouterStatus {
+			for _, listener := range router.Listeners {
+				if listener.Address == address && BindingProtocol(listener.Protocol) == protocol {
+					listeners = true
+				}
+			}
+			for _, connector := range router.Connectors {
+				if connector.Address == address && BindingProtocol(connector.Protocol) == protocol {
+					connectors = true
+				}
+			}
+		}
+	}
+	return listeners && connectors
+}
+
 func HasMatchingPair(networkStatus NetworkStatusInfo, address string) bool {
 	for _, addressInfo := range networkStatus.Addresses {
 		if addressInfo.Name == address {
@@ -269,3 +327,115 @@ func HasMatchingPair(networkStatus NetworkStatusInfo, address string) bool {
 	}
 	return false
 }
+
+// SiteCosts returns the lowest total link cost from the given site to
+// each site that can be reached from it over operational links. Links are
+// treated as bidirectional and a link without a cost counts as 1.
+func SiteCosts(siteId string, network []v2alpha1.SiteRecord) map[string]uint64 {
+	neighbours := map[string]map[string]uint64{}
+	addEdge := func(from string, to string, cost uint64) {
+		if _, ok := neighbours[from]; !ok {
+			neighbours[from] = map[string]uint64{}
+		}
+		if existing, ok := neighbours[from][to]; !ok || cost < existing {
+			neighbours[from][to] = cost
+		}
+	}
+	for _, site := range network {
+		for _, link := range site.Links {
+			if !link.Operational || link.RemoteSiteId == "" {
+				continue
+			}
+			cost := link.Cost
+			if cost == 0 {
+				cost = 1
+			}
+			addEdge(site.Id, link.RemoteSiteId, cost)
+			addEdge(link.RemoteSiteId, site.Id, cost)
+		}
+	}
+
+	costs := map[string]uint64{siteId: 0}
+	visited := map[string]bool{}
+	for {
+		current := ""
+		var lowest uint64 = math.MaxUint64
+		for id, cost := range costs {
+			if !visited[id] && (cost < lowest || (cost == lowest && id < current)) {
+				current = id
+				lowest = cost
+			}
+		}
+		if current == "" {
+			return costs
+		}
+		visited[current] = true
+		for next, cost := range neighbours[current] {
+			if existing, ok := costs[next]; !ok || lowest+cost < existing {
+				costs[next] = lowest + cost
+			}
+		}
+	}
+}
+
+// OrderRoutingKeysByCost returns the routing keys that have at least one
+// connector in the network, ordered by the lowest total link cost from the
+// given site to a site hosting one of those connectors. Keys at the same
+// cost keep the order in which they were supplied. Keys whose connectors
+// are only on sites with no known route from the given site are placed
+// last.
+func OrderRoutingKeysByCost(siteId string, network []v2alpha1.SiteRecord, routingKeys []string) []string {
+	siteCosts := SiteCosts(siteId, network)
+	keyCosts := map[string]uint64{}
+	for _, site := range network {
+		for _, service := range site.Services {
+			if service.Protocol != "" || len(service.Connectors) == 0 || !slices.Contains(routingKeys, service.RoutingKey) {
+				continue
+			}
+			cost, ok := siteCosts[site.Id]
+			if !ok {
+				cost = math.MaxUint64
+			}
+			if existing, ok := keyCosts[service.RoutingKey]; !ok || cost < existing {
+				keyCosts[service.RoutingKey] = cost
+			}
+		}
+	}
+	var ordered []string
+	for _, key := range routingKeys {
+		if _, ok := keyCosts[key]; ok && !slices.Contains(ordered, key) {
+			ordered = append(ordered, key)
+		}
+	}
+	slices.SortStableFunc(ordered, func(a string, b string) int {
+		return cmp.Compare(keyCosts[a], keyCosts[b])
+	})
+	return ordered
+}
+
+// ServicesWithoutConnectors returns the keys, as given by ServiceKey, of
+// the services that have at least one listener somewhere in the network
+// but no connector on any site. The keys are returned sorted.
+func ServicesWithoutConnectors(network []v2alpha1.SiteRecord) []string {
+	listeners := map[string]bool{}
+	connectors := map[string]bool{}
+	for _, site := range network {
+		for _, service := range site.Services {
+			key := ServiceKey(service.RoutingKey, service.Protocol)
+			if len(service.Listeners) > 0 {
+				listeners[key] = true
+			}
+			if len(service.Connectors) > 0 {
+				connectors[key] = true
+			}
+		}
+	}
+	var unmatched []string
+	for key := range listeners {
+		if !connectors[key] {
+			unmatched = append(unmatched, key)
+		}
+	}
+	slices.Sort(unmatched)
+	return unmatched
+}
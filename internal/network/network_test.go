@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"gotest.tools/v3/assert"
 )
 
@@ -208,3 +209,193 @@ func TestHasMatchingPair(t *testing.T) {
 		assert.Equal(t, scenario.expectedMatch, HasMatchingPair(networkStatus, scenario.address))
 	}
 }
+
+func TestHasMatchingPairForProtocol(t *testing.T) {
+	networkStatus := NetworkStatusInfo{
+		Addresses: []AddressInfo{
+			{
+				Name:           "dns",
+				Protocol:       "tcp",
+				ListenerCount:  1,
+				ConnectorCount: 1,
+			},
+		},
+		SiteStatus: []SiteStatusInfo{
+			{
+				RouterStatus: []RouterStatusInfo{
+					{
+						Listeners: []ListenerInfo{
+							{Name: "dns", Address: "dns", Protocol: "udp"},
+							{Name: "syslog", Address: "syslog", Protocol: "udp"},
+							{Name: "web", Address: "web", Protocol: "http1"},
+							{Name: "db", Address: "db", Protocol: "tcp"},
+						},
+						Connectors: []ConnectorInfo{
+							{DestHost: "10.0.0.1", Address: "dns", Protocol: "tcp"},
+							{DestHost: "10.0.0.2", Address: "syslog", Protocol: "udp"},
+							{DestHost: "10.0.0.3", Address: "web", Protocol: "http1"},
+							{DestHost: "10.0.0.4", Address: "db"},
+						},
+					},
+				},
+			},
+		},
+	}
+	scenarios := []struct {
+		address       string
+		protocol      string
+		expectedMatch bool
+	}{
+		{
+			address:       "dns",
+			protocol:      "tcp",
+			expectedMatch: false,
+		},
+		{
+			address:       "db",
+			protocol:      "tcp",
+			expectedMatch: true,
+		},
+		{
+			address:       "db",
+			protocol:      "",
+			expectedMatch: true,
+		},
+		{
+			address:       "dns",
+			protocol:      "udp",
+			expectedMatch: false,
+		},
+		{
+			address:       "syslog",
+			protocol:      "udp",
+			expectedMatch: true,
+		},
+		{
+			address:       "invalid-address",
+			protocol:      "udp",
+			expectedMatch: false,
+		},
+		{
+			address:       "web",
+			protocol:      "http",
+			expectedMatch: true,
+		},
+		{
+			address:       "web",
+			protocol:      "http2",
+			expectedMatch: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		assert.Equal(t, scenario.expectedMatch, HasMatchingPairForProtocol(networkStatus, scenario.address, scenario.protocol))
+	}
+}
+
+func TestOrderRoutingKeysByCost(t *testing.T) {
+	// a - b - c, with a direct but expensive link a - c
+	network := []v2alpha1.SiteRecord{
+		{
+			Id: "a",
+			Links: []v2alpha1.LinkRecord{
+				{Name: "to-b", RemoteSiteId: "b", Operational: true, Cost: 1},
+				{Name: "to-c", RemoteSiteId: "c", Operational: true, Cost: 10},
+			},
+			Services: []v2alpha1.ServiceRecord{
+				{RoutingKey: "listener-only", Listeners: []string{"l1"}},
+			},
+		},
+		{
+			Id: "b",
+			Links: []v2alpha1.LinkRecord{
+				{Name: "to-c", RemoteSiteId: "c", Operational: true, Cost: 1},
+				{Name: "to-d", RemoteSiteId: "d", Operational: false, Cost: 1},
+			},
+			Services: []v2alpha1.ServiceRecord{
+				{RoutingKey: "db-b", Connectors: []string{"10.0.0.2"}},
+			},
+		},
+		{
+			Id: "c",
+			Services: []v2alpha1.ServiceRecord{
+				{RoutingKey: "db-c", Connectors: []string{"10.0.0.3"}},
+				{RoutingKey: "db-c2", Connectors: []string{"10.0.0.4"}},
+				{RoutingKey: "dns", Protocol: "udp", Connectors: []string{"10.0.0.5"}},
+			},
+		},
+		{
+			Id: "d",
+			Services: []v2alpha1.ServiceRecord{
+				{RoutingKey: "db-d", Connectors: []string{"10.0.0.6"}},
+			},
+		},
+	}
+	costs := SiteCosts("a", network)
+	assert.DeepEqual(t, costs, map[string]uint64{"a": 0, "b": 1, "c": 2})
+
+	scenarios := []struct {
+		name        string
+		siteId      string
+		routingKeys []string
+		expected    []string
+	}{
+		{
+			name:        "nearest first",
+			siteId:      "a",
+			routingKeys: []string{"db-c", "db-b"},
+			expected:    []string{"db-b", "db-c"},
+		},
+		{
+			name:        "ties keep the supplied order",
+			siteId:      "a",
+			routingKeys: []string{"db-c2", "db-b", "db-c"},
+			expected:    []string{"db-b", "db-c2", "db-c"},
+		},
+		{
+			name:        "unknown route placed last",
+			siteId:      "a",
+			routingKeys: []string{"db-d", "db-c"},
+			expected:    []string{"db-c", "db-d"},
+		},
+		{
+			name:        "keys without connectors are unreachable",
+			siteId:      "a",
+			routingKeys: []string{"listener-only", "dns", "missing"},
+			expected:    nil,
+		},
+		{
+			name:        "local connectors are nearest",
+			siteId:      "c",
+			routingKeys: []string{"db-b", "db-c"},
+			expected:    []string{"db-c", "db-b"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			assert.DeepEqual(t, OrderRoutingKeysByCost(scenario.siteId, network, scenario.routingKeys), scenario.expected)
+		})
+	}
+}
+
+func TestServicesWithoutConnectors(t *testing.T) {
+	network := []v2alpha1.SiteRecord{
+		{
+			Id: "a",
+			Services: []v2alpha1.ServiceRecord{
+				{RoutingKey: "backend", Listeners: []string{"backend"}},
+				{RoutingKey: "frontend", Listeners: []string{"frontend"}},
+				{RoutingKey: "dns", Protocol: "udp", Listeners: []string{"dns"}},
+			},
+		},
+		{
+			Id: "b",
+			Services: []v2alpha1.ServiceRecord{
+				{RoutingKey: "backend", Connectors: []string{"10.0.0.1"}},
+				{RoutingKey: "dns", Connectors: []string{"10.0.0.2"}},
+				{RoutingKey: "unused", Connectors: []string{"10.0.0.3"}},
+			},
+		},
+	}
+	assert.DeepEqual(t, ServicesWithoutConnectors(network), []string{"frontend", "udp:dns"})
+	assert.Assert(t, ServicesWithoutConnectors(nil) == nil)
+}
@@ -71,6 +71,7 @@ type ListenerInfo struct {
 type ConnectorInfo struct {
 	DestHost string `json:"destHost,omitempty"`
 	DestPort string `json:"destPort,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
 	Address  string `json:"address,omitempty"`
 	Process  string `json:"process,omitempty"`
 	Target   string `json:"target,omitempty"`
@@ -1,7 +1,8 @@
 package fs
 
 import (
-	"fmt"
+	"os"
+
 	"github.com/skupperproject/skupper/internal/cmd/skupper/common"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 )
@@ -35,23 +36,36 @@ func (s *RouterAccessHandler) Add(resource v2alpha1.RouterAccess) error {
 	return nil
 }
 
-func (s *RouterAccessHandler) Get(name string) (*v2alpha1.RouterAccess, error) {
+func (s *RouterAccessHandler) Get(name string, opts GetOptions) (*v2alpha1.RouterAccess, error) {
 	var context v2alpha1.RouterAccess
 	fileName := name + ".yaml"
 
-	// First read from runtime directory, where output is found after bootstrap
-	// has run.  If no runtime sites try and display configured sites
-	err, file := s.ReadFile(s.pathProvider.GetRuntimeNamespace(), fileName, common.RouterAccesses)
-	if err != nil {
-		fmt.Println("Site not initialized yet")
-		err, file = s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.RouterAccesses)
+	if opts.RuntimeFirst == true {
+		// First read from runtime directory, where output is found after bootstrap
+		// has run.  If no runtime router accesses try and display configured ones
+		err, file := s.ReadFile(s.pathProvider.GetRuntimeNamespace(), fileName, common.RouterAccesses)
 		if err != nil {
-			return nil, err
+			if opts.LogWarning {
+				os.Stderr.WriteString("Site not initialized yet\n")
+			}
+			err, file = s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.RouterAccesses)
+			if err != nil {
+				return nil, err
+			}
 		}
-	}
 
-	if err = s.DecodeYaml(file, &context); err != nil {
-		return nil, err
+		if err = s.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
+	} else {
+		// read from input directory to get latest config
+		err, file := s.ReadFile(s.pathProvider.GetNamespace(), fileName, common.RouterAccesses)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.DecodeYaml(file, &context); err != nil {
+			return nil, err
+		}
 	}
 
 	return &context, nil
@@ -83,3 +97,33 @@ func (s *RouterAccessHandler) Update(name string) (*v2alpha1.RouterAccess, error
 
 	return &context, nil
 }
+
+func (s *RouterAccessHandler) List() ([]*v2alpha1.RouterAccess, error) {
+	var routerAccesses []*v2alpha1.RouterAccess
+
+	// First read from runtime directory, where output is found after bootstrap
+	// has run.  If no runtime router accesses try and display configured ones
+	path := s.pathProvider.GetRuntimeNamespace()
+	err, files := s.ReadDir(path, common.RouterAccesses)
+	if err != nil {
+		os.Stderr.WriteString("Site not initialized yet\n")
+		path = s.pathProvider.GetNamespace()
+		err, files = s.ReadDir(path, common.RouterAccesses)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range files {
+		err, ra := s.ReadFile(path, file.Name(), common.RouterAccesses)
+		if err != nil {
+			return nil, err
+		}
+		var context v2alpha1.RouterAccess
+		if err = s.DecodeYaml(ra, &context); err != nil {
+			return nil, err
+		}
+		routerAccesses = append(routerAccesses, &context)
+	}
+	return routerAccesses, nil
+}
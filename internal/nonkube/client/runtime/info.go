@@ -17,7 +17,7 @@ func GetLocalRouterAddress(namespace string) (string, error) {
 
 func GetLocalRouterPort(namespace string) (int, error) {
 	client := fs.NewRouterAccessHandler(namespace)
-	ra, err := client.Get("skupper-local")
+	ra, err := client.Get("skupper-local", fs.GetOptions{RuntimeFirst: true, LogWarning: true})
 	if err != nil {
 		return 0, fmt.Errorf("unable to determine router port: %w", err)
 	}
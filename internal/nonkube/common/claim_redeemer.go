@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/skupperproject/skupper/internal/site"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 
 	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
@@ -25,7 +26,19 @@ func RedeemClaims(siteState *api.SiteState) error {
 
 	logger := NewLogger()
 	for name, claim := range siteState.Claims {
-		decoder, err := RedeemAccessToken(claim, siteState.Site.Name)
+		if claim.Namespace == "" {
+			claim.Namespace = siteState.GetNamespace()
+		}
+		proxy, err := claimProxyURL(claim, siteState)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to redeem claim %s: %w", name, err))
+			logger.Error("RedeemClaims: invalid proxy configuration for claim",
+				slog.String("name", name),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		decoder, err := RedeemAccessToken(claim, siteState.Site.Name, siteState.Site.Name, proxy)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("failed to redeem claim %s: %w", name, err))
 			logger.Error("RedeemClaims: failed to redeem claim",
@@ -50,12 +63,36 @@ func RedeemClaims(siteState *api.SiteState) error {
 	return nil
 }
 
-// Redeem logic that populates siteState.Secrets and siteState.Links
-func RedeemAccessToken(claim *skupperv2alpha1.AccessToken, subject string) (*LinkDecoder, error) {
+// claimProxyURL returns the proxy through which the claim should be
+// redeemed, as configured on the claim itself or else on the site. The
+// proxy configuration secret must be one of the site's secrets.
+func claimProxyURL(claim *skupperv2alpha1.AccessToken, siteState *api.SiteState) (*url.URL, error) {
+	name := claim.Spec.GetProxyConfiguration()
+	if name == "" && siteState.Site != nil {
+		name = siteState.Site.Spec.GetProxyConfiguration()
+	}
+	if name == "" {
+		return nil, nil
+	}
+	secret, ok := siteState.Secrets[name]
+	if !ok {
+		return nil, fmt.Errorf("proxy configuration secret %q not found", name)
+	}
+	return site.ProxyURL(secret)
+}
+
+// Redeem logic that populates siteState.Secrets and siteState.Links.
+// The site name, if known, and the claim's namespace are sent so that
+// they can be recorded in the redemption history of the grant. If proxy is
+// not nil, the token is redeemed through that HTTP proxy.
+func RedeemAccessToken(claim *skupperv2alpha1.AccessToken, siteName string, subject string, proxy *url.URL) (*LinkDecoder, error) {
 	if claim.Spec.Ca == "" {
 		return nil, fmt.Errorf("token does not have a CA")
 	}
 	transport := &http.Transport{}
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
 	caPool := x509.NewCertPool()
 	caPool.AppendCertsFromPEM([]byte(claim.Spec.Ca))
 	transport.TLSClientConfig = &tls.Config{
@@ -82,8 +119,17 @@ func RedeemAccessToken(claim *skupperv2alpha1.AccessToken, subject string) (*Lin
 	}
 	request.Header.Add("name", claim.Name)
 	request.Header.Add("subject", subject)
+	if siteName != "" {
+		request.Header.Add("site-name", siteName)
+	}
+	if claim.Namespace != "" {
+		request.Header.Add("site-namespace", claim.Namespace)
+	}
 	response, err := client.Do(request)
 	if err != nil {
+		if proxy != nil {
+			return nil, site.ProxyError(proxy, err)
+		}
 		return nil, err
 	}
 	if response.StatusCode != http.StatusOK {
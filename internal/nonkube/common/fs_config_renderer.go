@@ -375,6 +375,11 @@ func (c *FileSystemConfigurationRenderer) createTlsCertificates(siteState *api.S
 	writeSecretFiles := func(basePath string, secret *corev1.Secret) error {
 		return writeSecretFilesIgnore(basePath, secret, false)
 	}
+	// a key algorithm set on the certificate takes precedence over
+	// the site wide default
+	keyAlgorithm := func(certificate *v2alpha1.Certificate) string {
+		return certs.KeyAlgorithmFromSettings(certificate.Spec.Settings, siteState.Site.Spec.Settings)
+	}
 	// create certificate authorities first
 	outputPath := c.GetOutputPath(siteState)
 	for name, certificate := range siteState.Certificates {
@@ -384,7 +389,7 @@ func (c *FileSystemConfigurationRenderer) createTlsCertificates(siteState *api.S
 		var secret *corev1.Secret
 		var ok bool
 		if secret, ok = siteState.Secrets[name]; !ok {
-			secret, err = certs.GenerateSecret(name, certificate.Spec.Subject, nil, 0, nil)
+			secret, err = certs.GenerateSecretWithKeyAlgorithm(name, certificate.Spec.Subject, nil, 0, nil, keyAlgorithm(certificate))
 			if err != nil {
 				return err
 			}
@@ -421,7 +426,7 @@ func (c *FileSystemConfigurationRenderer) createTlsCertificates(siteState *api.S
 		if certificate.Spec.Client {
 			purpose = "client"
 			if secret, ok = siteState.Secrets[name]; !ok {
-				secret, err = certs.GenerateSecret(name, certificate.Spec.Subject, certificate.Spec.Hosts, 0, caSecret)
+				secret, err = certs.GenerateSecretWithKeyAlgorithm(name, certificate.Spec.Subject, certificate.Spec.Hosts, 0, caSecret, keyAlgorithm(certificate))
 				if err != nil {
 					return err
 				}
@@ -435,7 +440,7 @@ func (c *FileSystemConfigurationRenderer) createTlsCertificates(siteState *api.S
 		} else if certificate.Spec.Server {
 			purpose = "server"
 			if secret, ok = siteState.Secrets[name]; !ok {
-				secret, err = certs.GenerateSecret(name, certificate.Spec.Subject, certificate.Spec.Hosts, 0, caSecret)
+				secret, err = certs.GenerateSecretWithKeyAlgorithm(name, certificate.Spec.Subject, certificate.Spec.Hosts, 0, caSecret, keyAlgorithm(certificate))
 				if err != nil {
 					return err
 				}
@@ -94,6 +94,37 @@ func testFileSystemConfigurationRendererRender(t *testing.T, addInputCertificate
 	}
 }
 
+func TestFileSystemConfigurationRenderer_KeyAlgorithm(t *testing.T) {
+	ss := fakeSiteState()
+	ss.Site.Spec.Settings = map[string]string{"keyAlgorithm": certs.KeyAlgorithmECDSAP256}
+	ss.CreateLinkAccessesCertificates()
+	ss.CreateBridgeCertificates()
+	for name, certificate := range ss.Certificates {
+		if name == "link-access-one" {
+			certificate.Spec.Settings = map[string]string{"keyAlgorithm": certs.KeyAlgorithmEd25519}
+		}
+	}
+	customOutputPath := t.TempDir()
+	fsConfigRenderer := new(FileSystemConfigurationRenderer)
+	fsConfigRenderer.customOutputPath = customOutputPath
+	assert.Assert(t, fsConfigRenderer.Render(ss))
+	outputPath := fsConfigRenderer.GetOutputPath(ss)
+
+	expected := map[string]string{
+		path.Join(string(api.IssuersPath), "skupper-site-ca"):               certs.KeyAlgorithmECDSAP256,
+		path.Join(string(api.CertificatesPath), "client-link-access-one"):   certs.KeyAlgorithmECDSAP256,
+		path.Join(string(api.CertificatesPath), "listener-one-credentials"): certs.KeyAlgorithmECDSAP256,
+		path.Join(string(api.CertificatesPath), "link-access-one"):          certs.KeyAlgorithmEd25519,
+	}
+	for certPath, algorithm := range expected {
+		data, err := os.ReadFile(path.Join(outputPath, certPath, "tls.crt"))
+		assert.Assert(t, err)
+		cert, err := certs.DecodeCertificate(data)
+		assert.Assert(t, err)
+		assert.Equal(t, certs.KeyAlgorithmOf(cert), algorithm, certPath)
+	}
+}
+
 func compareCertificates(t *testing.T, customOutputPath string) {
 	caPath := path.Join(customOutputPath, string(api.IssuersPath), "skupper-site-ca")
 	serverPath := path.Join(customOutputPath, string(api.CertificatesPath), "link-access-one")
@@ -196,6 +196,19 @@ func LoadRouterConfig(namespace string) (*qdr.RouterConfig, error) {
 	return &routerConfig, nil
 }
 
+func SaveRouterConfig(namespace string, routerConfig *qdr.RouterConfig) error {
+	routerConfigJson, err := qdr.MarshalRouterConfig(*routerConfig)
+	if err != nil {
+		return fmt.Errorf("unable to marshal router configuration: %w", err)
+	}
+	routerConfigPath := api.GetInternalOutputPath(namespace, api.RouterConfigPath)
+	routerConfigFile := path.Join(routerConfigPath, "skrouterd.json")
+	if err = os.WriteFile(routerConfigFile, []byte(routerConfigJson), 0644); err != nil {
+		return fmt.Errorf("unable to write router configuration: %w", err)
+	}
+	return nil
+}
+
 func LoadCurrentSiteState(namespace string) (*api.SiteState, error) {
 	runtimePath := api.GetInternalOutputPath(namespace, api.RuntimeSiteStatePath)
 	loader := &FileSystemSiteStateLoader{
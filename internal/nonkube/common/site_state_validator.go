@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"slices"
 
+	"github.com/skupperproject/skupper/internal/certs"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 	corev1 "k8s.io/api/core/v1"
@@ -13,6 +14,7 @@ import (
 
 var (
 	validLinkAccessRoles = []string{"edge", "inter-router"}
+	validBindingTypes    = []string{"", "tcp", "udp", "http", "http2"}
 	rfc1123Regex         = regexp.MustCompile("^[a-z0-9]([-a-z0-9]*[a-z0-9])?$")
 	hostnameRfc1123Regex = regexp.MustCompile(`^[a-z0-9]+([-.]{1}[a-z0-9]+)*$`)
 )
@@ -71,6 +73,9 @@ func (s *SiteStateValidator) validateSite(site *v2alpha1.Site) error {
 	if site.Spec.Edge && site.Spec.HA {
 		return fmt.Errorf("invalid site %q: edge sites cannot have HA enabled", site.Name)
 	}
+	if err := certs.ValidateKeyAlgorithm(certs.KeyAlgorithmFromSettings(site.Spec.Settings)); err != nil {
+		return fmt.Errorf("invalid site %q: %w", site.Name, err)
+	}
 	return nil
 }
 
@@ -135,7 +140,8 @@ func (s *SiteStateValidator) validateGrants(grants map[string]*v2alpha1.AccessGr
 }
 
 func (s *SiteStateValidator) validateListeners(listeners map[string]*v2alpha1.Listener) error {
-	hostPorts := map[string][]int{}
+	// tcp and udp listeners may share a port, so ports are tracked per protocol
+	hostPorts := map[corev1.Protocol]map[string][]int{}
 	for name, listener := range listeners {
 		if err := ValidateName(listener.Name); err != nil {
 			return fmt.Errorf("invalid listener name: %w", err)
@@ -148,13 +154,20 @@ func (s *SiteStateValidator) validateListeners(listeners map[string]*v2alpha1.Li
 		if ip == nil && !validHostname {
 			return fmt.Errorf("invalid listener host: %s - a valid IP address or hostname is expected (listener: %q)", listener.Spec.Host, name)
 		}
-		if slices.Contains(hostPorts[listener.Spec.Host], listener.Spec.Port) {
+		if !slices.Contains(validBindingTypes, listener.Spec.Type) {
+			return fmt.Errorf("invalid listener type: %s - valid types are tcp, udp, http and http2 (listener: %q)", listener.Spec.Type, name)
+		}
+		protocol := listener.Protocol()
+		if hostPorts[protocol] == nil {
+			hostPorts[protocol] = map[string][]int{}
+		}
+		if slices.Contains(hostPorts[protocol][listener.Spec.Host], listener.Spec.Port) {
 			return fmt.Errorf("port %d is already mapped for host %q (listener: %q)", listener.Spec.Port, listener.Spec.Host, name)
 		}
 		if listener.Spec.RoutingKey == "" {
 			return fmt.Errorf("routingKey is missing for listener: %s", listener.Name)
 		}
-		hostPorts[listener.Spec.Host] = append(hostPorts[listener.Spec.Host], listener.Spec.Port)
+		hostPorts[protocol][listener.Spec.Host] = append(hostPorts[protocol][listener.Spec.Host], listener.Spec.Port)
 	}
 	return nil
 }
@@ -175,6 +188,9 @@ func (s *SiteStateValidator) validateConnectors(connectors map[string]*v2alpha1.
 		if connector.Spec.RoutingKey == "" {
 			return fmt.Errorf("routingKey is missing for connector: %s", connector.Name)
 		}
+		if !slices.Contains(validBindingTypes, connector.Spec.Type) {
+			return fmt.Errorf("invalid connector type: %s - valid types are tcp, udp, http and http2 (connector: %q)", connector.Spec.Type, connector.Name)
+		}
 	}
 	return nil
 }
@@ -183,6 +199,9 @@ func (s *SiteStateValidator) validateMultiKeyListeners(multiKeyListeners map[str
 	// collect host:port pairs already used by listeners
 	hostPorts := map[string][]int{}
 	for _, listener := range listeners {
+		if listener.Protocol() != corev1.ProtocolTCP {
+			continue
+		}
 		hostPorts[listener.Spec.Host] = append(hostPorts[listener.Spec.Host], listener.Spec.Port)
 	}
 	for name, mkl := range multiKeyListeners {
@@ -201,16 +220,28 @@ func (s *SiteStateValidator) validateMultiKeyListeners(multiKeyListeners map[str
 			return fmt.Errorf("port %d is already mapped for host %q (multikeylistener: %q)", mkl.Spec.Port, mkl.Spec.Host, name)
 		}
 		hostPorts[mkl.Spec.Host] = append(hostPorts[mkl.Spec.Host], mkl.Spec.Port)
-		if mkl.Spec.Strategy.Priority == nil && mkl.Spec.Strategy.Weighted == nil {
-			return fmt.Errorf("invalid multikeylistener: %s - either strategy.priority or strategy.weighted is required", mkl.Name)
-		} else if mkl.Spec.Strategy.Priority != nil && mkl.Spec.Strategy.Weighted != nil {
-			return fmt.Errorf("invalid multikeylistener: %s - only one of strategy.priority or strategy.weighted must be defined", mkl.Name)
+		strategies := 0
+		for _, defined := range []bool{mkl.Spec.Strategy.Priority != nil, mkl.Spec.Strategy.Weighted != nil, mkl.Spec.Strategy.Closest != nil} {
+			if defined {
+				strategies++
+			}
 		}
+		if strategies == 0 {
+			return fmt.Errorf("invalid multikeylistener: %s - one of strategy.priority, strategy.weighted or strategy.closest is required", mkl.Name)
+		} else if strategies > 1 {
+			return fmt.Errorf("invalid multikeylistener: %s - only one of strategy.priority, strategy.weighted or strategy.closest must be defined", mkl.Name)
+		}
+		var orderedKeys []string
 		if mkl.Spec.Strategy.Priority != nil {
-			if len(mkl.Spec.Strategy.Priority.RoutingKeys) == 0 {
+			orderedKeys = mkl.Spec.Strategy.Priority.RoutingKeys
+		} else if mkl.Spec.Strategy.Closest != nil {
+			orderedKeys = mkl.Spec.Strategy.Closest.RoutingKeys
+		}
+		if mkl.Spec.Strategy.Priority != nil || mkl.Spec.Strategy.Closest != nil {
+			if len(orderedKeys) == 0 {
 				return fmt.Errorf("invalid multikeylistener: %s - routingKeys must not be empty", mkl.Name)
 			}
-			for _, key := range mkl.Spec.Strategy.Priority.RoutingKeys {
+			for _, key := range orderedKeys {
 				if key == "" {
 					return fmt.Errorf("invalid multikeylistener: %s - routingKey must not be empty", mkl.Name)
 				}
@@ -231,7 +262,9 @@ func (s *SiteStateValidator) validateMultiKeyListeners(multiKeyListeners map[str
 		}
 		if currentSiteState != nil {
 			if curMkl, ok := currentSiteState.MultiKeyListeners[name]; ok {
-				if (curMkl.Spec.Strategy.Priority != nil && mkl.Spec.Strategy.Priority == nil) || (curMkl.Spec.Strategy.Weighted != nil && mkl.Spec.Strategy.Weighted == nil) {
+				if (curMkl.Spec.Strategy.Priority != nil && mkl.Spec.Strategy.Priority == nil) ||
+					(curMkl.Spec.Strategy.Weighted != nil && mkl.Spec.Strategy.Weighted == nil) ||
+					(curMkl.Spec.Strategy.Closest != nil && mkl.Spec.Strategy.Closest == nil) {
 					return fmt.Errorf("invalid multikeylistener: %s - strategy cannot be changed", mkl.Name)
 				}
 			}
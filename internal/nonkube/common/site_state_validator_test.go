@@ -35,6 +35,21 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 			valid:         false,
 			errorContains: "edge sites cannot have HA enabled",
 		},
+		{
+			info: "invalid-site-key-algorithm",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.Settings = map[string]string{"keyAlgorithm": "rsa1024"}
+			}),
+			valid:         false,
+			errorContains: "unsupported key algorithm \"rsa1024\"",
+		},
+		{
+			info: "valid-site-key-algorithm",
+			siteState: customize(func(siteState *api.SiteState) {
+				siteState.Site.Spec.Settings = map[string]string{"keyAlgorithm": "ecdsa-p384"}
+			}),
+			valid: true,
+		},
 		{
 			info: "valid-site-edge-without-ha",
 			siteState: customize(func(siteState *api.SiteState) {
@@ -184,6 +199,26 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 			valid:         false,
 			errorContains: "is already mapped for host",
 		},
+		{
+			info: "valid-listener-udp-shares-port-with-tcp",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, listener := range siteState.Listeners {
+					listener.Spec.Host = "1.2.3.4"
+				}
+				siteState.Listeners["listener-one"].Spec.Type = "udp"
+			}),
+			valid: true,
+		},
+		{
+			info: "invalid-listener-type",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, listener := range siteState.Listeners {
+					listener.Spec.Type = "sctp"
+				}
+			}),
+			valid:         false,
+			errorContains: "invalid listener type: ",
+		},
 		{
 			info: "invalid-connector-name",
 			siteState: customize(func(siteState *api.SiteState) {
@@ -296,7 +331,7 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 				}
 			}),
 			valid:         false,
-			errorContains: "either strategy.priority or strategy.weighted is required",
+			errorContains: "one of strategy.priority, strategy.weighted or strategy.closest is required",
 		},
 		{
 			info: "invalid-multikeylistener-too-many-strategies",
@@ -307,7 +342,20 @@ func TestSiteStateValidator_Validate(t *testing.T) {
 				}
 			}),
 			valid:         false,
-			errorContains: "only one of strategy.priority or strategy.weighted must be defined",
+			errorContains: "only one of strategy.priority, strategy.weighted or strategy.closest must be defined",
+		},
+		{
+			info: "valid-multikeylistener-closest",
+			siteState: customize(func(siteState *api.SiteState) {
+				for _, mkl := range siteState.MultiKeyListeners {
+					mkl.Spec.Strategy.Priority = nil
+					mkl.Spec.Strategy.Weighted = nil
+					mkl.Spec.Strategy.Closest = &v2alpha1.ClosestStrategySpec{
+						RoutingKeys: []string{"db-east", "db-west"},
+					}
+				}
+			}),
+			valid: true,
 		},
 		{
 			info: "invalid-multikeylistener-empty-routing-keys",
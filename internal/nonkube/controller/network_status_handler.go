@@ -10,6 +10,7 @@ import (
 
 	"github.com/skupperproject/skupper/internal/network"
 	"github.com/skupperproject/skupper/internal/nonkube/common"
+	"github.com/skupperproject/skupper/internal/site"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
 	corev1 "k8s.io/api/core/v1"
@@ -78,7 +79,9 @@ func (n *NetworkStatusHandler) updateRuntimeSiteState(networkStatusInfo network.
 		return
 	}
 	delete(siteState.ConfigMaps, "skupper-network-status")
-	siteState.UpdateStatus(networkStatusInfo)
+	if siteState.UpdateStatus(networkStatusInfo) {
+		n.updateRouterConfig(siteState)
+	}
 	if ready {
 		siteState.Site.SetRunning(v2alpha1.ReadyCondition())
 	} else {
@@ -91,6 +94,27 @@ func (n *NetworkStatusHandler) updateRuntimeSiteState(networkStatusInfo network.
 	n.logger.Debug("Runtime site state updated")
 }
 
+// updateRouterConfig renders the routing keys of MultiKeyListeners with the
+// closest strategy in the order computed from the current network status,
+// so that the router picks up changes to link costs.
+func (n *NetworkStatusHandler) updateRouterConfig(siteState *api.SiteState) {
+	routerConfig, err := common.LoadRouterConfig(n.Namespace)
+	if err != nil {
+		n.logger.Warn("Error loading router config", slog.Any("error", err))
+		return
+	}
+	for _, mkl := range siteState.MultiKeyListeners {
+		if mkl.Spec.Strategy.Closest != nil {
+			site.UpdateListenerAddressesForMultiKeyListener(mkl, &routerConfig.Bridges)
+		}
+	}
+	if err = common.SaveRouterConfig(n.Namespace, routerConfig); err != nil {
+		n.logger.Error("Error saving router config", slog.Any("error", err))
+		return
+	}
+	n.logger.Debug("Router config updated with closest routing keys")
+}
+
 func (n *NetworkStatusHandler) resetStatus(ready bool) {
 	n.updateRuntimeSiteState(network.NetworkStatusInfo{}, ready)
 }
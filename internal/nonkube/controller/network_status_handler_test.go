@@ -14,6 +14,7 @@ import (
 
 	"github.com/skupperproject/skupper/internal/network"
 	"github.com/skupperproject/skupper/internal/nonkube/client/fs"
+	"github.com/skupperproject/skupper/internal/nonkube/common"
 	"github.com/skupperproject/skupper/internal/utils"
 	"github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
 	"github.com/skupperproject/skupper/pkg/nonkube/api"
@@ -118,6 +119,63 @@ func TestNetworkStatusHandler(t *testing.T) {
 
 }
 
+func TestNetworkStatusHandlerClosestRoutingKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	if os.Getuid() == 0 {
+		api.DefaultRootDataHome = tempDir
+	} else {
+		t.Setenv("XDG_DATA_HOME", tempDir)
+	}
+	namespace := "test-network-status-closest"
+	runtimePath := api.GetInternalOutputPath(namespace, api.RuntimeSiteStatePath)
+	assert.Assert(t, os.MkdirAll(runtimePath, 0755))
+	assert.Assert(t, os.MkdirAll(api.GetInternalOutputPath(namespace, api.RouterConfigPath), 0755))
+
+	siteState := fakeSiteState()
+	siteState.MultiKeyListeners = map[string]*v2alpha1.MultiKeyListener{
+		"db": {
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "MultiKeyListener",
+				APIVersion: "skupper.io/v2alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "db",
+			},
+			Spec: v2alpha1.MultiKeyListenerSpec{
+				Host: "0.0.0.0",
+				Port: 5432,
+				Strategy: v2alpha1.MultiKeyListenerStrategy{
+					Closest: &v2alpha1.ClosestStrategySpec{
+						RoutingKeys: []string{"listener-one-key", "connector-one-key"},
+					},
+				},
+			},
+		},
+	}
+	routerConfig := siteState.ToRouterConfig("", "podman")
+	assert.Assert(t, common.SaveRouterConfig(namespace, &routerConfig))
+	assert.Assert(t, api.MarshalSiteState(*siteState, runtimePath))
+
+	priorities := func() map[string]int {
+		config, err := common.LoadRouterConfig(namespace)
+		assert.Assert(t, err)
+		values := map[string]int{}
+		for name, la := range config.Bridges.ListenerAddresses {
+			values[name] = la.Value
+		}
+		return values
+	}
+	assert.DeepEqual(t, priorities(), map[string]int{"db/listener-one-key": 1, "db/connector-one-key": 0})
+
+	// the connector for connector-one-key is local, so it is the closest
+	nsHandler := NewNetworkStatusHandler(namespace)
+	nsHandler.updateRuntimeSiteState(fakeNetworkStatusInfo(), true)
+	assert.DeepEqual(t, priorities(), map[string]int{"db/listener-one-key": 0, "db/connector-one-key": 1})
+
+	nsHandler.resetStatus(false)
+	assert.DeepEqual(t, priorities(), map[string]int{"db/listener-one-key": 1, "db/connector-one-key": 0})
+}
+
 type testLogHandler struct {
 	handler  slog.Handler
 	messages []string
@@ -145,6 +145,36 @@ func asTcpEndpoint(record Record) TcpEndpoint {
 	return endpoint
 }
 
+func asUdpEndpoint(record Record) UdpEndpoint {
+	return UdpEndpoint{
+		Name:      record.AsString("name"),
+		Host:      record.AsString("host"),
+		Port:      record.AsString("port"),
+		Address:   record.AsString("address"),
+		SiteId:    record.AsString("siteId"),
+		ProcessID: record.AsString("processId"),
+	}
+}
+
+func asHttpEndpoint(record Record) HttpEndpoint {
+	endpoint := HttpEndpoint{
+		Name:            record.AsString("name"),
+		Host:            record.AsString("host"),
+		Port:            record.AsString("port"),
+		Address:         record.AsString("address"),
+		SiteId:          record.AsString("siteId"),
+		ProtocolVersion: record.AsString("protocolVersion"),
+		SslProfile:      record.AsString("sslProfile"),
+		ProcessID:       record.AsString("processId"),
+	}
+	if value, ok := record["verifyHostname"]; ok {
+		if verify, ok := value.(bool); ok {
+			endpoint.VerifyHostname = &verify
+		}
+	}
+	return endpoint
+}
+
 func asListenerAddress(record Record) ListenerAddress {
 	return ListenerAddress{
 		Name:     record.AsString("name"),
@@ -944,6 +974,38 @@ func (a *Agent) GetLocalBridgeConfig() (*BridgeConfig, error) {
 		config.AddTcpListener(asTcpEndpoint(record))
 	}
 
+	results, err = a.Query("io.skupper.router.udpConnector", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddUdpConnector(asUdpEndpoint(record))
+	}
+
+	results, err = a.Query("io.skupper.router.udpListener", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddUdpListener(asUdpEndpoint(record))
+	}
+
+	results, err = a.Query("io.skupper.router.httpConnector", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddHttpConnector(asHttpEndpoint(record))
+	}
+
+	results, err = a.Query("io.skupper.router.httpListener", []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range results {
+		config.AddHttpListener(asHttpEndpoint(record))
+	}
+
 	results, err = a.Query("io.skupper.router.listenerAddress", []string{})
 	if err != nil {
 		return nil, err
@@ -986,6 +1048,26 @@ func (a *Agent) UpdateLocalBridgeConfig(changes *BridgeConfigDifference) error {
 			return fmt.Errorf("Error deleting tcp listeners: %s", err)
 		}
 	}
+	for _, deleted := range changes.UdpConnectors.Deleted {
+		if err := a.Delete("io.skupper.router.udpConnector", deleted); err != nil {
+			return fmt.Errorf("Error deleting udp connectors: %s", err)
+		}
+	}
+	for _, deleted := range changes.UdpListeners.Deleted {
+		if err := a.Delete("io.skupper.router.udpListener", deleted); err != nil {
+			return fmt.Errorf("Error deleting udp listeners: %s", err)
+		}
+	}
+	for _, deleted := range changes.HttpConnectors.Deleted {
+		if err := a.Delete("io.skupper.router.httpConnector", deleted); err != nil {
+			return fmt.Errorf("Error deleting http connectors: %s", err)
+		}
+	}
+	for _, deleted := range changes.HttpListeners.Deleted {
+		if err := a.Delete("io.skupper.router.httpListener", deleted); err != nil {
+			return fmt.Errorf("Error deleting http listeners: %s", err)
+		}
+	}
 
 	for _, added := range changes.TcpConnectors.Added {
 		if err := a.Create("io.skupper.router.tcpConnector", added.Name, added); err != nil {
@@ -997,6 +1079,26 @@ func (a *Agent) UpdateLocalBridgeConfig(changes *BridgeConfigDifference) error {
 			return fmt.Errorf("Error adding tcp listeners: %s", err)
 		}
 	}
+	for _, added := range changes.UdpConnectors.Added {
+		if err := a.Create("io.skupper.router.udpConnector", added.Name, added); err != nil {
+			return fmt.Errorf("Error adding udp connectors: %s", err)
+		}
+	}
+	for _, added := range changes.UdpListeners.Added {
+		if err := a.Create("io.skupper.router.udpListener", added.Name, added); err != nil {
+			return fmt.Errorf("Error adding udp listeners: %s", err)
+		}
+	}
+	for _, added := range changes.HttpConnectors.Added {
+		if err := a.Create("io.skupper.router.httpConnector", added.Name, added); err != nil {
+			return fmt.Errorf("Error adding http connectors: %s", err)
+		}
+	}
+	for _, added := range changes.HttpListeners.Added {
+		if err := a.Create("io.skupper.router.httpListener", added.Name, added); err != nil {
+			return fmt.Errorf("Error adding http listeners: %s", err)
+		}
+	}
 
 	// Add listenerAddresses after their parent tcpListeners
 	for _, added := range changes.ListenerAddresses.Added {
@@ -1028,6 +1130,34 @@ func (a *Agent) GetBridges(routers []Router) ([]BridgeConfig, error) {
 		for _, record := range results {
 			config.AddTcpListener(asTcpEndpoint(record))
 		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.udpConnector", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddUdpConnector(asUdpEndpoint(record))
+		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.udpListener", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddUdpListener(asUdpEndpoint(record))
+		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.httpConnector", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddHttpConnector(asHttpEndpoint(record))
+		}
+		results, err = a.QueryByAgentAddress("io.skupper.router.httpListener", []string{}, agent)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range results {
+			config.AddHttpListener(asHttpEndpoint(record))
+		}
 
 		configs = append(configs, config)
 	}
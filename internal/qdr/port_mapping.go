@@ -44,18 +44,18 @@ func (p *PortMapping) recovered(key string, portstr string) {
 	p.mappings[key] = port
 }
 
-func portMappingKey(listener TcpEndpoint) string {
-	if strings.HasPrefix(listener.Name, TcpListenerNamePrefix) {
-		name := strings.TrimPrefix(listener.Name, TcpListenerNamePrefix)
-		if strings.Contains(name, "@") && listener.Address != "" {
-			return listener.Address
+func portMappingKey(listenerName string, address string) string {
+	if strings.HasPrefix(listenerName, TcpListenerNamePrefix) {
+		name := strings.TrimPrefix(listenerName, TcpListenerNamePrefix)
+		if strings.Contains(name, "@") && address != "" {
+			return address
 		}
 		return name
 	}
-	if strings.HasPrefix(listener.Name, "multiAddress/") {
-		return "multiaddress-" + strings.TrimPrefix(listener.Name, "multiAddress/")
+	if strings.HasPrefix(listenerName, "multiAddress/") {
+		return "multiaddress-" + strings.TrimPrefix(listenerName, "multiAddress/")
 	}
-	return listener.Name
+	return listenerName
 }
 
 func RecoverPortMapping(config *RouterConfig) *PortMapping {
@@ -70,7 +70,13 @@ func RecoverPortMapping(config *RouterConfig) *PortMapping {
 		}
 
 		for _, listener := range config.Bridges.TcpListeners {
-			mapping.recovered(portMappingKey(listener), listener.Port)
+			mapping.recovered(portMappingKey(listener.Name, listener.Address), listener.Port)
+		}
+		for _, listener := range config.Bridges.UdpListeners {
+			mapping.recovered(portMappingKey(listener.Name, listener.Address), listener.Port)
+		}
+		for _, listener := range config.Bridges.HttpListeners {
+			mapping.recovered(portMappingKey(listener.Name, listener.Address), listener.Port)
 		}
 	}
 	return mapping
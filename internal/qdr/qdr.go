@@ -33,6 +33,8 @@ type RouterConfigHandler interface {
 }
 
 type TcpEndpointMap map[string]TcpEndpoint
+type UdpEndpointMap map[string]UdpEndpoint
+type HttpEndpointMap map[string]HttpEndpoint
 type ListenerAddressMap map[string]ListenerAddress
 
 const (
@@ -43,6 +45,10 @@ const (
 type BridgeConfig struct {
 	TcpListeners      TcpEndpointMap
 	TcpConnectors     TcpEndpointMap
+	UdpListeners      UdpEndpointMap
+	UdpConnectors     UdpEndpointMap
+	HttpListeners     HttpEndpointMap
+	HttpConnectors    HttpEndpointMap
 	ListenerAddresses ListenerAddressMap
 }
 
@@ -62,6 +68,10 @@ func InitialConfig(id string, siteId string, version string, edge bool, helloAge
 		Bridges: BridgeConfig{
 			TcpListeners:      map[string]TcpEndpoint{},
 			TcpConnectors:     map[string]TcpEndpoint{},
+			UdpListeners:      map[string]UdpEndpoint{},
+			UdpConnectors:     map[string]UdpEndpoint{},
+			HttpListeners:     map[string]HttpEndpoint{},
+			HttpConnectors:    map[string]HttpEndpoint{},
 			ListenerAddresses: map[string]ListenerAddress{},
 		},
 	}
@@ -89,6 +99,10 @@ func NewBridgeConfig() BridgeConfig {
 	return BridgeConfig{
 		TcpListeners:      map[string]TcpEndpoint{},
 		TcpConnectors:     map[string]TcpEndpoint{},
+		UdpListeners:      map[string]UdpEndpoint{},
+		UdpConnectors:     map[string]UdpEndpoint{},
+		HttpListeners:     map[string]HttpEndpoint{},
+		HttpConnectors:    map[string]HttpEndpoint{},
 		ListenerAddresses: map[string]ListenerAddress{},
 	}
 }
@@ -101,6 +115,18 @@ func NewBridgeConfigCopy(src BridgeConfig) BridgeConfig {
 	for k, v := range src.TcpConnectors {
 		newBridges.TcpConnectors[k] = v
 	}
+	for k, v := range src.UdpListeners {
+		newBridges.UdpListeners[k] = v
+	}
+	for k, v := range src.UdpConnectors {
+		newBridges.UdpConnectors[k] = v
+	}
+	for k, v := range src.HttpListeners {
+		newBridges.HttpListeners[k] = v
+	}
+	for k, v := range src.HttpConnectors {
+		newBridges.HttpConnectors[k] = v
+	}
 	for k, v := range src.ListenerAddresses {
 		newBridges.ListenerAddresses[k] = v
 	}
@@ -211,6 +237,12 @@ func (r *RouterConfig) UnreferencedSslProfiles() map[string]SslProfile {
 	for _, o := range r.Bridges.TcpConnectors {
 		delete(results, o.SslProfile)
 	}
+	for _, o := range r.Bridges.HttpListeners {
+		delete(results, o.SslProfile)
+	}
+	for _, o := range r.Bridges.HttpConnectors {
+		delete(results, o.SslProfile)
+	}
 
 	return results
 }
@@ -291,6 +323,38 @@ func (r *RouterConfig) RemoveTcpListener(name string) (bool, TcpEndpoint) {
 	return r.Bridges.RemoveTcpListener(name)
 }
 
+func (r *RouterConfig) AddUdpConnector(e UdpEndpoint) {
+	r.Bridges.AddUdpConnector(e)
+}
+
+func (r *RouterConfig) RemoveUdpConnector(name string) (bool, UdpEndpoint) {
+	return r.Bridges.RemoveUdpConnector(name)
+}
+
+func (r *RouterConfig) AddUdpListener(e UdpEndpoint) {
+	r.Bridges.AddUdpListener(e)
+}
+
+func (r *RouterConfig) RemoveUdpListener(name string) (bool, UdpEndpoint) {
+	return r.Bridges.RemoveUdpListener(name)
+}
+
+func (r *RouterConfig) AddHttpConnector(e HttpEndpoint) {
+	r.Bridges.AddHttpConnector(e)
+}
+
+func (r *RouterConfig) RemoveHttpConnector(name string) (bool, HttpEndpoint) {
+	return r.Bridges.RemoveHttpConnector(name)
+}
+
+func (r *RouterConfig) AddHttpListener(e HttpEndpoint) {
+	r.Bridges.AddHttpListener(e)
+}
+
+func (r *RouterConfig) RemoveHttpListener(name string) (bool, HttpEndpoint) {
+	return r.Bridges.RemoveHttpListener(name)
+}
+
 func (r *RouterConfig) UpdateBridgeConfig(desired BridgeConfig) bool {
 	if reflect.DeepEqual(r.Bridges, desired) {
 		return false
@@ -350,6 +414,86 @@ func (bc *BridgeConfig) RemoveTcpListener(name string) (bool, TcpEndpoint) {
 	}
 }
 
+func (bc *BridgeConfig) AddUdpConnector(e UdpEndpoint) bool {
+	var updated = true
+	if existing, ok := bc.UdpConnectors[e.Name]; ok {
+		if e == existing {
+			updated = false
+		}
+	}
+	bc.UdpConnectors[e.Name] = e
+	return updated
+}
+
+func (bc *BridgeConfig) RemoveUdpConnector(name string) (bool, UdpEndpoint) {
+	uc, ok := bc.UdpConnectors[name]
+	if !ok {
+		return false, UdpEndpoint{}
+	}
+	delete(bc.UdpConnectors, name)
+	return true, uc
+}
+
+func (bc *BridgeConfig) AddUdpListener(e UdpEndpoint) bool {
+	var updated = true
+	if existing, ok := bc.UdpListeners[e.Name]; ok {
+		if e == existing {
+			updated = false
+		}
+	}
+	bc.UdpListeners[e.Name] = e
+	return updated
+}
+
+func (bc *BridgeConfig) RemoveUdpListener(name string) (bool, UdpEndpoint) {
+	ul, ok := bc.UdpListeners[name]
+	if !ok {
+		return false, UdpEndpoint{}
+	}
+	delete(bc.UdpListeners, name)
+	return true, ul
+}
+
+func (bc *BridgeConfig) AddHttpConnector(e HttpEndpoint) bool {
+	var updated = true
+	if existing, ok := bc.HttpConnectors[e.Name]; ok {
+		if e == existing {
+			updated = false
+		}
+	}
+	bc.HttpConnectors[e.Name] = e
+	return updated
+}
+
+func (bc *BridgeConfig) RemoveHttpConnector(name string) (bool, HttpEndpoint) {
+	hc, ok := bc.HttpConnectors[name]
+	if !ok {
+		return false, HttpEndpoint{}
+	}
+	delete(bc.HttpConnectors, name)
+	return true, hc
+}
+
+func (bc *BridgeConfig) AddHttpListener(e HttpEndpoint) bool {
+	var updated = true
+	if existing, ok := bc.HttpListeners[e.Name]; ok {
+		if e == existing {
+			updated = false
+		}
+	}
+	bc.HttpListeners[e.Name] = e
+	return updated
+}
+
+func (bc *BridgeConfig) RemoveHttpListener(name string) (bool, HttpEndpoint) {
+	hl, ok := bc.HttpListeners[name]
+	if !ok {
+		return false, HttpEndpoint{}
+	}
+	delete(bc.HttpListeners, name)
+	return true, hl
+}
+
 func (bc *BridgeConfig) AddListenerAddress(la ListenerAddress) bool {
 	var updated = true
 	if existing, ok := bc.ListenerAddresses[la.Name]; ok {
@@ -370,6 +514,16 @@ func (bc *BridgeConfig) RemoveListenerAddress(name string) (bool, ListenerAddres
 	return true, la
 }
 
+func GetUdpConnectors(bridges []BridgeConfig) []UdpEndpoint {
+	connectors := []UdpEndpoint{}
+	for _, bridge := range bridges {
+		for _, connector := range bridge.UdpConnectors {
+			connectors = append(connectors, connector)
+		}
+	}
+	return connectors
+}
+
 func GetTcpConnectors(bridges []BridgeConfig) []TcpEndpoint {
 	connectors := []TcpEndpoint{}
 	for _, bridge := range bridges {
@@ -688,6 +842,38 @@ type TcpEndpoint struct {
 	AuthenticatePeer     bool   `json:"authenticatePeer,omitempty"`
 }
 
+// UdpEndpoint describes a udpListener or udpConnector entity. Unlike
+// TcpEndpoint it carries no TLS or observer settings as neither apply to
+// datagram traffic.
+type UdpEndpoint struct {
+	Name      string `json:"name,omitempty"`
+	Host      string `json:"host,omitempty"`
+	Port      string `json:"port,omitempty"`
+	Address   string `json:"address,omitempty"`
+	SiteId    string `json:"siteId,omitempty"`
+	ProcessID string `json:"processId,omitempty"`
+}
+
+const (
+	HttpProtocolVersion1 = "HTTP1"
+	HttpProtocolVersion2 = "HTTP2"
+)
+
+// HttpEndpoint describes an httpListener or httpConnector entity. The
+// router load balances each request (or, for HTTP2, each stream) across
+// the connectors for the address rather than each connection.
+type HttpEndpoint struct {
+	Name            string `json:"name,omitempty"`
+	Host            string `json:"host,omitempty"`
+	Port            string `json:"port,omitempty"`
+	Address         string `json:"address,omitempty"`
+	SiteId          string `json:"siteId,omitempty"`
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+	SslProfile      string `json:"sslProfile,omitempty"`
+	VerifyHostname  *bool  `json:"verifyHostname,omitempty"`
+	ProcessID       string `json:"processId,omitempty"`
+}
+
 type ListenerAddress struct {
 	Name     string `json:"name,omitempty"`
 	Address  string `json:"address,omitempty"`
@@ -748,6 +934,61 @@ func (e TcpEndpoint) toRecord() Record {
 	return result
 }
 
+func (e UdpEndpoint) toRecord() Record {
+	result := make(map[string]any)
+	if e.Name != "" {
+		result["name"] = e.Name
+	}
+	if e.Host != "" {
+		result["host"] = e.Host
+	}
+	if e.Port != "" {
+		result["port"] = e.Port
+	}
+	if e.Address != "" {
+		result["address"] = e.Address
+	}
+	if e.SiteId != "" {
+		result["siteId"] = e.SiteId
+	}
+	if e.ProcessID != "" {
+		result["processId"] = e.ProcessID
+	}
+	return result
+}
+
+func (e HttpEndpoint) toRecord() Record {
+	result := make(map[string]any)
+	if e.Name != "" {
+		result["name"] = e.Name
+	}
+	if e.Host != "" {
+		result["host"] = e.Host
+	}
+	if e.Port != "" {
+		result["port"] = e.Port
+	}
+	if e.Address != "" {
+		result["address"] = e.Address
+	}
+	if e.SiteId != "" {
+		result["siteId"] = e.SiteId
+	}
+	if e.ProtocolVersion != "" {
+		result["protocolVersion"] = e.ProtocolVersion
+	}
+	if e.SslProfile != "" {
+		result["sslProfile"] = e.SslProfile
+	}
+	if e.VerifyHostname != nil {
+		result["verifyHostname"] = e.VerifyHostname
+	}
+	if e.ProcessID != "" {
+		result["processId"] = e.ProcessID
+	}
+	return result
+}
+
 type SiteConfig struct {
 	Name      string `json:"name,omitempty"`
 	Location  string `json:"location,omitempty"`
@@ -793,6 +1034,10 @@ func UnmarshalRouterConfig(config string) (RouterConfig, error) {
 		Bridges: BridgeConfig{
 			TcpListeners:      map[string]TcpEndpoint{},
 			TcpConnectors:     map[string]TcpEndpoint{},
+			UdpListeners:      map[string]UdpEndpoint{},
+			UdpConnectors:     map[string]UdpEndpoint{},
+			HttpListeners:     map[string]HttpEndpoint{},
+			HttpConnectors:    map[string]HttpEndpoint{},
 			ListenerAddresses: map[string]ListenerAddress{},
 		},
 	}
@@ -885,6 +1130,34 @@ func UnmarshalRouterConfig(config string) (RouterConfig, error) {
 				return result, fmt.Errorf("Invalid %s element got %#v", entityType, element[1])
 			}
 			result.Bridges.TcpListeners[listener.Name] = listener
+		case "udpConnector":
+			connector := UdpEndpoint{}
+			err = convert(element[1], &connector)
+			if err != nil {
+				return result, fmt.Errorf("Invalid %s element got %#v", entityType, element[1])
+			}
+			result.Bridges.UdpConnectors[connector.Name] = connector
+		case "udpListener":
+			listener := UdpEndpoint{}
+			err = convert(element[1], &listener)
+			if err != nil {
+				return result, fmt.Errorf("Invalid %s element got %#v", entityType, element[1])
+			}
+			result.Bridges.UdpListeners[listener.Name] = listener
+		case "httpConnector":
+			connector := HttpEndpoint{}
+			err = convert(element[1], &connector)
+			if err != nil {
+				return result, fmt.Errorf("Invalid %s element got %#v", entityType, element[1])
+			}
+			result.Bridges.HttpConnectors[connector.Name] = connector
+		case "httpListener":
+			listener := HttpEndpoint{}
+			err = convert(element[1], &listener)
+			if err != nil {
+				return result, fmt.Errorf("Invalid %s element got %#v", entityType, element[1])
+			}
+			result.Bridges.HttpListeners[listener.Name] = listener
 		case "listenerAddress":
 			la := ListenerAddress{}
 			err = convert(element[1], &la)
@@ -954,6 +1227,34 @@ func MarshalRouterConfig(config RouterConfig) (string, error) {
 		}
 		elements = append(elements, tuple)
 	}
+	for _, e := range config.Bridges.UdpConnectors {
+		tuple := []interface{}{
+			"udpConnector",
+			e,
+		}
+		elements = append(elements, tuple)
+	}
+	for _, e := range config.Bridges.UdpListeners {
+		tuple := []interface{}{
+			"udpListener",
+			e,
+		}
+		elements = append(elements, tuple)
+	}
+	for _, e := range config.Bridges.HttpConnectors {
+		tuple := []interface{}{
+			"httpConnector",
+			e,
+		}
+		elements = append(elements, tuple)
+	}
+	for _, e := range config.Bridges.HttpListeners {
+		tuple := []interface{}{
+			"httpListener",
+			e,
+		}
+		elements = append(elements, tuple)
+	}
 	for _, e := range config.Bridges.ListenerAddresses {
 		tuple := []interface{}{
 			"listenerAddress",
@@ -1100,6 +1401,16 @@ type TcpEndpointDifference struct {
 	Added   []TcpEndpoint
 }
 
+type UdpEndpointDifference struct {
+	Deleted []string
+	Added   []UdpEndpoint
+}
+
+type HttpEndpointDifference struct {
+	Deleted []string
+	Added   []HttpEndpoint
+}
+
 type ListenerAddressDifference struct {
 	Deleted []string
 	Added   []ListenerAddress
@@ -1108,6 +1419,10 @@ type ListenerAddressDifference struct {
 type BridgeConfigDifference struct {
 	TcpListeners       TcpEndpointDifference
 	TcpConnectors      TcpEndpointDifference
+	UdpListeners       UdpEndpointDifference
+	UdpConnectors      UdpEndpointDifference
+	HttpListeners      HttpEndpointDifference
+	HttpConnectors     HttpEndpointDifference
 	ListenerAddresses  ListenerAddressDifference
 	AddedSslProfiles   []string
 	DeletedSSlProfiles []string
@@ -1178,6 +1493,67 @@ func (a TcpEndpointMap) Difference(b TcpEndpointMap) TcpEndpointDifference {
 	return result
 }
 
+func (a UdpEndpoint) Equivalent(b UdpEndpoint) bool {
+	return equivalentHost(a.Host, b.Host) && a.Port == b.Port && a.Address == b.Address &&
+		a.SiteId == b.SiteId && a.ProcessID == b.ProcessID
+}
+
+func (a UdpEndpointMap) Difference(b UdpEndpointMap) UdpEndpointDifference {
+	result := UdpEndpointDifference{}
+	for key, v1 := range b {
+		v2, ok := a[key]
+		if !ok {
+			result.Added = append(result.Added, v1)
+		} else if !v1.Equivalent(v2) {
+			result.Deleted = append(result.Deleted, v1.Name)
+			result.Added = append(result.Added, v1)
+		}
+	}
+	for key, v1 := range a {
+		_, ok := b[key]
+		if !ok {
+			result.Deleted = append(result.Deleted, v1.Name)
+		}
+	}
+	return result
+}
+
+func (a HttpEndpoint) equivalentVerifyHostname(b HttpEndpoint) bool {
+	if a.VerifyHostname == nil {
+		return b.VerifyHostname == nil || *b.VerifyHostname == true
+	}
+	if b.VerifyHostname == nil {
+		return a.VerifyHostname == nil || *a.VerifyHostname == true
+	}
+	return *a.VerifyHostname == *b.VerifyHostname
+}
+
+func (a HttpEndpoint) Equivalent(b HttpEndpoint) bool {
+	return equivalentHost(a.Host, b.Host) && a.Port == b.Port && a.Address == b.Address &&
+		a.SiteId == b.SiteId && a.ProcessID == b.ProcessID && a.ProtocolVersion == b.ProtocolVersion &&
+		a.SslProfile == b.SslProfile && a.equivalentVerifyHostname(b)
+}
+
+func (a HttpEndpointMap) Difference(b HttpEndpointMap) HttpEndpointDifference {
+	result := HttpEndpointDifference{}
+	for key, v1 := range b {
+		v2, ok := a[key]
+		if !ok {
+			result.Added = append(result.Added, v1)
+		} else if !v1.Equivalent(v2) {
+			result.Deleted = append(result.Deleted, v1.Name)
+			result.Added = append(result.Added, v1)
+		}
+	}
+	for key, v1 := range a {
+		_, ok := b[key]
+		if !ok {
+			result.Deleted = append(result.Deleted, v1.Name)
+		}
+	}
+	return result
+}
+
 func (a ListenerAddressMap) Difference(b ListenerAddressMap) ListenerAddressDifference {
 	result := ListenerAddressDifference{}
 	for key, v1 := range b {
@@ -1203,6 +1579,10 @@ func (a *BridgeConfig) Difference(b *BridgeConfig) *BridgeConfigDifference {
 		logger:            slog.New(slog.Default().Handler()).With("component", "qdr.bridgeConfigDifference"),
 		TcpConnectors:     a.TcpConnectors.Difference(b.TcpConnectors),
 		TcpListeners:      a.TcpListeners.Difference(b.TcpListeners),
+		UdpConnectors:     a.UdpConnectors.Difference(b.UdpConnectors),
+		UdpListeners:      a.UdpListeners.Difference(b.UdpListeners),
+		HttpConnectors:    a.HttpConnectors.Difference(b.HttpConnectors),
+		HttpListeners:     a.HttpListeners.Difference(b.HttpListeners),
 		ListenerAddresses: a.ListenerAddresses.Difference(b.ListenerAddresses),
 	}
 
@@ -1257,6 +1637,12 @@ func getSslProfilesDifference(before *BridgeConfig, desired *BridgeConfig) (Adde
 	for _, tcpListener := range before.TcpListeners {
 		originalSslConfig[tcpListener.SslProfile] = tcpListener.SslProfile
 	}
+	for _, httpConnector := range before.HttpConnectors {
+		originalSslConfig[httpConnector.SslProfile] = httpConnector.SslProfile
+	}
+	for _, httpListener := range before.HttpListeners {
+		originalSslConfig[httpListener.SslProfile] = httpListener.SslProfile
+	}
 
 	for _, tcpConnector := range desired.TcpConnectors {
 		newSslConfig[tcpConnector.SslProfile] = tcpConnector.SslProfile
@@ -1264,6 +1650,12 @@ func getSslProfilesDifference(before *BridgeConfig, desired *BridgeConfig) (Adde
 	for _, tcpListener := range desired.TcpListeners {
 		newSslConfig[tcpListener.SslProfile] = tcpListener.SslProfile
 	}
+	for _, httpConnector := range desired.HttpConnectors {
+		newSslConfig[httpConnector.SslProfile] = httpConnector.SslProfile
+	}
+	for _, httpListener := range desired.HttpListeners {
+		newSslConfig[httpListener.SslProfile] = httpListener.SslProfile
+	}
 
 	//Auto-generated Skupper certs will be deleted if they are not used in the desired configuration
 	for key, name := range originalSslConfig {
@@ -1294,17 +1686,30 @@ func (a *TcpEndpointDifference) Empty() bool {
 	return len(a.Deleted) == 0 && len(a.Added) == 0
 }
 
+func (a *UdpEndpointDifference) Empty() bool {
+	return len(a.Deleted) == 0 && len(a.Added) == 0
+}
+
+func (a *HttpEndpointDifference) Empty() bool {
+	return len(a.Deleted) == 0 && len(a.Added) == 0
+}
+
 func (a *ListenerAddressDifference) Empty() bool {
 	return len(a.Deleted) == 0 && len(a.Added) == 0
 }
 
 func (a *BridgeConfigDifference) Empty() bool {
-	return a.TcpConnectors.Empty() && a.TcpListeners.Empty() && a.ListenerAddresses.Empty()
+	return a.TcpConnectors.Empty() && a.TcpListeners.Empty() && a.UdpConnectors.Empty() && a.UdpListeners.Empty() &&
+		a.HttpConnectors.Empty() && a.HttpListeners.Empty() && a.ListenerAddresses.Empty()
 }
 
 func (a *BridgeConfigDifference) Print() {
 	a.logger.Info("TcpConnectors", slog.Any("added", a.TcpConnectors.Added), slog.Any("deleted", a.TcpConnectors.Deleted))
 	a.logger.Info("TcpListeners", slog.Any("added", a.TcpListeners.Added), slog.Any("deleted", a.TcpListeners.Deleted))
+	a.logger.Info("UdpConnectors", slog.Any("added", a.UdpConnectors.Added), slog.Any("deleted", a.UdpConnectors.Deleted))
+	a.logger.Info("UdpListeners", slog.Any("added", a.UdpListeners.Added), slog.Any("deleted", a.UdpListeners.Deleted))
+	a.logger.Info("HttpConnectors", slog.Any("added", a.HttpConnectors.Added), slog.Any("deleted", a.HttpConnectors.Deleted))
+	a.logger.Info("HttpListeners", slog.Any("added", a.HttpListeners.Added), slog.Any("deleted", a.HttpListeners.Deleted))
 	a.logger.Info("ListenerAddresses", slog.Any("added", a.ListenerAddresses.Added), slog.Any("deleted", a.ListenerAddresses.Deleted))
 	a.logger.Info("SslProfiles", slog.Any("added", a.AddedSslProfiles), slog.Any("deleted", a.DeletedSSlProfiles))
 }
@@ -3,6 +3,7 @@ package qdr
 import (
 	"encoding/json"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/skupperproject/skupper/api/types"
@@ -287,6 +288,44 @@ func TestMarshalUnmarshalRouterConfig(t *testing.T) {
 					SiteId:  "def",
 				},
 			},
+			UdpConnectors: map[string]UdpEndpoint{
+				"u1": UdpEndpoint{
+					Name:    "u1",
+					Address: "dns",
+					Host:    "resolver.com",
+					Port:    "53",
+					SiteId:  "abc",
+				},
+			},
+			UdpListeners: map[string]UdpEndpoint{
+				"u1": UdpEndpoint{
+					Name:    "u1",
+					Address: "dns",
+					Host:    "0.0.0.0",
+					Port:    "53",
+					SiteId:  "def",
+				},
+			},
+			HttpConnectors: map[string]HttpEndpoint{
+				"h1": HttpEndpoint{
+					Name:            "h1",
+					Address:         "grpc",
+					Host:            "backend.com",
+					Port:            "9090",
+					SiteId:          "abc",
+					ProtocolVersion: HttpProtocolVersion2,
+				},
+			},
+			HttpListeners: map[string]HttpEndpoint{
+				"h1": HttpEndpoint{
+					Name:            "h1",
+					Address:         "grpc",
+					Host:            "0.0.0.0",
+					Port:            "9090",
+					SiteId:          "def",
+					ProtocolVersion: HttpProtocolVersion2,
+				},
+			},
 			ListenerAddresses: ListenerAddressMap{},
 		},
 		Addresses: map[string]Address{
@@ -805,3 +844,70 @@ func TestTcpEndpointEquivalentHttpModes(t *testing.T) {
 		t.Errorf("expected http1 vs none to be not equivalent")
 	}
 }
+
+func TestUdpEndpointMapDifference(t *testing.T) {
+	before := UdpEndpointMap{
+		"unchanged": {Name: "unchanged", Address: "dns", Port: "53"},
+		"changed":   {Name: "changed", Address: "syslog", Port: "514"},
+		"removed":   {Name: "removed", Address: "ntp", Port: "123"},
+	}
+	after := UdpEndpointMap{
+		"unchanged": {Name: "unchanged", Address: "dns", Port: "53", Host: "0.0.0.0"},
+		"changed":   {Name: "changed", Address: "syslog", Port: "1514"},
+		"added":     {Name: "added", Address: "snmp", Port: "161"},
+	}
+	diff := before.Difference(after)
+	sort.Strings(diff.Deleted)
+	assert.DeepEqual(t, diff.Deleted, []string{"changed", "removed"})
+	var added []string
+	for _, e := range diff.Added {
+		added = append(added, e.Name)
+	}
+	sort.Strings(added)
+	assert.DeepEqual(t, added, []string{"added", "changed"})
+	assert.Assert(t, !diff.Empty())
+
+	bridges := NewBridgeConfig()
+	bridges.AddUdpListener(UdpEndpoint{Name: "l", Address: "dns", Port: "53"})
+	desired := NewBridgeConfigCopy(bridges)
+	desired.AddUdpConnector(UdpEndpoint{Name: "c", Address: "dns", Host: "10.0.0.1", Port: "53"})
+	bridgeDiff := bridges.Difference(&desired)
+	assert.Assert(t, bridgeDiff.UdpListeners.Empty())
+	assert.Equal(t, len(bridgeDiff.UdpConnectors.Added), 1)
+	assert.Assert(t, !bridgeDiff.Empty())
+}
+
+func TestHttpEndpointMapDifference(t *testing.T) {
+	verify := true
+	before := HttpEndpointMap{
+		"unchanged": {Name: "unchanged", Address: "web", Port: "8080", ProtocolVersion: HttpProtocolVersion1},
+		"changed":   {Name: "changed", Address: "grpc", Port: "9090", ProtocolVersion: HttpProtocolVersion1},
+		"removed":   {Name: "removed", Address: "api", Port: "8081", ProtocolVersion: HttpProtocolVersion1},
+		"verify":    {Name: "verify", Address: "secure", Port: "8443", SslProfile: "tls"},
+	}
+	after := HttpEndpointMap{
+		"unchanged": {Name: "unchanged", Address: "web", Port: "8080", ProtocolVersion: HttpProtocolVersion1, Host: "0.0.0.0"},
+		"changed":   {Name: "changed", Address: "grpc", Port: "9090", ProtocolVersion: HttpProtocolVersion2},
+		"added":     {Name: "added", Address: "metrics", Port: "9100", ProtocolVersion: HttpProtocolVersion1},
+		"verify":    {Name: "verify", Address: "secure", Port: "8443", SslProfile: "tls", VerifyHostname: &verify},
+	}
+	diff := before.Difference(after)
+	sort.Strings(diff.Deleted)
+	assert.DeepEqual(t, diff.Deleted, []string{"changed", "removed"})
+	var added []string
+	for _, e := range diff.Added {
+		added = append(added, e.Name)
+	}
+	sort.Strings(added)
+	assert.DeepEqual(t, added, []string{"added", "changed"})
+	assert.Assert(t, !diff.Empty())
+
+	bridges := NewBridgeConfig()
+	bridges.AddHttpListener(HttpEndpoint{Name: "l", Address: "grpc", Port: "9090", ProtocolVersion: HttpProtocolVersion2})
+	desired := NewBridgeConfigCopy(bridges)
+	desired.AddHttpConnector(HttpEndpoint{Name: "c", Address: "grpc", Host: "10.0.0.1", Port: "9090", ProtocolVersion: HttpProtocolVersion2})
+	bridgeDiff := bridges.Difference(&desired)
+	assert.Assert(t, bridgeDiff.HttpListeners.Empty())
+	assert.Equal(t, len(bridgeDiff.HttpConnectors.Added), 1)
+	assert.Assert(t, !bridgeDiff.Empty())
+}
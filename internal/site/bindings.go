@@ -245,6 +245,10 @@ func (b *Bindings) ToBridgeConfig() qdr.BridgeConfig {
 	config := qdr.BridgeConfig{
 		TcpListeners:      qdr.TcpEndpointMap{},
 		TcpConnectors:     qdr.TcpEndpointMap{},
+		UdpListeners:      qdr.UdpEndpointMap{},
+		UdpConnectors:     qdr.UdpEndpointMap{},
+		HttpListeners:     qdr.HttpEndpointMap{},
+		HttpConnectors:    qdr.HttpEndpointMap{},
 		ListenerAddresses: qdr.ListenerAddressMap{},
 	}
 	for _, c := range b.connectors {
@@ -38,6 +38,27 @@ func updateBridgeConfigForConnector(name string, siteId string, connector *skupp
 			ProcessID:      processID,
 			VerifyHostname: getVerifyHostname(connector),
 		})
+	} else if connector.Spec.Type == "udp" {
+		return config.AddUdpConnector(qdr.UdpEndpoint{
+			Name:      name,
+			SiteId:    siteId,
+			Host:      host,
+			Port:      strconv.Itoa(connector.Spec.Port),
+			Address:   address,
+			ProcessID: processID,
+		})
+	} else if protocolVersion := HttpProtocolVersion(connector.Spec.Type); protocolVersion != "" {
+		return config.AddHttpConnector(qdr.HttpEndpoint{
+			Name:            name,
+			SiteId:          siteId,
+			Host:            host,
+			Port:            strconv.Itoa(connector.Spec.Port),
+			Address:         address,
+			ProtocolVersion: protocolVersion,
+			SslProfile:      GetSslProfileName(connector.Spec.TlsCredentials, connector.Spec.UseClientCert),
+			ProcessID:       processID,
+			VerifyHostname:  getVerifyHostname(connector),
+		})
 	}
 	return false
 }
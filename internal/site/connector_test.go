@@ -20,6 +20,8 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 		args               args
 		expectedTcpAdded   int
 		expectedTcpDeleted int
+		expectedUdpAdded   int
+		expectedHttpAdded  int
 	}{
 		{
 			name: "no spec type",
@@ -63,6 +65,50 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 			expectedTcpAdded:   1,
 			expectedTcpDeleted: 0,
 		},
+		{
+			name: "udp spec type",
+			args: args{
+				siteId: "my-site-123",
+				connector: &skupperv2alpha1.Connector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "dns",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ConnectorSpec{
+						RoutingKey: "dns:53",
+						Host:       "10.10.10.1",
+						Port:       53,
+						Type:       "udp",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedUdpAdded:   1,
+		},
+		{
+			name: "http2 spec type",
+			args: args{
+				siteId: "my-site-123",
+				connector: &skupperv2alpha1.Connector{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "grpc",
+						Namespace: "test",
+					},
+					Spec: skupperv2alpha1.ConnectorSpec{
+						RoutingKey: "grpc:9090",
+						Host:       "10.10.10.1",
+						Port:       9090,
+						Type:       "http2",
+					},
+				},
+				config: qdr.NewBridgeConfig(),
+			},
+			expectedTcpAdded:   0,
+			expectedTcpDeleted: 0,
+			expectedHttpAdded:  1,
+		},
 		{
 			name: "bad spec type",
 			args: args{
@@ -92,6 +138,8 @@ func TestUpdateBridgeConfigForConnector(t *testing.T) {
 			result := tt.args.config.Difference(&configToUpdate)
 			assert.Assert(t, len(result.TcpConnectors.Added) == tt.expectedTcpAdded)
 			assert.Assert(t, len(result.TcpConnectors.Deleted) == tt.expectedTcpDeleted)
+			assert.Assert(t, len(result.UdpConnectors.Added) == tt.expectedUdpAdded)
+			assert.Assert(t, len(result.HttpConnectors.Added) == tt.expectedHttpAdded)
 		})
 	}
 }
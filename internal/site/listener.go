@@ -23,5 +23,35 @@ func UpdateBridgeConfigForListenerWithHostAndPort(siteId string, listener *skupp
 			SslProfile: listener.Spec.TlsCredentials,
 			Observer:   listener.Spec.Observer,
 		})
+	} else if listener.Spec.Type == "udp" {
+		config.AddUdpListener(qdr.UdpEndpoint{
+			Name:    name,
+			SiteId:  siteId,
+			Host:    host,
+			Port:    strconv.Itoa(port),
+			Address: listener.Spec.RoutingKey,
+		})
+	} else if protocolVersion := HttpProtocolVersion(listener.Spec.Type); protocolVersion != "" {
+		config.AddHttpListener(qdr.HttpEndpoint{
+			Name:            name,
+			SiteId:          siteId,
+			Host:            host,
+			Port:            strconv.Itoa(port),
+			Address:         listener.Spec.RoutingKey,
+			ProtocolVersion: protocolVersion,
+			SslProfile:      listener.Spec.TlsCredentials,
+		})
+	}
+}
+
+// HttpProtocolVersion returns the router protocol version for an http
+// binding type, or an empty string if the type is not http based.
+func HttpProtocolVersion(bindingType string) string {
+	switch bindingType {
+	case "http":
+		return qdr.HttpProtocolVersion1
+	case "http2":
+		return qdr.HttpProtocolVersion2
 	}
+	return ""
 }
@@ -1,6 +1,7 @@
 package site
 
 import (
+	"slices"
 	"strconv"
 
 	"github.com/skupperproject/skupper/internal/qdr"
@@ -20,7 +21,7 @@ func listenerAddressName(mklName, address string) string {
 }
 
 // UpdateBridgeConfigForMultiKeyListener creates the tcpListener and listenerAddress
-// entities needed to implement a MultiKeyListener with priority strategy.
+// entities needed to implement a MultiKeyListener.
 func UpdateBridgeConfigForMultiKeyListener(siteId string, mkl *skupperv2alpha1.MultiKeyListener, config *qdr.BridgeConfig) {
 	UpdateBridgeConfigForMultiKeyListenerWithHostAndPort(siteId, mkl, mkl.Spec.Host, mkl.Spec.Port, config)
 }
@@ -40,21 +41,38 @@ func UpdateBridgeConfigForMultiKeyListenerWithHostAndPort(siteId string, mkl *sk
 		Observer:         mkl.Spec.Observer,
 	}
 
-	// Create listenerAddress entities for each routing key in the strategy
-	if mkl.Spec.Strategy.Priority != nil {
-		tcpListenerConfig.MultiAddressStrategy = "priority"
-		numKeys := len(mkl.Spec.Strategy.Priority.RoutingKeys)
-		for i, routingKey := range mkl.Spec.Strategy.Priority.RoutingKeys {
-			laName := listenerAddressName(name, routingKey)
-			config.AddListenerAddress(qdr.ListenerAddress{
-				Name:     laName,
-				Address:  routingKey,
-				Value:    numKeys - 1 - i, // higher value = higher priority
-				Listener: tcpListenerName,
-			})
+	tcpListenerConfig.MultiAddressStrategy = addListenerAddresses(mkl, tcpListenerName, config)
+	config.AddTcpListener(tcpListenerConfig)
+}
+
+// UpdateListenerAddressesForMultiKeyListener replaces the listenerAddress
+// entities of a MultiKeyListener, leaving its tcpListener unchanged. It
+// applies a new order of routing keys for the closest strategy without
+// rendering the rest of the configuration.
+func UpdateListenerAddressesForMultiKeyListener(mkl *skupperv2alpha1.MultiKeyListener, config *qdr.BridgeConfig) {
+	tcpListenerName := multiAddressTcpListenerName(mkl.Name)
+	for laName, la := range config.ListenerAddresses {
+		if la.Listener == tcpListenerName {
+			config.RemoveListenerAddress(laName)
 		}
+	}
+	addListenerAddresses(mkl, tcpListenerName, config)
+}
+
+// addListenerAddresses creates listenerAddress entities for each routing key
+// in the strategy and returns the multi address strategy of the tcpListener.
+func addListenerAddresses(mkl *skupperv2alpha1.MultiKeyListener, tcpListenerName string, config *qdr.BridgeConfig) string {
+	name := mkl.Name
+	if mkl.Spec.Strategy.Priority != nil {
+		addPriorityListenerAddresses(name, tcpListenerName, mkl.Spec.Strategy.Priority.RoutingKeys, config)
+		return "priority"
+	} else if mkl.Spec.Strategy.Closest != nil {
+		// the router has no notion of locality, so the closest strategy
+		// is rendered as a priority strategy in the order computed
+		// from the network status
+		addPriorityListenerAddresses(name, tcpListenerName, closestRoutingKeys(mkl), config)
+		return "priority"
 	} else if mkl.Spec.Strategy.Weighted != nil {
-		tcpListenerConfig.MultiAddressStrategy = "weighted"
 		for routingKey, weight := range mkl.Spec.Strategy.Weighted.RoutingKeys {
 			laName := listenerAddressName(name, routingKey)
 			config.AddListenerAddress(qdr.ListenerAddress{
@@ -64,8 +82,43 @@ func UpdateBridgeConfigForMultiKeyListenerWithHostAndPort(siteId string, mkl *sk
 				Listener: tcpListenerName,
 			})
 		}
+		return "weighted"
 	}
-	config.AddTcpListener(tcpListenerConfig)
+	return ""
+}
+
+func addPriorityListenerAddresses(name string, tcpListenerName string, routingKeys []string, config *qdr.BridgeConfig) {
+	numKeys := len(routingKeys)
+	for i, routingKey := range routingKeys {
+		laName := listenerAddressName(name, routingKey)
+		config.AddListenerAddress(qdr.ListenerAddress{
+			Name:     laName,
+			Address:  routingKey,
+			Value:    numKeys - 1 - i, // higher value = higher priority
+			Listener: tcpListenerName,
+		})
+	}
+}
+
+// closestRoutingKeys returns the routing keys of a MultiKeyListener with
+// the closest strategy from nearest to furthest. Keys that were reachable
+// when the status was last computed come first, in the order recorded
+// there, followed by the remaining keys in the order they were specified.
+func closestRoutingKeys(mkl *skupperv2alpha1.MultiKeyListener) []string {
+	var ordered []string
+	if mkl.Status.Strategy != nil && mkl.Status.Strategy.Closest != nil {
+		for _, routingKey := range mkl.Status.Strategy.Closest.RoutingKeysReachable {
+			if slices.Contains(mkl.Spec.Strategy.Closest.RoutingKeys, routingKey) && !slices.Contains(ordered, routingKey) {
+				ordered = append(ordered, routingKey)
+			}
+		}
+	}
+	for _, routingKey := range mkl.Spec.Strategy.Closest.RoutingKeys {
+		if !slices.Contains(ordered, routingKey) {
+			ordered = append(ordered, routingKey)
+		}
+	}
+	return ordered
 }
 
 // RemoveBridgeConfigForMultiKeyListener removes the tcpListener and listenerAddress
@@ -0,0 +1,124 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/skupperproject/skupper/internal/qdr"
+	skupperv2alpha1 "github.com/skupperproject/skupper/pkg/apis/skupper/v2alpha1"
+	"gotest.tools/v3/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateBridgeConfigForMultiKeyListenerClosest(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           *skupperv2alpha1.StrategyStatus
+		expectedPriority map[string]int
+	}{
+		{
+			name: "no status uses the order specified",
+			expectedPriority: map[string]int{
+				"db-east":  2,
+				"db-west":  1,
+				"db-south": 0,
+			},
+		},
+		{
+			name: "reachable keys ordered by status",
+			status: &skupperv2alpha1.StrategyStatus{
+				Closest: &skupperv2alpha1.ClosestStrategyStatus{
+					RoutingKeysReachable: []string{"db-south", "db-east"},
+				},
+			},
+			expectedPriority: map[string]int{
+				"db-south": 2,
+				"db-east":  1,
+				"db-west":  0,
+			},
+		},
+		{
+			name: "unknown keys in status ignored",
+			status: &skupperv2alpha1.StrategyStatus{
+				Closest: &skupperv2alpha1.ClosestStrategyStatus{
+					RoutingKeysReachable: []string{"db-north", "db-west"},
+				},
+			},
+			expectedPriority: map[string]int{
+				"db-west":  2,
+				"db-east":  1,
+				"db-south": 0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mkl := &skupperv2alpha1.MultiKeyListener{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "db",
+					Namespace: "test",
+				},
+				Spec: skupperv2alpha1.MultiKeyListenerSpec{
+					Host: "0.0.0.0",
+					Port: 5432,
+					Strategy: skupperv2alpha1.MultiKeyListenerStrategy{
+						Closest: &skupperv2alpha1.ClosestStrategySpec{
+							RoutingKeys: []string{"db-east", "db-west", "db-south"},
+						},
+					},
+				},
+			}
+			if tt.status != nil {
+				mkl.Status.Strategy = tt.status
+			}
+			config := qdr.NewBridgeConfig()
+			UpdateBridgeConfigForMultiKeyListener("my-site-123", mkl, &config)
+
+			listener, ok := config.TcpListeners["multiAddress/db"]
+			assert.Assert(t, ok)
+			assert.Equal(t, listener.MultiAddressStrategy, "priority")
+			assert.Equal(t, len(config.ListenerAddresses), len(tt.expectedPriority))
+			for routingKey, priority := range tt.expectedPriority {
+				la, ok := config.ListenerAddresses["db/"+routingKey]
+				assert.Assert(t, ok, routingKey)
+				assert.Equal(t, la.Value, priority, routingKey)
+				assert.Equal(t, la.Listener, "multiAddress/db")
+			}
+		})
+	}
+}
+
+func TestUpdateListenerAddressesForMultiKeyListener(t *testing.T) {
+	mkl := &skupperv2alpha1.MultiKeyListener{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "db",
+			Namespace: "test",
+		},
+		Spec: skupperv2alpha1.MultiKeyListenerSpec{
+			Host: "0.0.0.0",
+			Port: 5432,
+			Strategy: skupperv2alpha1.MultiKeyListenerStrategy{
+				Closest: &skupperv2alpha1.ClosestStrategySpec{
+					RoutingKeys: []string{"db-east", "db-west"},
+				},
+			},
+		},
+	}
+	config := qdr.NewBridgeConfig()
+	UpdateBridgeConfigForMultiKeyListener("my-site-123", mkl, &config)
+	config.AddListenerAddress(qdr.ListenerAddress{
+		Name:     "other/db-east",
+		Address:  "db-east",
+		Listener: "multiAddress/other",
+	})
+	listener := config.TcpListeners["multiAddress/db"]
+
+	mkl.SetRoutingKeysReachable([]string{"db-west", "db-east"})
+	UpdateListenerAddressesForMultiKeyListener(mkl, &config)
+
+	assert.DeepEqual(t, config.TcpListeners["multiAddress/db"], listener)
+	assert.Equal(t, len(config.ListenerAddresses), 3)
+	assert.Equal(t, config.ListenerAddresses["db/db-west"].Value, 1)
+	assert.Equal(t, config.ListenerAddresses["db/db-east"].Value, 0)
+	_, ok := config.ListenerAddresses["other/db-east"]
+	assert.Assert(t, ok)
+}
@@ -0,0 +1,42 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ProxyURL returns the URL of the HTTP proxy described by a secret of
+// the form referenced by the proxy-configuration setting: a host, an
+// optional port and, for proxies requiring authentication, a username
+// and password.
+func ProxyURL(secret *corev1.Secret) (*url.URL, error) {
+	host := string(secret.Data["host"])
+	if host == "" {
+		return nil, fmt.Errorf("proxy configuration %q does not specify a host", secret.Name)
+	}
+	proxy := &url.URL{
+		Scheme: "http",
+		Host:   host,
+	}
+	if port := string(secret.Data["port"]); port != "" {
+		proxy.Host = net.JoinHostPort(host, port)
+	}
+	if username := string(secret.Data["username"]); username != "" {
+		proxy.User = url.UserPassword(username, string(secret.Data["password"]))
+	}
+	return proxy, nil
+}
+
+// ProxyError describes a failed request made through a proxy,
+// distinguishing failure to reach the proxy from other errors.
+func ProxyError(proxy *url.URL, err error) error {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "proxyconnect" {
+		return fmt.Errorf("could not connect to proxy %s: %w", proxy.Host, opErr.Err)
+	}
+	return fmt.Errorf("request through proxy %s failed: %w", proxy.Host, err)
+}
@@ -60,12 +60,14 @@ type MultiKeyListenerStatus struct {
 	Strategy *StrategyStatus `json:"strategy,omitempty"`
 }
 
-// +kubebuilder:validation:ExactlyOneOf=priority;weighted
+// +kubebuilder:validation:ExactlyOneOf=priority;weighted;closest
 type StrategyStatus struct {
 	// priority status
 	Priority *PriorityStrategyStatus `json:"priority,omitempty"`
 	// weighted status
 	Weighted *WeightedStrategyStatus `json:"weighted,omitempty"`
+	// closest status
+	Closest *ClosestStrategyStatus `json:"closest,omitempty"`
 }
 
 type PriorityStrategyStatus struct {
@@ -74,6 +76,13 @@ type PriorityStrategyStatus struct {
 	RoutingKeysReachable []string `json:"routingKeysReachable"`
 }
 
+type ClosestStrategyStatus struct {
+	// routingKeysReachable is a list of routingKeys with at least one
+	// reachable connector, ordered from the nearest to the furthest by the
+	// total link cost from this site to the connectors.
+	RoutingKeysReachable []string `json:"routingKeysReachable"`
+}
+
 type WeightedStrategyStatus struct {
 	// routingKeysReachable is a mapping of routingKeys to weights with at
 	// least one reachable connector. The value of each routingKey is the
@@ -118,11 +127,12 @@ type MultiKeyListenerSpec struct {
 // MultiKeyListenerStrategy contains configuration for each strategy. Only one
 // strategy can be specified at a time.
 //
-// +kubebuilder:validation:ExactlyOneOf=priority;weighted
-// +kubebuilder:validation:XValidation:rule="(!has(oldSelf.priority) || has(self.priority)) && (!has(oldSelf.weighted) || has(self.weighted))",message="strategy is immutable"
+// +kubebuilder:validation:ExactlyOneOf=priority;weighted;closest
+// +kubebuilder:validation:XValidation:rule="(!has(oldSelf.priority) || has(self.priority)) && (!has(oldSelf.weighted) || has(self.weighted)) && (!has(oldSelf.closest) || has(self.closest))",message="strategy is immutable"
 type MultiKeyListenerStrategy struct {
 	Priority *PriorityStrategySpec `json:"priority,omitempty"`
 	Weighted *WeightedStrategySpec `json:"weighted,omitempty"`
+	Closest  *ClosestStrategySpec  `json:"closest,omitempty"`
 }
 
 // PriorityStrategySpec specifies an ordered set of routing keys to
@@ -139,6 +149,22 @@ type PriorityStrategySpec struct {
 	RoutingKeys []string `json:"routingKeys"`
 }
 
+// ClosestStrategySpec specifies a set of routing keys to route traffic to
+// based on locality.
+//
+// With this strategy 100% of traffic will be directed to the reachable routing
+// key whose connectors are the lowest total link cost away from this site.
+// Further routing keys are only used when all nearer ones become unreachable.
+// Routing keys at the same distance are ordered as listed.
+type ClosestStrategySpec struct {
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=256
+	// +listType=set
+
+	// routingKeys to route traffic to.
+	RoutingKeys []string `json:"routingKeys"`
+}
+
 // WeightedStrategySpec defines a mapping of routing keys to weights.
 //
 // The listener distributes traffic among reachable routing keys according to
@@ -241,6 +267,7 @@ func (m *MultiKeyListener) SetRoutingKeysReachable(keys []string) bool {
 
 	if m.Spec.Strategy.Priority != nil {
 		m.Status.Strategy.Weighted = nil
+		m.Status.Strategy.Closest = nil
 		changed := false
 		if m.Status.Strategy.Priority == nil {
 			m.Status.Strategy.Priority = &PriorityStrategyStatus{}
@@ -252,8 +279,23 @@ func (m *MultiKeyListener) SetRoutingKeysReachable(keys []string) bool {
 			changed = true
 		}
 		return changed
+	} else if m.Spec.Strategy.Closest != nil {
+		m.Status.Strategy.Priority = nil
+		m.Status.Strategy.Weighted = nil
+		changed := false
+		if m.Status.Strategy.Closest == nil {
+			m.Status.Strategy.Closest = &ClosestStrategyStatus{}
+			changed = true
+		}
+
+		if !reflect.DeepEqual(m.Status.Strategy.Closest.RoutingKeysReachable, keys) {
+			m.Status.Strategy.Closest.RoutingKeysReachable = keys
+			changed = true
+		}
+		return changed
 	} else if m.Spec.Strategy.Weighted != nil {
 		m.Status.Strategy.Priority = nil
+		m.Status.Strategy.Closest = nil
 		changed := false
 		if m.Status.Strategy.Weighted == nil {
 			m.Status.Strategy.Weighted = &WeightedStrategyStatus{}
@@ -284,6 +326,8 @@ func (m *MultiKeyListener) SetRoutingKeysReachable(keys []string) bool {
 func (m *MultiKeyListener) GetRoutingKeys() []string {
 	if m.Spec.Strategy.Priority != nil {
 		return m.Spec.Strategy.Priority.RoutingKeys
+	} else if m.Spec.Strategy.Closest != nil {
+		return m.Spec.Strategy.Closest.RoutingKeys
 	} else if m.Spec.Strategy.Weighted != nil {
 		routingKeys := []string{}
 		for k := range m.Spec.Strategy.Weighted.RoutingKeys {
@@ -76,3 +76,35 @@ func TestMultiKeyListener_SetHasDestination(t *testing.T) {
 		}
 	})
 }
+
+func TestMultiKeyListener_SetRoutingKeysReachableClosest(t *testing.T) {
+	mkl := &MultiKeyListener{
+		Spec: MultiKeyListenerSpec{
+			Strategy: MultiKeyListenerStrategy{
+				Closest: &ClosestStrategySpec{RoutingKeys: []string{"east", "west", "south"}},
+			},
+		},
+	}
+	if changed := mkl.SetRoutingKeysReachable([]string{"west", "east"}); !changed {
+		t.Errorf("expected SetRoutingKeysReachable to report a change")
+	}
+	if mkl.Status.Strategy.Closest == nil {
+		t.Fatalf("expected closest strategy status to be set")
+	}
+	if mkl.Status.Strategy.Priority != nil || mkl.Status.Strategy.Weighted != nil {
+		t.Errorf("expected only closest strategy status to be set")
+	}
+	if got := mkl.Status.Strategy.Closest.RoutingKeysReachable; len(got) != 2 || got[0] != "west" || got[1] != "east" {
+		t.Errorf("expected reachable keys [west east], got %v", got)
+	}
+	if changed := mkl.SetRoutingKeysReachable([]string{"west", "east"}); changed {
+		t.Errorf("expected repeated SetRoutingKeysReachable to report no change")
+	}
+	// a change in order alone is significant for the closest strategy
+	if changed := mkl.SetRoutingKeysReachable([]string{"east", "west"}); !changed {
+		t.Errorf("expected a change in order to be reported")
+	}
+	if got := mkl.GetRoutingKeys(); len(got) != 3 {
+		t.Errorf("expected all routing keys from the closest strategy, got %v", got)
+	}
+}
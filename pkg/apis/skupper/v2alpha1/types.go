@@ -223,6 +223,16 @@ func (s *SiteSpec) GetRouterDataConnectionCount() string {
 	return ""
 }
 
+// GetProxyConfiguration returns the name of the secret describing the
+// proxy through which AccessTokens are redeemed, for tokens that do
+// not specify their own.
+func (s *SiteSpec) GetProxyConfiguration() string {
+	if value, ok := s.Settings["proxy-configuration"]; ok {
+		return value
+	}
+	return ""
+}
+
 func (s *Site) SetConfigured(err error) bool {
 	if s.Status.SetCondition(CONDITION_TYPE_CONFIGURED, ErrorOrReadyCondition(err), s.ObjectMeta.Generation) {
 		s.Status.setReady(s.requiredConditions(), s.ObjectMeta.Generation)
@@ -252,6 +262,24 @@ func (s *Site) SetRunning(state ConditionState) bool {
 	return false
 }
 
+func (s *Site) SetAutoscaling(autoscaling []AutoscalingStatus) bool {
+	if reflect.DeepEqual(s.Status.Autoscaling, autoscaling) {
+		return false
+	}
+	s.Status.Autoscaling = autoscaling
+	return true
+}
+
+// SetAutoscalingRefused records why the autoscaling requested for the
+// site was refused in the Autoscaling condition, which is removed when
+// err is nil.
+func (s *Site) SetAutoscalingRefused(err error) bool {
+	if err == nil {
+		return meta.RemoveStatusCondition(&s.Status.Conditions, CONDITION_TYPE_AUTOSCALING)
+	}
+	return s.Status.SetCondition(CONDITION_TYPE_AUTOSCALING, ErrorCondition(err), s.ObjectMeta.Generation)
+}
+
 // RefreshAggregatedStatus updates StatusType, Message, and Ready from current conditions.
 func (s *Site) RefreshAggregatedStatus() bool {
 	return s.Status.setReady(s.requiredConditions(), s.ObjectMeta.Generation)
@@ -286,14 +314,37 @@ const CONDITION_TYPE_PROCESSED = "Processed"
 const CONDITION_TYPE_REDEEMED = "Redeemed"
 const CONDITION_TYPE_OPERATIONAL = "Operational"
 const CONDITION_TYPE_READY = "Ready"
+const CONDITION_TYPE_EXPIRING = "Expiring"
+const CONDITION_TYPE_AUTOSCALING = "Autoscaling"
 
 type SiteStatus struct {
 	Status         `json:",inline"`
-	Endpoints      []Endpoint   `json:"endpoints,omitempty"`
-	SitesInNetwork int          `json:"sitesInNetwork,omitempty"`
-	Network        []SiteRecord `json:"network,omitempty"`
-	DefaultIssuer  string       `json:"defaultIssuer,omitempty"`
-	Controller     *Controller  `json:"controller,omitempty"`
+	Endpoints      []Endpoint          `json:"endpoints,omitempty"`
+	SitesInNetwork int                 `json:"sitesInNetwork,omitempty"`
+	Network        []SiteRecord        `json:"network,omitempty"`
+	DefaultIssuer  string              `json:"defaultIssuer,omitempty"`
+	Controller     *Controller         `json:"controller,omitempty"`
+	Autoscaling    []AutoscalingStatus `json:"autoscaling,omitempty"`
+}
+
+// AutoscalingStatus reports the state of the autoscaler for one of the
+// router deployments of a site.
+type AutoscalingStatus struct {
+	Group           string                    `json:"group"`
+	Mode            string                    `json:"mode"`
+	MinReplicas     int32                     `json:"minReplicas,omitempty"`
+	MaxReplicas     int32                     `json:"maxReplicas,omitempty"`
+	CurrentReplicas int32                     `json:"currentReplicas,omitempty"`
+	DesiredReplicas int32                     `json:"desiredReplicas,omitempty"`
+	Recommendations []ContainerRecommendation `json:"recommendations,omitempty"`
+}
+
+// ContainerRecommendation is the resource request recommended for a
+// container by a VerticalPodAutoscaler.
+type ContainerRecommendation struct {
+	Container string `json:"container"`
+	Cpu       string `json:"cpu,omitempty"`
+	Memory    string `json:"memory,omitempty"`
 }
 
 type Controller struct {
@@ -329,6 +380,7 @@ type SiteRecord struct {
 
 type ServiceRecord struct {
 	RoutingKey string   `json:"routingKey,omitempty"`
+	Protocol   string   `json:"protocol,omitempty"`
 	Connectors []string `json:"connectors,omitempty"`
 	Listeners  []string `json:"listeners,omitempty"`
 }
@@ -338,6 +390,7 @@ type LinkRecord struct {
 	RemoteSiteId   string `json:"remoteSiteId,omitempty"`
 	RemoteSiteName string `json:"remoteSiteName,omitempty"`
 	Operational    bool   `json:"operational,omitempty"`
+	Cost           uint64 `json:"cost,omitempty"`
 }
 
 // +genclient
@@ -393,6 +446,22 @@ func (l *Listener) Protocol() corev1.Protocol {
 	return corev1.ProtocolTCP
 }
 
+// AppProtocol returns the application protocol to advertise on the
+// Service port for the listener, or an empty string if none applies.
+func (l *Listener) AppProtocol() string {
+	switch l.Spec.Type {
+	case "http", "http2":
+		if l.Spec.TlsCredentials != "" {
+			return "https"
+		}
+		if l.Spec.Type == "http2" {
+			return "kubernetes.io/h2c"
+		}
+		return "http"
+	}
+	return ""
+}
+
 func (s *Listener) IsConfigured() bool {
 	return meta.IsStatusConditionTrue(s.Status.Conditions, CONDITION_TYPE_CONFIGURED)
 }
@@ -669,6 +738,13 @@ type AccessTokenSpec struct {
 	Settings map[string]string `json:"settings,omitempty"`
 }
 
+func (s *AccessTokenSpec) GetProxyConfiguration() string {
+	if value, ok := s.Settings["proxy-configuration"]; ok {
+		return value
+	}
+	return ""
+}
+
 type AccessTokenStatus struct {
 	Status   `json:",inline"`
 	Redeemed bool `json:"redeemed,omitempty"`
@@ -712,6 +788,15 @@ func (s *AccessGrant) IsReady() bool {
 	return meta.IsStatusConditionTrue(s.Status.Conditions, CONDITION_TYPE_READY)
 }
 
+// RecordRedemption appends a redemption to the history in the grant's
+// status, discarding the oldest entries so that at most limit are kept.
+func (g *AccessGrant) RecordRedemption(redemption AccessGrantRedemption, limit int) {
+	g.Status.RedemptionHistory = append(g.Status.RedemptionHistory, redemption)
+	if limit >= 0 && len(g.Status.RedemptionHistory) > limit {
+		g.Status.RedemptionHistory = g.Status.RedemptionHistory[len(g.Status.RedemptionHistory)-limit:]
+	}
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AccessGrantList contains a List of AccessGrant instances
@@ -736,6 +821,17 @@ type AccessGrantStatus struct {
 	Ca             string `json:"ca,omitempty"`
 	Redemptions    int    `json:"redemptions,omitempty"`
 	ExpirationTime string `json:"expirationTime,omitempty"`
+	// RedemptionHistory holds the most recent successful
+	// redemptions, oldest first.
+	RedemptionHistory []AccessGrantRedemption `json:"redemptionHistory,omitempty"`
+}
+
+type AccessGrantRedemption struct {
+	Subject   string `json:"subject,omitempty"`
+	Site      string `json:"site,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Address   string `json:"address,omitempty"`
+	Time      string `json:"time,omitempty"`
 }
 
 // +genclient
@@ -875,6 +971,33 @@ func (c *Certificate) SetReady(err error) bool {
 	return c.Status.SetCondition(CONDITION_TYPE_READY, ErrorOrReadyCondition(err), c.ObjectMeta.Generation)
 }
 
+// SetExpiration records when the certificate expires and whether it is
+// within the window in which it is due to be renewed.
+func (c *Certificate) SetExpiration(expiration time.Time, expiring bool) bool {
+	changed := false
+	formatted := expiration.UTC().Format(time.RFC3339)
+	if c.Status.Expiration != formatted {
+		c.Status.Expiration = formatted
+		changed = true
+	}
+	state := ConditionState{
+		Status:  v1.ConditionFalse,
+		Reason:  "Valid",
+		Message: "Certificate is valid until " + formatted,
+	}
+	if expiring {
+		state = ConditionState{
+			Status:  v1.ConditionTrue,
+			Reason:  "Expiring",
+			Message: "Certificate expires at " + formatted,
+		}
+	}
+	if c.Status.SetCondition(CONDITION_TYPE_EXPIRING, state, c.ObjectMeta.Generation) {
+		changed = true
+	}
+	return changed
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -110,10 +110,31 @@ func (in *AccessGrantSpec) DeepCopy() *AccessGrantSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessGrantRedemption) DeepCopyInto(out *AccessGrantRedemption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessGrantRedemption.
+func (in *AccessGrantRedemption) DeepCopy() *AccessGrantRedemption {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessGrantRedemption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessGrantStatus) DeepCopyInto(out *AccessGrantStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.RedemptionHistory != nil {
+		in, out := &in.RedemptionHistory, &out.RedemptionHistory
+		*out = make([]AccessGrantRedemption, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -435,6 +456,27 @@ func (in *AttachedConnectorStatus) DeepCopy() *AttachedConnectorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingStatus) DeepCopyInto(out *AutoscalingStatus) {
+	*out = *in
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = make([]ContainerRecommendation, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingStatus.
+func (in *AutoscalingStatus) DeepCopy() *AutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Certificate) DeepCopyInto(out *Certificate) {
 	*out = *in
@@ -541,6 +583,48 @@ func (in *CertificateStatus) DeepCopy() *CertificateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClosestStrategySpec) DeepCopyInto(out *ClosestStrategySpec) {
+	*out = *in
+	if in.RoutingKeys != nil {
+		in, out := &in.RoutingKeys, &out.RoutingKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClosestStrategySpec.
+func (in *ClosestStrategySpec) DeepCopy() *ClosestStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClosestStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClosestStrategyStatus) DeepCopyInto(out *ClosestStrategyStatus) {
+	*out = *in
+	if in.RoutingKeysReachable != nil {
+		in, out := &in.RoutingKeysReachable, &out.RoutingKeysReachable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClosestStrategyStatus.
+func (in *ClosestStrategyStatus) DeepCopy() *ClosestStrategyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClosestStrategyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConditionState) DeepCopyInto(out *ConditionState) {
 	*out = *in
@@ -663,6 +747,22 @@ func (in *ConnectorStatus) DeepCopy() *ConnectorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRecommendation) DeepCopyInto(out *ContainerRecommendation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecommendation.
+func (in *ContainerRecommendation) DeepCopy() *ContainerRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Controller) DeepCopyInto(out *Controller) {
 	*out = *in
@@ -1044,6 +1144,11 @@ func (in *MultiKeyListenerStrategy) DeepCopyInto(out *MultiKeyListenerStrategy)
 		*out = new(WeightedStrategySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Closest != nil {
+		in, out := &in.Closest, &out.Closest
+		*out = new(ClosestStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1556,6 +1661,13 @@ func (in *SiteStatus) DeepCopyInto(out *SiteStatus) {
 		*out = new(Controller)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = make([]AutoscalingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1605,6 +1717,11 @@ func (in *StrategyStatus) DeepCopyInto(out *StrategyStatus) {
 		*out = new(WeightedStrategyStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Closest != nil {
+		in, out := &in.Closest, &out.Closest
+		*out = new(ClosestStrategyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -369,10 +369,14 @@ func (s *SiteState) SetNamespace(namespace string) {
 	setNamespaceOnMap(s.ConfigMaps, namespace)
 }
 
-func (s *SiteState) UpdateStatus(networkStatus network.NetworkStatusInfo) {
+// UpdateStatus updates the status of the site and its resources from the
+// network status. It returns true when the order of the routing keys of any
+// MultiKeyListener with the closest strategy has changed, in which case the
+// router configuration needs to be updated.
+func (s *SiteState) UpdateStatus(networkStatus network.NetworkStatusInfo) bool {
 	siteRecords := network.ExtractSiteRecords(networkStatus)
 	if reflect.DeepEqual(s.Site.Status.Network, siteRecords) {
-		return
+		return false
 	}
 	s.Site.Status.Network = siteRecords
 	s.Site.Status.SitesInNetwork = len(siteRecords)
@@ -394,12 +398,21 @@ func (s *SiteState) UpdateStatus(networkStatus network.NetworkStatusInfo) {
 
 	// updating listeners and connectors
 	for _, listener := range s.Listeners {
-		listener.SetHasMatchingConnector(network.HasMatchingPair(networkStatus, listener.Spec.RoutingKey))
+		listener.SetHasMatchingConnector(network.HasMatchingPairForProtocol(networkStatus, listener.Spec.RoutingKey, listener.Spec.Type))
 	}
 	for _, connector := range s.Connectors {
-		connector.SetHasMatchingListener(network.HasMatchingPair(networkStatus, connector.Spec.RoutingKey))
+		connector.SetHasMatchingListener(network.HasMatchingPairForProtocol(networkStatus, connector.Spec.RoutingKey, connector.Spec.Type))
 	}
+	closestChanged := false
 	for _, mkl := range s.MultiKeyListeners {
+		if mkl.Spec.Strategy.Closest != nil {
+			reachableKeys := network.OrderRoutingKeysByCost(s.SiteId, siteRecords, mkl.GetRoutingKeys())
+			mkl.SetHasDestination(len(reachableKeys) > 0)
+			if mkl.SetRoutingKeysReachable(reachableKeys) {
+				closestChanged = true
+			}
+			continue
+		}
 		hasDestination := false
 		var reachableKeys []string
 		for _, routingKey := range mkl.GetRoutingKeys() {
@@ -414,6 +427,7 @@ func (s *SiteState) UpdateStatus(networkStatus network.NetworkStatusInfo) {
 		mkl.SetHasDestination(hasDestination)
 		mkl.SetRoutingKeysReachable(reachableKeys)
 	}
+	return closestChanged
 }
 
 func marshal(outputDirectory, resourceType, resourceName string, resource interface{}) error {
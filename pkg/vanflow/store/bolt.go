@@ -0,0 +1,250 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltStoreConfig configures a store whose contents are persisted to a
+// bucket in a bbolt database.
+type BoltStoreConfig struct {
+	SyncMapStoreConfig
+
+	// DB is the database the store is persisted to. Several stores may
+	// share one database as long as each uses its own Bucket.
+	DB     *bolt.DB
+	Bucket string
+
+	// Types lists an exemplar of each record type the store may contain.
+	// Persisted records of any other type, or that cannot be decoded, are
+	// dropped when the store is loaded.
+	Types []vanflow.Record
+}
+
+// BoltStore is a store held in memory that is backed by a bbolt
+// database. Reads are served from memory. Changes are written to the
+// database by Flush, so that a high rate of updates to the same records
+// does not result in a high rate of disk writes.
+type BoltStore struct {
+	Interface
+
+	db     *bolt.DB
+	bucket []byte
+	types  map[string]reflect.Type
+
+	mu    sync.Mutex
+	dirty map[string]struct{}
+	reset bool
+}
+
+// NewBoltStore creates a BoltStore, loading any records previously
+// persisted to its bucket. Loading does not call the configured event
+// handlers.
+func NewBoltStore(cfg BoltStoreConfig) (*BoltStore, error) {
+	s := &BoltStore{
+		Interface: NewSyncMapStore(cfg.SyncMapStoreConfig),
+		db:        cfg.DB,
+		bucket:    []byte(cfg.Bucket),
+		types:     make(map[string]reflect.Type, len(cfg.Types)),
+		dirty:     make(map[string]struct{}),
+	}
+	for _, exemplar := range cfg.Types {
+		s.types[exemplar.GetTypeMeta().String()] = reflect.TypeOf(exemplar)
+	}
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	s.Interface.Replace(entries)
+	return s, nil
+}
+
+func (s *BoltStore) load() ([]Entry, error) {
+	var entries []Entry
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(s.bucket)
+		if err != nil {
+			return err
+		}
+		var invalid [][]byte
+		err = bucket.ForEach(func(k, v []byte) error {
+			entry, err := s.decode(v)
+			if err != nil {
+				invalid = append(invalid, k)
+				return nil
+			}
+			entries = append(entries, entry)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range invalid {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading records from bucket %s: %w", s.bucket, err)
+	}
+	return entries, nil
+}
+
+func (s *BoltStore) Add(record vanflow.Record, source SourceRef) bool {
+	ok := s.Interface.Add(record, source)
+	if ok {
+		s.markDirty(record.Identity())
+	}
+	return ok
+}
+
+func (s *BoltStore) Update(record vanflow.Record) bool {
+	ok := s.Interface.Update(record)
+	if ok {
+		s.markDirty(record.Identity())
+	}
+	return ok
+}
+
+func (s *BoltStore) Delete(id string) (Entry, bool) {
+	prev, ok := s.Interface.Delete(id)
+	if ok {
+		s.markDirty(id)
+	}
+	return prev, ok
+}
+
+func (s *BoltStore) Patch(record vanflow.Record, source SourceRef) {
+	s.Interface.Patch(record, source)
+	s.markDirty(record.Identity())
+}
+
+func (s *BoltStore) Replace(entries []Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Interface.Replace(entries)
+	s.reset = true
+	s.dirty = make(map[string]struct{})
+}
+
+func (s *BoltStore) markDirty(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dirty[id] = struct{}{}
+}
+
+// Flush writes all changes made since the last flush to the database.
+func (s *BoltStore) Flush() error {
+	s.mu.Lock()
+	dirty, reset := s.dirty, s.reset
+	s.dirty, s.reset = make(map[string]struct{}), false
+	var entries []Entry
+	if reset {
+		entries = s.Interface.List()
+	}
+	s.mu.Unlock()
+
+	if len(dirty) == 0 && !reset {
+		return nil
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if reset {
+			if err := tx.DeleteBucket(s.bucket); err != nil && err != bolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		bucket, err := tx.CreateBucketIfNotExists(s.bucket)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := s.put(bucket, entry); err != nil {
+				return err
+			}
+		}
+		for id := range dirty {
+			if entry, ok := s.Interface.Get(id); ok {
+				err = s.put(bucket, entry)
+			} else {
+				err = bucket.Delete([]byte(id))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// keep the changes so that they are retried on the next flush
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for id := range dirty {
+			s.dirty[id] = struct{}{}
+		}
+		s.reset = s.reset || reset
+		return fmt.Errorf("error writing records to bucket %s: %w", s.bucket, err)
+	}
+	return nil
+}
+
+func (s *BoltStore) put(bucket *bolt.Bucket, entry Entry) error {
+	data, err := s.encode(entry)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(entry.Record.Identity()), data)
+}
+
+type persistedEntry struct {
+	Type       string
+	LastUpdate time.Time
+	Source     SourceRef
+	Record     json.RawMessage
+}
+
+func (s *BoltStore) encode(entry Entry) ([]byte, error) {
+	record, err := json.Marshal(entry.Record)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding record %s: %w", entry.Record.Identity(), err)
+	}
+	return json.Marshal(persistedEntry{
+		Type:       entry.Record.GetTypeMeta().String(),
+		LastUpdate: entry.LastUpdate,
+		Source:     entry.Source,
+		Record:     record,
+	})
+}
+
+func (s *BoltStore) decode(data []byte) (Entry, error) {
+	var persisted persistedEntry
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return Entry{}, err
+	}
+	typ, ok := s.types[persisted.Type]
+	if !ok {
+		return Entry{}, fmt.Errorf("unknown record type %q", persisted.Type)
+	}
+	value := reflect.New(typ)
+	if err := json.Unmarshal(persisted.Record, value.Interface()); err != nil {
+		return Entry{}, err
+	}
+	record, ok := value.Elem().Interface().(vanflow.Record)
+	if !ok {
+		return Entry{}, fmt.Errorf("type %q is not a record", persisted.Type)
+	}
+	return Entry{
+		Metadata: Metadata{
+			LastUpdate: persisted.LastUpdate,
+			Source:     persisted.Source,
+		},
+		Record: record,
+	}, nil
+}
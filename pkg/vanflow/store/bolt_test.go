@@ -0,0 +1,130 @@
+package store
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/skupperproject/skupper/pkg/vanflow"
+	bolt "go.etcd.io/bbolt"
+)
+
+func openTestDB(t *testing.T, path string) *bolt.DB {
+	t.Helper()
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatalf("unexpected error opening database: %s", err)
+	}
+	return db
+}
+
+func TestBoltStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.db")
+	types := []vanflow.Record{vanflow.RouterRecord{}, vanflow.LogRecord{}}
+	source := SourceRef{ID: "test", Version: "0"}
+	startTime := time.Now().Truncate(time.Millisecond)
+
+	db := openTestDB(t, path)
+	stor, err := NewBoltStore(BoltStoreConfig{DB: db, Bucket: "records", Types: types})
+	if err != nil {
+		t.Fatalf("unexpected error creating store: %s", err)
+	}
+	stor.Add(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("0", startTime), Parent: ptrTo("site")}, source)
+	stor.Patch(vanflow.RouterRecord{BaseRecord: vanflow.NewBase("0"), Namespace: ptrTo("ns")}, source)
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("1"), LogText: ptrTo("deleted")}, source)
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("2"), LogText: ptrTo("initial")}, source)
+	if err := stor.Flush(); err != nil {
+		t.Fatalf("unexpected error flushing store: %s", err)
+	}
+	stor.Delete("1")
+	stor.Update(vanflow.LogRecord{BaseRecord: vanflow.NewBase("2"), LogText: ptrTo("updated")})
+	if err := stor.Flush(); err != nil {
+		t.Fatalf("unexpected error flushing store: %s", err)
+	}
+	expected := stor.List()
+	if err := db.Close(); err != nil {
+		t.Fatalf("unexpected error closing database: %s", err)
+	}
+
+	db = openTestDB(t, path)
+	defer db.Close()
+	restored, err := NewBoltStore(BoltStoreConfig{
+		SyncMapStoreConfig: SyncMapStoreConfig{Handlers: EventHandlerFuncs{
+			OnAdd: func(Entry) { t.Errorf("unexpected call to OnAdd") },
+		}},
+		DB:     db,
+		Bucket: "records",
+		Types:  types,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error restoring store: %s", err)
+	}
+	actual := restored.List()
+	if !cmp.Equal(actual, expected, ignoreOrder) {
+		t.Errorf("restored contents do not match expected: %s", cmp.Diff(actual, expected, ignoreOrder))
+	}
+	if matching := restored.Index(SourceIndex, Entry{Metadata: Metadata{Source: source}}); len(matching) != 2 {
+		t.Errorf("expected restored records to be indexed by source, got %d", len(matching))
+	}
+}
+
+func TestBoltStoreReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.db")
+	types := []vanflow.Record{vanflow.LogRecord{}}
+	source := SourceRef{ID: "test", Version: "0"}
+
+	db := openTestDB(t, path)
+	defer db.Close()
+	stor, err := NewBoltStore(BoltStoreConfig{DB: db, Bucket: "records", Types: types})
+	if err != nil {
+		t.Fatalf("unexpected error creating store: %s", err)
+	}
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("0")}, source)
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("1")}, source)
+	stor.Replace([]Entry{{Metadata: Metadata{Source: source}, Record: vanflow.LogRecord{BaseRecord: vanflow.NewBase("2")}}})
+	if err := stor.Flush(); err != nil {
+		t.Fatalf("unexpected error flushing store: %s", err)
+	}
+
+	restored, err := NewBoltStore(BoltStoreConfig{DB: db, Bucket: "records", Types: types})
+	if err != nil {
+		t.Fatalf("unexpected error restoring store: %s", err)
+	}
+	entries := restored.List()
+	if len(entries) != 1 || entries[0].Record.Identity() != "2" {
+		t.Errorf("expected only the replacement record to be persisted, got %v", entries)
+	}
+}
+
+func TestBoltStoreDropsUnknownTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.db")
+	source := SourceRef{ID: "test", Version: "0"}
+
+	db := openTestDB(t, path)
+	defer db.Close()
+	stor, err := NewBoltStore(BoltStoreConfig{DB: db, Bucket: "records", Types: []vanflow.Record{vanflow.LogRecord{}, vanflow.SiteRecord{}}})
+	if err != nil {
+		t.Fatalf("unexpected error creating store: %s", err)
+	}
+	stor.Add(vanflow.LogRecord{BaseRecord: vanflow.NewBase("0")}, source)
+	stor.Add(vanflow.SiteRecord{BaseRecord: vanflow.NewBase("1")}, source)
+	if err := stor.Flush(); err != nil {
+		t.Fatalf("unexpected error flushing store: %s", err)
+	}
+
+	restored, err := NewBoltStore(BoltStoreConfig{DB: db, Bucket: "records", Types: []vanflow.Record{vanflow.SiteRecord{}}})
+	if err != nil {
+		t.Fatalf("unexpected error restoring store: %s", err)
+	}
+	entries := restored.List()
+	if len(entries) != 1 || entries[0].Record.Identity() != "1" {
+		t.Errorf("expected only the site record to be restored, got %v", entries)
+	}
+}
+
+var ignoreOrder = cmpopts.SortSlices(func(a, b Entry) bool {
+	return strings.Compare(a.Record.Identity(), b.Record.Identity()) < 0
+})